@@ -28,6 +28,7 @@ import (
 	"github.com/celestiaorg/celestia-node/das"
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	shareServ "github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
 	"github.com/celestiaorg/celestia-node/state"
@@ -140,6 +141,7 @@ func init() {
 
 	mathInt, _ := math.NewIntFromString("42")
 	addToExampleValues(mathInt)
+	addToExampleValues(sdk.NewDecWithPrec(8, 2))
 
 	addToExampleValues(network.Connected)
 	addToExampleValues(network.ReachabilityPrivate)
@@ -177,6 +179,11 @@ func init() {
 		panic(err)
 	}
 	addToExampleValues(libhead.Hash(hash))
+	addToExampleValues(map[string][]share.Namespace{hashStr: {namespace}})
+	addToExampleValues(map[uint64]shareServ.NamespacedShares{42: {}})
+	addToExampleValues(map[string]shareServ.NamespacedShares{namespace.String(): {}})
+	addToExampleValues(shareServ.ProofEncodingFull)
+	addToExampleValues(rsmt2d.Row)
 
 	txConfig := state.NewTxConfig(
 		state.WithGasPrice(0.002),
@@ -12,6 +12,7 @@ import (
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-jsonrpc/auth"
 	logging "github.com/ipfs/go-log/v2"
+	"go.opentelemetry.io/otel/propagation"
 
 	"github.com/celestiaorg/celestia-node/api/rpc/perms"
 	"github.com/celestiaorg/celestia-node/libs/authtoken"
@@ -44,13 +45,23 @@ func NewServer(address, port string, authDisabled bool, signer jwt.Signer, verif
 		verifier:     verifier,
 		authDisabled: authDisabled,
 	}
-	srv.srv.Handler = &auth.Handler{
+	srv.srv.Handler = traceContextHandler(&auth.Handler{
 		Verify: srv.verifyAuth,
 		Next:   rpc.ServeHTTP,
-	}
+	})
 	return srv
 }
 
+// traceContextHandler continues the traces of callers propagating W3C trace context in the
+// request headers, so that the spans of the served request are attached to the caller's trace.
+func traceContextHandler(next http.Handler) http.Handler {
+	propagator := propagation.TraceContext{}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // verifyAuth is the RPC server's auth middleware. This middleware is only
 // reached if a token is provided in the header of the request, otherwise only
 // methods with `read` permissions are accessible.
@@ -36,6 +36,8 @@ import (
 	nodeMock "github.com/celestiaorg/celestia-node/nodebuilder/node/mocks"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	p2pMock "github.com/celestiaorg/celestia-node/nodebuilder/p2p/mocks"
+	"github.com/celestiaorg/celestia-node/nodebuilder/pruner"
+	pruneMock "github.com/celestiaorg/celestia-node/nodebuilder/pruner/mocks"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
 	shareMock "github.com/celestiaorg/celestia-node/nodebuilder/share/mocks"
 	statemod "github.com/celestiaorg/celestia-node/nodebuilder/state"
@@ -102,6 +104,7 @@ type api struct {
 	Blob       blob.Module
 	DA         da.Module
 	Blobstream blobstream.Module
+	Prune      pruner.Module
 }
 
 func TestModulesImplementFullAPI(t *testing.T) {
@@ -316,6 +319,7 @@ func setupNodeWithAuthedRPC(t *testing.T,
 		blobMock.NewMockModule(ctrl),
 		daMock.NewMockModule(ctrl),
 		blobstreamMock.NewMockModule(ctrl),
+		pruneMock.NewMockModule(ctrl),
 	}
 
 	// given the behavior of fx.Invoke, this invoke will be called last as it is added at the root
@@ -330,6 +334,7 @@ func setupNodeWithAuthedRPC(t *testing.T,
 		srv.RegisterService("node", mockAPI.Node, &node.API{})
 		srv.RegisterService("blob", mockAPI.Blob, &blob.API{})
 		srv.RegisterService("da", mockAPI.DA, &da.API{})
+		srv.RegisterService("prune", mockAPI.Prune, &pruner.API{})
 	})
 	// fx.Replace does not work here, but fx.Decorate does
 	nd := nodebuilder.TestNode(t, node.Full, invokeRPC, fx.Decorate(func() (jwt.Signer, jwt.Verifier, error) {
@@ -356,4 +361,5 @@ type mockAPI struct {
 	Blob       *blobMock.MockModule
 	DA         *daMock.MockModule
 	Blobstream *blobstreamMock.MockModule
+	Prune      *pruneMock.MockModule
 }
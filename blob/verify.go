@@ -0,0 +1,84 @@
+package blob
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// VerifyBlobInclusion verifies that the blob of the given namespace is included in the block of
+// the header, using the Proof returned by GetProof. The blob is split into shares, which must be
+// exactly the leaves the Proof covers, and the Proof is verified against the row roots committed
+// to by the header's data root. Any mismatch results in an error wrapping ErrInvalidProof.
+//
+// As the Proof covers the whole namespaced data of the rows the blob spans, a blob sharing its rows
+// with other data of the same namespace can't be verified on its own.
+func VerifyBlobInclusion(
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+	blob *Blob,
+	proof *Proof,
+) error {
+	if header == nil || header.DAH == nil || blob == nil || proof == nil || proof.Len() == 0 {
+		return fmt.Errorf("%w: nil header, blob or proof", ErrInvalidProof)
+	}
+	if !bytes.Equal(header.DAH.Hash(), header.DataHash) {
+		return fmt.Errorf("%w: DAH does not match the data root of header at height %d",
+			ErrInvalidProof, header.Height())
+	}
+	if !namespace.Equals(blob.Namespace()) {
+		return fmt.Errorf("%w: blob namespace %s differs from %s",
+			ErrInvalidProof, blob.Namespace().String(), namespace.String())
+	}
+
+	shares, err := BlobsToShares(blob)
+	if err != nil {
+		return fmt.Errorf("splitting blob into shares: %w", err)
+	}
+	leaves := make([][]byte, 0, len(shares))
+	for _, shr := range shares {
+		namespaceBytes := share.GetNamespace(shr)
+		leaf := make([]byte, len(namespaceBytes)+len(shr))
+		copy(leaf, namespaceBytes)
+		copy(leaf[len(namespaceBytes):], shr)
+		leaves = append(leaves, leaf)
+	}
+
+	covered := 0
+	for _, rowProof := range *proof {
+		if rowProof == nil {
+			return fmt.Errorf("%w: nil row proof", ErrInvalidProof)
+		}
+		covered += rowProof.End() - rowProof.Start()
+	}
+	if covered != len(leaves) {
+		return fmt.Errorf("%w: proof covers %d shares, blob has %d", ErrInvalidProof, covered, len(leaves))
+	}
+
+	// the proof doesn't carry row indexes, so try every sequence of rows that may hold the namespace
+	odsWidth := len(header.DAH.RowRoots) / 2
+	for _, row := range share.RowsWithNamespace(header.DAH, namespace) {
+		if row+proof.Len() > odsWidth {
+			break
+		}
+		if proof.verifyRows(header.DAH.RowRoots[row:], namespace, leaves) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: blob is not included at height %d", ErrInvalidProof, header.Height())
+}
+
+// verifyRows verifies the row proofs against the consecutive row roots, splitting the namespaced
+// leaves between the rows by the proof ranges.
+func (p Proof) verifyRows(rowRoots [][]byte, namespace share.Namespace, leaves [][]byte) bool {
+	for i, rowProof := range p {
+		size := rowProof.End() - rowProof.Start()
+		if !rowProof.VerifyNamespace(share.NewSHA256Hasher(), namespace.ToNMT(), leaves[:size], rowRoots[i]) {
+			return false
+		}
+		leaves = leaves[size:]
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/blob/blobtest"
+)
+
+func TestVerifyBlobInclusion(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	appBlobs, err := blobtest.GenerateV0Blobs([]int{18, 14}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	shares, err := BlobsToShares(blobs...)
+	require.NoError(t, err)
+	service := createService(ctx, t, shares)
+
+	h, err := service.headerGetter(ctx, 1)
+	require.NoError(t, err)
+	proof, err := service.GetProof(ctx, 1, blobs[0].Namespace(), blobs[0].Commitment)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		err := VerifyBlobInclusion(h, blobs[0].Namespace(), blobs[0], proof)
+		require.NoError(t, err)
+	})
+
+	t.Run("other blob", func(t *testing.T) {
+		err := VerifyBlobInclusion(h, blobs[1].Namespace(), blobs[1], proof)
+		require.ErrorIs(t, err, ErrInvalidProof)
+	})
+
+	t.Run("tampered blob", func(t *testing.T) {
+		tampered, err := NewBlobV0(blobs[0].Namespace(), bytes.Repeat([]byte{1}, len(blobs[0].Data)))
+		require.NoError(t, err)
+		err = VerifyBlobInclusion(h, blobs[0].Namespace(), tampered, proof)
+		require.ErrorIs(t, err, ErrInvalidProof)
+	})
+
+	t.Run("namespace mismatch", func(t *testing.T) {
+		err := VerifyBlobInclusion(h, blobs[1].Namespace(), blobs[0], proof)
+		require.ErrorIs(t, err, ErrInvalidProof)
+	})
+
+	t.Run("data root mismatch", func(t *testing.T) {
+		otherHeader := *h
+		otherHeader.DataHash = bytes.Repeat([]byte{1}, len(h.DataHash))
+		err := VerifyBlobInclusion(&otherHeader, blobs[0].Namespace(), blobs[0], proof)
+		require.ErrorIs(t, err, ErrInvalidProof)
+	})
+}
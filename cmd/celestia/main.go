@@ -17,6 +17,7 @@ func WithSubcommands() func(*cobra.Command, []*pflag.FlagSet) {
 			cmdnode.Start(cmdnode.WithFlagSet(flags)),
 			cmdnode.AuthCmd(flags...),
 			cmdnode.ResetStore(flags...),
+			cmdnode.StoreCmd(flags...),
 			cmdnode.RemoveConfigCmd(flags...),
 			cmdnode.UpdateConfigCmd(flags...),
 		)
@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+const (
+	repairFlag      = "repair"
+	fromFlag        = "from"
+	toFlag          = "to"
+	trustedHashFlag = "trusted-hash"
+	sinceHeightFlag = "since-height"
+	destFlag        = "dest"
+	srcFlag         = "src"
+)
+
+// StoreCmd constructs a CLI command to maintain the store of Celestia Node.
+func StoreCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store [subcommand]",
+		Short: "Maintains the node's store.",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(
+		storeGCCmd(fsets...),
+		storeMigrateCmd(fsets...),
+		storeCheckCmd(fsets...),
+		storeExportCmd(fsets...),
+		storeImportCmd(fsets...),
+		storeBackupCmd(fsets...),
+		storeRestoreCmd(fsets...),
+	)
+	return cmd
+}
+
+func storeGCCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Removes the garbage left in the node's store by crashes and compacts its datastore.",
+		Long: "Removes the garbage left in the node's store by crashes and compacts its datastore. " +
+			"The node must be stopped. Use the share.CollectGarbage RPC method for running nodes.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			result, err := nodebuilder.CollectGarbage(ctx, StorePath(ctx), NodeType(ctx))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("removed %d files, reclaimed %d bytes\n", result.RemovedFiles, result.ReclaimedBytes)
+			return nil
+		},
+	}
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	return cmd
+}
+
+func storeMigrateCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Converts the CAR files of the legacy EDS store into the current file format in place.",
+		Long: "Converts the CAR files of the legacy EDS store into the current file format in place. " +
+			"The node must be stopped. An interrupted migration resumes from its last checkpoint.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			progress := func(status store.MigrationProgress) {
+				fmt.Printf("processed height %d of %d, converted %d EDSes\n",
+					status.Height, status.Head, status.Converted)
+			}
+			status, err := nodebuilder.MigrateStore(ctx, StorePath(ctx), NodeType(ctx), progress)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("migration completed, converted %d EDSes\n", status.Converted)
+			return nil
+		},
+	}
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	return cmd
+}
+
+func storeCheckCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Verifies the EDSes kept in the node's store against the roots of their headers.",
+		Long: "Verifies the EDSes kept in the node's store against the roots of their headers and reports " +
+			"missing, truncated and corrupted files. The node must be stopped. With --repair, corrupted " +
+			"EDSes are removed from the store. Removed EDSes are not re-fetched.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			repair, err := cmd.Flags().GetBool(repairFlag)
+			if err != nil {
+				return err
+			}
+			result, err := nodebuilder.CheckStore(ctx, StorePath(ctx), NodeType(ctx), repair)
+			if err != nil {
+				return err
+			}
+			for _, failure := range result.Corrupted {
+				fmt.Printf("height %d: %s\n", failure.Height, failure.Reason)
+			}
+			fmt.Printf("checked %d heights (%d without headers), found %d corrupted, removed %d\n",
+				result.Checked, result.Unverified, len(result.Corrupted), result.Removed)
+			return nil
+		},
+	}
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().Bool(repairFlag, false, "Removes the corrupted EDSes from the store.")
+	return cmd
+}
+
+func storeExportCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <file>",
+		Short: "Writes a snapshot of the headers and EDSes of a height range kept by the node to a file.",
+		Long: "Writes a snapshot of the headers and EDSes of a height range kept by the node to a file, " +
+			"which new nodes can be bootstrapped from with the import command. The node must be stopped. " +
+			"Use the share.ExportSnapshot RPC method for running nodes.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			from, err := cmd.Flags().GetUint64(fromFlag)
+			if err != nil {
+				return err
+			}
+			to, err := cmd.Flags().GetUint64(toFlag)
+			if err != nil {
+				return err
+			}
+
+			// ensure existing files are never overwritten
+			f, err := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+			if err != nil {
+				return err
+			}
+			w := bufio.NewWriter(f)
+			err = nodebuilder.ExportSnapshot(ctx, StorePath(ctx), NodeType(ctx), from, to, w)
+			if err == nil {
+				err = w.Flush()
+			}
+			err = errors.Join(err, f.Close())
+			if err != nil {
+				// don't leave partially written files behind
+				return errors.Join(err, os.Remove(args[0]))
+			}
+			fmt.Printf("exported heights %d to %d\n", from, to)
+			return nil
+		},
+	}
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().Uint64(fromFlag, 1, "The first exported height.")
+	cmd.Flags().Uint64(toFlag, 0, "The last exported height.")
+	return cmd
+}
+
+func storeImportCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Bootstraps the node's store from a snapshot written by the export command.",
+		Long: "Bootstraps the node's store from a snapshot written by the export command. The node must " +
+			"be stopped. A node without headers is initialized with the first header of the snapshot, which " +
+			"must have the trusted hash. The following headers are verified against their predecessors and " +
+			"every EDS against its header. Use the share.ImportSnapshot RPC method for running nodes.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			trustedHash, err := cmd.Flags().GetString(trustedHashFlag)
+			if err != nil {
+				return err
+			}
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			manifest, err := nodebuilder.ImportSnapshot(
+				ctx, StorePath(ctx), NodeType(ctx), trustedHash, bufio.NewReader(f),
+			)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("imported heights %d to %d\n", manifest.From, manifest.To)
+			return nil
+		},
+	}
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().String(trustedHashFlag, "",
+		"The hash of the first header of the snapshot. Defaults to the trusted hash of the node config.")
+	return cmd
+}
+
+func storeBackupCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Writes an incremental backup of the headers and EDSes added since the last backup.",
+		Long: "Writes an incremental backup of the headers and EDSes kept by the node to the backup " +
+			"directory. Every backup covers the heights from the one following the last backup in the " +
+			"directory up to the head, unless --since-height is set. Backups are checksummed, so that the " +
+			"restore command verifies them before restoring. The node must be stopped.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			since, err := cmd.Flags().GetUint64(sinceHeightFlag)
+			if err != nil {
+				return err
+			}
+			dest, err := cmd.Flags().GetString(destFlag)
+			if err != nil {
+				return err
+			}
+			if dest == "" {
+				return fmt.Errorf("--%s is required", destFlag)
+			}
+
+			backup, err := nodebuilder.BackupStore(ctx, StorePath(ctx), NodeType(ctx), since, dest)
+			if err != nil {
+				return err
+			}
+			if backup == nil {
+				fmt.Println("no new heights to back up")
+				return nil
+			}
+			fmt.Printf("backed up heights %d to %d to %s\n", backup.From, backup.To, backup.File)
+			return nil
+		},
+	}
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().Uint64(sinceHeightFlag, 0,
+		"The first backed up height. Defaults to the height following the last backup.")
+	cmd.Flags().String(destFlag, "", "The backup directory.")
+	return cmd
+}
+
+func storeRestoreCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restores the node's store from the backups written by the backup command.",
+		Long: "Restores the node's store from the backups written by the backup command. The checksums " +
+			"of all the backups are verified before restoring them in order. A node without headers is " +
+			"initialized with the first backed up header, which must have the trusted hash. The following " +
+			"headers are verified against their predecessors and every EDS against its header. The node " +
+			"must be stopped.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			src, err := cmd.Flags().GetString(srcFlag)
+			if err != nil {
+				return err
+			}
+			if src == "" {
+				return fmt.Errorf("--%s is required", srcFlag)
+			}
+			trustedHash, err := cmd.Flags().GetString(trustedHashFlag)
+			if err != nil {
+				return err
+			}
+
+			backups, err := nodebuilder.RestoreStore(ctx, StorePath(ctx), NodeType(ctx), trustedHash, src)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("restored %d backups up to height %d\n", len(backups), backups[len(backups)-1].To)
+			return nil
+		},
+	}
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().String(srcFlag, "", "The backup directory.")
+	cmd.Flags().String(trustedHashFlag, "",
+		"The hash of the first backed up header. Defaults to the trusted hash of the node config.")
+	return cmd
+}
@@ -39,6 +39,8 @@ type Listener struct {
 	construct          header.ConstructFn
 	store              *store.Store
 	availabilityWindow pruner.AvailabilityWindow
+	// concurrentWrites is the number of blocks extended and stored concurrently
+	concurrentWrites int
 
 	headerBroadcaster libhead.Broadcaster[*header.ExtendedHeader]
 	hashBroadcaster   shrexsub.BroadcastFn
@@ -84,6 +86,7 @@ func NewListener(
 		construct:          construct,
 		store:              store,
 		availabilityWindow: p.availabilityWindow,
+		concurrentWrites:   p.concurrentWrites,
 		listenerTimeout:    5 * blocktime,
 		metrics:            metrics,
 		chainID:            p.chainID,
@@ -179,11 +182,25 @@ func (cl *Listener) resubscribe(ctx context.Context) <-chan types.EventDataSigne
 
 // listen kicks off a loop, listening for new block events from Core,
 // generating ExtendedHeaders and broadcasting them to the header-sub
-// gossipsub network.
+// gossipsub network. Up to concurrentWrites blocks are extended and stored
+// concurrently, while their headers are broadcast in order.
 func (cl *Listener) listen(ctx context.Context, sub <-chan types.EventDataSignedBlock) error {
 	defer log.Info("listener: listening stopped")
 	timeout := time.NewTimer(cl.listenerTimeout)
 	defer timeout.Stop()
+
+	// the broadcasting loop holds one block, so the queue holds the rest of the pipelined blocks
+	queue := make(chan *pendingBlock, cl.concurrentWrites-1)
+	broadcastDone := make(chan struct{})
+	go func() {
+		defer close(broadcastDone)
+		cl.broadcastLoop(ctx, queue)
+	}()
+	defer func() {
+		close(queue)
+		<-broadcastDone
+	}()
+
 	for {
 		select {
 		case b, ok := <-sub:
@@ -199,13 +216,16 @@ func (cl *Listener) listen(ctx context.Context, sub <-chan types.EventDataSigned
 
 			log.Debugw("listener: new block from core", "height", b.Header.Height)
 
-			err := cl.handleNewSignedBlock(ctx, b)
-			if err != nil {
-				log.Errorw("listener: handling new block msg",
-					"height", b.Header.Height,
-					"hash", b.Header.Hash().String(),
-					"err", err)
+			block := &pendingBlock{block: b, done: make(chan struct{})}
+			select {
+			case queue <- block:
+			case <-ctx.Done():
+				return ctx.Err()
 			}
+			go func() {
+				defer close(block.done)
+				block.eh, block.err = cl.storeNewSignedBlock(ctx, b)
+			}()
 
 			if !timeout.Stop() {
 				<-timeout.C
@@ -220,7 +240,42 @@ func (cl *Listener) listen(ctx context.Context, sub <-chan types.EventDataSigned
 	}
 }
 
-func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataSignedBlock) error {
+// pendingBlock is a block being extended and stored by the Listener.
+type pendingBlock struct {
+	block types.EventDataSignedBlock
+	done  chan struct{}
+	eh    *header.ExtendedHeader
+	err   error
+}
+
+// broadcastLoop broadcasts the headers of the queued blocks in order once they are stored.
+func (cl *Listener) broadcastLoop(ctx context.Context, queue <-chan *pendingBlock) {
+	for block := range queue {
+		<-block.done
+		b := block.block
+		if block.err != nil {
+			log.Errorw("listener: handling new block msg",
+				"height", b.Header.Height,
+				"hash", b.Header.Hash().String(),
+				"err", block.err)
+			continue
+		}
+
+		err := cl.broadcast(ctx, block.eh)
+		if err != nil {
+			log.Errorw("listener: handling new block msg",
+				"height", b.Header.Height,
+				"hash", b.Header.Hash().String(),
+				"err", err)
+		}
+	}
+}
+
+// storeNewSignedBlock extends the block, generates its ExtendedHeader and stores the EDS.
+func (cl *Listener) storeNewSignedBlock(
+	ctx context.Context,
+	b types.EventDataSignedBlock,
+) (*header.ExtendedHeader, error) {
 	ctx, span := tracer.Start(ctx, "handle-new-signed-block")
 	defer span.End()
 	span.SetAttributes(
@@ -229,7 +284,7 @@ func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataS
 
 	eds, err := extendBlock(b.Data, b.Header.Version.App)
 	if err != nil {
-		return fmt.Errorf("extending block data: %w", err)
+		return nil, fmt.Errorf("extending block data: %w", err)
 	}
 
 	// generate extended header
@@ -240,9 +295,13 @@ func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataS
 
 	err = storeEDS(ctx, eh, eds, cl.store, cl.availabilityWindow)
 	if err != nil {
-		return fmt.Errorf("storing EDS: %w", err)
+		return nil, fmt.Errorf("storing EDS: %w", err)
 	}
+	return eh, nil
+}
 
+// broadcast notifies the network of the stored block.
+func (cl *Listener) broadcast(ctx context.Context, eh *header.ExtendedHeader) error {
 	syncing, err := cl.fetcher.IsSyncing(ctx)
 	if err != nil {
 		return fmt.Errorf("getting sync state: %w", err)
@@ -256,8 +315,8 @@ func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataS
 		})
 		if err != nil && !errors.Is(err, context.Canceled) {
 			log.Errorw("listener: broadcasting data hash",
-				"height", b.Header.Height,
-				"hash", b.Header.Hash(), "err", err) // TODO: hash or datahash?
+				"height", eh.Height(),
+				"hash", eh.Hash(), "err", err) // TODO: hash or datahash?
 		}
 	}
 
@@ -265,7 +324,7 @@ func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataS
 	err = cl.headerBroadcaster.Broadcast(ctx, eh, pubsub.WithLocalPublication(syncing))
 	if err != nil && !errors.Is(err, context.Canceled) {
 		log.Errorw("listener: broadcasting next header",
-			"height", b.Header.Height,
+			"height", eh.Height(),
 			"err", err)
 	}
 	return nil
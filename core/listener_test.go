@@ -73,6 +73,57 @@ func TestListener(t *testing.T) {
 	require.Nil(t, cl.cancel)
 }
 
+// TestListener_ConcurrentWrites tests that the headers of concurrently stored blocks are
+// broadcast in order.
+func TestListener_ConcurrentWrites(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+
+	ps0, ps1 := createMocknetWithTwoPubsubEndpoints(ctx, t)
+	subscriber, err := p2p.NewSubscriber[*header.ExtendedHeader](
+		ps1,
+		header.MsgID,
+		p2p.WithSubscriberNetworkID(testChainID),
+	)
+	require.NoError(t, err)
+	err = subscriber.SetVerifier(func(context.Context, *header.ExtendedHeader) error {
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, subscriber.Start(ctx))
+	subs, err := subscriber.Subscribe()
+	require.NoError(t, err)
+	t.Cleanup(subs.Cancel)
+
+	cfg := DefaultTestConfig()
+	cfg.Genesis.ChainID = testChainID
+	fetcher, _ := createCoreFetcher(t, cfg)
+	eds := createEdsPubSub(ctx, t)
+
+	store, err := store.NewStore(store.DefaultParameters(), t.TempDir())
+	require.NoError(t, err)
+	cl := createListener(ctx, t, fetcher, ps0, eds, store, testChainID, WithConcurrentWrites(4))
+	err = cl.Start(ctx)
+	require.NoError(t, err)
+
+	var prev uint64
+	for i := 0; i < 5; i++ {
+		h, err := subs.NextHeader(ctx)
+		require.NoError(t, err)
+		if prev != 0 {
+			require.Equal(t, prev+1, h.Height())
+		}
+		prev = h.Height()
+
+		has, err := store.HasByHeight(ctx, h.Height())
+		require.NoError(t, err)
+		require.True(t, has)
+	}
+
+	err = cl.Stop(ctx)
+	require.NoError(t, err)
+}
+
 func TestListenerWithWrongChainRPC(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	t.Cleanup(cancel)
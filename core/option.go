@@ -12,11 +12,13 @@ type params struct {
 	metrics            bool
 	chainID            string
 	availabilityWindow pruner.AvailabilityWindow
+	concurrentWrites   int
 }
 
 func defaultParams() params {
 	return params{
 		availabilityWindow: archival.Window,
+		concurrentWrites:   1,
 	}
 }
 
@@ -39,3 +41,13 @@ func WithAvailabilityWindow(window pruner.AvailabilityWindow) Option {
 		p.availabilityWindow = window
 	}
 }
+
+// WithConcurrentWrites sets the number of new blocks the Listener extends and stores concurrently.
+// Headers are broadcast in order regardless.
+func WithConcurrentWrites(n int) Option {
+	return func(p *params) {
+		if n > 0 {
+			p.concurrentWrites = n
+		}
+	}
+}
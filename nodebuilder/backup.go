@@ -0,0 +1,79 @@
+package nodebuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-datastore"
+
+	headerstore "github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	modshare "github.com/celestiaorg/celestia-node/nodebuilder/share"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+// BackupStore writes an incremental backup of the headers and EDSes kept by the stopped node under
+// the given path to the dest directory. It backs up the heights up to the head, starting from the
+// one following the last backup in the directory, or from sinceHeight, if it is set. It returns nil
+// if there are no new heights to back up.
+func BackupStore(
+	ctx context.Context,
+	path string,
+	tp node.Type,
+	sinceHeight uint64,
+	dest string,
+) (backup *modshare.Backup, err error) {
+	err = withStoppedStore(path, tp, func(cfg *Config, edsStore *store.Store, ds datastore.Batching) error {
+		hstore, err := headerstore.NewStore[*header.ExtendedHeader](ds, headerstore.WithParams(cfg.Header.Store))
+		if err != nil {
+			return fmt.Errorf("opening header store: %w", err)
+		}
+		head, err := hstore.Head(ctx)
+		if err != nil {
+			return fmt.Errorf("reading head: %w", err)
+		}
+
+		from := sinceHeight
+		if from == 0 {
+			index, err := modshare.ReadBackupIndex(dest)
+			if err != nil {
+				return err
+			}
+			from = index.Next()
+		}
+		if from > head.Height() {
+			return nil
+		}
+		backup, err = modshare.WriteBackup(ctx, dest, hstore, store.NewGetter(edsStore), from, head.Height())
+		return err
+	})
+	return backup, err
+}
+
+// RestoreStore verifies the backups of the src directory written by BackupStore and reads them in
+// order into the stopped node under the given path. The headers and EDSes are verified the way
+// ImportSnapshot does.
+func RestoreStore(
+	ctx context.Context,
+	path string,
+	tp node.Type,
+	trustedHash string,
+	src string,
+) (backups []modshare.Backup, err error) {
+	err = withStoppedStore(path, tp, func(cfg *Config, edsStore *store.Store, ds datastore.Batching) (err error) {
+		hstore, verify, err := snapshotVerifier(ctx, cfg, ds, trustedHash)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// flushes the appended headers
+			err = errors.Join(err, hstore.Stop(ctx))
+		}()
+		backups, err = modshare.RestoreBackups(ctx, src, edsStore, verify)
+		return err
+	})
+	return backups, err
+}
@@ -0,0 +1,62 @@
+package nodebuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/flock"
+	dsbadger "github.com/ipfs/go-ds-badger4"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+// CheckStore verifies the EDS files of the stopped node under the given path against the roots of
+// the headers kept in its header store. With repair, corrupted EDSes are removed from the store.
+func CheckStore(ctx context.Context, path string, tp node.Type, repair bool) (_ *store.CheckResult, err error) {
+	if tp == node.Light {
+		return nil, errors.New("light nodes keep no EDS store")
+	}
+
+	path, err = storePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	flk := flock.New(lockPath(path))
+	ok, err := flk.TryLock()
+	if err != nil {
+		return nil, fmt.Errorf("locking file: %w", err)
+	}
+	if !ok {
+		return nil, ErrOpened
+	}
+	defer flk.Unlock() //nolint:errcheck
+
+	cfg, err := LoadConfig(configPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	edsStore, err := store.NewStore(cfg.Share.EDSStoreParams, cfg.Share.EDSStoreDir(path))
+	if err != nil {
+		return nil, fmt.Errorf("opening eds store: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, edsStore.Stop(ctx))
+	}()
+
+	ds, err := dsbadger.NewDatastore(dataPath(path), constraintBadgerConfig())
+	if err != nil {
+		return nil, fmt.Errorf("opening datastore: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, ds.Close())
+	}()
+
+	getRoots, _, err := headerRoots(ctx, ds, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return edsStore.Check(ctx, getRoots, repair)
+}
@@ -10,6 +10,8 @@ import (
 const (
 	DefaultRPCPort  = "26657"
 	DefaultGRPCPort = "9090"
+
+	defaultConcurrentWrites = 4
 )
 
 var MetricsEnabled bool
@@ -19,20 +21,27 @@ type Config struct {
 	IP       string
 	RPCPort  string
 	GRPCPort string
+	// ConcurrentWrites is the number of new blocks the bridge node extends and stores concurrently
+	// to keep up with bursts. Headers are broadcast in order regardless.
+	ConcurrentWrites int
 }
 
 // DefaultConfig returns default configuration for managing the
 // node's connection to a Celestia-Core endpoint.
 func DefaultConfig() Config {
 	return Config{
-		IP:       "",
-		RPCPort:  DefaultRPCPort,
-		GRPCPort: DefaultGRPCPort,
+		IP:               "",
+		RPCPort:          DefaultRPCPort,
+		GRPCPort:         DefaultGRPCPort,
+		ConcurrentWrites: defaultConcurrentWrites,
 	}
 }
 
 // Validate performs basic validation of the config.
 func (cfg *Config) Validate() error {
+	if cfg.ConcurrentWrites < 0 {
+		return fmt.Errorf("nodebuilder/core: concurrent writes cannot be negative")
+	}
 	if !cfg.IsEndpointConfigured() {
 		return nil
 	}
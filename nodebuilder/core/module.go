@@ -59,7 +59,7 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 					chainID p2p.Network,
 					opts []core.Option,
 				) (*core.Listener, error) {
-					opts = append(opts, core.WithChainID(chainID))
+					opts = append(opts, core.WithChainID(chainID), core.WithConcurrentWrites(cfg.ConcurrentWrites))
 
 					if MetricsEnabled {
 						opts = append(opts, core.WithMetrics())
@@ -0,0 +1,63 @@
+package nodebuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/flock"
+	dsbadger "github.com/ipfs/go-ds-badger4"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+// CollectGarbage removes the garbage of the EDS store of the stopped node under the given path and
+// compacts its datastore. Light nodes keep no EDS store, so only their datastore is compacted.
+func CollectGarbage(ctx context.Context, path string, tp node.Type) (*store.GCResult, error) {
+	path, err := storePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	flk := flock.New(lockPath(path))
+	ok, err := flk.TryLock()
+	if err != nil {
+		return nil, fmt.Errorf("locking file: %w", err)
+	}
+	if !ok {
+		return nil, ErrOpened
+	}
+	defer flk.Unlock() //nolint:errcheck
+
+	result := &store.GCResult{}
+	if tp != node.Light {
+		cfg, err := LoadConfig(configPath(path))
+		if err != nil {
+			return nil, fmt.Errorf("loading config: %w", err)
+		}
+		edsStore, err := store.NewStore(cfg.Share.EDSStoreParams, cfg.Share.EDSStoreDir(path))
+		if err != nil {
+			return nil, fmt.Errorf("opening eds store: %w", err)
+		}
+		result, err = edsStore.GC(ctx)
+		if err = errors.Join(err, edsStore.Stop(ctx)); err != nil {
+			return nil, err
+		}
+	}
+
+	ds, err := dsbadger.NewDatastore(dataPath(path), constraintBadgerConfig())
+	if err != nil {
+		return nil, fmt.Errorf("opening datastore: %w", err)
+	}
+	err = ds.CollectGarbage(ctx)
+	if err != nil {
+		err = fmt.Errorf("collecting datastore garbage: %w", err)
+	}
+	// the datastore compacts its tables on close
+	err = errors.Join(err, ds.Close())
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
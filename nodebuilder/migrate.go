@@ -0,0 +1,113 @@
+package nodebuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gofrs/flock"
+	"github.com/ipfs/go-datastore"
+	dsbadger "github.com/ipfs/go-ds-badger4"
+
+	libhead "github.com/celestiaorg/go-header"
+	headerstore "github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+// MigrateStore converts the CAR files of the legacy EDS store of the stopped node under the given
+// path into the current file format in place. The heights of the EDSes are taken from the header
+// store of the node. The migration resumes from its last checkpoint when interrupted.
+func MigrateStore(
+	ctx context.Context,
+	path string,
+	tp node.Type,
+	progress func(store.MigrationProgress),
+) (_ store.MigrationProgress, err error) {
+	if tp == node.Light {
+		return store.MigrationProgress{}, errors.New("light nodes keep no EDS store")
+	}
+
+	path, err = storePath(path)
+	if err != nil {
+		return store.MigrationProgress{}, err
+	}
+
+	flk := flock.New(lockPath(path))
+	ok, err := flk.TryLock()
+	if err != nil {
+		return store.MigrationProgress{}, fmt.Errorf("locking file: %w", err)
+	}
+	if !ok {
+		return store.MigrationProgress{}, ErrOpened
+	}
+	defer flk.Unlock() //nolint:errcheck
+
+	cfg, err := LoadConfig(configPath(path))
+	if err != nil {
+		return store.MigrationProgress{}, fmt.Errorf("loading config: %w", err)
+	}
+	edsStore, err := store.NewStore(cfg.Share.EDSStoreParams, cfg.Share.EDSStoreDir(path))
+	if err != nil {
+		return store.MigrationProgress{}, fmt.Errorf("opening eds store: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, edsStore.Stop(ctx))
+	}()
+
+	ds, err := dsbadger.NewDatastore(dataPath(path), constraintBadgerConfig())
+	if err != nil {
+		return store.MigrationProgress{}, fmt.Errorf("opening datastore: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, ds.Close())
+	}()
+
+	getRoots, head, err := headerRoots(ctx, ds, cfg)
+	if err != nil {
+		return store.MigrationProgress{}, err
+	}
+	status, err := edsStore.MigrateCAR(ctx, head, getRoots, progress)
+	if err != nil {
+		return status, err
+	}
+
+	// the index and transients directories of the legacy store are not used anymore
+	err = errors.Join(os.RemoveAll(indexPath(path)), os.RemoveAll(transientsPath(path)))
+	if err != nil {
+		return status, fmt.Errorf("removing legacy directories: %w", err)
+	}
+	return status, nil
+}
+
+// headerRoots returns the getter of the axis roots of the heights kept in the header store of the
+// node and the height of its head.
+func headerRoots(ctx context.Context, ds datastore.Batching, cfg *Config) (store.RootsGetter, uint64, error) {
+	hstore, err := headerstore.NewStore[*header.ExtendedHeader](ds, headerstore.WithParams(cfg.Header.Store))
+	if err != nil {
+		return nil, 0, fmt.Errorf("opening header store: %w", err)
+	}
+	head, err := hstore.Head(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading head: %w", err)
+	}
+
+	getRoots := func(ctx context.Context, height uint64) (*share.AxisRoots, error) {
+		if height > head.Height() {
+			return nil, store.ErrNotFound
+		}
+		eh, err := hstore.GetByHeight(ctx, height)
+		if errors.Is(err, libhead.ErrNotFound) {
+			return nil, store.ErrNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		return eh.DAH, nil
+	}
+	return getRoots, head.Height(), nil
+}
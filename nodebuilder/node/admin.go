@@ -2,9 +2,11 @@ package node
 
 import (
 	"context"
+	"time"
 
 	"github.com/cristalhq/jwt/v5"
 	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/ipfs/go-datastore"
 	logging "github.com/ipfs/go-log/v2"
 
 	"github.com/celestiaorg/celestia-node/libs/authtoken"
@@ -16,13 +18,20 @@ type module struct {
 	tp       Type
 	signer   jwt.Signer
 	verifier jwt.Verifier
+	cascade  RetrievalCascade
+	// edsStats is nil for light nodes, which keep no EDS store.
+	edsStats  EDSStatsFn
+	datastore datastore.Batching
 }
 
-func newModule(tp Type, signer jwt.Signer, verifier jwt.Verifier) Module {
+func newModule(tp Type, params moduleParams) Module {
 	return &module{
-		tp:       tp,
-		signer:   signer,
-		verifier: verifier,
+		tp:        tp,
+		signer:    params.Signer,
+		verifier:  params.Verifier,
+		cascade:   params.Cascade,
+		edsStats:  params.EDSStats,
+		datastore: params.Datastore,
 	}
 }
 
@@ -31,12 +40,27 @@ func newModule(tp Type, signer jwt.Signer, verifier jwt.Verifier) Module {
 type Info struct {
 	Type       Type   `json:"type"`
 	APIVersion string `json:"api_version"`
+	// RetrievalCascade is the effective order of the getters retrieving share data.
+	RetrievalCascade RetrievalCascade `json:"retrieval_cascade,omitempty"`
 }
 
+// GetterInfo describes a getter of the share retrieval cascade.
+type GetterInfo struct {
+	Name string `json:"name"`
+	// Timeout bounds the time a single request spends in the getter. Zero means the request
+	// timeout is split between the getters.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// RetrievalCascade lists the getters retrieving share data in the order they are tried.
+// It is provided by the share module.
+type RetrievalCascade []GetterInfo
+
 func (m *module) Info(context.Context) (Info, error) {
 	return Info{
-		Type:       m.tp,
-		APIVersion: APIVersion,
+		Type:             m.tp,
+		APIVersion:       APIVersion,
+		RetrievalCascade: m.cascade,
 	}, nil
 }
 
@@ -11,7 +11,7 @@ import (
 )
 
 func init() {
-	Cmd.AddCommand(nodeInfoCmd, logCmd, verifyCmd, authCmd)
+	Cmd.AddCommand(nodeInfoCmd, logCmd, verifyCmd, authCmd, storeStatsCmd)
 }
 
 var Cmd = &cobra.Command{
@@ -37,6 +37,22 @@ var nodeInfoCmd = &cobra.Command{
 	},
 }
 
+var storeStatsCmd = &cobra.Command{
+	Use:   "store-stats",
+	Args:  cobra.NoArgs,
+	Short: "Returns the disk usage of the node store and the heights kept in it.",
+	RunE: func(c *cobra.Command, _ []string) error {
+		client, err := cmdnode.ParseClientFromCtx(c.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		stats, err := client.Node.StoreStats(c.Context())
+		return cmdnode.PrintOutput(stats, err, nil)
+	},
+}
+
 var logCmd = &cobra.Command{
 	Use:   "log-level",
 	Args:  cobra.MinimumNArgs(1),
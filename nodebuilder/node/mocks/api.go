@@ -109,3 +109,18 @@ func (mr *MockModuleMockRecorder) Ready(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockModule)(nil).Ready), arg0)
 }
+
+// StoreStats mocks base method.
+func (m *MockModule) StoreStats(arg0 context.Context) (node.StoreStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreStats", arg0)
+	ret0, _ := ret[0].(node.StoreStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreStats indicates an expected call of StoreStats.
+func (mr *MockModuleMockRecorder) StoreStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreStats", reflect.TypeOf((*MockModule)(nil).StoreStats), arg0)
+}
@@ -2,15 +2,28 @@ package node
 
 import (
 	"github.com/cristalhq/jwt/v5"
+	"github.com/ipfs/go-datastore"
 	"go.uber.org/fx"
 )
 
 func ConstructModule(tp Type) fx.Option {
 	return fx.Module(
 		"node",
-		fx.Provide(func(signer jwt.Signer, verifier jwt.Verifier) Module {
-			return newModule(tp, signer, verifier)
+		fx.Provide(func(params moduleParams) Module {
+			return newModule(tp, params)
 		}),
 		fx.Provide(jwtSignerAndVerifier),
 	)
 }
+
+type moduleParams struct {
+	fx.In
+
+	Signer   jwt.Signer
+	Verifier jwt.Verifier
+	// Cascade is provided by the share module, which may be absent.
+	Cascade RetrievalCascade `optional:"true"`
+	// EDSStats is provided by the share module of the nodes keeping the EDS store.
+	EDSStats  EDSStatsFn `optional:"true"`
+	Datastore datastore.Batching
+}
@@ -24,6 +24,9 @@ type Module interface {
 	AuthVerify(ctx context.Context, token string) ([]auth.Permission, error)
 	// AuthNew signs and returns a new token with the given permissions.
 	AuthNew(ctx context.Context, perms []auth.Permission) (string, error)
+
+	// StoreStats returns the disk usage of the node store and the heights kept in its EDS store.
+	StoreStats(context.Context) (StoreStats, error)
 }
 
 var _ Module = (*API)(nil)
@@ -35,6 +38,7 @@ type API struct {
 		LogLevelSet func(ctx context.Context, name, level string) error                `perm:"admin"`
 		AuthVerify  func(ctx context.Context, token string) ([]auth.Permission, error) `perm:"admin"`
 		AuthNew     func(ctx context.Context, perms []auth.Permission) (string, error) `perm:"admin"`
+		StoreStats  func(context.Context) (StoreStats, error)                          `perm:"admin"`
 	}
 }
 
@@ -57,3 +61,7 @@ func (api *API) AuthVerify(ctx context.Context, token string) ([]auth.Permission
 func (api *API) AuthNew(ctx context.Context, perms []auth.Permission) (string, error) {
 	return api.Internal.AuthNew(ctx, perms)
 }
+
+func (api *API) StoreStats(ctx context.Context) (StoreStats, error) {
+	return api.Internal.StoreStats(ctx)
+}
@@ -0,0 +1,68 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-datastore"
+)
+
+// StoreStats describes the disk usage of the node store.
+type StoreStats struct {
+	// TotalSize is the size of the node store on disk in bytes, excluding the cold tier.
+	TotalSize uint64 `json:"total_size"`
+	// DatastoreSize is the size of the datastore keeping headers and indexes.
+	DatastoreSize uint64 `json:"datastore_size"`
+	// EDS is the statistics of the EDS store, which light nodes do not keep.
+	EDS *EDSStats `json:"eds,omitempty"`
+}
+
+// EDSStats describes the disk usage and the stored heights of the EDS store.
+type EDSStats struct {
+	EDSTierStats
+	// Cold is the statistics of the cold tier, if it is configured.
+	Cold *EDSTierStats `json:"cold,omitempty"`
+	// Shards are the statistics of the shards other than the EDS store itself, if sharding is
+	// configured.
+	Shards []*EDSTierStats `json:"shards,omitempty"`
+}
+
+// EDSTierStats describes the disk usage and the stored heights of a tier of the EDS store.
+type EDSTierStats struct {
+	// TotalSize is the size of the EDS files of the stored heights in bytes.
+	TotalSize int64 `json:"total_size"`
+	// ODSSize is the size of the ODS files, which keep the first quadrant of the EDSes.
+	ODSSize int64 `json:"ods_size"`
+	// Q4Size is the size of the Q4 files, which keep the fourth quadrant of the EDSes.
+	Q4Size int64 `json:"q4_size"`
+	// Heights is the number of stored heights.
+	Heights int `json:"heights"`
+	// LowestHeight is the lowest stored height.
+	LowestHeight uint64 `json:"lowest_height"`
+	// HighestHeight is the highest stored height.
+	HighestHeight uint64 `json:"highest_height"`
+	// LowestContiguousHeight is the lowest height, such that all heights from it up to the
+	// highest one are stored.
+	LowestContiguousHeight uint64 `json:"lowest_contiguous_height"`
+}
+
+// EDSStatsFn returns the statistics of the EDS store.
+// It is provided by the share module of the nodes keeping the EDS store.
+type EDSStatsFn func(context.Context) (*EDSStats, error)
+
+func (m *module) StoreStats(ctx context.Context) (StoreStats, error) {
+	size, err := datastore.DiskUsage(ctx, m.datastore)
+	if err != nil {
+		return StoreStats{}, fmt.Errorf("reading datastore size: %w", err)
+	}
+	stats := StoreStats{TotalSize: size, DatastoreSize: size}
+
+	if m.edsStats != nil {
+		stats.EDS, err = m.edsStats(ctx)
+		if err != nil {
+			return StoreStats{}, fmt.Errorf("reading EDS store stats: %w", err)
+		}
+		stats.TotalSize += uint64(stats.EDS.TotalSize)
+	}
+	return stats, nil
+}
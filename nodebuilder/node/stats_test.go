@@ -0,0 +1,29 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModule_StoreStats(t *testing.T) {
+	ctx := context.Background()
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+
+	// light nodes keep no EDS store
+	stats, err := newModule(Light, moduleParams{Datastore: ds}).StoreStats(ctx)
+	require.NoError(t, err)
+	require.Nil(t, stats.EDS)
+
+	edsStats := func(context.Context) (*EDSStats, error) {
+		return &EDSStats{EDSTierStats: EDSTierStats{TotalSize: 1024, Heights: 1}}, nil
+	}
+	stats, err = newModule(Full, moduleParams{Datastore: ds, EDSStats: edsStats}).StoreStats(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, stats.EDS)
+	require.Equal(t, 1, stats.EDS.Heights)
+	require.EqualValues(t, stats.DatastoreSize+1024, stats.TotalSize)
+}
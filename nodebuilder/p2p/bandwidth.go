@@ -0,0 +1,214 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hst "github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"golang.org/x/time/rate"
+
+	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex"
+)
+
+// bandwidthProtocols maps the protocols sharing the bandwidth limits to the part of their protocol
+// IDs identifying them.
+var bandwidthProtocols = map[string]string{
+	"shrex":   "/shrex/",
+	"bitswap": "/ipfs/bitswap/",
+	"header":  "/header-ex/",
+}
+
+// bandwidthConfig caps the bandwidth the data retrieval protocols of the node use, so that nodes
+// on constrained networks do not need traffic shaping on the OS level.
+type bandwidthConfig struct {
+	// DownloadLimit is the maximum amount of bytes per second read from streams of the limited
+	// protocols. Zero disables the limit.
+	DownloadLimit uint64
+	// UploadLimit is the maximum amount of bytes per second written to streams of the limited
+	// protocols. Zero disables the limit.
+	UploadLimit uint64
+	// Weights are the relative shares of the limits each of the protocols gets, keyed by "shrex",
+	// "bitswap" and "header". Protocols without a weight are not limited.
+	Weights map[string]uint
+}
+
+// defaultBandwidthConfig returns the unlimited bandwidth config, where data retrieval protocols
+// get most of the limits once set.
+func defaultBandwidthConfig() bandwidthConfig {
+	return bandwidthConfig{
+		Weights: map[string]uint{
+			"shrex":   4,
+			"bitswap": 4,
+			"header":  1,
+		},
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *bandwidthConfig) Validate() error {
+	for name := range cfg.Weights {
+		if _, ok := bandwidthProtocols[name]; !ok {
+			return fmt.Errorf("unknown bandwidth weight protocol: %s", name)
+		}
+	}
+	return nil
+}
+
+// bandwidthLimits are the rate limiters of a single protocol. Nil limiters do not limit.
+type bandwidthLimits struct {
+	download, upload *rate.Limiter
+}
+
+// limitedHost is a host limiting the bandwidth of streams of the protocols with a weight,
+// whether opened by the node or by remote peers.
+type limitedHost struct {
+	hst.Host
+
+	limits map[string]*bandwidthLimits
+}
+
+// newLimitedHost wraps the host into a limitedHost. It returns the host itself when no limits are
+// configured.
+func newLimitedHost(h hst.Host, cfg bandwidthConfig) (hst.Host, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.DownloadLimit == 0 && cfg.UploadLimit == 0 {
+		return h, nil
+	}
+
+	var total uint
+	for _, weight := range cfg.Weights {
+		total += weight
+	}
+	limits := make(map[string]*bandwidthLimits, len(cfg.Weights))
+	for name, weight := range cfg.Weights {
+		if weight == 0 {
+			continue
+		}
+		limits[name] = &bandwidthLimits{
+			download: newBandwidthLimiter(weightedLimit(cfg.DownloadLimit, weight, total)),
+			upload:   newBandwidthLimiter(weightedLimit(cfg.UploadLimit, weight, total)),
+		}
+	}
+	return &limitedHost{Host: h, limits: limits}, nil
+}
+
+// weightedLimit returns the share of the limit for the weight, which is never rounded down to zero.
+func weightedLimit(limit uint64, weight, total uint) uint64 {
+	if limit == 0 {
+		return 0
+	}
+	return max(limit*uint64(weight)/uint64(total), 1)
+}
+
+// newBandwidthLimiter creates a limiter allowing the given amount of bytes per second, bursting up
+// to a second worth of bytes. It returns nil for zero limit.
+func newBandwidthLimiter(limit uint64) *rate.Limiter {
+	if limit == 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(limit), int(limit))
+}
+
+var _ shrex.StreamWrapper = (*limitedHost)(nil)
+
+func (h *limitedHost) NewStream(
+	ctx context.Context,
+	p peer.ID,
+	pids ...protocol.ID,
+) (network.Stream, error) {
+	stream, err := h.Host.NewStream(ctx, p, pids...)
+	if err != nil {
+		return nil, err
+	}
+	return h.wrap(stream), nil
+}
+
+// WrapStream limits the bandwidth of the streams opened directly on connections, bypassing the
+// host, as shrex does over QUIC.
+func (h *limitedHost) WrapStream(stream network.Stream) network.Stream {
+	return h.wrap(stream)
+}
+
+func (h *limitedHost) SetStreamHandler(pid protocol.ID, handler network.StreamHandler) {
+	h.Host.SetStreamHandler(pid, h.wrapHandler(handler))
+}
+
+func (h *limitedHost) SetStreamHandlerMatch(
+	pid protocol.ID,
+	match func(protocol.ID) bool,
+	handler network.StreamHandler,
+) {
+	h.Host.SetStreamHandlerMatch(pid, match, h.wrapHandler(handler))
+}
+
+func (h *limitedHost) wrapHandler(handler network.StreamHandler) network.StreamHandler {
+	return func(stream network.Stream) {
+		handler(h.wrap(stream))
+	}
+}
+
+// wrap limits the bandwidth of the stream, if its protocol is limited.
+func (h *limitedHost) wrap(stream network.Stream) network.Stream {
+	for name, match := range bandwidthProtocols {
+		if !strings.Contains(string(stream.Protocol()), match) {
+			continue
+		}
+		if limits, ok := h.limits[name]; ok {
+			return &limitedStream{Stream: stream, limits: limits}
+		}
+		return stream
+	}
+	return stream
+}
+
+// limitedStream is a stream drawing the bytes it reads and writes from the limits.
+type limitedStream struct {
+	network.Stream
+
+	limits *bandwidthLimits
+}
+
+func (s *limitedStream) Read(p []byte) (int, error) {
+	if s.limits.download == nil {
+		return s.Stream.Read(p)
+	}
+	// never read more than the limiter can allow at once
+	if burst := s.limits.download.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := s.Stream.Read(p)
+	if n > 0 {
+		// bytes are accounted after reading, as the amount is unknown until then
+		if waitErr := s.limits.download.WaitN(context.Background(), n); waitErr != nil && err == nil {
+			err = waitErr
+		}
+	}
+	return n, err
+}
+
+func (s *limitedStream) Write(p []byte) (int, error) {
+	if s.limits.upload == nil {
+		return s.Stream.Write(p)
+	}
+	var written int
+	burst := s.limits.upload.Burst()
+	for len(p) > 0 {
+		chunk := p[:min(len(p), burst)]
+		if err := s.limits.upload.WaitN(context.Background(), len(chunk)); err != nil {
+			return written, err
+		}
+		n, err := s.Stream.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
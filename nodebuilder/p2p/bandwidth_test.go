@@ -0,0 +1,127 @@
+package p2p
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	hst "github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	quic "github.com/libp2p/go-libp2p/p2p/transport/quic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex"
+)
+
+func TestLimitedHost(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	client, server := net.Hosts()[0], net.Hosts()[1]
+
+	const limit = 32 << 10
+	cfg := defaultBandwidthConfig()
+	cfg.Weights = map[string]uint{"shrex": 1}
+	cfg.DownloadLimit = limit
+	limited, err := newLimitedHost(server, cfg)
+	require.NoError(t, err)
+
+	limitedID := protocol.ID("/test/shrex/v0.1.0/eds")
+	unlimitedID := protocol.ID("/test/other/v0.1.0")
+	received := make(chan time.Time, 2)
+	handler := func(stream network.Stream) {
+		defer stream.Close()
+		_, err := io.Copy(io.Discard, stream)
+		assert.NoError(t, err)
+		received <- time.Now()
+	}
+	limited.SetStreamHandler(limitedID, handler)
+	limited.SetStreamHandler(unlimitedID, handler)
+
+	send := func(id protocol.ID) time.Duration {
+		stream, err := client.NewStream(ctx, server.ID(), id)
+		require.NoError(t, err)
+		start := time.Now()
+		// the burst is used up right away, the rest is read at the limit
+		_, err = stream.Write(make([]byte, 3*limit))
+		require.NoError(t, err)
+		require.NoError(t, stream.CloseWrite())
+		select {
+		case end := <-received:
+			return end.Sub(start)
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+			return 0
+		}
+	}
+	require.GreaterOrEqual(t, send(limitedID), 1500*time.Millisecond)
+	require.Less(t, send(unlimitedID), time.Second)
+}
+
+func TestLimitedHost_QUICStream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	newQUICHost := func() hst.Host {
+		h, err := libp2p.New(
+			libp2p.NoTransports,
+			libp2p.Transport(quic.NewTransport),
+			libp2p.ListenAddrStrings("/ip4/127.0.0.1/udp/0/quic-v1"),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, h.Close()) })
+		return h
+	}
+	client, server := newQUICHost(), newQUICHost()
+	require.NoError(t, client.Connect(ctx, peer.AddrInfo{ID: server.ID(), Addrs: server.Addrs()}))
+
+	const limit = 32 << 10
+	cfg := defaultBandwidthConfig()
+	cfg.Weights = map[string]uint{"shrex": 1}
+	cfg.DownloadLimit = limit
+	limited, err := newLimitedHost(client, cfg)
+	require.NoError(t, err)
+
+	protocolID := protocol.ID("/test/shrex/v0.1.0/eds")
+	server.SetStreamHandler(protocolID, func(stream network.Stream) {
+		defer stream.Close()
+		_, err := stream.Write(make([]byte, 3*limit))
+		assert.NoError(t, err)
+	})
+
+	// shrex opens the stream on the QUIC connection directly, bypassing the host
+	stream, err := shrex.NewStream(ctx, limited, server.ID(), true, protocolID)
+	require.NoError(t, err)
+	require.IsType(t, &limitedStream{}, stream)
+	start := time.Now()
+	read, err := io.Copy(io.Discard, stream)
+	require.NoError(t, err)
+	require.EqualValues(t, 3*limit, read)
+	// the burst is used up right away, the rest is read at the limit
+	require.GreaterOrEqual(t, time.Since(start), 1500*time.Millisecond)
+}
+
+func TestBandwidthConfig(t *testing.T) {
+	cfg := defaultBandwidthConfig()
+	require.NoError(t, cfg.Validate())
+	cfg.Weights["unknown"] = 1
+	require.Error(t, cfg.Validate())
+
+	// no limits leave the host as is
+	net, err := mocknet.FullMeshConnected(1)
+	require.NoError(t, err)
+	h, err := newLimitedHost(net.Hosts()[0], defaultBandwidthConfig())
+	require.NoError(t, err)
+	require.Equal(t, net.Hosts()[0], h)
+
+	require.Equal(t, uint64(1), weightedLimit(2, 1, 9))
+	require.Zero(t, weightedLimit(0, 1, 9))
+}
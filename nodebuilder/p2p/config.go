@@ -27,6 +27,8 @@ type Config struct {
 	PeerExchange bool
 	// ConnManager is a configuration tuple for ConnectionManager.
 	ConnManager connManagerConfig
+	// Bandwidth caps the bandwidth of the shrex, bitswap and header exchange protocols.
+	Bandwidth bandwidthConfig
 
 	// Allowlist for IPColocation PubSub parameter, a list of string CIDRs
 	IPColocationWhitelist []string
@@ -63,6 +65,7 @@ func DefaultConfig(tp node.Type) Config {
 		MutualPeers:  []string{},
 		PeerExchange: tp == node.Bridge || tp == node.Full,
 		ConnManager:  defaultConnManagerConfig(tp),
+		Bandwidth:    defaultBandwidthConfig(),
 	}
 }
 
@@ -29,8 +29,9 @@ import (
 
 // routedHost constructs a wrapped Host that may fallback to address discovery,
 // if any top-level operation on the Host is provided with PeerID(Hash(PbK)) only.
-func routedHost(base HostBase, r routing.PeerRouting) hst.Host {
-	return routedhost.Wrap(base, r)
+// The bandwidth of streams of the data retrieval protocols is limited according to the config.
+func routedHost(base HostBase, r routing.PeerRouting, cfg *Config) (hst.Host, error) {
+	return newLimitedHost(routedhost.Wrap(base, r), cfg.Bandwidth)
 }
 
 func newUserAgent() *UserAgent {
@@ -1,9 +1,39 @@
 package pruner
 
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/pruner/full"
+	"github.com/celestiaorg/celestia-node/pruner/light"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
 var MetricsEnabled bool
 
 type Config struct {
 	EnableService bool
+	// RetentionWindow is how long blocks are kept before they are pruned. It can't be shorter than
+	// the availability window of the node type, which is used when it is zero.
+	RetentionWindow time.Duration
+	// RetentionBlocks sets the retention window as a number of blocks instead, converted to a
+	// duration with the block time of the network. It can't be set together with RetentionWindow.
+	RetentionBlocks uint64
+	// PinnedNamespaces lists the hex-encoded namespaces, whose blocks bridge and full nodes retain
+	// past the retention window. More can be pinned at runtime with the PinNamespace endpoint.
+	PinnedNamespaces []string `toml:",omitempty"`
+	// PrePruneGrace is the delay between notifying the SubscribePrePrune subscribers about the
+	// blocks about to be pruned and pruning them.
+	PrePruneGrace time.Duration
+	// MaxStoreSize limits the size of the EDS store of bridge and full nodes in bytes. Once exceeded,
+	// the oldest blocks are pruned before the retention window ends, but never within the
+	// availability window. Zero disables the limit.
+	MaxStoreSize uint64
 }
 
 func DefaultConfig() Config {
@@ -11,3 +41,74 @@ func DefaultConfig() Config {
 		EnableService: false,
 	}
 }
+
+// Validate ensures the retention window covers the availability window of the node type.
+func (cfg *Config) Validate(tp node.Type) error {
+	if cfg.RetentionWindow != 0 && cfg.RetentionBlocks != 0 {
+		return errors.New("retention window and retention blocks are mutually exclusive")
+	}
+	if cfg.RetentionWindow < 0 {
+		return errors.New("retention window cannot be negative")
+	}
+	if cfg.RetentionBlocks > uint64(math.MaxInt64/p2p.BlockTime) {
+		return fmt.Errorf("retention blocks %d overflow the retention window", cfg.RetentionBlocks)
+	}
+	if cfg.PrePruneGrace < 0 {
+		return errors.New("pre-prune grace cannot be negative")
+	}
+	if cfg.MaxStoreSize != 0 {
+		switch {
+		case tp == node.Light:
+			return errors.New("max store size is not supported by light nodes")
+		case !cfg.EnableService:
+			return errors.New("max store size requires pruning to be enabled")
+		case cfg.MaxStoreSize > math.MaxInt64:
+			return fmt.Errorf("max store size %d overflows", cfg.MaxStoreSize)
+		}
+	}
+	if _, err := cfg.pinnedNamespaces(); err != nil {
+		return err
+	}
+	retention, minimum := cfg.retentionWindow(tp), availabilityWindow(tp)
+	if retention < minimum {
+		return fmt.Errorf("retention window %s is shorter than the availability window %s",
+			retention.Duration(), minimum.Duration())
+	}
+	return nil
+}
+
+// pinnedNamespaces decodes the namespaces pinned in the config.
+func (cfg *Config) pinnedNamespaces() ([]share.Namespace, error) {
+	namespaces := make([]share.Namespace, 0, len(cfg.PinnedNamespaces))
+	for _, str := range cfg.PinnedNamespaces {
+		ns, err := share.NamespaceFromString(str)
+		if err != nil {
+			return nil, fmt.Errorf("pinned namespace %s: %w", str, err)
+		}
+		if err := ns.ValidateForData(); err != nil {
+			return nil, fmt.Errorf("pinned namespace %s: %w", str, err)
+		}
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces, nil
+}
+
+// retentionWindow returns the window blocks are kept for before they are pruned.
+func (cfg *Config) retentionWindow(tp node.Type) pruner.AvailabilityWindow {
+	switch {
+	case cfg.RetentionWindow != 0:
+		return pruner.AvailabilityWindow(cfg.RetentionWindow)
+	case cfg.RetentionBlocks != 0:
+		return pruner.AvailabilityWindow(time.Duration(cfg.RetentionBlocks) * p2p.BlockTime)
+	default:
+		return availabilityWindow(tp)
+	}
+}
+
+// availabilityWindow returns the window nodes of the type must keep blocks for.
+func availabilityWindow(tp node.Type) pruner.AvailabilityWindow {
+	if tp == node.Light {
+		return light.Window
+	}
+	return full.Window
+}
@@ -0,0 +1,65 @@
+package pruner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/pruner/full"
+	"github.com/celestiaorg/celestia-node/pruner/light"
+)
+
+func TestConfig_RetentionWindow(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		name     string
+		tp       node.Type
+		cfg      Config
+		expected pruner.AvailabilityWindow
+		wantErr  bool
+	}{
+		{name: "light default", tp: node.Light, expected: light.Window},
+		{name: "full default", tp: node.Full, expected: full.Window},
+		{
+			name:     "90 days",
+			tp:       node.Bridge,
+			cfg:      Config{RetentionWindow: 90 * day},
+			expected: pruner.AvailabilityWindow(90 * day),
+		},
+		{
+			name:     "blocks",
+			tp:       node.Full,
+			cfg:      Config{RetentionBlocks: 1_000_000},
+			expected: pruner.AvailabilityWindow(1_000_000 * p2p.BlockTime),
+		},
+		// light nodes may keep less than full nodes must
+		{
+			name:     "light minimum",
+			tp:       node.Light,
+			cfg:      Config{RetentionWindow: light.Window.Duration()},
+			expected: light.Window,
+		},
+		{name: "below full window", tp: node.Full, cfg: Config{RetentionWindow: light.Window.Duration()}, wantErr: true},
+		{name: "below light window", tp: node.Light, cfg: Config{RetentionBlocks: 100}, wantErr: true},
+		{name: "both set", tp: node.Full, cfg: Config{RetentionWindow: 90 * day, RetentionBlocks: 1}, wantErr: true},
+		{name: "negative", tp: node.Full, cfg: Config{RetentionWindow: -day}, wantErr: true},
+		{name: "overflow", tp: node.Full, cfg: Config{RetentionBlocks: 1 << 62}, wantErr: true},
+		{name: "invalid pin", tp: node.Full, cfg: Config{PinnedNamespaces: []string{"pin"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate(tt.tp)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, tt.cfg.retentionWindow(tt.tp))
+		})
+	}
+}
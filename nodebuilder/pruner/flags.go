@@ -5,16 +5,38 @@ import (
 	flag "github.com/spf13/pflag"
 )
 
-const pruningFlag = "experimental-pruning"
+const (
+	pruningFlag                = "experimental-pruning"
+	pruningRetentionFlag       = "experimental-pruning-retention"
+	pruningRetentionBlocksFlag = "experimental-pruning-retention-blocks"
+)
 
 func Flags() *flag.FlagSet {
 	flags := &flag.FlagSet{}
 
 	flags.Bool(pruningFlag, false, "EXPERIMENTAL: Enables pruning of blocks outside the pruning window.")
+	flags.Duration(
+		pruningRetentionFlag,
+		0,
+		"EXPERIMENTAL: Keeps blocks for the given duration before pruning them. "+
+			"Defaults to the availability window, which is also the minimum.",
+	)
+	flags.Uint64(
+		pruningRetentionBlocksFlag,
+		0,
+		"EXPERIMENTAL: Keeps the given number of the most recent blocks before pruning them. "+
+			"Conflicts with --"+pruningRetentionFlag+".",
+	)
 
 	return flags
 }
 
 func ParseFlags(cmd *cobra.Command, cfg *Config) {
 	cfg.EnableService = cmd.Flag(pruningFlag).Changed
+	if cmd.Flag(pruningRetentionFlag).Changed {
+		cfg.RetentionWindow, _ = cmd.Flags().GetDuration(pruningRetentionFlag)
+	}
+	if cmd.Flag(pruningRetentionBlocksFlag).Changed {
+		cfg.RetentionBlocks, _ = cmd.Flags().GetUint64(pruningRetentionBlocksFlag)
+	}
 }
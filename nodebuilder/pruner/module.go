@@ -6,24 +6,55 @@ import (
 	"github.com/ipfs/go-datastore"
 	"go.uber.org/fx"
 
+	libhead "github.com/celestiaorg/go-header"
+
 	"github.com/celestiaorg/celestia-node/core"
+	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/libs/fxutil"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/pruner"
 	"github.com/celestiaorg/celestia-node/pruner/archival"
 	"github.com/celestiaorg/celestia-node/pruner/full"
 	"github.com/celestiaorg/celestia-node/pruner/light"
+	"github.com/celestiaorg/celestia-node/store"
 )
 
 func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 	baseComponents := fx.Options(
 		fx.Supply(cfg),
+		fx.Error(cfg.Validate(tp)),
 		availWindow(tp, cfg.EnableService),
+		fx.Provide(fx.Annotate(
+			func(ds datastore.Batching) (*pruner.Pins, error) {
+				namespaces, err := cfg.pinnedNamespaces()
+				if err != nil {
+					return nil, err
+				}
+				return pruner.NewPins(ds, namespaces...), nil
+			},
+			fx.OnStart(func(ctx context.Context, pins *pruner.Pins) error {
+				return pins.Load(ctx)
+			}),
+		)),
+		fx.Provide(func(params moduleParams) Module {
+			return &module{tp: tp, pins: params.Pins, service: params.Service}
+		}),
 	)
 
 	prunerService := fx.Options(
 		fx.Provide(fx.Annotate(
-			newPrunerService,
+			func(
+				p pruner.Pruner,
+				getter libhead.Store[*header.ExtendedHeader],
+				ds datastore.Batching,
+				opts []pruner.Option,
+			) (*pruner.Service, error) {
+				opts = append(opts, pruner.WithPruneGrace(cfg.PrePruneGrace))
+				// blocks are kept for the retention window, which may exceed the availability window
+				return newPrunerService(p, cfg.retentionWindow(tp), getter, ds, opts...)
+			},
+			// the options are only provided by bridge and full nodes
+			fx.ParamTags(``, ``, ``, `optional:"true"`),
 			fx.OnStart(func(ctx context.Context, p *pruner.Service) error {
 				return p.Start(ctx)
 			}),
@@ -45,7 +76,7 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 				fx.Provide(light.NewPruner),
 			)
 		}
-		// We do not trigger DetectPreviousRun for Light nodes, to allow them to disable pruning at wish.
+		// We do not trigger ConvertToArchival for Light nodes, to allow them to disable pruning at wish.
 		// They are not expected to store a samples outside the sampling window and so partially pruned is
 		// not a concern.
 		return fx.Module("prune",
@@ -57,12 +88,18 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 				baseComponents,
 				prunerService,
 				fxutil.ProvideAs(full.NewPruner, new(pruner.Pruner)),
+				fx.Provide(func(edsStore *store.Store) []pruner.Option {
+					return quotaOptions(tp, cfg, edsStore)
+				}),
+				fx.Invoke(func(ctx context.Context, ds datastore.Batching) error {
+					return pruner.ConvertToPruned(ctx, ds)
+				}),
 			)
 		}
 		return fx.Module("prune",
 			baseComponents,
 			fx.Invoke(func(ctx context.Context, ds datastore.Batching) error {
-				return pruner.DetectPreviousRun(ctx, ds)
+				return pruner.ConvertToArchival(ctx, ds)
 			}),
 		)
 	case node.Bridge:
@@ -71,6 +108,12 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 				baseComponents,
 				prunerService,
 				fxutil.ProvideAs(full.NewPruner, new(pruner.Pruner)),
+				fx.Provide(func(edsStore *store.Store) []pruner.Option {
+					return quotaOptions(tp, cfg, edsStore)
+				}),
+				fx.Invoke(func(ctx context.Context, ds datastore.Batching) error {
+					return pruner.ConvertToPruned(ctx, ds)
+				}),
 				fx.Provide(func(window pruner.AvailabilityWindow) []core.Option {
 					return []core.Option{core.WithAvailabilityWindow(window)}
 				}),
@@ -79,7 +122,7 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		return fx.Module("prune",
 			baseComponents,
 			fx.Invoke(func(ctx context.Context, ds datastore.Batching) error {
-				return pruner.DetectPreviousRun(ctx, ds)
+				return pruner.ConvertToArchival(ctx, ds)
 			}),
 			fx.Provide(func() []core.Option {
 				return []core.Option{}
@@ -90,6 +133,36 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 	}
 }
 
+// quotaOptions limits the size of the EDS store, if configured.
+func quotaOptions(tp node.Type, cfg *Config, edsStore *store.Store) []pruner.Option {
+	if cfg.MaxStoreSize == 0 {
+		return nil
+	}
+	usage := func(ctx context.Context) (int64, error) {
+		stats, err := edsStore.Stats(ctx)
+		if err != nil {
+			return 0, err
+		}
+		size := stats.TotalSize
+		if stats.Cold != nil {
+			size += stats.Cold.TotalSize
+		}
+		for _, shard := range stats.Shards {
+			size += shard.TotalSize
+		}
+		return size, nil
+	}
+	return []pruner.Option{pruner.WithQuota(int64(cfg.MaxStoreSize), availabilityWindow(tp), usage)}
+}
+
+type moduleParams struct {
+	fx.In
+
+	Pins *pruner.Pins
+	// Service is only provided with pruning enabled
+	Service *pruner.Service `optional:"true"`
+}
+
 func availWindow(tp node.Type, pruneEnabled bool) fx.Option {
 	switch tp {
 	case node.Light:
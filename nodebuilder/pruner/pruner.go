@@ -0,0 +1,126 @@
+package pruner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var _ Module = (*API)(nil)
+
+// Module defines the API related to the pruning of blocks.
+//
+//go:generate mockgen -destination=mocks/api.go -package=mocks . Module
+type Module interface {
+	// PinNamespace retains the blocks containing the namespace past the retention window, so that
+	// its shares stay available on pruned nodes. The whole ODS of the retained blocks is kept, not
+	// only the namespace data. Blocks pruned already are not restored.
+	PinNamespace(ctx context.Context, ns share.Namespace) error
+	// UnpinNamespace stops retaining the blocks containing the namespace. The blocks retained so
+	// far are kept.
+	UnpinNamespace(ctx context.Context, ns share.Namespace) error
+	// PinnedNamespaces returns the pinned namespaces, including the ones pinned in the config.
+	PinnedNamespaces(ctx context.Context) ([]share.Namespace, error)
+	// Preview returns the heights the next pruning pass would remove and approximately how many
+	// bytes it would reclaim, without removing anything.
+	Preview(ctx context.Context) (*pruner.Preview, error)
+	// PrunedRanges returns the ranges of heights pruned so far, telling blocks removed by pruning
+	// apart from ones the node never had.
+	PrunedRanges(ctx context.Context) ([]pruner.HeightRange, error)
+	// SubscribePrePrune subscribes to the headers of the blocks about to be pruned. They are sent
+	// the configured grace delay before the blocks are pruned, giving the last chance to extract
+	// their data.
+	SubscribePrePrune(ctx context.Context) (<-chan *header.ExtendedHeader, error)
+}
+
+// API is a wrapper around the Module for RPC.
+type API struct {
+	Internal struct {
+		PinNamespace      func(ctx context.Context, ns share.Namespace) error              `perm:"admin"`
+		UnpinNamespace    func(ctx context.Context, ns share.Namespace) error              `perm:"admin"`
+		PinnedNamespaces  func(ctx context.Context) ([]share.Namespace, error)             `perm:"read"`
+		Preview           func(ctx context.Context) (*pruner.Preview, error)               `perm:"read"`
+		PrunedRanges      func(ctx context.Context) ([]pruner.HeightRange, error)          `perm:"read"`
+		SubscribePrePrune func(ctx context.Context) (<-chan *header.ExtendedHeader, error) `perm:"admin"`
+	}
+}
+
+func (api *API) PinNamespace(ctx context.Context, ns share.Namespace) error {
+	return api.Internal.PinNamespace(ctx, ns)
+}
+
+func (api *API) UnpinNamespace(ctx context.Context, ns share.Namespace) error {
+	return api.Internal.UnpinNamespace(ctx, ns)
+}
+
+func (api *API) PinnedNamespaces(ctx context.Context) ([]share.Namespace, error) {
+	return api.Internal.PinnedNamespaces(ctx)
+}
+
+func (api *API) Preview(ctx context.Context) (*pruner.Preview, error) {
+	return api.Internal.Preview(ctx)
+}
+
+func (api *API) PrunedRanges(ctx context.Context) ([]pruner.HeightRange, error) {
+	return api.Internal.PrunedRanges(ctx)
+}
+
+func (api *API) SubscribePrePrune(ctx context.Context) (<-chan *header.ExtendedHeader, error) {
+	return api.Internal.SubscribePrePrune(ctx)
+}
+
+// ErrPruningDisabled is returned by the endpoints requiring pruning on a node running without it.
+var ErrPruningDisabled = errors.New("pruning is disabled")
+
+// errLightNodePins is returned by the pins endpoints on light nodes, which keep no blocks.
+var errLightNodePins = errors.New("light nodes keep no blocks to retain")
+
+type module struct {
+	tp      node.Type
+	pins    *pruner.Pins
+	service *pruner.Service
+}
+
+func (m *module) PinNamespace(ctx context.Context, ns share.Namespace) error {
+	if m.tp == node.Light {
+		return errLightNodePins
+	}
+	return m.pins.Pin(ctx, ns)
+}
+
+func (m *module) UnpinNamespace(ctx context.Context, ns share.Namespace) error {
+	if m.tp == node.Light {
+		return errLightNodePins
+	}
+	return m.pins.Unpin(ctx, ns)
+}
+
+func (m *module) PinnedNamespaces(context.Context) ([]share.Namespace, error) {
+	return m.pins.List(), nil
+}
+
+func (m *module) Preview(ctx context.Context) (*pruner.Preview, error) {
+	if m.service == nil {
+		return nil, ErrPruningDisabled
+	}
+	return m.service.Preview(ctx)
+}
+
+func (m *module) PrunedRanges(ctx context.Context) ([]pruner.HeightRange, error) {
+	if m.service == nil {
+		// nothing is pruned without pruning
+		return nil, nil
+	}
+	return m.service.PrunedRanges(ctx)
+}
+
+func (m *module) SubscribePrePrune(ctx context.Context) (<-chan *header.ExtendedHeader, error) {
+	if m.service == nil {
+		return nil, ErrPruningDisabled
+	}
+	return m.service.SubscribePrePrune(ctx), nil
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	modprune "github.com/celestiaorg/celestia-node/nodebuilder/pruner"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
+	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/peers"
 	"github.com/celestiaorg/celestia-node/state"
 )
 
@@ -43,6 +44,12 @@ func WithNetwork(net p2p.Network) fx.Option {
 	return fx.Replace(net)
 }
 
+// WithPeerSelector sets the strategy selecting peers for share retrieval over shrex, e.g.
+// peers.NewAffinitySelector to route retrieval to an own fleet of nodes first.
+func WithPeerSelector(selector peers.PeerSelector) fx.Option {
+	return fx.Supply(fx.Annotate(selector, fx.As(new(peers.PeerSelector))))
+}
+
 // WithBootstrappers sets custom bootstrap peers.
 func WithBootstrappers(peers p2p.Bootstrappers) fx.Option {
 	return fx.Replace(peers)
@@ -95,6 +102,7 @@ func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type) fx.Opti
 		fx.Invoke(fraud.WithMetrics[*header.ExtendedHeader]),
 		fx.Invoke(node.WithMetrics),
 		fx.Invoke(share.WithDiscoveryMetrics),
+		fx.Invoke(share.WithGetterMetrics),
 	)
 
 	samplingMetrics := fx.Options(
@@ -110,6 +118,7 @@ func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type) fx.Opti
 		opts = fx.Options(
 			baseComponents,
 			fx.Invoke(share.WithStoreMetrics),
+			fx.Invoke(share.WithScrubberMetrics),
 			fx.Invoke(share.WithShrexServerMetrics),
 			samplingMetrics,
 		)
@@ -122,6 +131,7 @@ func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type) fx.Opti
 		opts = fx.Options(
 			baseComponents,
 			fx.Invoke(share.WithStoreMetrics),
+			fx.Invoke(share.WithScrubberMetrics),
 			fx.Invoke(share.WithShrexServerMetrics),
 		)
 	default:
@@ -0,0 +1,146 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/wrapper"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+// AxisHalfResult wraps the return value of the GetRow and GetColumn endpoints
+// because Json-RPC doesn't support more than two return values.
+type AxisHalfResult struct {
+	// Shares are the shares of the first half of the axis, from which the second half can be
+	// recomputed.
+	Shares []share.Share `json:"shares"`
+	// Proof proves the Shares against the DAH root of the axis.
+	Proof *nmt.Proof `json:"proof"`
+}
+
+func (m module) GetRow(ctx context.Context, header *header.ExtendedHeader, rowIdx int) (*AxisHalfResult, error) {
+	return m.getAxisHalf(ctx, header, rsmt2d.Row, rowIdx)
+}
+
+func (m module) GetColumn(ctx context.Context, header *header.ExtendedHeader, colIdx int) (*AxisHalfResult, error) {
+	return m.getAxisHalf(ctx, header, rsmt2d.Col, colIdx)
+}
+
+// GetSamples gets the shares at the given coordinates together with their inclusion proofs against
+// the DAH row roots. Rows are fetched and verified once, no matter how many of their coordinates
+// are requested.
+func (m module) GetSamples(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	coords []Coordinate,
+) ([]shwap.Sample, error) {
+	width := len(header.DAH.RowRoots)
+	rows := make(map[int][]int)
+	for _, coord := range coords {
+		if coord.Row < 0 || coord.Row >= width || coord.Col < 0 || coord.Col >= width {
+			return nil, fmt.Errorf("coordinate (%d, %d) is out of the square bounds", coord.Row, coord.Col)
+		}
+		rows[coord.Row] = append(rows[coord.Row], coord.Col)
+	}
+
+	samples := make(map[Coordinate]shwap.Sample, len(coords))
+	for rowIdx, cols := range rows {
+		shares, tree, err := m.getAxis(ctx, header, rsmt2d.Row, rowIdx)
+		if err != nil {
+			return nil, err
+		}
+		for _, colIdx := range cols {
+			proof, err := tree.ProveRange(colIdx, colIdx+1)
+			if err != nil {
+				return nil, fmt.Errorf("proving share at row %d, col %d: %w", rowIdx, colIdx, err)
+			}
+			samples[Coordinate{Row: rowIdx, Col: colIdx}] = shwap.Sample{
+				Share:     shares[colIdx],
+				Proof:     &proof,
+				ProofType: rsmt2d.Row,
+			}
+		}
+	}
+
+	result := make([]shwap.Sample, len(coords))
+	for i, coord := range coords {
+		result[i] = samples[coord]
+	}
+	return result, nil
+}
+
+// getAxisHalf fetches the first half of the axis and proves it against the DAH root of the axis.
+func (m module) getAxisHalf(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	axisType rsmt2d.Axis,
+	axisIdx int,
+) (*AxisHalfResult, error) {
+	width := len(header.DAH.RowRoots)
+	if axisIdx < 0 || axisIdx >= width {
+		return nil, fmt.Errorf("axis index %d is out of the square bounds", axisIdx)
+	}
+
+	shares, tree, err := m.getAxis(ctx, header, axisType, axisIdx)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := tree.ProveRange(0, width/2)
+	if err != nil {
+		return nil, fmt.Errorf("proving axis half: %w", err)
+	}
+	return &AxisHalfResult{Shares: shares[:width/2], Proof: &proof}, nil
+}
+
+// getAxis fetches the first half of the axis, recomputes the whole axis out of it and verifies it
+// against the DAH root of the axis. It returns the shares of the axis along with the tree built
+// over them.
+func (m module) getAxis(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	axisType rsmt2d.Axis,
+	axisIdx int,
+) ([]share.Share, *wrapper.ErasuredNamespacedMerkleTree, error) {
+	width := len(header.DAH.RowRoots)
+	coords := make([]Coordinate, width/2)
+	for i := range coords {
+		coords[i] = Coordinate{Row: axisIdx, Col: i}
+		if axisType == rsmt2d.Col {
+			coords[i] = Coordinate{Row: i, Col: axisIdx}
+		}
+	}
+	half, err := m.GetShares(ctx, header, coords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shares, err := eds.AxisHalf{Shares: half}.Extended()
+	if err != nil {
+		return nil, nil, fmt.Errorf("extending axis half: %w", err)
+	}
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(width/2), uint(axisIdx))
+	for _, shr := range shares {
+		if err := tree.Push(shr); err != nil {
+			return nil, nil, fmt.Errorf("building axis tree: %w", err)
+		}
+	}
+	root, err := tree.Root()
+	if err != nil {
+		return nil, nil, fmt.Errorf("computing axis root: %w", err)
+	}
+	expected := header.DAH.RowRoots[axisIdx]
+	if axisType == rsmt2d.Col {
+		expected = header.DAH.ColumnRoots[axisIdx]
+	}
+	if !bytes.Equal(root, expected) {
+		return nil, nil, fmt.Errorf("root of axis %d does not match the DAH", axisIdx)
+	}
+	return shares, &tree, nil
+}
@@ -0,0 +1,124 @@
+package share
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/nmt"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestModule_GetAxisHalf(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const odsSize = 4
+	square := edstest.RandEDS(t, odsSize)
+	roots, err := share.NewAxisRoots(square)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	ctrl := gomock.NewController(t)
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetShare(gomock.Any(), eh, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *header.ExtendedHeader, row, col int) (share.Share, error) {
+			return square.GetCell(uint(row), uint(col)), nil
+		}).AnyTimes()
+	m := module{Getter: getter}
+
+	t.Run("row", func(t *testing.T) {
+		res, err := m.GetRow(ctx, eh, 1)
+		require.NoError(t, err)
+		require.Equal(t, square.Row(1)[:odsSize], res.Shares)
+		verifyAxisHalf(t, res, roots.RowRoots[1])
+	})
+
+	t.Run("column", func(t *testing.T) {
+		res, err := m.GetColumn(ctx, eh, 2)
+		require.NoError(t, err)
+		require.Equal(t, square.Col(2)[:odsSize], res.Shares)
+		verifyAxisHalf(t, res, roots.ColumnRoots[2])
+	})
+
+	t.Run("extended row", func(t *testing.T) {
+		const lastRow = 2*odsSize - 1
+		res, err := m.GetRow(ctx, eh, lastRow)
+		require.NoError(t, err)
+		require.Equal(t, square.Row(lastRow)[:odsSize], res.Shares)
+	})
+
+	t.Run("out of bounds", func(t *testing.T) {
+		_, err := m.GetRow(ctx, eh, 2*odsSize)
+		require.Error(t, err)
+		_, err = m.GetColumn(ctx, eh, -1)
+		require.Error(t, err)
+	})
+
+	t.Run("roots mismatch", func(t *testing.T) {
+		otherEh := headertest.RandExtendedHeaderWithRoot(t, roots)
+		otherSquare := edstest.RandEDS(t, odsSize)
+		getter.EXPECT().GetShare(gomock.Any(), otherEh, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *header.ExtendedHeader, row, col int) (share.Share, error) {
+				return otherSquare.GetCell(uint(row), uint(col)), nil
+			}).AnyTimes()
+		_, err := m.GetRow(ctx, otherEh, 0)
+		require.Error(t, err)
+	})
+}
+
+// verifyAxisHalf verifies the proof of the first half of an axis from the original data square.
+// The half spans multiple namespaces, so it is verified as a single subtree root of the axis tree.
+func verifyAxisHalf(t *testing.T, res *AxisHalfResult, root []byte) {
+	t.Helper()
+	tree := nmt.New(share.NewSHA256Hasher(), nmt.NamespaceIDSize(share.NamespaceSize), nmt.IgnoreMaxNamespace(true))
+	for _, shr := range res.Shares {
+		err := tree.Push(append(append([]byte{}, share.GetNamespace(shr)...), shr...))
+		require.NoError(t, err)
+	}
+	subtreeRoot, err := tree.Root()
+	require.NoError(t, err)
+
+	hasher := nmt.NewNmtHasher(share.NewSHA256Hasher(), share.NamespaceSize, true)
+	ok, err := res.Proof.VerifySubtreeRootInclusion(hasher, [][]byte{subtreeRoot}, len(res.Shares), root)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestModule_GetSamples(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const odsSize = 4
+	square := edstest.RandEDS(t, odsSize)
+	roots, err := share.NewAxisRoots(square)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	ctrl := gomock.NewController(t)
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetShare(gomock.Any(), eh, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *header.ExtendedHeader, row, col int) (share.Share, error) {
+			return square.GetCell(uint(row), uint(col)), nil
+		}).AnyTimes()
+	m := module{Getter: getter}
+
+	coords := []Coordinate{{Row: 0, Col: 0}, {Row: 2*odsSize - 1, Col: odsSize + 1}, {Row: 0, Col: 3}}
+	samples, err := m.GetSamples(ctx, eh, coords)
+	require.NoError(t, err)
+	require.Len(t, samples, len(coords))
+	for i, coord := range coords {
+		require.Equal(t, square.GetCell(uint(coord.Row), uint(coord.Col)), samples[i].Share)
+		require.NoError(t, samples[i].Verify(roots, coord.Row, coord.Col))
+	}
+
+	_, err = m.GetSamples(ctx, eh, []Coordinate{{Row: 0, Col: 2 * odsSize}})
+	require.Error(t, err)
+}
@@ -0,0 +1,234 @@
+package share
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/store"
+	"github.com/celestiaorg/celestia-node/store/file"
+)
+
+// backupIndexName is the name of the index of the backups within the backup directory.
+const backupIndexName = "backups.json"
+
+// Backup describes an incremental backup, which is a snapshot of the headers and EDSes of the
+// heights added since the previous backup.
+type Backup struct {
+	// File is the name of the snapshot file within the backup directory.
+	File string `json:"file"`
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	// SHA256 is the checksum of the snapshot file, which is verified before restoring it.
+	SHA256 string `json:"sha256"`
+}
+
+// BackupIndex lists the backups of a backup directory in the order they were written.
+type BackupIndex struct {
+	Backups []Backup `json:"backups"`
+}
+
+// ReadBackupIndex reads the index of the backup directory. The index of a missing or empty
+// directory is empty.
+func ReadBackupIndex(dir string) (*BackupIndex, error) {
+	data, err := os.ReadFile(filepath.Join(dir, backupIndexName))
+	if errors.Is(err, os.ErrNotExist) {
+		return &BackupIndex{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading backup index: %w", err)
+	}
+	index := &BackupIndex{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("unmarshaling backup index: %w", err)
+	}
+	return index, nil
+}
+
+// Next returns the height following the last backup, which the next backup starts from.
+func (idx *BackupIndex) Next() uint64 {
+	var next uint64 = 1
+	for _, backup := range idx.Backups {
+		next = max(next, backup.To+1)
+	}
+	return next
+}
+
+// Verify checks that the backups cover the heights without gaps or overlaps and that their files
+// match the checksums, without reading the snapshots.
+func (idx *BackupIndex) Verify(dir string) error {
+	var next uint64
+	for i, backup := range idx.Backups {
+		if i > 0 && backup.From > next {
+			return fmt.Errorf("backups miss heights %d to %d", next, backup.From-1)
+		}
+		if i > 0 && backup.From < next {
+			return fmt.Errorf("backup %s overlaps heights %d to %d", backup.File, backup.From, min(backup.To, next-1))
+		}
+		next = backup.To + 1
+
+		sum, err := checksumFile(filepath.Join(dir, backup.File))
+		if err != nil {
+			return fmt.Errorf("backup %s: %w", backup.File, err)
+		}
+		if sum != backup.SHA256 {
+			return fmt.Errorf("backup %s: checksum %s does not match %s", backup.File, sum, backup.SHA256)
+		}
+	}
+	return nil
+}
+
+func (idx *BackupIndex) write(dir string) error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, backupIndexName)
+	tmpPath := path + file.TmpFileExt
+	if err := os.WriteFile(tmpPath, data, exportFilePerm); err != nil {
+		return fmt.Errorf("writing backup index: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Join(fmt.Errorf("writing backup index: %w", err), os.Remove(tmpPath))
+	}
+	return nil
+}
+
+// WriteBackup writes a backup of the heights in [from, to] to the backup directory and records it
+// in the index. The backup must continue the previous backups without a gap or an overlap, so that
+// they can be restored in order.
+func WriteBackup(
+	ctx context.Context,
+	dir string,
+	headers HeaderGetter,
+	getter shwap.Getter,
+	from, to uint64,
+) (*Backup, error) {
+	if err := os.MkdirAll(dir, exportDirPerm); err != nil {
+		return nil, fmt.Errorf("creating backup directory: %w", err)
+	}
+	index, err := ReadBackupIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid backup range: %d to %d", from, to)
+	}
+	if len(index.Backups) != 0 {
+		switch next := index.Next(); {
+		case from > next:
+			return nil, fmt.Errorf("backup from height %d would miss heights %d to %d", from, next, from-1)
+		case from < next:
+			return nil, fmt.Errorf("backup from height %d would overlap heights %d to %d backed up already",
+				from, from, min(to, next-1))
+		}
+	}
+
+	backup := Backup{File: fmt.Sprintf("backup-%d-%d.tar", from, to), From: from, To: to}
+	path := filepath.Join(dir, backup.File)
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("backup %s already exists", backup.File)
+	}
+
+	// the snapshot is written to a temporary file, so that interrupted backups leave no file behind
+	f, err := os.CreateTemp(dir, backup.File+".*"+file.TmpFileExt)
+	if err != nil {
+		return nil, fmt.Errorf("creating backup file: %w", err)
+	}
+	hash := sha256.New()
+	w := bufio.NewWriter(io.MultiWriter(f, hash))
+	err = WriteSnapshot(ctx, w, headers, getter, from, to)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	err = errors.Join(err, f.Close())
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("writing backup file: %w", err), os.Remove(f.Name()))
+	}
+
+	backup.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	index.Backups = append(index.Backups, backup)
+	if err := index.write(dir); err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+// RestoreBackups verifies the backups of the backup directory and reads them into the store in
+// order. Every header is passed to verify, and the EDSes are verified against the trusted headers
+// it returns, the way ReadSnapshot does.
+func RestoreBackups(
+	ctx context.Context,
+	dir string,
+	edsStore *store.Store,
+	verify VerifyHeaderFn,
+) ([]Backup, error) {
+	index, err := ReadBackupIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(index.Backups) == 0 {
+		return nil, fmt.Errorf("no backups in %s", dir)
+	}
+	if err := index.Verify(dir); err != nil {
+		return nil, err
+	}
+
+	for _, backup := range index.Backups {
+		if err := restoreBackup(ctx, dir, backup, edsStore, verify); err != nil {
+			return nil, fmt.Errorf("backup %s: %w", backup.File, err)
+		}
+	}
+	return index.Backups, nil
+}
+
+func restoreBackup(
+	ctx context.Context,
+	dir string,
+	backup Backup,
+	edsStore *store.Store,
+	verify VerifyHeaderFn,
+) error {
+	f, err := os.Open(filepath.Join(dir, backup.File))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	manifest, err := ReadSnapshot(ctx, bufio.NewReader(f), edsStore, verify)
+	if err != nil {
+		return err
+	}
+	if manifest.From != backup.From || manifest.To != backup.To {
+		return fmt.Errorf("snapshot of heights %d to %d does not match the index", manifest.From, manifest.To)
+	}
+	return nil
+}
+
+func checksumFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
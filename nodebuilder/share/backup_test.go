@@ -0,0 +1,88 @@
+package share
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+func TestBackup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	squares := []*rsmt2d.ExtendedDataSquare{edstest.RandEDS(t, 4), share.EmptyEDS(), edstest.RandEDS(t, 8)}
+	headers := headertest.ExtendedHeadersFromEdsses(t, squares)
+	ctrl := gomock.NewController(t)
+	headerGetter := headerMock.NewMockModule(ctrl)
+	for _, eh := range headers {
+		headerGetter.EXPECT().GetByHeight(gomock.Any(), eh.Height()).Return(eh, nil).AnyTimes()
+	}
+	getter := mock.NewMockGetter(ctrl)
+	for i, eh := range headers {
+		getter.EXPECT().GetEDS(gomock.Any(), eh).Return(squares[i], nil).AnyTimes()
+	}
+
+	dir := t.TempDir()
+	index, err := ReadBackupIndex(dir)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, index.Next())
+
+	backup, err := WriteBackup(ctx, dir, headerGetter, getter, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, "backup-1-2.tar", backup.File)
+	// backups must continue the previous ones without a gap or an overlap
+	_, err = WriteBackup(ctx, dir, headerGetter, getter, 4, 4)
+	require.Error(t, err)
+	_, err = WriteBackup(ctx, dir, headerGetter, getter, 2, 3)
+	require.Error(t, err)
+	_, err = WriteBackup(ctx, dir, headerGetter, getter, 1, 1)
+	require.Error(t, err)
+	_, err = WriteBackup(ctx, dir, headerGetter, getter, 3, 3)
+	require.NoError(t, err)
+
+	index, err = ReadBackupIndex(dir)
+	require.NoError(t, err)
+	require.Len(t, index.Backups, 2)
+	require.EqualValues(t, 4, index.Next())
+	require.NoError(t, index.Verify(dir))
+	overlapping := &BackupIndex{Backups: []Backup{index.Backups[0], index.Backups[0]}}
+	require.Error(t, overlapping.Verify(dir))
+
+	verify := func(_ context.Context, eh *header.ExtendedHeader) (*header.ExtendedHeader, error) {
+		return headers[eh.Height()-1], nil
+	}
+	edsStore, err := store.NewStore(store.DefaultParameters(), t.TempDir())
+	require.NoError(t, err)
+	restored, err := RestoreBackups(ctx, dir, edsStore, verify)
+	require.NoError(t, err)
+	require.Equal(t, index.Backups, restored)
+	for _, eh := range headers {
+		has, err := edsStore.HasByHeight(ctx, eh.Height())
+		require.NoError(t, err)
+		require.True(t, has)
+	}
+
+	// corrupted backups are detected before restoring anything
+	path := filepath.Join(dir, index.Backups[1].File)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	data[len(data)/2] ^= 0xFF
+	require.NoError(t, os.WriteFile(path, data, exportFilePerm))
+	require.Error(t, index.Verify(dir))
+	_, err = RestoreBackups(ctx, dir, edsStore, verify)
+	require.Error(t, err)
+}
@@ -11,9 +11,13 @@ import (
 	rpc "github.com/celestiaorg/celestia-node/api/rpc/client"
 	cmdnode "github.com/celestiaorg/celestia-node/cmd"
 	"github.com/celestiaorg/celestia-node/header"
+	shareServ "github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/share"
 )
 
+// flagCompressed makes get-eds transfer the EDS compressed.
+var flagCompressed = "compressed"
+
 func init() {
 	Cmd.AddCommand(
 		sharesAvailableCmd,
@@ -21,6 +25,8 @@ func init() {
 		getShare,
 		getEDS,
 	)
+
+	getEDS.PersistentFlags().Bool(flagCompressed, false, "Transfers the EDS compressed")
 }
 
 var Cmd = &cobra.Command{
@@ -157,7 +163,16 @@ var getEDS = &cobra.Command{
 			return err
 		}
 
-		shares, err := client.Share.GetEDS(cmd.Context(), eh)
+		compressed, err := cmd.Flags().GetBool(flagCompressed)
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if compressed {
+			ctx = shareServ.WithCompressedEDS(ctx)
+		}
+
+		shares, err := client.Share.GetEDS(ctx, eh)
 		return cmdnode.PrintOutput(shares, err, nil)
 	},
 }
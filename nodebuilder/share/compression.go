@@ -0,0 +1,77 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+type compressedEDSKey struct{}
+
+// WithCompressedEDS returns a copy of the context making GetEDS calls of the API transfer the EDS
+// zstd compressed with GetCompressedEDS. The EDS is recomputed out of the decompressed ODS and
+// verified against the DAH before being returned, so the result is the same as without the
+// option. Compression pays off over high-latency links, especially for sparse squares, which are
+// mostly padding.
+//
+// Unlike the other options carried through the context, it is applied by the API, so that it
+// takes effect for RPC clients.
+func WithCompressedEDS(ctx context.Context) context.Context {
+	return context.WithValue(ctx, compressedEDSKey{}, true)
+}
+
+func compressedEDSFromContext(ctx context.Context) bool {
+	compressed, _ := ctx.Value(compressedEDSKey{}).(bool)
+	return compressed
+}
+
+func (m module) GetCompressedEDS(ctx context.Context, header *header.ExtendedHeader) ([]byte, error) {
+	extendedDataSquare, err := m.GetEDS(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder, err := zstd.NewWriter(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("creating zstd encoder: %w", err)
+	}
+	for _, shr := range extendedDataSquare.FlattenedODS() {
+		if _, err := encoder.Write(shr); err != nil {
+			encoder.Close()
+			return nil, fmt.Errorf("compressing ODS: %w", err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("compressing ODS: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressEDS recomputes the EDS out of the ODS compressed by GetCompressedEDS and verifies it
+// against the DAH of the header. Only the shares of the square are decompressed, no matter how
+// much the data expands to.
+func decompressEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	data []byte,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	decoder, err := zstd.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("creating zstd decoder: %w", err)
+	}
+	defer decoder.Close()
+
+	accessor, err := eds.ReadAccessor(ctx, decoder, header.DAH)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing EDS: %w", err)
+	}
+	return accessor.ExtendedDataSquare, nil
+}
@@ -0,0 +1,49 @@
+package share
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestAPI_GetEDSCompressed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const odsSize = 16
+	// a sparse square is mostly padding
+	square := edstest.RandEDSWithTailPadding(t, odsSize, odsSize*odsSize-4)
+	roots, err := share.NewAxisRoots(square)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	ctrl := gomock.NewController(t)
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetEDS(gomock.Any(), eh).Return(square, nil).AnyTimes()
+	m := module{Getter: getter}
+
+	var api API
+	api.Internal.GetEDS = m.GetEDS
+	api.Internal.GetCompressedEDS = m.GetCompressedEDS
+
+	data, err := api.GetCompressedEDS(ctx, eh)
+	require.NoError(t, err)
+	require.Less(t, len(data), len(square.FlattenedODS())*share.Size/4)
+
+	got, err := api.GetEDS(WithCompressedEDS(ctx), eh)
+	require.NoError(t, err)
+	require.True(t, square.Equals(got))
+
+	// the decompressed square is verified against the DAH
+	otherEh := headertest.RandExtendedHeaderWithRoot(t, edstest.RandomAxisRoots(t, 2*odsSize))
+	getter.EXPECT().GetEDS(gomock.Any(), otherEh).Return(square, nil).AnyTimes()
+	_, err = api.GetEDS(WithCompressedEDS(ctx), otherEh)
+	require.Error(t, err)
+}
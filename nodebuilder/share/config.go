@@ -2,23 +2,52 @@ package share
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/share/availability/light"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/peers"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/shrexeds"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/shrexnd"
 	"github.com/celestiaorg/celestia-node/store"
+	"github.com/celestiaorg/celestia-node/store/backend"
 )
 
 const (
 	defaultBlockstoreCacheSize = 128
+	// defaultHotWindow is about a week of 6 second blocks
+	defaultHotWindow = 100_000
 )
 
 type Config struct {
 	// EDSStoreParams sets eds store configuration parameters
-	EDSStoreParams      *store.Parameters
+	EDSStoreParams *store.Parameters
+	// EDSStorePath overrides the directory of the EDS store, which is the node store by default.
+	// Read-only replicas point it to the store of the writing node.
+	EDSStorePath string `toml:",omitempty"`
+	// Scrubber sets the background verification of the EDSes kept by bridge and full nodes against
+	// the roots of their headers.
+	Scrubber *store.ScrubParams
+	// Backfill sets the download of the historical EDSes missing in the stores of bridge and full
+	// nodes, which is run through the StartBackfill endpoint and after converting a pruned node to an
+	// archival one.
+	Backfill *store.BackfillParams
+	// S3Backend sets the S3-compatible object storage historical EDSes are offloaded to by bridge
+	// and full nodes. It is disabled unless a bucket is set.
+	S3Backend *backend.S3Config
+	// ColdTier sets the cold tier EDSes past the hot window are moved to by bridge and full nodes.
+	// It is disabled unless a path is set.
+	ColdTier *ColdTierConfig
+	// Shards sets the directories, e.g. on separate disks, the EDSes of bridge and full nodes are
+	// spread over along with the EDS store. It is disabled unless paths are set and can't be combined
+	// with the cold tier or the S3 backend.
+	Shards *ShardsConfig
+	// IndexNamespaces makes bridge and full nodes index the heights of blob namespaces of stored
+	// EDSes for the HeightsForNamespace endpoint.
+	IndexNamespaces     bool
 	BlockStoreCacheSize uint
 
 	UseShareExchange bool
@@ -31,17 +60,107 @@ type Config struct {
 
 	LightAvailability *light.Parameters `toml:",omitempty"`
 	Discovery         *discovery.Parameters
+	// BreakerParams sets the circuit breaker parameters for network retrieval
+	BreakerParams *getters.BreakerParameters
+	// MemoryParams sets the memory budget of concurrent EDS retrieval
+	MemoryParams *getters.MemoryParameters
+	// PriorityParams sets the scheduling of network retrieval by request priority
+	PriorityParams *getters.PriorityParameters
+	// RetryPolicy sets the retries of failed network retrieval. It can be overridden per request
+	// with shwap.WithRetryPolicy.
+	RetryPolicy *shwap.RetryPolicy
+	// NamespaceCacheParams sets the cache of namespace data served to requests
+	NamespaceCacheParams *getters.NamespaceCacheParameters
+	// PrefetchParams sets the prefetching of subscribed and recently requested namespaces for new
+	// headers into the namespace cache. It has no effect when the namespace cache is disabled.
+	PrefetchParams *getters.PrefetchParameters
+	// LocalOnly disables network retrieval, so that only the data in the local store is served.
+	// It is not supported by light nodes, which keep no local store.
+	LocalOnly bool
+	// Cascade overrides the order of the getters retrieving share data. Getters missing from it
+	// are disabled and the store getter can only go first. When empty, the store getter goes first
+	// on bridge and full nodes, followed by the shrex getter if UseShareExchange is set and the
+	// bitswap getter.
+	Cascade []GetterConfig `toml:",omitempty"`
+}
+
+// ColdTierConfig configures the cold tier of the EDS store.
+type ColdTierConfig struct {
+	// Path is the directory of the cold tier, e.g. on an HDD or a network mount.
+	Path string
+	// HotWindow is the number of the most recent heights kept in the node store. It can be changed
+	// at runtime with the SetHotWindow endpoint.
+	HotWindow uint64
+}
+
+// Enabled reports whether the cold tier is configured.
+func (cfg *ColdTierConfig) Enabled() bool {
+	return cfg != nil && cfg.Path != ""
+}
+
+// ShardsConfig configures the sharding of the EDS store.
+type ShardsConfig struct {
+	// Paths are the directories of the shards besides the EDS store.
+	Paths []string
+	// Policy selects the shard of every EDS: "height" distributes the EDSes by height modulo the
+	// number of shards, while "fill" puts them to the shard with the least filled disk.
+	Policy store.ShardPolicy
+}
+
+// Enabled reports whether sharding is configured.
+func (cfg *ShardsConfig) Enabled() bool {
+	return cfg != nil && len(cfg.Paths) != 0
+}
+
+func (cfg *ShardsConfig) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	for _, path := range cfg.Paths {
+		if path == "" {
+			return fmt.Errorf("empty shard path")
+		}
+	}
+	return cfg.Policy.Validate()
+}
+
+// Names of the getters of the retrieval cascade.
+const (
+	StoreGetterName   = "store"
+	ShrexGetterName   = "shrex"
+	BitswapGetterName = "bitswap"
+)
+
+// GetterConfig configures a getter of the retrieval cascade.
+type GetterConfig struct {
+	// Name is the name of the getter: "store", "shrex" or "bitswap".
+	Name string
+	// Timeout bounds the time a single request may spend in the getter. Zero splits the timeout of
+	// the request between the getters left in the cascade.
+	Timeout time.Duration
 }
 
 func DefaultConfig(tp node.Type) Config {
 	cfg := Config{
-		EDSStoreParams:      store.DefaultParameters(),
-		BlockStoreCacheSize: defaultBlockstoreCacheSize,
-		Discovery:           discovery.DefaultParameters(),
-		ShrExEDSParams:      shrexeds.DefaultParameters(),
-		ShrExNDParams:       shrexnd.DefaultParameters(),
-		UseShareExchange:    true,
-		PeerManagerParams:   peers.DefaultParameters(),
+		EDSStoreParams:       store.DefaultParameters(),
+		Scrubber:             store.DefaultScrubParams(),
+		Backfill:             store.DefaultBackfillParams(),
+		S3Backend:            backend.DefaultS3Config(),
+		ColdTier:             &ColdTierConfig{HotWindow: defaultHotWindow},
+		Shards:               &ShardsConfig{Policy: store.ShardByFill},
+		IndexNamespaces:      true,
+		BlockStoreCacheSize:  defaultBlockstoreCacheSize,
+		Discovery:            discovery.DefaultParameters(),
+		ShrExEDSParams:       shrexeds.DefaultParameters(),
+		ShrExNDParams:        shrexnd.DefaultParameters(),
+		UseShareExchange:     true,
+		PeerManagerParams:    peers.DefaultParameters(),
+		BreakerParams:        getters.DefaultBreakerParameters(),
+		MemoryParams:         getters.DefaultMemoryParameters(),
+		PriorityParams:       getters.DefaultPriorityParameters(),
+		RetryPolicy:          shwap.DefaultRetryPolicy(),
+		NamespaceCacheParams: getters.DefaultNamespaceCacheParameters(),
+		PrefetchParams:       getters.DefaultPrefetchParameters(),
 	}
 
 	if tp == node.Light {
@@ -51,6 +170,14 @@ func DefaultConfig(tp node.Type) Config {
 	return cfg
 }
 
+// EDSStoreDir returns the directory of the EDS store of the node store under the given path.
+func (cfg *Config) EDSStoreDir(path string) string {
+	if cfg.EDSStorePath != "" {
+		return cfg.EDSStorePath
+	}
+	return path
+}
+
 // Validate performs basic validation of the config.
 func (cfg *Config) Validate(tp node.Type) error {
 	if tp == node.Light {
@@ -59,6 +186,10 @@ func (cfg *Config) Validate(tp node.Type) error {
 		}
 	}
 
+	if tp == node.Light && cfg.LocalOnly {
+		return fmt.Errorf("nodebuilder/share: local only retrieval is not supported by light nodes")
+	}
+
 	if err := cfg.Discovery.Validate(); err != nil {
 		return fmt.Errorf("discovery: %w", err)
 	}
@@ -78,5 +209,106 @@ func (cfg *Config) Validate(tp node.Type) error {
 	if err := cfg.EDSStoreParams.Validate(); err != nil {
 		return fmt.Errorf("eds store: %w", err)
 	}
+
+	if err := cfg.Scrubber.Validate(); err != nil {
+		return fmt.Errorf("eds store: %w", err)
+	}
+
+	if err := cfg.Backfill.Validate(); err != nil {
+		return fmt.Errorf("eds store: %w", err)
+	}
+
+	if err := cfg.S3Backend.Validate(); err != nil {
+		return fmt.Errorf("eds store: %w", err)
+	}
+
+	if err := cfg.Shards.Validate(); err != nil {
+		return fmt.Errorf("eds store: shards: %w", err)
+	}
+
+	if cfg.Shards.Enabled() && (cfg.ColdTier.Enabled() || cfg.S3Backend.Enabled()) {
+		return fmt.Errorf("eds store: shards can't be combined with the cold tier or the S3 backend")
+	}
+
+	if err := cfg.BreakerParams.Validate(); err != nil {
+		return fmt.Errorf("circuit breaker: %w", err)
+	}
+
+	if err := cfg.PriorityParams.Validate(); err != nil {
+		return fmt.Errorf("priority scheduler: %w", err)
+	}
+
+	if err := cfg.RetryPolicy.Validate(); err != nil {
+		return fmt.Errorf("retry policy: %w", err)
+	}
+
+	if err := cfg.NamespaceCacheParams.Validate(); err != nil {
+		return fmt.Errorf("namespace cache: %w", err)
+	}
+
+	if err := cfg.PrefetchParams.Validate(); err != nil {
+		return fmt.Errorf("namespace prefetcher: %w", err)
+	}
+
+	if err := cfg.validateCascade(tp); err != nil {
+		return fmt.Errorf("nodebuilder/share: cascade: %w", err)
+	}
+	return nil
+}
+
+func (cfg *Config) validateCascade(tp node.Type) error {
+	seen := make(map[string]bool, len(cfg.Cascade))
+	for i, getter := range cfg.Cascade {
+		switch getter.Name {
+		case StoreGetterName:
+			if tp == node.Light {
+				return fmt.Errorf("store getter is not supported by light nodes")
+			}
+			if i != 0 {
+				return fmt.Errorf("store getter must go first, as it serves local data only")
+			}
+		case ShrexGetterName, BitswapGetterName:
+		default:
+			return fmt.Errorf("unknown getter %q", getter.Name)
+		}
+		if seen[getter.Name] {
+			return fmt.Errorf("getter %q is listed more than once", getter.Name)
+		}
+		seen[getter.Name] = true
+		if getter.Timeout < 0 {
+			return fmt.Errorf("invalid timeout of getter %q: %v, value should be non-negative",
+				getter.Name, getter.Timeout)
+		}
+	}
+	if len(cfg.Cascade) != 0 && len(cfg.retrievalCascade(tp)) == 0 {
+		return fmt.Errorf("no getters are left with local only retrieval")
+	}
 	return nil
 }
+
+// retrievalCascade returns the effective order of the getters of the node type, taking the
+// defaults and LocalOnly into account.
+func (cfg *Config) retrievalCascade(tp node.Type) []GetterConfig {
+	cascade := cfg.Cascade
+	if len(cascade) == 0 {
+		cascade = nil
+		if tp != node.Light {
+			cascade = append(cascade, GetterConfig{Name: StoreGetterName})
+		}
+		if cfg.UseShareExchange {
+			cascade = append(cascade, GetterConfig{Name: ShrexGetterName})
+		}
+		cascade = append(cascade, GetterConfig{Name: BitswapGetterName})
+	}
+
+	if !cfg.LocalOnly {
+		return cascade
+	}
+	var local []GetterConfig
+	for _, getter := range cascade {
+		if getter.Name == StoreGetterName {
+			local = append(local, getter)
+		}
+	}
+	return local
+}
@@ -1,11 +1,19 @@
 package share
 
 import (
+	"context"
+	"errors"
+	"path/filepath"
+
 	"github.com/ipfs/boxo/blockstore"
 	"github.com/ipfs/boxo/exchange"
+	"github.com/ipfs/go-datastore"
 	"go.uber.org/fx"
 
+	libhead "github.com/celestiaorg/go-header"
+
 	headerServ "github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/pruner"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/shwap"
@@ -15,8 +23,137 @@ import (
 	"github.com/celestiaorg/celestia-node/store"
 )
 
-func newShareModule(getter shwap.Getter, avail share.Availability, header headerServ.Module) Module {
-	return &module{getter, avail, header}
+type moduleParams struct {
+	fx.In
+
+	Getter       shwap.Getter
+	Availability share.Availability
+	Header       headerServ.Module
+	Path         node.StorePath
+	// Store is only provided for bridge and full nodes.
+	Store     *store.Store `optional:"true"`
+	Datastore datastore.Batching
+	// Prefetcher is nil when prefetching is disabled.
+	Prefetcher *getters.NamespacePrefetcher
+	// Backfiller is only provided for bridge and full nodes.
+	Backfiller *store.Backfiller `optional:"true"`
+}
+
+func newShareModule(params moduleParams) Module {
+	return &module{
+		Getter:       params.Getter,
+		Availability: params.Availability,
+		hs:           params.Header,
+		store:        params.Store,
+		datastore:    params.Datastore,
+		exportDir:    filepath.Join(string(params.Path), exportDirName),
+		prefetcher:   params.Prefetcher,
+		backfiller:   params.Backfiller,
+	}
+}
+
+// namespacePrefetcher prefetches namespaces through the getter into the namespace cache. It is
+// nil when either prefetching or the namespace cache is disabled.
+func namespacePrefetcher(
+	lc fx.Lifecycle,
+	getter shwap.Getter,
+	hs headerServ.Module,
+	cfg Config,
+) *getters.NamespacePrefetcher {
+	if cfg.PrefetchParams.MaxNamespaces == 0 || cfg.NamespaceCacheParams.Size == 0 {
+		return nil
+	}
+	prefetcher := getters.NewNamespacePrefetcher(getter, hs.Subscribe, *cfg.PrefetchParams)
+	lc.Append(fx.StopHook(prefetcher.Stop))
+	return prefetcher
+}
+
+// scrubber verifies the stored EDSes in the background and fetches the corrupted ones again through
+// the getter. It is nil when scrubbing is disabled.
+func scrubber(
+	lc fx.Lifecycle,
+	edsStore *store.Store,
+	getter shwap.Getter,
+	hs headerServ.Module,
+	cfg Config,
+) *store.Scrubber {
+	if !cfg.Scrubber.Enabled() {
+		return nil
+	}
+	getRoots := func(ctx context.Context, height uint64) (*share.AxisRoots, error) {
+		eh, err := hs.GetByHeight(ctx, height)
+		if errors.Is(err, libhead.ErrNotFound) {
+			return nil, store.ErrNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		return eh.DAH, nil
+	}
+	refetch := func(ctx context.Context, height uint64) error {
+		eh, err := hs.GetByHeight(ctx, height)
+		if err != nil {
+			return err
+		}
+		eds, err := getter.GetEDS(ctx, eh)
+		if err != nil {
+			return err
+		}
+		return putEDS(ctx, edsStore, height, eh, eds)
+	}
+	s := store.NewScrubber(edsStore, getRoots, refetch, *cfg.Scrubber)
+	lc.Append(fx.StartStopHook(s.Start, s.Stop))
+	return s
+}
+
+// backfiller downloads the historical EDSes missing in the store through the getter. It takes over
+// the backfill left pending by the conversion of a pruned node to an archival one.
+func backfiller(
+	lc fx.Lifecycle,
+	edsStore *store.Store,
+	getter shwap.Getter,
+	hs headerServ.Module,
+	ds datastore.Batching,
+	cfg Config,
+) *store.Backfiller {
+	params := store.DefaultBackfillParams()
+	if cfg.Backfill != nil {
+		params = cfg.Backfill
+	}
+	fetch := func(ctx context.Context, height uint64) error {
+		eh, err := hs.GetByHeight(ctx, height)
+		if err != nil {
+			return err
+		}
+		eds, err := getter.GetEDS(ctx, eh)
+		if err != nil {
+			return err
+		}
+		return putEDS(ctx, edsStore, height, eh, eds)
+	}
+	b := store.NewBackfiller(edsStore, fetch, *params)
+	lc.Append(fx.StartStopHook(
+		func(ctx context.Context) error {
+			if err := b.Start(ctx); err != nil {
+				return err
+			}
+			pending, err := pruner.PendingBackfill(ctx, ds)
+			if err != nil || pending == nil {
+				return err
+			}
+			err = b.Backfill(ctx, pending.From, pending.To)
+			switch {
+			case errors.Is(err, store.ErrBackfillRunning), errors.Is(err, store.ErrReadOnly):
+				// left for the next start or the writer of the store
+				return nil
+			case err != nil:
+				return err
+			}
+			return pruner.ClearPendingBackfill(ctx, ds)
+		},
+		b.Stop,
+	))
+	return b
 }
 
 func bitswapGetter(
@@ -33,30 +170,121 @@ func bitswapGetter(
 func lightGetter(
 	shrexGetter *shrex_getter.Getter,
 	bitswapGetter *bitswap.Getter,
+	metrics *getters.SourceMetrics,
 	cfg Config,
 ) shwap.Getter {
-	var cascade []shwap.Getter
-	if cfg.UseShareExchange {
-		cascade = append(cascade, shrexGetter)
+	available := map[string]shwap.Getter{
+		ShrexGetterName:   shrexGetter,
+		BitswapGetterName: bitswapGetter,
 	}
-	cascade = append(cascade, bitswapGetter)
-	return getters.NewCascadeGetter(cascade)
+	cascade := configuredCascadeGetter(cfg, cfg.retrievalCascade(node.Light), available, metrics)
+	return getters.NewMemoryLimitGetter(cascade, *cfg.MemoryParams)
 }
 
 // Getter is added to bridge nodes for the case where Bridge nodes are
 // running in a pruned mode. This ensures the block can be retrieved from
 // the network if it was pruned from the local store.
 func bridgeAndFullGetter(
+	tp node.Type,
 	storeGetter *store.Getter,
 	shrexGetter *shrex_getter.Getter,
 	bitswapGetter *bitswap.Getter,
+	metrics *getters.SourceMetrics,
 	cfg Config,
 ) shwap.Getter {
-	var cascade []shwap.Getter
-	cascade = append(cascade, storeGetter)
-	if cfg.UseShareExchange {
-		cascade = append(cascade, shrexGetter)
+	available := map[string]shwap.Getter{
+		StoreGetterName:   storeGetter,
+		ShrexGetterName:   shrexGetter,
+		BitswapGetterName: bitswapGetter,
+	}
+	cascade := configuredCascadeGetter(cfg, cfg.retrievalCascade(tp), available, metrics)
+	return getters.NewMemoryLimitGetter(cascade, *cfg.MemoryParams)
+}
+
+// edsStats exposes the statistics of the EDS store for node.StoreStats.
+func edsStats(edsStore *store.Store) node.EDSStatsFn {
+	return func(ctx context.Context) (*node.EDSStats, error) {
+		stats, err := edsStore.Stats(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// the node package mirrors the fields of the store statistics
+		nodeStats := &node.EDSStats{EDSTierStats: node.EDSTierStats(stats.TierStats)}
+		if stats.Cold != nil {
+			cold := node.EDSTierStats(*stats.Cold)
+			nodeStats.Cold = &cold
+		}
+		for _, shard := range stats.Shards {
+			shardStats := node.EDSTierStats(*shard)
+			nodeStats.Shards = append(nodeStats.Shards, &shardStats)
+		}
+		return nodeStats, nil
+	}
+}
+
+// retrievalCascade describes the effective retrieval cascade of the node for node.Info.
+func retrievalCascade(tp node.Type, cfg Config) node.RetrievalCascade {
+	cascade := cfg.retrievalCascade(tp)
+	info := make(node.RetrievalCascade, len(cascade))
+	for i, getter := range cascade {
+		info[i] = node.GetterInfo{Name: getter.Name, Timeout: getter.Timeout}
+	}
+	return info
+}
+
+// configuredCascadeGetter builds the getters cascade out of the available getters in the order
+// of the given configuration, where the store getter is the only local one and can only go
+// first. Getters with a timeout configured are bounded by it. Every getter records its requests
+// to the metrics under its name. Namespace data returned by the cascade is cached when the
+// namespace cache is enabled.
+func configuredCascadeGetter(
+	cfg Config,
+	cascade []GetterConfig,
+	available map[string]shwap.Getter,
+	metrics *getters.SourceMetrics,
+) shwap.Getter {
+	var local, network []shwap.Getter
+	for _, getterCfg := range cascade {
+		var getter shwap.Getter = getters.NewMetricsGetter(available[getterCfg.Name], getterCfg.Name, metrics)
+		if getterCfg.Timeout > 0 {
+			getter = getters.NewTimeoutGetter(getter, getterCfg.Timeout)
+		}
+		if getterCfg.Name == StoreGetterName {
+			local = append(local, getter)
+			continue
+		}
+		network = append(network, getter)
+	}
+	getter := cascadeGetter(cfg.BreakerParams, cfg.PriorityParams, cfg.RetryPolicy, local, network)
+	if cfg.NamespaceCacheParams.Size > 0 {
+		getter = getters.NewNamespaceCacheGetter(getter, *cfg.NamespaceCacheParams)
+	}
+	return getter
+}
+
+// cascadeGetter builds the getters cascade out of local and network getters. Network getters
+// share a single circuit breaker and go after the local ones, so local data is still served while
+// network retrieval is suspended. Network getters also share a single priority scheduler, which
+// goes before the breaker so that time spent waiting for a slot is not accounted as a network
+// failure. Failed network requests are retried according to the retry policy, releasing the
+// scheduler slot while backing off. Concurrent identical requests are coalesced into a single
+// retrieval in front of every network getter. Network getters are skipped for requests restricted
+// to the local store with shwap.WithLocalOnly.
+func cascadeGetter(
+	breakerParams *getters.BreakerParameters,
+	priorityParams *getters.PriorityParameters,
+	retryPolicy *shwap.RetryPolicy,
+	local, network []shwap.Getter,
+) shwap.Getter {
+	breaker := getters.NewCircuitBreaker(*breakerParams)
+	scheduler := getters.NewPriorityScheduler(*priorityParams)
+	cascade := append([]shwap.Getter{}, local...)
+	for _, getter := range network {
+		getter = getters.NewBreakerGetter(getter, breaker)
+		getter = getters.NewPriorityGetter(getter, scheduler)
+		getter = getters.NewRetryGetter(getter, *retryPolicy)
+		getter = getters.NewSingleFlightGetter(getter)
+		cascade = append(cascade, getters.NewNetworkGetter(getter))
 	}
-	cascade = append(cascade, bitswapGetter)
 	return getters.NewCascadeGetter(cascade)
 }
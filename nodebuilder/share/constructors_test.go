@@ -0,0 +1,157 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+// TestCascadeGetter_LocalServedWhileBreakerOpen verifies that local data is still served after
+// the circuit breaker suspends network retrieval.
+func TestCascadeGetter_LocalServedWhileBreakerOpen(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	eds := edstest.RandEDS(t, 4)
+	roots, err := share.NewAxisRoots(eds)
+	require.NoError(t, err)
+	localEh := headertest.RandExtendedHeaderWithRoot(t, roots)
+	remoteEh := headertest.RandExtendedHeader(t)
+	netErr := errors.New("network failure")
+
+	ctrl := gomock.NewController(t)
+	local := mock.NewMockGetter(ctrl)
+	local.EXPECT().GetEDS(gomock.Any(), localEh).Return(eds, nil).AnyTimes()
+	local.EXPECT().GetEDS(gomock.Any(), remoteEh).Return(nil, shwap.ErrNotFound).AnyTimes()
+	// network is only reached once, before the breaker opens
+	network := mock.NewMockGetter(ctrl)
+	network.EXPECT().GetEDS(gomock.Any(), remoteEh).Return(nil, netErr).Times(1)
+
+	params := &getters.BreakerParameters{FailureThreshold: 1, Cooldown: time.Hour}
+	getter := cascadeGetter(
+		params,
+		getters.DefaultPriorityParameters(),
+		shwap.DefaultRetryPolicy(),
+		[]shwap.Getter{local},
+		[]shwap.Getter{network},
+	)
+
+	_, err = getter.GetEDS(ctx, remoteEh)
+	require.ErrorIs(t, err, netErr)
+
+	_, err = getter.GetEDS(ctx, remoteEh)
+	require.ErrorIs(t, err, getters.ErrEDSUnavailable)
+
+	got, err := getter.GetEDS(ctx, localEh)
+	require.NoError(t, err)
+	require.Equal(t, eds, got)
+}
+
+// TestCascadeGetter_LocalOnly verifies that local only requests are served from the local store
+// and are not forwarded to the network.
+func TestCascadeGetter_LocalOnly(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	ctx = shwap.WithGetOptions(ctx, shwap.WithLocalOnly())
+
+	eds := edstest.RandEDS(t, 4)
+	roots, err := share.NewAxisRoots(eds)
+	require.NoError(t, err)
+	localEh := headertest.RandExtendedHeaderWithRoot(t, roots)
+	remoteEh := headertest.RandExtendedHeader(t)
+
+	ctrl := gomock.NewController(t)
+	local := mock.NewMockGetter(ctrl)
+	local.EXPECT().GetEDS(gomock.Any(), localEh).Return(eds, nil).Times(1)
+	local.EXPECT().GetEDS(gomock.Any(), remoteEh).Return(nil, shwap.ErrNotFound).Times(1)
+	// network must not be reached
+	network := mock.NewMockGetter(ctrl)
+
+	getter := cascadeGetter(
+		getters.DefaultBreakerParameters(),
+		getters.DefaultPriorityParameters(),
+		shwap.DefaultRetryPolicy(),
+		[]shwap.Getter{local},
+		[]shwap.Getter{network},
+	)
+
+	got, err := getter.GetEDS(ctx, localEh)
+	require.NoError(t, err)
+	require.Equal(t, eds, got)
+
+	_, err = getter.GetEDS(ctx, remoteEh)
+	require.ErrorIs(t, err, shwap.ErrNotFound)
+}
+
+func TestConfig_RetrievalCascade(t *testing.T) {
+	cfg := DefaultConfig(node.Full)
+	require.Equal(t, []GetterConfig{
+		{Name: StoreGetterName},
+		{Name: ShrexGetterName},
+		{Name: BitswapGetterName},
+	}, cfg.retrievalCascade(node.Full))
+
+	cfg = DefaultConfig(node.Light)
+	cfg.UseShareExchange = false
+	require.Equal(t, []GetterConfig{{Name: BitswapGetterName}}, cfg.retrievalCascade(node.Light))
+
+	// bridge nodes skipping bitswap
+	cfg = DefaultConfig(node.Bridge)
+	cfg.Cascade = []GetterConfig{{Name: StoreGetterName}, {Name: ShrexGetterName, Timeout: time.Second}}
+	require.NoError(t, cfg.Validate(node.Bridge))
+	require.Equal(t, cfg.Cascade, cfg.retrievalCascade(node.Bridge))
+	cfg.LocalOnly = true
+	require.Equal(t, cfg.Cascade[:1], cfg.retrievalCascade(node.Bridge))
+
+	invalid := [][]GetterConfig{
+		{{Name: "unknown"}},
+		{{Name: BitswapGetterName}, {Name: BitswapGetterName}},
+		{{Name: BitswapGetterName}, {Name: StoreGetterName}},
+		{{Name: StoreGetterName, Timeout: -time.Second}},
+	}
+	for _, cascade := range invalid {
+		cfg = DefaultConfig(node.Full)
+		cfg.Cascade = cascade
+		require.Error(t, cfg.Validate(node.Full), cascade)
+	}
+	cfg = DefaultConfig(node.Light)
+	cfg.Cascade = []GetterConfig{{Name: StoreGetterName}}
+	require.Error(t, cfg.Validate(node.Light))
+}
+
+// TestConfiguredCascadeGetter verifies that getters are tried in the configured order and that
+// getters missing from the configuration are never reached.
+func TestConfiguredCascadeGetter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	eh := headertest.RandExtendedHeader(t)
+	eds := edstest.RandEDS(t, 4)
+
+	ctrl := gomock.NewController(t)
+	// shrex is disabled, so it must not be reached
+	shrex := mock.NewMockGetter(ctrl)
+	bitswap := mock.NewMockGetter(ctrl)
+	bitswap.EXPECT().GetEDS(gomock.Any(), eh).Return(eds, nil).Times(1)
+
+	cfg := DefaultConfig(node.Light)
+	cfg.Cascade = []GetterConfig{{Name: BitswapGetterName, Timeout: time.Second}}
+	available := map[string]shwap.Getter{ShrexGetterName: shrex, BitswapGetterName: bitswap}
+	getter := configuredCascadeGetter(cfg, cfg.retrievalCascade(node.Light), available, getters.NewSourceMetrics())
+
+	got, err := getter.GetEDS(ctx, eh)
+	require.NoError(t, err)
+	require.Equal(t, eds, got)
+}
@@ -0,0 +1,116 @@
+package share
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+const (
+	// exportDirName is the name of the directory within the node store that EDSes are exported to.
+	exportDirName = "exports"
+
+	exportDirPerm  = 0o755
+	exportFilePerm = 0o600
+)
+
+// ExportEDS writes the ODS of the block at the given height to the file at the given path within
+// the export directory.
+//
+// The file holds the height as an 8-byte big-endian integer followed by the shares of the ODS in
+// row-major order, the same way shrex-eds transfers them. The EDS can be recomputed from it and
+// verified against the header at the height, which ImportEDS does.
+func (m module) ExportEDS(ctx context.Context, height uint64, path string) error {
+	filePath, err := m.exportPath(path)
+	if err != nil {
+		return err
+	}
+	extendedHeader, err := m.hs.GetByHeight(ctx, height)
+	if err != nil {
+		return err
+	}
+	extendedDataSquare, err := m.GetEDS(ctx, extendedHeader)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), exportDirPerm); err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+	// ensure existing files are never overwritten
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, exportFilePerm)
+	if err != nil {
+		return fmt.Errorf("creating export file: %w", err)
+	}
+
+	w := bufio.NewWriter(f)
+	err = binary.Write(w, binary.BigEndian, height)
+	for _, shr := range extendedDataSquare.FlattenedODS() {
+		if err != nil {
+			break
+		}
+		_, err = w.Write(shr)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if errClose := f.Close(); errClose != nil {
+		err = errors.Join(err, errClose)
+	}
+	if err != nil {
+		// don't leave partially written files behind
+		return errors.Join(fmt.Errorf("writing export file: %w", err), os.Remove(filePath))
+	}
+	return nil
+}
+
+// ImportEDS reads an EDS exported with ExportEDS from the file at the given path within the
+// export directory, verifies it against the header at the height recorded in the file and puts
+// it into the store.
+func (m module) ImportEDS(ctx context.Context, path string) error {
+	if m.store == nil {
+		return errors.New("importing EDS requires an EDS store, which light nodes do not keep")
+	}
+	filePath, err := m.exportPath(path)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("opening export file: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var height uint64
+	if err := binary.Read(r, binary.BigEndian, &height); err != nil {
+		return fmt.Errorf("reading height: %w", err)
+	}
+	extendedHeader, err := m.hs.GetByHeight(ctx, height)
+	if err != nil {
+		return err
+	}
+	accessor, err := eds.ReadAccessor(ctx, r, extendedHeader.DAH)
+	if err != nil {
+		return fmt.Errorf("reading EDS at height %d: %w", height, err)
+	}
+
+	if err := putEDS(ctx, m.store, height, extendedHeader, accessor.ExtendedDataSquare); err != nil {
+		return fmt.Errorf("storing EDS at height %d: %w", height, err)
+	}
+	return nil
+}
+
+// exportPath resolves the path of an export file, which must stay within the export directory.
+func (m module) exportPath(path string) (string, error) {
+	if !filepath.IsLocal(path) {
+		return "", fmt.Errorf("export path %q must be relative to the export directory", path)
+	}
+	return filepath.Join(m.exportDir, path), nil
+}
@@ -0,0 +1,94 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+func TestModule_ExportEDS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const height = 42
+	square := edstest.RandEDS(t, 4)
+	roots, err := share.NewAxisRoots(square)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	ctrl := gomock.NewController(t)
+	hs := headerMock.NewMockModule(ctrl)
+	hs.EXPECT().GetByHeight(gomock.Any(), uint64(height)).Return(eh, nil).AnyTimes()
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetEDS(gomock.Any(), eh).Return(square, nil).AnyTimes()
+	m := module{Getter: getter, hs: hs, exportDir: t.TempDir()}
+
+	err = m.ExportEDS(ctx, height, filepath.Join("audit", "block.ods"))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(m.exportDir, "audit", "block.ods"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(height), binary.BigEndian.Uint64(data[:8]))
+	require.Equal(t, bytes.Join(square.FlattenedODS(), nil), data[8:])
+
+	// existing files are not overwritten
+	err = m.ExportEDS(ctx, height, filepath.Join("audit", "block.ods"))
+	require.ErrorIs(t, err, os.ErrExist)
+
+	// exports can't escape the export directory
+	err = m.ExportEDS(ctx, height, filepath.Join("..", "block.ods"))
+	require.Error(t, err)
+	err = m.ExportEDS(ctx, height, filepath.Join(m.exportDir, "block.ods"))
+	require.Error(t, err)
+}
+
+func TestModule_ImportEDS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const height = 42
+	square := edstest.RandEDS(t, 4)
+	roots, err := share.NewAxisRoots(square)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	ctrl := gomock.NewController(t)
+	hs := headerMock.NewMockModule(ctrl)
+	hs.EXPECT().GetByHeight(gomock.Any(), uint64(height)).Return(eh, nil).AnyTimes()
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetEDS(gomock.Any(), eh).Return(square, nil).AnyTimes()
+	edsStore, err := store.NewStore(store.DefaultParameters(), t.TempDir())
+	require.NoError(t, err)
+	m := module{Getter: getter, hs: hs, store: edsStore, exportDir: t.TempDir()}
+
+	require.NoError(t, m.ExportEDS(ctx, height, "block.ods"))
+	require.NoError(t, m.ImportEDS(ctx, "block.ods"))
+	has, err := edsStore.HasByHeight(ctx, height)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	// tampered data fails the verification against the header
+	path := filepath.Join(m.exportDir, "tampered.ods")
+	data, err := os.ReadFile(filepath.Join(m.exportDir, "block.ods"))
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xFF
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.Error(t, m.ImportEDS(ctx, "tampered.ods"))
+
+	// light nodes keep no store
+	m.store = nil
+	require.Error(t, m.ImportEDS(ctx, "block.ods"))
+}
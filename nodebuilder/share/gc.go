@@ -0,0 +1,30 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+// garbageCollector is implemented by the datastores able to reclaim the space of deleted values.
+type garbageCollector interface {
+	CollectGarbage(ctx context.Context) error
+}
+
+func (m module) CollectGarbage(ctx context.Context) (*store.GCResult, error) {
+	if m.store == nil {
+		return nil, errors.New("collecting garbage requires an EDS store, which light nodes do not keep")
+	}
+	result, err := m.store.GC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if gc, ok := m.datastore.(garbageCollector); ok {
+		if err := gc.CollectGarbage(ctx); err != nil {
+			return nil, fmt.Errorf("collecting datastore garbage: %w", err)
+		}
+	}
+	return result, nil
+}
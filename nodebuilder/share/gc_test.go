@@ -0,0 +1,36 @@
+package share
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+func TestModule_CollectGarbage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	edsStore, err := store.NewStore(store.DefaultParameters(), dir)
+	require.NoError(t, err)
+	m := module{store: edsStore, datastore: datastore.NewMapDatastore()}
+
+	// leftover of an interrupted download
+	err = os.WriteFile(filepath.Join(dir, "blocks", "download.tmp"), []byte("garbage"), 0o600)
+	require.NoError(t, err)
+
+	result, err := m.CollectGarbage(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.RemovedFiles)
+	require.EqualValues(t, len("garbage"), result.ReclaimedBytes)
+
+	// light nodes keep no EDS store
+	_, err = module{}.CollectGarbage(ctx)
+	require.Error(t, err)
+}
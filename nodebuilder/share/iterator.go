@@ -0,0 +1,119 @@
+package share
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// ShareIterator iterates over the shares of a namespace in row-major order, fetching them lazily
+// one row at a time. It is not safe for concurrent use.
+type ShareIterator struct {
+	ctx       context.Context
+	getter    Module
+	header    *header.ExtendedHeader
+	namespace share.Namespace
+
+	rows   []int
+	buffer []share.Share
+	err    error
+}
+
+// NamespaceIterator returns a ShareIterator over the shares of the given namespace committed to
+// by the header. Shares are fetched with Module.GetShare as the iteration proceeds, so it works
+// with both the in-process Module and the RPC client. At most the namespace shares of a single row
+// are held in memory at a time.
+func NamespaceIterator(
+	ctx context.Context,
+	getter Module,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (*ShareIterator, error) {
+	if err := namespace.ValidateForData(); err != nil {
+		return nil, err
+	}
+	return &ShareIterator{
+		ctx:       ctx,
+		getter:    getter,
+		header:    header,
+		namespace: namespace,
+		rows:      share.RowsWithNamespace(header.DAH, namespace),
+	}, nil
+}
+
+// Next returns the next share of the namespace. It returns false once all the shares are
+// iterated over or fetching fails, which is reported by Err.
+func (it *ShareIterator) Next() (share.Share, bool) {
+	for len(it.buffer) == 0 {
+		if it.err != nil || len(it.rows) == 0 {
+			return nil, false
+		}
+		row := it.rows[0]
+		it.rows = it.rows[1:]
+		it.buffer, it.err = it.fetchRow(row)
+	}
+
+	shr := it.buffer[0]
+	it.buffer = it.buffer[1:]
+	return shr, true
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ShareIterator) Err() error {
+	return it.err
+}
+
+// fetchRow fetches the shares of the namespace in the given row. Shares within a row are sorted
+// by namespace, so the bounds of the namespace are found with a binary search before fetching
+// the shares in between.
+func (it *ShareIterator) fetchRow(row int) ([]share.Share, error) {
+	odsWidth := len(it.header.DAH.RowRoots) / 2
+	from, err := it.searchRow(row, odsWidth, it.namespace.IsLessOrEqual)
+	if err != nil {
+		return nil, err
+	}
+	to, err := it.searchRow(row, odsWidth, it.namespace.IsLess)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]share.Share, to-from)
+	errGroup, ctx := errgroup.WithContext(it.ctx)
+	for col := from; col < to; col++ {
+		errGroup.Go(func() error {
+			shr, err := it.getter.GetShare(ctx, it.header, row, col)
+			if err != nil {
+				return fmt.Errorf("getting share at row %d, col %d: %w", row, col, err)
+			}
+			shares[col-from] = shr
+			return nil
+		})
+	}
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+// searchRow returns the first column of the row in [0, width) for which the namespace of the
+// share satisfies the predicate, or width if there is none.
+func (it *ShareIterator) searchRow(row, width int, pred func(share.Namespace) bool) (int, error) {
+	lo, hi := 0, width
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		shr, err := it.getter.GetShare(it.ctx, it.header, row, mid)
+		if err != nil {
+			return 0, fmt.Errorf("getting share at row %d, col %d: %w", row, mid, err)
+		}
+		if pred(share.GetNamespace(shr)) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
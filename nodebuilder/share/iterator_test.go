@@ -0,0 +1,93 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestNamespaceIterator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const (
+		odsSize = 8
+		amount  = 20
+	)
+	namespace := sharetest.RandV0Namespace()
+	square, roots := edstest.RandEDSWithNamespace(t, namespace, amount, odsSize)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	ctrl := gomock.NewController(t)
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetShare(gomock.Any(), eh, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *header.ExtendedHeader, row, col int) (share.Share, error) {
+			return square.GetCell(uint(row), uint(col)), nil
+		}).AnyTimes()
+	m := module{Getter: getter}
+
+	t.Run("success", func(t *testing.T) {
+		it, err := NamespaceIterator(ctx, m, eh, namespace)
+		require.NoError(t, err)
+		require.Equal(t, namespacedShares(square, namespace), collect(it))
+		require.NoError(t, it.Err())
+	})
+
+	t.Run("absent namespace", func(t *testing.T) {
+		absent, err := namespace.AddInt(1)
+		require.NoError(t, err)
+		it, err := NamespaceIterator(ctx, m, eh, absent)
+		require.NoError(t, err)
+		require.Empty(t, collect(it))
+		require.NoError(t, it.Err())
+	})
+
+	t.Run("invalid namespace", func(t *testing.T) {
+		_, err := NamespaceIterator(ctx, m, eh, share.ParitySharesNamespace)
+		require.Error(t, err)
+	})
+
+	t.Run("getter error", func(t *testing.T) {
+		errGet := errors.New("get share failed")
+		failingEh := headertest.RandExtendedHeaderWithRoot(t, roots)
+		failing := mock.NewMockGetter(ctrl)
+		failing.EXPECT().GetShare(gomock.Any(), failingEh, gomock.Any(), gomock.Any()).
+			Return(nil, errGet).AnyTimes()
+
+		it, err := NamespaceIterator(ctx, module{Getter: failing}, failingEh, namespace)
+		require.NoError(t, err)
+		_, ok := it.Next()
+		require.False(t, ok)
+		require.ErrorIs(t, it.Err(), errGet)
+	})
+}
+
+func collect(it *ShareIterator) []share.Share {
+	var shares []share.Share
+	for shr, ok := it.Next(); ok; shr, ok = it.Next() {
+		shares = append(shares, shr)
+	}
+	return shares
+}
+
+func namespacedShares(square *rsmt2d.ExtendedDataSquare, namespace share.Namespace) []share.Share {
+	var shares []share.Share
+	for _, shr := range square.FlattenedODS() {
+		if namespace.Equals(share.GetNamespace(shr)) {
+			shares = append(shares, shr)
+		}
+	}
+	return shares
+}
@@ -11,8 +11,11 @@ import (
 	header "github.com/celestiaorg/celestia-node/header"
 	share "github.com/celestiaorg/celestia-node/nodebuilder/share"
 	share0 "github.com/celestiaorg/celestia-node/share"
+	shwap "github.com/celestiaorg/celestia-node/share/shwap"
+	store "github.com/celestiaorg/celestia-node/store"
 	rsmt2d "github.com/celestiaorg/rsmt2d"
 	gomock "github.com/golang/mock/gomock"
+	types "github.com/tendermint/tendermint/types"
 )
 
 // MockModule is a mock of Module interface.
@@ -38,6 +41,124 @@ func (m *MockModule) EXPECT() *MockModuleMockRecorder {
 	return m.recorder
 }
 
+// AvailabilityStatus mocks base method.
+func (m *MockModule) AvailabilityStatus(arg0 context.Context, arg1 uint64) (share0.AvailabilityStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilityStatus", arg0, arg1)
+	ret0, _ := ret[0].(share0.AvailabilityStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AvailabilityStatus indicates an expected call of AvailabilityStatus.
+func (mr *MockModuleMockRecorder) AvailabilityStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilityStatus", reflect.TypeOf((*MockModule)(nil).AvailabilityStatus), arg0, arg1)
+}
+
+// BackfillProgress mocks base method.
+func (m *MockModule) BackfillProgress(arg0 context.Context) (*store.BackfillProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillProgress", arg0)
+	ret0, _ := ret[0].(*store.BackfillProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillProgress indicates an expected call of BackfillProgress.
+func (mr *MockModuleMockRecorder) BackfillProgress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillProgress", reflect.TypeOf((*MockModule)(nil).BackfillProgress), arg0)
+}
+
+// CollectGarbage mocks base method.
+func (m *MockModule) CollectGarbage(arg0 context.Context) (*store.GCResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectGarbage", arg0)
+	ret0, _ := ret[0].(*store.GCResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectGarbage indicates an expected call of CollectGarbage.
+func (mr *MockModuleMockRecorder) CollectGarbage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectGarbage", reflect.TypeOf((*MockModule)(nil).CollectGarbage), arg0)
+}
+
+// EDSByteSize mocks base method.
+func (m *MockModule) EDSByteSize(arg0 context.Context, arg1 uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EDSByteSize", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EDSByteSize indicates an expected call of EDSByteSize.
+func (mr *MockModuleMockRecorder) EDSByteSize(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EDSByteSize", reflect.TypeOf((*MockModule)(nil).EDSByteSize), arg0, arg1)
+}
+
+// ExportEDS mocks base method.
+func (m *MockModule) ExportEDS(arg0 context.Context, arg1 uint64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportEDS", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportEDS indicates an expected call of ExportEDS.
+func (mr *MockModuleMockRecorder) ExportEDS(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEDS", reflect.TypeOf((*MockModule)(nil).ExportEDS), arg0, arg1, arg2)
+}
+
+// ExportSnapshot mocks base method.
+func (m *MockModule) ExportSnapshot(arg0 context.Context, arg1, arg2 uint64, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSnapshot", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportSnapshot indicates an expected call of ExportSnapshot.
+func (mr *MockModuleMockRecorder) ExportSnapshot(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSnapshot", reflect.TypeOf((*MockModule)(nil).ExportSnapshot), arg0, arg1, arg2, arg3)
+}
+
+// GetColumn mocks base method.
+func (m *MockModule) GetColumn(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 int) (*share.AxisHalfResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetColumn", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*share.AxisHalfResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetColumn indicates an expected call of GetColumn.
+func (mr *MockModuleMockRecorder) GetColumn(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumn", reflect.TypeOf((*MockModule)(nil).GetColumn), arg0, arg1, arg2)
+}
+
+// GetCompressedEDS mocks base method.
+func (m *MockModule) GetCompressedEDS(arg0 context.Context, arg1 *header.ExtendedHeader) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompressedEDS", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompressedEDS indicates an expected call of GetCompressedEDS.
+func (mr *MockModuleMockRecorder) GetCompressedEDS(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompressedEDS", reflect.TypeOf((*MockModule)(nil).GetCompressedEDS), arg0, arg1)
+}
+
 // GetEDS mocks base method.
 func (m *MockModule) GetEDS(arg0 context.Context, arg1 *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
 	m.ctrl.T.Helper()
@@ -53,6 +174,51 @@ func (mr *MockModuleMockRecorder) GetEDS(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEDS", reflect.TypeOf((*MockModule)(nil).GetEDS), arg0, arg1)
 }
 
+// GetEDSHash mocks base method.
+func (m *MockModule) GetEDSHash(arg0 context.Context, arg1 *header.ExtendedHeader) ([32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEDSHash", arg0, arg1)
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEDSHash indicates an expected call of GetEDSHash.
+func (mr *MockModuleMockRecorder) GetEDSHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEDSHash", reflect.TypeOf((*MockModule)(nil).GetEDSHash), arg0, arg1)
+}
+
+// GetODS mocks base method.
+func (m *MockModule) GetODS(arg0 context.Context, arg1 *header.ExtendedHeader) ([][]share0.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetODS", arg0, arg1)
+	ret0, _ := ret[0].([][]share0.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetODS indicates an expected call of GetODS.
+func (mr *MockModuleMockRecorder) GetODS(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetODS", reflect.TypeOf((*MockModule)(nil).GetODS), arg0, arg1)
+}
+
+// GetPartialEDS mocks base method.
+func (m *MockModule) GetPartialEDS(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 string) (*share.PartialEDS, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPartialEDS", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*share.PartialEDS)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPartialEDS indicates an expected call of GetPartialEDS.
+func (mr *MockModuleMockRecorder) GetPartialEDS(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartialEDS", reflect.TypeOf((*MockModule)(nil).GetPartialEDS), arg0, arg1, arg2)
+}
+
 // GetRange mocks base method.
 func (m *MockModule) GetRange(arg0 context.Context, arg1 uint64, arg2, arg3 int) (*share.GetRangeResult, error) {
 	m.ctrl.T.Helper()
@@ -68,6 +234,81 @@ func (mr *MockModuleMockRecorder) GetRange(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRange", reflect.TypeOf((*MockModule)(nil).GetRange), arg0, arg1, arg2, arg3)
 }
 
+// GetRangeByCoords mocks base method.
+func (m *MockModule) GetRangeByCoords(arg0 context.Context, arg1 uint64, arg2, arg3, arg4, arg5 int) (*share.GetRangeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRangeByCoords", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*share.GetRangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRangeByCoords indicates an expected call of GetRangeByCoords.
+func (mr *MockModuleMockRecorder) GetRangeByCoords(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRangeByCoords", reflect.TypeOf((*MockModule)(nil).GetRangeByCoords), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// GetRangeByNamespace mocks base method.
+func (m *MockModule) GetRangeByNamespace(arg0 context.Context, arg1 uint64, arg2 share0.Namespace, arg3, arg4 int) (*share.GetRangeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRangeByNamespace", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*share.GetRangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRangeByNamespace indicates an expected call of GetRangeByNamespace.
+func (mr *MockModuleMockRecorder) GetRangeByNamespace(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRangeByNamespace", reflect.TypeOf((*MockModule)(nil).GetRangeByNamespace), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetRangeWithOptions mocks base method.
+func (m *MockModule) GetRangeWithOptions(arg0 context.Context, arg1 uint64, arg2, arg3 int, arg4 share.GetRangeOptions) (*share.GetRangeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRangeWithOptions", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*share.GetRangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRangeWithOptions indicates an expected call of GetRangeWithOptions.
+func (mr *MockModuleMockRecorder) GetRangeWithOptions(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRangeWithOptions", reflect.TypeOf((*MockModule)(nil).GetRangeWithOptions), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetRow mocks base method.
+func (m *MockModule) GetRow(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 int) (*share.AxisHalfResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRow", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*share.AxisHalfResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRow indicates an expected call of GetRow.
+func (mr *MockModuleMockRecorder) GetRow(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRow", reflect.TypeOf((*MockModule)(nil).GetRow), arg0, arg1, arg2)
+}
+
+// GetSamples mocks base method.
+func (m *MockModule) GetSamples(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 []share.Coordinate) ([]shwap.Sample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSamples", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]shwap.Sample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSamples indicates an expected call of GetSamples.
+func (mr *MockModuleMockRecorder) GetSamples(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSamples", reflect.TypeOf((*MockModule)(nil).GetSamples), arg0, arg1, arg2)
+}
+
 // GetShare mocks base method.
 func (m *MockModule) GetShare(arg0 context.Context, arg1 *header.ExtendedHeader, arg2, arg3 int) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -83,6 +324,21 @@ func (mr *MockModuleMockRecorder) GetShare(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShare", reflect.TypeOf((*MockModule)(nil).GetShare), arg0, arg1, arg2, arg3)
 }
 
+// GetShares mocks base method.
+func (m *MockModule) GetShares(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 []share.Coordinate) ([]share0.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShares", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]share0.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShares indicates an expected call of GetShares.
+func (mr *MockModuleMockRecorder) GetShares(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShares", reflect.TypeOf((*MockModule)(nil).GetShares), arg0, arg1, arg2)
+}
+
 // GetSharesByNamespace mocks base method.
 func (m *MockModule) GetSharesByNamespace(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 share0.Namespace) (share.NamespacedShares, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +354,197 @@ func (mr *MockModuleMockRecorder) GetSharesByNamespace(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharesByNamespace", reflect.TypeOf((*MockModule)(nil).GetSharesByNamespace), arg0, arg1, arg2)
 }
 
+// GetSharesByNamespaceColMajor mocks base method.
+func (m *MockModule) GetSharesByNamespaceColMajor(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 share0.Namespace) (share.NamespacedShares, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharesByNamespaceColMajor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(share.NamespacedShares)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharesByNamespaceColMajor indicates an expected call of GetSharesByNamespaceColMajor.
+func (mr *MockModuleMockRecorder) GetSharesByNamespaceColMajor(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharesByNamespaceColMajor", reflect.TypeOf((*MockModule)(nil).GetSharesByNamespaceColMajor), arg0, arg1, arg2)
+}
+
+// GetSharesByNamespacePage mocks base method.
+func (m *MockModule) GetSharesByNamespacePage(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 share0.Namespace, arg3 string, arg4 int) (*share.NamespacedSharesPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharesByNamespacePage", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*share.NamespacedSharesPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharesByNamespacePage indicates an expected call of GetSharesByNamespacePage.
+func (mr *MockModuleMockRecorder) GetSharesByNamespacePage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharesByNamespacePage", reflect.TypeOf((*MockModule)(nil).GetSharesByNamespacePage), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetSharesByNamespaceRange mocks base method.
+func (m *MockModule) GetSharesByNamespaceRange(arg0 context.Context, arg1 share0.Namespace, arg2, arg3 uint64) (map[uint64]share.NamespacedShares, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharesByNamespaceRange", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[uint64]share.NamespacedShares)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharesByNamespaceRange indicates an expected call of GetSharesByNamespaceRange.
+func (mr *MockModuleMockRecorder) GetSharesByNamespaceRange(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharesByNamespaceRange", reflect.TypeOf((*MockModule)(nil).GetSharesByNamespaceRange), arg0, arg1, arg2, arg3)
+}
+
+// GetSharesByNamespaces mocks base method.
+func (m *MockModule) GetSharesByNamespaces(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 []share0.Namespace) (map[string]share.NamespacedShares, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharesByNamespaces", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]share.NamespacedShares)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharesByNamespaces indicates an expected call of GetSharesByNamespaces.
+func (mr *MockModuleMockRecorder) GetSharesByNamespaces(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharesByNamespaces", reflect.TypeOf((*MockModule)(nil).GetSharesByNamespaces), arg0, arg1, arg2)
+}
+
+// GetSharesForTx mocks base method.
+func (m *MockModule) GetSharesForTx(arg0 context.Context, arg1 uint64, arg2 []byte) (*share.GetRangeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharesForTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*share.GetRangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharesForTx indicates an expected call of GetSharesForTx.
+func (mr *MockModuleMockRecorder) GetSharesForTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharesForTx", reflect.TypeOf((*MockModule)(nil).GetSharesForTx), arg0, arg1, arg2)
+}
+
+// GetTxNamespaces mocks base method.
+func (m *MockModule) GetTxNamespaces(arg0 context.Context, arg1 uint64) (map[string][]share0.Namespace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTxNamespaces", arg0, arg1)
+	ret0, _ := ret[0].(map[string][]share0.Namespace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxNamespaces indicates an expected call of GetTxNamespaces.
+func (mr *MockModuleMockRecorder) GetTxNamespaces(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxNamespaces", reflect.TypeOf((*MockModule)(nil).GetTxNamespaces), arg0, arg1)
+}
+
+// HasNamespaceData mocks base method.
+func (m *MockModule) HasNamespaceData(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 share0.Namespace) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasNamespaceData", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasNamespaceData indicates an expected call of HasNamespaceData.
+func (mr *MockModuleMockRecorder) HasNamespaceData(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasNamespaceData", reflect.TypeOf((*MockModule)(nil).HasNamespaceData), arg0, arg1, arg2)
+}
+
+// HeightsForNamespace mocks base method.
+func (m *MockModule) HeightsForNamespace(arg0 context.Context, arg1 share0.Namespace, arg2, arg3 uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeightsForNamespace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeightsForNamespace indicates an expected call of HeightsForNamespace.
+func (mr *MockModuleMockRecorder) HeightsForNamespace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeightsForNamespace", reflect.TypeOf((*MockModule)(nil).HeightsForNamespace), arg0, arg1, arg2, arg3)
+}
+
+// ImportEDS mocks base method.
+func (m *MockModule) ImportEDS(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportEDS", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportEDS indicates an expected call of ImportEDS.
+func (mr *MockModuleMockRecorder) ImportEDS(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportEDS", reflect.TypeOf((*MockModule)(nil).ImportEDS), arg0, arg1)
+}
+
+// ImportSnapshot mocks base method.
+func (m *MockModule) ImportSnapshot(arg0 context.Context, arg1 string) (*share.SnapshotManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(*share.SnapshotManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSnapshot indicates an expected call of ImportSnapshot.
+func (mr *MockModuleMockRecorder) ImportSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSnapshot", reflect.TypeOf((*MockModule)(nil).ImportSnapshot), arg0, arg1)
+}
+
+// InvalidateAvailability mocks base method.
+func (m *MockModule) InvalidateAvailability(arg0 context.Context, arg1 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateAvailability", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateAvailability indicates an expected call of InvalidateAvailability.
+func (mr *MockModuleMockRecorder) InvalidateAvailability(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateAvailability", reflect.TypeOf((*MockModule)(nil).InvalidateAvailability), arg0, arg1)
+}
+
+// Reconstruct mocks base method.
+func (m *MockModule) Reconstruct(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 []share.ReconstructionSample) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconstruct", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconstruct indicates an expected call of Reconstruct.
+func (mr *MockModuleMockRecorder) Reconstruct(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconstruct", reflect.TypeOf((*MockModule)(nil).Reconstruct), arg0, arg1, arg2)
+}
+
+// SetHotWindow mocks base method.
+func (m *MockModule) SetHotWindow(arg0 context.Context, arg1 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHotWindow", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHotWindow indicates an expected call of SetHotWindow.
+func (mr *MockModuleMockRecorder) SetHotWindow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHotWindow", reflect.TypeOf((*MockModule)(nil).SetHotWindow), arg0, arg1)
+}
+
 // SharesAvailable mocks base method.
 func (m *MockModule) SharesAvailable(arg0 context.Context, arg1 *header.ExtendedHeader) error {
 	m.ctrl.T.Helper()
@@ -111,3 +558,90 @@ func (mr *MockModuleMockRecorder) SharesAvailable(arg0, arg1 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharesAvailable", reflect.TypeOf((*MockModule)(nil).SharesAvailable), arg0, arg1)
 }
+
+// StartBackfill mocks base method.
+func (m *MockModule) StartBackfill(arg0 context.Context, arg1, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartBackfill", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartBackfill indicates an expected call of StartBackfill.
+func (mr *MockModuleMockRecorder) StartBackfill(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartBackfill", reflect.TypeOf((*MockModule)(nil).StartBackfill), arg0, arg1, arg2)
+}
+
+// StreamSharesByNamespace mocks base method.
+func (m *MockModule) StreamSharesByNamespace(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 share0.Namespace) (<-chan *share.NamespacedRowResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSharesByNamespace", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan *share.NamespacedRowResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamSharesByNamespace indicates an expected call of StreamSharesByNamespace.
+func (mr *MockModuleMockRecorder) StreamSharesByNamespace(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSharesByNamespace", reflect.TypeOf((*MockModule)(nil).StreamSharesByNamespace), arg0, arg1, arg2)
+}
+
+// SubscribeEDS mocks base method.
+func (m *MockModule) SubscribeEDS(arg0 context.Context) (<-chan *share.EDSSubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeEDS", arg0)
+	ret0, _ := ret[0].(<-chan *share.EDSSubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeEDS indicates an expected call of SubscribeEDS.
+func (mr *MockModuleMockRecorder) SubscribeEDS(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeEDS", reflect.TypeOf((*MockModule)(nil).SubscribeEDS), arg0)
+}
+
+// SubscribeSharesByNamespace mocks base method.
+func (m *MockModule) SubscribeSharesByNamespace(arg0 context.Context, arg1 share0.Namespace) (<-chan *share.NamespaceSubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeSharesByNamespace", arg0, arg1)
+	ret0, _ := ret[0].(<-chan *share.NamespaceSubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeSharesByNamespace indicates an expected call of SubscribeSharesByNamespace.
+func (mr *MockModuleMockRecorder) SubscribeSharesByNamespace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeSharesByNamespace", reflect.TypeOf((*MockModule)(nil).SubscribeSharesByNamespace), arg0, arg1)
+}
+
+// VerifyNamespaceProof mocks base method.
+func (m *MockModule) VerifyNamespaceProof(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 share0.Namespace, arg3 int, arg4 share.NamespacedRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyNamespaceProof", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyNamespaceProof indicates an expected call of VerifyNamespaceProof.
+func (mr *MockModuleMockRecorder) VerifyNamespaceProof(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyNamespaceProof", reflect.TypeOf((*MockModule)(nil).VerifyNamespaceProof), arg0, arg1, arg2, arg3, arg4)
+}
+
+// VerifyShareProof mocks base method.
+func (m *MockModule) VerifyShareProof(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 *types.ShareProof, arg3 []share0.Share) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyShareProof", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyShareProof indicates an expected call of VerifyShareProof.
+func (mr *MockModuleMockRecorder) VerifyShareProof(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyShareProof", reflect.TypeOf((*MockModule)(nil).VerifyShareProof), arg0, arg1, arg2, arg3)
+}
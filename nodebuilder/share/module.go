@@ -16,12 +16,14 @@ import (
 	"github.com/celestiaorg/celestia-node/share/availability/full"
 	"github.com/celestiaorg/celestia-node/share/availability/light"
 	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/peers"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/shrex_getter"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/shrexeds"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/shrexnd"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/shrexsub"
 	"github.com/celestiaorg/celestia-node/store"
+	"github.com/celestiaorg/celestia-node/store/backend"
 )
 
 func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option {
@@ -35,6 +37,11 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 		fx.Supply(*cfg),
 		fx.Options(options...),
 		fx.Provide(newShareModule),
+		fx.Provide(getters.NewSourceMetrics),
+		fx.Provide(namespacePrefetcher),
+		fx.Provide(func() node.RetrievalCascade {
+			return retrievalCascade(tp, *cfg)
+		}),
 		availabilityComponents(tp, cfg),
 		shrexComponents(tp, cfg),
 		bitswapComponents(tp, cfg),
@@ -144,7 +151,9 @@ func shrexComponents(tp node.Type, cfg *Config) fx.Option {
 		return fx.Options(
 			opts,
 			shrexServerComponents(cfg),
-			fx.Provide(store.NewGetter),
+			fx.Provide(func(edsStore *store.Store) *store.Getter {
+				return store.NewGetter(edsStore)
+			}),
 			fx.Provide(func(shrexSub *shrexsub.PubSub) shrexsub.BroadcastFn {
 				return shrexSub.Broadcast
 			}),
@@ -153,7 +162,9 @@ func shrexComponents(tp node.Type, cfg *Config) fx.Option {
 		return fx.Options(
 			opts,
 			shrexServerComponents(cfg),
-			fx.Provide(store.NewGetter),
+			fx.Provide(func(edsStore *store.Store) *store.Getter {
+				return store.NewGetter(edsStore)
+			}),
 			fx.Provide(func(shrexSub *shrexsub.PubSub) shrexsub.BroadcastFn {
 				return shrexSub.Broadcast
 			}),
@@ -206,9 +217,30 @@ func shrexServerComponents(cfg *Config) fx.Option {
 
 func edsStoreComponents(cfg *Config) fx.Option {
 	return fx.Options(
+		fx.Provide(scrubber),
+		fx.Invoke(func(*store.Scrubber) {}),
+		fx.Provide(backfiller),
+		fx.Provide(edsStats),
 		fx.Provide(fx.Annotate(
-			func(path node.StorePath) (*store.Store, error) {
-				return store.NewStore(cfg.EDSStoreParams, string(path))
+			func(path node.StorePath, ds datastore.Batching) (*store.Store, error) {
+				var opts []store.Option
+				if cfg.IndexNamespaces {
+					opts = append(opts, store.WithNamespaceIndex(ds))
+				}
+				if cfg.S3Backend.Enabled() {
+					s3, err := backend.NewS3(cfg.S3Backend)
+					if err != nil {
+						return nil, err
+					}
+					opts = append(opts, store.WithBackend(s3))
+				}
+				if cfg.ColdTier.Enabled() {
+					opts = append(opts, store.WithColdTier(cfg.ColdTier.Path, cfg.ColdTier.HotWindow))
+				}
+				if cfg.Shards.Enabled() {
+					opts = append(opts, store.WithShards(cfg.Shards.Policy, cfg.Shards.Paths...))
+				}
+				return store.NewStore(cfg.EDSStoreParams, cfg.EDSStoreDir(string(path)), opts...)
 			},
 			fx.OnStop(func(ctx context.Context, store *store.Store) error {
 				return store.Stop(ctx)
@@ -227,6 +259,7 @@ func availabilityComponents(tp node.Type, cfg *Config) fx.Option {
 						getter,
 						ds,
 						light.WithSampleAmount(cfg.LightAvailability.SampleAmount),
+						light.WithSampleTimeout(cfg.LightAvailability.SampleTimeout),
 					)
 				},
 				fx.OnStop(func(ctx context.Context, la *light.ShareAvailability) error {
@@ -3,6 +3,7 @@ package share
 import (
 	"errors"
 
+	"github.com/celestiaorg/celestia-node/share/shwap/getters"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/peers"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/shrex_getter"
@@ -53,6 +54,19 @@ func WithShrexGetterMetrics(sg *shrex_getter.Getter) error {
 	return sg.WithMetrics()
 }
 
+// WithGetterMetrics is a utility function to turn on per source getter metrics and that is
+// expected to be "invoked" by the fx lifecycle.
+func WithGetterMetrics(metrics *getters.SourceMetrics) error {
+	return metrics.WithMetrics()
+}
+
 func WithStoreMetrics(s *store.Store) error {
 	return s.WithMetrics()
 }
+
+func WithScrubberMetrics(s *store.Scrubber) error {
+	if s == nil {
+		return nil
+	}
+	return s.WithMetrics()
+}
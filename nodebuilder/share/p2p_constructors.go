@@ -31,6 +31,22 @@ const (
 	protocolVersion = "v0.1.0"
 )
 
+// peerSelectorParams carries the peer selector of share retrieval, which is only provided when
+// overridden with nodebuilder.WithPeerSelector.
+type peerSelectorParams struct {
+	fx.In
+
+	Selector peers.PeerSelector `optional:"true"`
+}
+
+// options turns the selector into peer manager options.
+func (p peerSelectorParams) options() []peers.Option {
+	if p.Selector == nil {
+		return nil
+	}
+	return []peers.Option{peers.WithPeerSelector(p.Selector)}
+}
+
 // TODO @renaynay: rename
 func peerComponents(tp node.Type, cfg *Config) fx.Option {
 	return fx.Options(
@@ -55,8 +71,9 @@ func fullDiscoveryAndPeerManager(tp node.Type, cfg *Config) fx.Option {
 			// we must ensure Syncer is started before PeerManager
 			// so that Syncer registers header validator before PeerManager subscribes to headers
 			_ *sync.Syncer[*header.ExtendedHeader],
+			selector peerSelectorParams,
 		) (*peers.Manager, *discovery.Discovery, error) {
-			var managerOpts []peers.Option
+			managerOpts := selector.options()
 			if tp != node.Bridge {
 				// BNs do not need the overhead of shrexsub peer pools as
 				// BNs do not sync blocks off the DA network.
@@ -112,12 +129,14 @@ func archivalDiscoveryAndPeerManager(tp node.Type, cfg *Config) fx.Option {
 			h host.Host,
 			disc p2pdisc.Discovery,
 			gater *conngater.BasicConnectionGater,
+			selector peerSelectorParams,
 		) (map[string]*peers.Manager, []*discovery.Discovery, error) {
 			archivalPeerManager, err := peers.NewManager(
 				*cfg.PeerManagerParams,
 				h,
 				gater,
 				archivalNodesTag,
+				selector.options()...,
 			)
 			if err != nil {
 				return nil, nil, err
@@ -0,0 +1,134 @@
+package share
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// PartialEDS is the result of GetPartialEDS.
+type PartialEDS struct {
+	// Shares are the ODS shares in row-major order. Shares that were retrieved by earlier calls or
+	// were not retrieved before the context was done are nil.
+	Shares []share.Share `json:"shares"`
+	// ResumeToken is a bitmap of all the ODS shares retrieved so far, including the earlier calls.
+	// Passing it to GetPartialEDS resumes the retrieval of the remaining shares.
+	ResumeToken string `json:"resume_token"`
+	// Complete reports whether all the ODS shares were retrieved.
+	Complete bool `json:"complete"`
+}
+
+// Merge copies the shares retrieved by the next call into p and takes over its resume token.
+func (p *PartialEDS) Merge(next *PartialEDS) error {
+	if len(p.Shares) != len(next.Shares) {
+		return fmt.Errorf("merging partial squares of %d and %d shares", len(p.Shares), len(next.Shares))
+	}
+	for i, shr := range next.Shares {
+		if shr != nil {
+			p.Shares[i] = shr
+		}
+	}
+	p.ResumeToken = next.ResumeToken
+	p.Complete = next.Complete
+	return nil
+}
+
+// GetPartialEDS retrieves the ODS shares that are not marked as retrieved by the resume token,
+// until either all of them are retrieved or the context is done. Unlike GetEDS, it does not
+// discard the shares retrieved before the context is done, but returns them with a resume token
+// for a follow-up call. An empty token starts the retrieval from scratch.
+func (m module) GetPartialEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	resumeToken string,
+) (*PartialEDS, error) {
+	odsWidth := len(header.DAH.RowRoots) / 2
+	retrieved, err := decodeResumeToken(resumeToken, odsWidth*odsWidth)
+	if err != nil {
+		return nil, err
+	}
+
+	var lock sync.Mutex
+	shares := make([]share.Share, odsWidth*odsWidth)
+	errGroup, groupCtx := errgroup.WithContext(ctx)
+	errGroup.SetLimit(maxConcurrentShares)
+	for idx := range shares {
+		if retrieved.has(idx) {
+			continue
+		}
+		if groupCtx.Err() != nil {
+			break
+		}
+		errGroup.Go(func() error {
+			row, col := idx/odsWidth, idx%odsWidth
+			shr, err := m.GetShare(groupCtx, header, row, col)
+			switch {
+			case err != nil && ctx.Err() != nil:
+				// the deadline is reached, so keep what was retrieved so far
+				return nil
+			case err != nil:
+				return fmt.Errorf("getting share (%d, %d): %w", row, col, err)
+			}
+			lock.Lock()
+			shares[idx] = shr
+			retrieved.set(idx)
+			lock.Unlock()
+			return nil
+		})
+	}
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &PartialEDS{
+		Shares:      shares,
+		ResumeToken: retrieved.encode(),
+		Complete:    retrieved.full(len(shares)),
+	}, nil
+}
+
+// shareBitmap marks the ODS shares by their index in row-major order.
+type shareBitmap []byte
+
+func decodeResumeToken(token string, amount int) (shareBitmap, error) {
+	bitmapLen := (amount + 7) / 8
+	if token == "" {
+		return make(shareBitmap, bitmapLen), nil
+	}
+	bitmap, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("decoding resume token: %w", err)
+	}
+	if len(bitmap) != bitmapLen {
+		return nil, errors.New("resume token does not match the square size")
+	}
+	return bitmap, nil
+}
+
+func (b shareBitmap) has(idx int) bool {
+	return b[idx/8]&(1<<(idx%8)) != 0
+}
+
+func (b shareBitmap) set(idx int) {
+	b[idx/8] |= 1 << (idx % 8)
+}
+
+func (b shareBitmap) full(amount int) bool {
+	for idx := range amount {
+		if !b.has(idx) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b shareBitmap) encode() string {
+	return base64.StdEncoding.EncodeToString(b)
+}
@@ -0,0 +1,75 @@
+package share
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestModule_GetPartialEDS(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	const (
+		odsSize       = 4
+		availableRows = 2
+	)
+	square := edstest.RandEDS(t, odsSize)
+	roots, err := share.NewAxisRoots(square)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	// only the first rows are available until the square is fully published
+	var published atomic.Bool
+	var refetched atomic.Int32
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetShare(gomock.Any(), eh, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *header.ExtendedHeader, row, col int) (share.Share, error) {
+			switch {
+			case published.Load() && row < availableRows:
+				refetched.Add(1)
+			case !published.Load() && row >= availableRows:
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return square.GetCell(uint(row), uint(col)), nil
+		}).AnyTimes()
+	m := module{Getter: getter}
+
+	deadlineCtx, deadlineCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	t.Cleanup(deadlineCancel)
+	partial, err := m.GetPartialEDS(deadlineCtx, eh, "")
+	require.NoError(t, err)
+	require.False(t, partial.Complete)
+	ods := square.FlattenedODS()
+	for idx, shr := range partial.Shares {
+		if idx/odsSize < availableRows {
+			require.Equal(t, ods[idx], shr)
+			continue
+		}
+		require.Nil(t, shr)
+	}
+
+	// resuming only retrieves the missing shares
+	published.Store(true)
+	next, err := m.GetPartialEDS(ctx, eh, partial.ResumeToken)
+	require.NoError(t, err)
+	require.True(t, next.Complete)
+	require.Zero(t, refetched.Load())
+	require.NoError(t, partial.Merge(next))
+	require.True(t, partial.Complete)
+	require.Equal(t, ods, partial.Shares)
+
+	_, err = m.GetPartialEDS(ctx, eh, "AAAA")
+	require.Error(t, err)
+}
@@ -0,0 +1,22 @@
+package share
+
+// ProofEncoding selects how proofs are included in the results of GetRangeWithOptions.
+type ProofEncoding uint8
+
+const (
+	// ProofEncodingFull includes the complete proof of the shares. It is the default.
+	ProofEncodingFull ProofEncoding = iota
+	// ProofEncodingNone omits the proof, leaving it nil. The proof is not generated at all, so it
+	// suits consumers that trust the node and discard the proof anyway.
+	ProofEncodingNone
+)
+
+// GetRangeOptions configures a single GetRangeWithOptions request. The zero value keeps the defaults.
+type GetRangeOptions struct {
+	// BestEffortProof makes GetRangeWithOptions return the shares even if their proof can't be
+	// generated. In that case, the result has a nil Proof and ProofUnavailable set. By default, it
+	// fails if the proof can't be generated.
+	BestEffortProof bool `json:"best_effort_proof,omitempty"`
+	// ProofEncoding selects how the proof is included in the result.
+	ProofEncoding ProofEncoding `json:"proof_encoding,omitempty"`
+}
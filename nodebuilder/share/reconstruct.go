@@ -0,0 +1,81 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/pruner/full"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+// ReconstructionSample is a sample of the EDS along with its coordinates, as gathered from the
+// network for the Reconstruct method.
+type ReconstructionSample struct {
+	Row    int          `json:"row"`
+	Col    int          `json:"col"`
+	Sample shwap.Sample `json:"sample"`
+}
+
+// Reconstruct verifies the given samples against the roots of the header, erasure-decodes the EDS
+// out of them and puts it into the store. Samples that do not verify fail the whole
+// reconstruction, as they point at a misbehaving source.
+func (m module) Reconstruct(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	samples []ReconstructionSample,
+) error {
+	if m.store == nil {
+		return errors.New("reconstructing EDS requires an EDS store, which light nodes do not keep")
+	}
+	has, err := m.store.HasByHeight(ctx, header.Height())
+	if err != nil {
+		return fmt.Errorf("checking EDS at height %d: %w", header.Height(), err)
+	}
+	if has {
+		return nil
+	}
+
+	roots := header.DAH
+	width := len(roots.RowRoots)
+	shares := make([]share.Share, width*width)
+	for _, sample := range samples {
+		if sample.Row < 0 || sample.Row >= width || sample.Col < 0 || sample.Col >= width {
+			return fmt.Errorf("sample (%d, %d) is out of bounds of the %dx%d square",
+				sample.Row, sample.Col, width, width)
+		}
+		if err := sample.Sample.Verify(roots, sample.Row, sample.Col); err != nil {
+			return fmt.Errorf("verifying sample (%d, %d): %w", sample.Row, sample.Col, err)
+		}
+		shares[sample.Row*width+sample.Col] = sample.Sample.Share
+	}
+
+	square, err := rsmt2d.ImportExtendedDataSquare(
+		shares,
+		share.DefaultRSMT2DCodec(),
+		wrapper.NewConstructor(uint64(width/2)),
+	)
+	if err != nil {
+		return fmt.Errorf("importing EDS: %w", err)
+	}
+	if err := square.Repair(roots.RowRoots, roots.ColumnRoots); err != nil {
+		return fmt.Errorf("repairing EDS at height %d: %w", header.Height(), err)
+	}
+
+	// archival nodes should not store Q4 outside the availability window.
+	if pruner.IsWithinAvailabilityWindow(header.Time(), full.Window) {
+		err = m.store.PutODSQ4(ctx, roots, header.Height(), square)
+	} else {
+		err = m.store.PutODS(ctx, roots, header.Height(), square)
+	}
+	if err != nil {
+		return fmt.Errorf("storing EDS at height %d: %w", header.Height(), err)
+	}
+	return nil
+}
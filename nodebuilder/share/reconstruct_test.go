@@ -0,0 +1,60 @@
+package share
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+func TestModule_Reconstruct(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	square := edstest.RandEDS(t, 4)
+	roots, err := share.NewAxisRoots(square)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+	edsStore, err := store.NewStore(store.DefaultParameters(), t.TempDir())
+	require.NoError(t, err)
+	m := module{store: edsStore}
+
+	// a quarter of the shares is enough to decode the square
+	odsWidth := int(square.Width()) / 2
+	samples := make([]ReconstructionSample, 0, odsWidth*odsWidth)
+	for row := range odsWidth {
+		for col := range odsWidth {
+			sample, err := shwap.SampleFromShares(square.Row(uint(row)), rsmt2d.Row, row, col)
+			require.NoError(t, err)
+			samples = append(samples, ReconstructionSample{Row: row, Col: col, Sample: sample})
+		}
+	}
+
+	// samples that do not verify fail the reconstruction
+	tampered := append([]ReconstructionSample{}, samples...)
+	tampered[0].Row, tampered[0].Col = 1, 1
+	require.Error(t, m.Reconstruct(ctx, eh, tampered))
+
+	// not enough samples to decode
+	require.Error(t, m.Reconstruct(ctx, eh, samples[:len(samples)-1]))
+
+	require.NoError(t, m.Reconstruct(ctx, eh, samples))
+	accessor, err := edsStore.GetByHeight(ctx, eh.Height())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = accessor.Close() })
+	got, err := accessor.AxisRoots(ctx)
+	require.NoError(t, err)
+	require.True(t, roots.Equals(got))
+
+	// light nodes keep no store
+	m.store = nil
+	require.Error(t, m.Reconstruct(ctx, eh, samples))
+}
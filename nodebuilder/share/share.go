@@ -2,26 +2,88 @@ package share
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
 
+	"github.com/ipfs/go-datastore"
 	"github.com/tendermint/tendermint/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
 
+	appshares "github.com/celestiaorg/go-square/shares"
 	"github.com/celestiaorg/nmt"
 	"github.com/celestiaorg/rsmt2d"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/utils"
 	headerServ "github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters"
+	"github.com/celestiaorg/celestia-node/store"
 )
 
 var _ Module = (*API)(nil)
 
-// GetRangeResult wraps the return value of the GetRange endpoint
+var tracer = otel.Tracer("share/module")
+
+const (
+	// maxConcurrentShares is the maximum amount of shares fetched concurrently.
+	maxConcurrentShares = 64
+	// maxNamespaceRangeHeights is the maximum amount of heights GetSharesByNamespaceRange serves.
+	maxNamespaceRangeHeights = 100
+	// maxConcurrentHeights is the maximum amount of heights GetSharesByNamespaceRange fetches
+	// concurrently.
+	maxConcurrentHeights = 8
+	// maxNamespacesPerQuery is the maximum amount of namespaces GetSharesByNamespaces serves.
+	maxNamespacesPerQuery = 100
+	// maxConcurrentNamespaces is the maximum amount of namespaces GetSharesByNamespaces fetches
+	// concurrently.
+	maxConcurrentNamespaces = 8
+)
+
+// GetRangeResult wraps the return value of the GetRange and GetSharesForTx endpoints
 // because Json-RPC doesn't support more than two return values.
 type GetRangeResult struct {
 	Shares []share.Share
 	Proof  *types.ShareProof
+	// EDSWidth is the width of the extended data square the range belongs to.
+	EDSWidth int
+	// StartIndex is the index of the first share of the range in the flattened ODS.
+	StartIndex int
+	// FromRow, FromCol, ToRow and ToCol are the ODS coordinates of the first and the last share of
+	// the range. Both coordinates are inclusive.
+	FromRow, FromCol int
+	ToRow, ToCol     int
+	// ProofUnavailable is set when the proof couldn't be generated for the GetRangeWithOptions
+	// request with GetRangeOptions.BestEffortProof, in which case Proof is nil.
+	ProofUnavailable bool
+}
+
+// newGetRangeResult creates a GetRangeResult for the ODS shares [start, end) of the given square.
+func newGetRangeResult(
+	extendedDataSquare *rsmt2d.ExtendedDataSquare,
+	start, end int,
+	proof *types.ShareProof,
+) *GetRangeResult {
+	odsWidth := int(extendedDataSquare.Width() / 2)
+	return &GetRangeResult{
+		Shares:     extendedDataSquare.FlattenedODS()[start:end],
+		Proof:      proof,
+		EDSWidth:   int(extendedDataSquare.Width()),
+		StartIndex: start,
+		FromRow:    start / odsWidth,
+		FromCol:    start % odsWidth,
+		ToRow:      (end - 1) / odsWidth,
+		ToCol:      (end - 1) % odsWidth,
+	}
 }
 
 // Module provides access to any data square or block share on the network.
@@ -44,42 +106,369 @@ type Module interface {
 	// SharesAvailable subjectively validates if Shares committed to the given
 	// ExtendedHeader are available on the Network.
 	SharesAvailable(context.Context, *header.ExtendedHeader) error
+	// AvailabilityStatus reports whether the availability of the block at the given height was
+	// validated, whether it succeeded, and, for light nodes, when and with how many samples. It
+	// never triggers a validation, so "not validated yet" can be told apart from "not available".
+	AvailabilityStatus(ctx context.Context, height uint64) (share.AvailabilityStatus, error)
+	// InvalidateAvailability removes the persisted availability verdict of the block at the given
+	// height, so that its availability is validated again on the next check. It is only supported
+	// by bridge and full nodes.
+	InvalidateAvailability(ctx context.Context, height uint64) error
 	// GetShare gets a Share by coordinates in EDS.
 	GetShare(ctx context.Context, header *header.ExtendedHeader, row, col int) (share.Share, error)
+	// GetShares gets the Shares at the given coordinates in EDS in a single call. Shares are
+	// returned in the order of the coordinates and are requested from the getter in a single batch,
+	// so that many of them can be retrieved in a single round trip.
+	GetShares(ctx context.Context, header *header.ExtendedHeader, coords []Coordinate) ([]share.Share, error)
+	// GetSamples gets the Samples at the given coordinates in EDS. Each Sample holds the share
+	// together with its inclusion proof against the DAH row root, so that it can be verified
+	// independently of the node. Samples are returned in the order of the coordinates.
+	GetSamples(ctx context.Context, header *header.ExtendedHeader, coords []Coordinate) ([]shwap.Sample, error)
+	// GetRow gets the first half of the row with the given index in EDS together with its proof
+	// against the DAH row root. The second half can be recomputed from the first one.
+	GetRow(ctx context.Context, header *header.ExtendedHeader, rowIdx int) (*AxisHalfResult, error)
+	// GetColumn gets the first half of the column with the given index in EDS together with its
+	// proof against the DAH column root. The second half can be recomputed from the first one.
+	GetColumn(ctx context.Context, header *header.ExtendedHeader, colIdx int) (*AxisHalfResult, error)
 	// GetEDS gets the full EDS identified by the given extended header.
 	GetEDS(ctx context.Context, header *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error)
+	// GetCompressedEDS gets the full EDS identified by the given extended header and returns its
+	// ODS shares in row-major order compressed with zstd, from which the EDS can be recomputed.
+	// See WithCompressedEDS for using it transparently through GetEDS.
+	GetCompressedEDS(ctx context.Context, header *header.ExtendedHeader) ([]byte, error)
+	// GetPartialEDS gets the ODS shares not yet marked as retrieved by the resume token, until either
+	// all of them are retrieved or the context is done. The shares retrieved so far are returned
+	// with a resume token for a follow-up call instead of being discarded. See PartialEDS.
+	GetPartialEDS(ctx context.Context, header *header.ExtendedHeader, resumeToken string) (*PartialEDS, error)
+	// GetODS gets the original data square identified by the given extended header as a list of
+	// its rows. It is a quarter of the EDS, which can be recomputed from it.
+	GetODS(ctx context.Context, header *header.ExtendedHeader) ([][]share.Share, error)
+	// GetEDSHash gets the full EDS identified by the given extended header and returns the SHA-256
+	// hash of all its shares in row-major order. Nodes holding the same EDS produce the same hash.
+	GetEDSHash(ctx context.Context, header *header.ExtendedHeader) ([32]byte, error)
 	// GetSharesByNamespace gets all shares from an EDS within the given namespace.
 	// Shares are returned in a row-by-row order if the namespace spans multiple rows.
+	// Namespace data only lives in the original data square, so proofs are always against the
+	// DAH row roots of the original rows and never against the extended ones.
 	GetSharesByNamespace(
 		ctx context.Context, header *header.ExtendedHeader, namespace share.Namespace,
 	) (NamespacedShares, error)
+	// GetSharesByNamespaces gets all shares from an EDS within each of the given namespaces in a
+	// single call. The result maps the hex encoded namespaces to the same NamespacedShares
+	// GetSharesByNamespace returns for them. The query is limited to 100 namespaces.
+	GetSharesByNamespaces(
+		ctx context.Context, header *header.ExtendedHeader, namespaces []share.Namespace,
+	) (map[string]NamespacedShares, error)
+	// GetSharesByNamespaceRange gets all shares within the given namespace, together with their
+	// proofs, for every height in the inclusive range [fromHeight, toHeight]. The range is limited
+	// to 100 heights. Heights without the namespace map to rows with absence proofs or no rows.
+	GetSharesByNamespaceRange(
+		ctx context.Context, namespace share.Namespace, fromHeight, toHeight uint64,
+	) (map[uint64]NamespacedShares, error)
+	// GetSharesByNamespacePage gets a page of at most maxRows rows of the shares within the given
+	// namespace in the same order and with the same proofs as GetSharesByNamespace. The page
+	// starts at the given page token, with an empty token for the first page, and the returned
+	// NextPageToken is empty once there are no more rows. Only the rows of the page are fetched.
+	GetSharesByNamespacePage(
+		ctx context.Context, header *header.ExtendedHeader, namespace share.Namespace, pageToken string, maxRows int,
+	) (*NamespacedSharesPage, error)
+	// GetSharesByNamespaceColMajor gets all shares from an EDS within the given namespace in
+	// column-major order. Each NamespacedRow holds the shares of a single column ordered top to
+	// bottom, columns are ordered left to right, and proofs are against the DAH column roots.
+	GetSharesByNamespaceColMajor(
+		ctx context.Context, header *header.ExtendedHeader, namespace share.Namespace,
+	) (NamespacedColumns, error)
+	// HasNamespaceData reports whether the EDS may contain shares of the given namespace. It only
+	// inspects the namespace ranges of the DAH row roots and never fetches shares. A false result is
+	// definitive, while a true result may still be followed by an empty GetSharesByNamespace, as the
+	// namespace can fall in between the namespaces of a row.
+	HasNamespaceData(ctx context.Context, header *header.ExtendedHeader, namespace share.Namespace) (bool, error)
+	// HeightsForNamespace returns the heights of the blocks containing blobs of the namespace within
+	// the given inclusive range in ascending order. It is served out of the namespace index of the
+	// EDS store, which only covers the blocks stored since the index was enabled, and is not
+	// supported by light nodes.
+	HeightsForNamespace(ctx context.Context, namespace share.Namespace, from, to uint64) ([]uint64, error)
 	// GetRange gets a list of shares and their corresponding proof.
 	GetRange(ctx context.Context, height uint64, start, end int) (*GetRangeResult, error)
+	// GetRangeWithOptions is GetRange configured by the options. See GetRangeOptions for omitting
+	// the proof or retrieving the shares when it can't be built.
+	GetRangeWithOptions(
+		ctx context.Context, height uint64, start, end int, opts GetRangeOptions,
+	) (*GetRangeResult, error)
+	// GetRangeByCoords gets the shares of the original data square in row-major order from the
+	// (fromRow, fromCol) coordinate up to the (toRow, toCol) coordinate inclusive, together with
+	// their proof against the data root.
+	GetRangeByCoords(
+		ctx context.Context, height uint64, fromRow, fromCol, toRow, toCol int,
+	) (*GetRangeResult, error)
+	// GetRangeByNamespace gets the shares of the given namespace at the given height within the
+	// range [start, end), indexed relatively to the first share of the namespace, together with
+	// their proof against the data root.
+	GetRangeByNamespace(
+		ctx context.Context, height uint64, namespace share.Namespace, start, end int,
+	) (*GetRangeResult, error)
+	// GetSharesForTx gets the shares of the blobs paid for by the PayForBlobs transaction with the
+	// given hash at the given height, together with their inclusion proof. Shares span from the
+	// first share of the first blob to the last share of the last blob, so all the blobs of the
+	// transaction must belong to a single namespace to be proven.
+	GetSharesForTx(ctx context.Context, height uint64, txHash []byte) (*GetRangeResult, error)
+	// GetTxNamespaces maps the upper-case hex hash of every PayForBlobs transaction at the given
+	// height to the namespaces of the blobs it pays for. Transactions not paying for blobs are
+	// omitted.
+	GetTxNamespaces(ctx context.Context, height uint64) (map[string][]share.Namespace, error)
+	// VerifyShareProof verifies that the proof proves the given shares against the data root of
+	// the header. It fails with ErrInvalidProof on any mismatch.
+	VerifyShareProof(
+		ctx context.Context, header *header.ExtendedHeader, proof *types.ShareProof, shares []share.Share,
+	) error
+	// VerifyNamespaceProof verifies that the NamespacedRow holds all the shares of the given
+	// namespace in the row with the given index, as committed to by the DAH of the header. It
+	// fails with ErrInvalidProof on any mismatch.
+	VerifyNamespaceProof(
+		ctx context.Context, header *header.ExtendedHeader, namespace share.Namespace, rowIdx int, row NamespacedRow,
+	) error
+	// EDSByteSize reports the size in bytes of the full EDS at the given height.
+	// It is computed from the header's square size and does not fetch any shares.
+	EDSByteSize(ctx context.Context, height uint64) (int64, error)
+	// SubscribeEDS streams the EDS of every new header as it arrives.
+	// The channel is closed when the context is canceled. Failed retrievals are retried until
+	// successful, and not reading from the channel closes the stream after 16 responses.
+	SubscribeEDS(ctx context.Context) (<-chan *EDSSubscriptionResponse, error)
+	// SubscribeSharesByNamespace streams the shares of the given namespace, together with their
+	// proofs, for every new header committing to the namespace. Blocks without the namespace are
+	// skipped. The stream follows the same rules as SubscribeEDS.
+	SubscribeSharesByNamespace(
+		ctx context.Context, namespace share.Namespace,
+	) (<-chan *NamespaceSubscriptionResponse, error)
+	// StreamSharesByNamespace streams the same rows GetSharesByNamespace returns one at a time,
+	// as soon as each of them is fetched and verified, so that the first rows can be processed
+	// while the later ones are still being retrieved. The channel is closed once all the rows are
+	// sent, the context is canceled or a row fails to be retrieved, which clients detect by
+	// comparing the received row indexes against the rows of the DAH containing the namespace.
+	StreamSharesByNamespace(
+		ctx context.Context, header *header.ExtendedHeader, namespace share.Namespace,
+	) (<-chan *NamespacedRowResponse, error)
+	// ExportEDS writes the EDS of the block at the given height to a file at the given path,
+	// which is relative to the "exports" directory of the node store. Existing files are never
+	// overwritten. The file can be handed to other nodes and imported with ImportEDS.
+	ExportEDS(ctx context.Context, height uint64, path string) error
+	// ImportEDS reads the EDS from a file at the given path relative to the "exports" directory
+	// of the node store, as written by ExportEDS, verifies it against the DAH of the header at
+	// its height and puts it into the EDS store. It is not supported by light nodes.
+	ImportEDS(ctx context.Context, path string) error
+	// ExportSnapshot writes a snapshot of the headers and EDSes of the heights in [from, to] to a
+	// file at the given path, which is relative to the "exports" directory of the node store.
+	// Existing files are never overwritten. New nodes can be bootstrapped from the snapshot with the
+	// "store import" command.
+	ExportSnapshot(ctx context.Context, from, to uint64, path string) error
+	// ImportSnapshot reads a snapshot from a file at the given path relative to the "exports"
+	// directory of the node store, as written by ExportSnapshot, and puts its EDSes into the EDS
+	// store. The EDSes are verified against the headers the node synced, which the headers of the
+	// snapshot must match. It is not supported by light nodes.
+	ImportSnapshot(ctx context.Context, path string) (*SnapshotManifest, error)
+	// Reconstruct erasure-decodes the EDS of the block out of externally gathered samples, which
+	// are verified against the DAH of the header, and puts it into the EDS store. It lets recovery
+	// coordinators push shares collected from light nodes into a full node during a data
+	// withholding incident. It is not supported by light nodes.
+	Reconstruct(ctx context.Context, header *header.ExtendedHeader, samples []ReconstructionSample) error
+	// CollectGarbage removes the garbage left in the EDS store by crashes and interrupted writes,
+	// and reclaims the space of deleted values in the datastore. It is not supported by light
+	// nodes.
+	CollectGarbage(ctx context.Context) (*store.GCResult, error)
+	// SetHotWindow sets the number of the most recent heights kept in the hot tier of the EDS
+	// store, while older ones are moved to the cold tier. The change is not persisted to the
+	// config. It requires the cold tier to be configured and is not supported by light nodes.
+	SetHotWindow(ctx context.Context, window uint64) error
+	// StartBackfill starts downloading the EDSes of the heights of the given inclusive range missing
+	// in the EDS store from the network in the background. The backfill resumes after a restart.
+	// Only one backfill runs at a time and it is not supported by light nodes.
+	StartBackfill(ctx context.Context, from, to uint64) error
+	// BackfillProgress returns the progress of the last backfill.
+	BackfillProgress(ctx context.Context) (*store.BackfillProgress, error)
 }
 
 // API is a wrapper around Module for the RPC.
 type API struct {
 	Internal struct {
-		SharesAvailable func(context.Context, *header.ExtendedHeader) error `perm:"read"`
-		GetShare        func(
+		SharesAvailable    func(context.Context, *header.ExtendedHeader) error `perm:"read"`
+		AvailabilityStatus func(
+			ctx context.Context,
+			height uint64,
+		) (share.AvailabilityStatus, error) `perm:"read"`
+		InvalidateAvailability func(
+			ctx context.Context,
+			height uint64,
+		) error `perm:"admin"`
+		GetShare func(
 			ctx context.Context,
 			header *header.ExtendedHeader,
 			row, col int,
 		) (share.Share, error) `perm:"read"`
+		GetShares func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			coords []Coordinate,
+		) ([]share.Share, error) `perm:"read"`
+		GetSamples func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			coords []Coordinate,
+		) ([]shwap.Sample, error) `perm:"read"`
+		GetRow func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			rowIdx int,
+		) (*AxisHalfResult, error) `perm:"read"`
+		GetColumn func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			colIdx int,
+		) (*AxisHalfResult, error) `perm:"read"`
 		GetEDS func(
 			ctx context.Context,
 			header *header.ExtendedHeader,
 		) (*rsmt2d.ExtendedDataSquare, error) `perm:"read"`
+		GetCompressedEDS func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+		) ([]byte, error) `perm:"read"`
+		GetPartialEDS func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			resumeToken string,
+		) (*PartialEDS, error) `perm:"read"`
+		GetODS func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+		) ([][]share.Share, error) `perm:"read"`
+		GetEDSHash func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+		) ([32]byte, error) `perm:"read"`
 		GetSharesByNamespace func(
 			ctx context.Context,
 			header *header.ExtendedHeader,
 			namespace share.Namespace,
 		) (NamespacedShares, error) `perm:"read"`
+		GetSharesByNamespaces func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			namespaces []share.Namespace,
+		) (map[string]NamespacedShares, error) `perm:"read"`
+		GetSharesByNamespaceRange func(
+			ctx context.Context,
+			namespace share.Namespace,
+			fromHeight, toHeight uint64,
+		) (map[uint64]NamespacedShares, error) `perm:"read"`
+		GetSharesByNamespacePage func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			namespace share.Namespace,
+			pageToken string,
+			maxRows int,
+		) (*NamespacedSharesPage, error) `perm:"read"`
+		GetSharesByNamespaceColMajor func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			namespace share.Namespace,
+		) (NamespacedColumns, error) `perm:"read"`
+		HasNamespaceData func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			namespace share.Namespace,
+		) (bool, error) `perm:"read"`
+		HeightsForNamespace func(
+			ctx context.Context,
+			namespace share.Namespace,
+			from, to uint64,
+		) ([]uint64, error) `perm:"read"`
 		GetRange func(
 			ctx context.Context,
 			height uint64,
 			start, end int,
 		) (*GetRangeResult, error) `perm:"read"`
+		GetRangeWithOptions func(
+			ctx context.Context,
+			height uint64,
+			start, end int,
+			opts GetRangeOptions,
+		) (*GetRangeResult, error) `perm:"read"`
+		GetRangeByCoords func(
+			ctx context.Context,
+			height uint64,
+			fromRow, fromCol, toRow, toCol int,
+		) (*GetRangeResult, error) `perm:"read"`
+		GetRangeByNamespace func(
+			ctx context.Context,
+			height uint64,
+			namespace share.Namespace,
+			start, end int,
+		) (*GetRangeResult, error) `perm:"read"`
+		GetSharesForTx func(
+			ctx context.Context,
+			height uint64,
+			txHash []byte,
+		) (*GetRangeResult, error) `perm:"read"`
+		GetTxNamespaces func(
+			ctx context.Context,
+			height uint64,
+		) (map[string][]share.Namespace, error) `perm:"read"`
+		VerifyShareProof func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			proof *types.ShareProof,
+			shares []share.Share,
+		) error `perm:"read"`
+		VerifyNamespaceProof func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			namespace share.Namespace,
+			rowIdx int,
+			row NamespacedRow,
+		) error `perm:"read"`
+		EDSByteSize func(
+			ctx context.Context,
+			height uint64,
+		) (int64, error) `perm:"read"`
+		SubscribeEDS func(
+			ctx context.Context,
+		) (<-chan *EDSSubscriptionResponse, error) `perm:"read"`
+		SubscribeSharesByNamespace func(
+			ctx context.Context,
+			namespace share.Namespace,
+		) (<-chan *NamespaceSubscriptionResponse, error) `perm:"read"`
+		StreamSharesByNamespace func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			namespace share.Namespace,
+		) (<-chan *NamespacedRowResponse, error) `perm:"read"`
+		ExportEDS func(
+			ctx context.Context,
+			height uint64,
+			path string,
+		) error `perm:"admin"`
+		ImportEDS func(
+			ctx context.Context,
+			path string,
+		) error `perm:"admin"`
+		ExportSnapshot func(
+			ctx context.Context,
+			from, to uint64,
+			path string,
+		) error `perm:"admin"`
+		ImportSnapshot func(
+			ctx context.Context,
+			path string,
+		) (*SnapshotManifest, error) `perm:"admin"`
+		Reconstruct func(
+			ctx context.Context,
+			header *header.ExtendedHeader,
+			samples []ReconstructionSample,
+		) error `perm:"admin"`
+		CollectGarbage   func(ctx context.Context) (*store.GCResult, error)         `perm:"admin"`
+		SetHotWindow     func(ctx context.Context, window uint64) error             `perm:"admin"`
+		StartBackfill    func(ctx context.Context, from, to uint64) error           `perm:"admin"`
+		BackfillProgress func(ctx context.Context) (*store.BackfillProgress, error) `perm:"read"`
 	}
 }
 
@@ -87,18 +476,193 @@ func (api *API) SharesAvailable(ctx context.Context, header *header.ExtendedHead
 	return api.Internal.SharesAvailable(ctx, header)
 }
 
+func (api *API) AvailabilityStatus(ctx context.Context, height uint64) (share.AvailabilityStatus, error) {
+	return api.Internal.AvailabilityStatus(ctx, height)
+}
+
+func (api *API) InvalidateAvailability(ctx context.Context, height uint64) error {
+	return api.Internal.InvalidateAvailability(ctx, height)
+}
+
 func (api *API) GetShare(ctx context.Context, header *header.ExtendedHeader, row, col int) (share.Share, error) {
 	return api.Internal.GetShare(ctx, header, row, col)
 }
 
+func (api *API) GetShares(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	coords []Coordinate,
+) ([]share.Share, error) {
+	return api.Internal.GetShares(ctx, header, coords)
+}
+
+func (api *API) GetSamples(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	coords []Coordinate,
+) ([]shwap.Sample, error) {
+	return api.Internal.GetSamples(ctx, header, coords)
+}
+
+func (api *API) GetRow(ctx context.Context, header *header.ExtendedHeader, rowIdx int) (*AxisHalfResult, error) {
+	return api.Internal.GetRow(ctx, header, rowIdx)
+}
+
+func (api *API) GetColumn(ctx context.Context, header *header.ExtendedHeader, colIdx int) (*AxisHalfResult, error) {
+	return api.Internal.GetColumn(ctx, header, colIdx)
+}
+
 func (api *API) GetEDS(ctx context.Context, header *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
+	if compressedEDSFromContext(ctx) {
+		data, err := api.Internal.GetCompressedEDS(ctx, header)
+		if err != nil {
+			return nil, err
+		}
+		return decompressEDS(ctx, header, data)
+	}
 	return api.Internal.GetEDS(ctx, header)
 }
 
+func (api *API) GetCompressedEDS(ctx context.Context, header *header.ExtendedHeader) ([]byte, error) {
+	return api.Internal.GetCompressedEDS(ctx, header)
+}
+
+func (api *API) GetPartialEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	resumeToken string,
+) (*PartialEDS, error) {
+	return api.Internal.GetPartialEDS(ctx, header, resumeToken)
+}
+
+func (api *API) GetODS(ctx context.Context, header *header.ExtendedHeader) ([][]share.Share, error) {
+	return api.Internal.GetODS(ctx, header)
+}
+
+func (api *API) GetEDSHash(ctx context.Context, header *header.ExtendedHeader) ([32]byte, error) {
+	return api.Internal.GetEDSHash(ctx, header)
+}
+
 func (api *API) GetRange(ctx context.Context, height uint64, start, end int) (*GetRangeResult, error) {
 	return api.Internal.GetRange(ctx, height, start, end)
 }
 
+func (api *API) GetRangeWithOptions(
+	ctx context.Context,
+	height uint64,
+	start, end int,
+	opts GetRangeOptions,
+) (*GetRangeResult, error) {
+	return api.Internal.GetRangeWithOptions(ctx, height, start, end, opts)
+}
+
+func (api *API) GetRangeByCoords(
+	ctx context.Context,
+	height uint64,
+	fromRow, fromCol, toRow, toCol int,
+) (*GetRangeResult, error) {
+	return api.Internal.GetRangeByCoords(ctx, height, fromRow, fromCol, toRow, toCol)
+}
+
+func (api *API) GetRangeByNamespace(
+	ctx context.Context,
+	height uint64,
+	namespace share.Namespace,
+	start, end int,
+) (*GetRangeResult, error) {
+	return api.Internal.GetRangeByNamespace(ctx, height, namespace, start, end)
+}
+
+func (api *API) GetSharesForTx(ctx context.Context, height uint64, txHash []byte) (*GetRangeResult, error) {
+	return api.Internal.GetSharesForTx(ctx, height, txHash)
+}
+
+func (api *API) GetTxNamespaces(ctx context.Context, height uint64) (map[string][]share.Namespace, error) {
+	return api.Internal.GetTxNamespaces(ctx, height)
+}
+
+func (api *API) VerifyShareProof(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	proof *types.ShareProof,
+	shares []share.Share,
+) error {
+	return api.Internal.VerifyShareProof(ctx, header, proof, shares)
+}
+
+func (api *API) VerifyNamespaceProof(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+	rowIdx int,
+	row NamespacedRow,
+) error {
+	return api.Internal.VerifyNamespaceProof(ctx, header, namespace, rowIdx, row)
+}
+
+func (api *API) EDSByteSize(ctx context.Context, height uint64) (int64, error) {
+	return api.Internal.EDSByteSize(ctx, height)
+}
+
+func (api *API) SubscribeEDS(ctx context.Context) (<-chan *EDSSubscriptionResponse, error) {
+	return api.Internal.SubscribeEDS(ctx)
+}
+
+func (api *API) SubscribeSharesByNamespace(
+	ctx context.Context,
+	namespace share.Namespace,
+) (<-chan *NamespaceSubscriptionResponse, error) {
+	return api.Internal.SubscribeSharesByNamespace(ctx, namespace)
+}
+
+func (api *API) StreamSharesByNamespace(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (<-chan *NamespacedRowResponse, error) {
+	return api.Internal.StreamSharesByNamespace(ctx, header, namespace)
+}
+
+func (api *API) ExportEDS(ctx context.Context, height uint64, path string) error {
+	return api.Internal.ExportEDS(ctx, height, path)
+}
+
+func (api *API) ImportEDS(ctx context.Context, path string) error {
+	return api.Internal.ImportEDS(ctx, path)
+}
+
+func (api *API) ExportSnapshot(ctx context.Context, from, to uint64, path string) error {
+	return api.Internal.ExportSnapshot(ctx, from, to, path)
+}
+
+func (api *API) ImportSnapshot(ctx context.Context, path string) (*SnapshotManifest, error) {
+	return api.Internal.ImportSnapshot(ctx, path)
+}
+
+func (api *API) Reconstruct(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	samples []ReconstructionSample,
+) error {
+	return api.Internal.Reconstruct(ctx, header, samples)
+}
+
+func (api *API) CollectGarbage(ctx context.Context) (*store.GCResult, error) {
+	return api.Internal.CollectGarbage(ctx)
+}
+
+func (api *API) SetHotWindow(ctx context.Context, window uint64) error {
+	return api.Internal.SetHotWindow(ctx, window)
+}
+
+func (api *API) StartBackfill(ctx context.Context, from, to uint64) error {
+	return api.Internal.StartBackfill(ctx, from, to)
+}
+
+func (api *API) BackfillProgress(ctx context.Context) (*store.BackfillProgress, error) {
+	return api.Internal.BackfillProgress(ctx)
+}
+
 func (api *API) GetSharesByNamespace(
 	ctx context.Context,
 	header *header.ExtendedHeader,
@@ -107,17 +671,266 @@ func (api *API) GetSharesByNamespace(
 	return api.Internal.GetSharesByNamespace(ctx, header, namespace)
 }
 
+func (api *API) GetSharesByNamespaces(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespaces []share.Namespace,
+) (map[string]NamespacedShares, error) {
+	return api.Internal.GetSharesByNamespaces(ctx, header, namespaces)
+}
+
+func (api *API) GetSharesByNamespaceRange(
+	ctx context.Context,
+	namespace share.Namespace,
+	fromHeight, toHeight uint64,
+) (map[uint64]NamespacedShares, error) {
+	return api.Internal.GetSharesByNamespaceRange(ctx, namespace, fromHeight, toHeight)
+}
+
+func (api *API) GetSharesByNamespacePage(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+	pageToken string,
+	maxRows int,
+) (*NamespacedSharesPage, error) {
+	return api.Internal.GetSharesByNamespacePage(ctx, header, namespace, pageToken, maxRows)
+}
+
+func (api *API) GetSharesByNamespaceColMajor(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (NamespacedColumns, error) {
+	return api.Internal.GetSharesByNamespaceColMajor(ctx, header, namespace)
+}
+
+func (api *API) HasNamespaceData(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (bool, error) {
+	return api.Internal.HasNamespaceData(ctx, header, namespace)
+}
+
+func (api *API) HeightsForNamespace(
+	ctx context.Context,
+	namespace share.Namespace,
+	from, to uint64,
+) ([]uint64, error) {
+	return api.Internal.HeightsForNamespace(ctx, namespace, from, to)
+}
+
 type module struct {
 	shwap.Getter
 	share.Availability
 	hs headerServ.Module
+	// store is the EDS store of the node, which is nil for light nodes.
+	store *store.Store
+	// datastore is the datastore of the node.
+	datastore datastore.Batching
+	// exportDir is the directory EDSes are exported to.
+	exportDir string
+	// prefetcher prefetches requested namespaces for new headers, which is nil when disabled.
+	prefetcher *getters.NamespacePrefetcher
+	// backfiller downloads the EDSes missing in the store, which is nil for light nodes.
+	backfiller *store.Backfiller
 }
 
 func (m module) SharesAvailable(ctx context.Context, header *header.ExtendedHeader) error {
 	return m.Availability.SharesAvailable(ctx, header)
 }
 
+func (m module) AvailabilityStatus(ctx context.Context, height uint64) (share.AvailabilityStatus, error) {
+	extendedHeader, err := m.hs.GetByHeight(ctx, height)
+	if err != nil {
+		return share.AvailabilityStatus{}, err
+	}
+	return m.Availability.Status(ctx, extendedHeader)
+}
+
+// availabilityInvalidator is implemented by the share.Availability persisting its verdicts.
+type availabilityInvalidator interface {
+	Invalidate(ctx context.Context, height uint64) error
+}
+
+func (m module) InvalidateAvailability(ctx context.Context, height uint64) error {
+	invalidator, ok := m.Availability.(availabilityInvalidator)
+	if !ok {
+		return fmt.Errorf("invalidating availability: %w", shwap.ErrOperationNotSupported)
+	}
+	return invalidator.Invalidate(ctx, height)
+}
+
+func (m module) GetShares(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	coords []Coordinate,
+) ([]share.Share, error) {
+	width := len(header.DAH.RowRoots)
+	// fetch every distinct coordinate only once, in a single batch
+	unique := make(map[Coordinate]int, len(coords))
+	rowIdxs := make([]int, 0, len(coords))
+	colIdxs := make([]int, 0, len(coords))
+	for _, coord := range coords {
+		if coord.Row < 0 || coord.Row >= width || coord.Col < 0 || coord.Col >= width {
+			return nil, fmt.Errorf("coordinate (%d, %d) is out of the square bounds", coord.Row, coord.Col)
+		}
+		if _, ok := unique[coord]; ok {
+			continue
+		}
+		unique[coord] = len(rowIdxs)
+		rowIdxs = append(rowIdxs, coord.Row)
+		colIdxs = append(colIdxs, coord.Col)
+	}
+
+	fetched, err := m.Getter.GetShares(ctx, header, rowIdxs, colIdxs)
+	if err != nil {
+		return nil, fmt.Errorf("getting shares: %w", err)
+	}
+	if len(fetched) != len(rowIdxs) {
+		return nil, fmt.Errorf("getter returned %d shares for %d coordinates", len(fetched), len(rowIdxs))
+	}
+
+	shares := make([]share.Share, len(coords))
+	for i, coord := range coords {
+		shares[i] = fetched[unique[coord]]
+	}
+	return shares, nil
+}
+
+func (m module) GetODS(ctx context.Context, header *header.ExtendedHeader) ([][]share.Share, error) {
+	extendedDataSquare, err := m.GetEDS(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	odsWidth := extendedDataSquare.Width() / 2
+	ods := make([][]share.Share, odsWidth)
+	for i := range ods {
+		ods[i] = extendedDataSquare.Row(uint(i))[:odsWidth]
+	}
+	return ods, nil
+}
+
+func (m module) GetEDSHash(ctx context.Context, header *header.ExtendedHeader) ([32]byte, error) {
+	extendedDataSquare, err := m.GetEDS(ctx, header)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	// shares have a fixed size, so their concatenation unambiguously identifies the square
+	hasher := sha256.New()
+	for _, shr := range extendedDataSquare.Flattened() {
+		hasher.Write(shr)
+	}
+	var hash [32]byte
+	hasher.Sum(hash[:0])
+	return hash, nil
+}
+
 func (m module) GetRange(ctx context.Context, height uint64, start, end int) (*GetRangeResult, error) {
+	return m.GetRangeWithOptions(ctx, height, start, end, GetRangeOptions{})
+}
+
+func (m module) GetRangeWithOptions(
+	ctx context.Context,
+	height uint64,
+	start, end int,
+	opts GetRangeOptions,
+) (*GetRangeResult, error) {
+	extendedHeader, err := m.hs.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	extendedDataSquare, err := m.GetEDS(ctx, extendedHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := proveRange(extendedDataSquare, start, end, opts.ProofEncoding)
+	if err == nil || !opts.BestEffortProof {
+		return res, err
+	}
+
+	ods := extendedDataSquare.FlattenedODS()
+	if start < 0 || start >= end || end > len(ods) {
+		return nil, err
+	}
+	// the error is not returned, as the RPC drops the result along with it
+	res = newGetRangeResult(extendedDataSquare, start, end, nil)
+	res.ProofUnavailable = true
+	return res, nil
+}
+
+func (m module) GetRangeByCoords(
+	ctx context.Context,
+	height uint64,
+	fromRow, fromCol, toRow, toCol int,
+) (*GetRangeResult, error) {
+	extendedHeader, err := m.hs.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	odsWidth := len(extendedHeader.DAH.RowRoots) / 2
+	inODS := func(row, col int) bool {
+		return row >= 0 && row < odsWidth && col >= 0 && col < odsWidth
+	}
+	if !inODS(fromRow, fromCol) || !inODS(toRow, toCol) {
+		return nil, fmt.Errorf("coordinates (%d, %d) to (%d, %d) are out of the original square bounds",
+			fromRow, fromCol, toRow, toCol)
+	}
+	start, end := fromRow*odsWidth+fromCol, toRow*odsWidth+toCol+1
+	if start >= end {
+		return nil, fmt.Errorf("coordinate (%d, %d) goes after (%d, %d)", fromRow, fromCol, toRow, toCol)
+	}
+
+	extendedDataSquare, err := m.GetEDS(ctx, extendedHeader)
+	if err != nil {
+		return nil, err
+	}
+	return proveRange(extendedDataSquare, start, end, ProofEncodingFull)
+}
+
+func (m module) GetRangeByNamespace(
+	ctx context.Context,
+	height uint64,
+	namespace share.Namespace,
+	start, end int,
+) (*GetRangeResult, error) {
+	if err := namespace.ValidateForData(); err != nil {
+		return nil, err
+	}
+	extendedHeader, err := m.hs.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	extendedDataSquare, err := m.GetEDS(ctx, extendedHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	// shares of a namespace are laid out contiguously in the ODS
+	ods := extendedDataSquare.FlattenedODS()
+	nsStart := slices.IndexFunc(ods, func(shr share.Share) bool {
+		return namespace.Equals(share.GetNamespace(shr))
+	})
+	if nsStart == -1 {
+		return nil, fmt.Errorf("namespace %s is not present at height %d", namespace.String(), height)
+	}
+	nsEnd := nsStart
+	for nsEnd < len(ods) && namespace.Equals(share.GetNamespace(ods[nsEnd])) {
+		nsEnd++
+	}
+	if start < 0 || start >= end || nsStart+end > nsEnd {
+		return nil, fmt.Errorf("invalid range [%d, %d) for namespace %s of %d shares",
+			start, end, namespace.String(), nsEnd-nsStart)
+	}
+	return proveRange(extendedDataSquare, nsStart+start, nsStart+end, ProofEncodingFull)
+}
+
+func (m module) GetSharesForTx(ctx context.Context, height uint64, txHash []byte) (*GetRangeResult, error) {
 	extendedHeader, err := m.hs.GetByHeight(ctx, height)
 	if err != nil {
 		return nil, err
@@ -127,37 +940,382 @@ func (m module) GetRange(ctx context.Context, height uint64, start, end int) (*G
 		return nil, err
 	}
 
+	start, end, err := eds.BlobTxRange(extendedDataSquare, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("locating tx %X at height %d: %w", txHash, height, err)
+	}
+	return proveRange(extendedDataSquare, start, end, ProofEncodingFull)
+}
+
+func (m module) GetTxNamespaces(ctx context.Context, height uint64) (map[string][]share.Namespace, error) {
+	extendedHeader, err := m.hs.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	extendedDataSquare, err := m.GetEDS(ctx, extendedHeader)
+	if err != nil {
+		return nil, err
+	}
+	return eds.BlobTxNamespaces(extendedDataSquare)
+}
+
+// proveRange proves the range of ODS shares [start, end) against the data root, unless the proof is
+// omitted with ProofEncodingNone.
+func proveRange(
+	extendedDataSquare *rsmt2d.ExtendedDataSquare,
+	start, end int,
+	encoding ProofEncoding,
+) (*GetRangeResult, error) {
+	if encoding == ProofEncodingNone {
+		ods := extendedDataSquare.FlattenedODS()
+		if start < 0 || start >= end || end > len(ods) {
+			return nil, fmt.Errorf("invalid range [%d, %d) for ODS of %d shares", start, end, len(ods))
+		}
+		return newGetRangeResult(extendedDataSquare, start, end, nil), nil
+	}
+
 	proof, err := eds.ProveShares(extendedDataSquare, start, end)
 	if err != nil {
 		return nil, err
 	}
-	return &GetRangeResult{
-		extendedDataSquare.FlattenedODS()[start:end],
-		proof,
-	}, nil
+	return newGetRangeResult(extendedDataSquare, start, end, proof), nil
+}
+
+func (m module) EDSByteSize(ctx context.Context, height uint64) (int64, error) {
+	extendedHeader, err := m.hs.GetByHeight(ctx, height)
+	if err != nil {
+		return 0, err
+	}
+	width := int64(len(extendedHeader.DAH.RowRoots))
+	return width * width * share.Size, nil
 }
 
 func (m module) GetSharesByNamespace(
 	ctx context.Context,
 	header *header.ExtendedHeader,
 	namespace share.Namespace,
-) (NamespacedShares, error) {
+) (_ NamespacedShares, err error) {
+	ctx, span := tracer.Start(ctx, "share/get-shares-by-namespace", trace.WithAttributes(
+		attribute.Int64("height", int64(header.Height())),
+		attribute.String("namespace", namespace.String()),
+	))
+	defer func() { utils.SetStatusAndEnd(span, err) }()
+
+	m.prefetcher.Touch(namespace)
 	nd, err := m.Getter.GetSharesByNamespace(ctx, header, namespace)
 	if err != nil {
 		return nil, err
 	}
+	shares := convertToNamespacedShares(nd)
+	for i := range shares {
+		shares[i].Blobs = blobBoundaries(shares[i].Shares)
+	}
+	return shares, nil
+}
+
+// GetSharesByNamespaces scans the DAH row roots once for all the namespaces, so that namespaces
+// outside of every row are resolved without any request, and fetches the rest concurrently.
+func (m module) GetSharesByNamespaces(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespaces []share.Namespace,
+) (map[string]NamespacedShares, error) {
+	if len(namespaces) > maxNamespacesPerQuery {
+		return nil, fmt.Errorf("%d namespaces exceed the limit of %d namespaces",
+			len(namespaces), maxNamespacesPerQuery)
+	}
+	for _, namespace := range namespaces {
+		if err := namespace.ValidateForData(); err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", namespace, err)
+		}
+	}
+
+	inRows := make([]bool, len(namespaces))
+	for _, row := range header.DAH.RowRoots[:len(header.DAH.RowRoots)/2] {
+		for i, namespace := range namespaces {
+			inRows[i] = inRows[i] || !namespace.IsOutsideRange(row, row)
+		}
+	}
+
+	var lock sync.Mutex
+	sharesByNamespace := make(map[string]NamespacedShares, len(namespaces))
+	errGroup, ctx := errgroup.WithContext(ctx)
+	errGroup.SetLimit(maxConcurrentNamespaces)
+	for i, namespace := range namespaces {
+		if !inRows[i] {
+			lock.Lock()
+			sharesByNamespace[namespace.String()] = NamespacedShares{}
+			lock.Unlock()
+			continue
+		}
+		errGroup.Go(func() error {
+			shares, err := m.GetSharesByNamespace(ctx, header, namespace)
+			if err != nil {
+				return fmt.Errorf("getting shares of namespace %s: %w", namespace, err)
+			}
+			lock.Lock()
+			sharesByNamespace[namespace.String()] = shares
+			lock.Unlock()
+			return nil
+		})
+	}
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+	return sharesByNamespace, nil
+}
+
+func (m module) GetSharesByNamespaceRange(
+	ctx context.Context,
+	namespace share.Namespace,
+	fromHeight, toHeight uint64,
+) (map[uint64]NamespacedShares, error) {
+	if err := namespace.ValidateForData(); err != nil {
+		return nil, err
+	}
+	if fromHeight == 0 || fromHeight > toHeight {
+		return nil, fmt.Errorf("invalid height range [%d, %d]", fromHeight, toHeight)
+	}
+	if toHeight-fromHeight >= maxNamespaceRangeHeights {
+		return nil, fmt.Errorf("height range [%d, %d] exceeds the limit of %d heights",
+			fromHeight, toHeight, maxNamespaceRangeHeights)
+	}
+
+	var lock sync.Mutex
+	sharesByHeight := make(map[uint64]NamespacedShares, toHeight-fromHeight+1)
+	errGroup, ctx := errgroup.WithContext(ctx)
+	errGroup.SetLimit(maxConcurrentHeights)
+	for height := fromHeight; height <= toHeight; height++ {
+		errGroup.Go(func() error {
+			header, err := m.hs.GetByHeight(ctx, height)
+			if err != nil {
+				return fmt.Errorf("getting header at height %d: %w", height, err)
+			}
+			shares, err := m.GetSharesByNamespace(ctx, header, namespace)
+			if err != nil {
+				return fmt.Errorf("getting shares at height %d: %w", height, err)
+			}
+			lock.Lock()
+			sharesByHeight[height] = shares
+			lock.Unlock()
+			return nil
+		})
+	}
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+	return sharesByHeight, nil
+}
+
+// GetSharesByNamespacePage fetches only the rows of the page. The page token is the index of the
+// row the page starts at, so that every page is served without fetching the rows before it.
+func (m module) GetSharesByNamespacePage(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+	pageToken string,
+	maxRows int,
+) (*NamespacedSharesPage, error) {
+	if maxRows <= 0 {
+		return nil, fmt.Errorf("invalid max rows per page: %d", maxRows)
+	}
+	if err := namespace.ValidateForData(); err != nil {
+		return nil, err
+	}
+
+	rows := share.RowsWithNamespace(header.DAH, namespace)
+	first := 0
+	if pageToken != "" {
+		rowIdx, err := strconv.Atoi(pageToken)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page token: %q", pageToken)
+		}
+		var found bool
+		first, found = slices.BinarySearch(rows, rowIdx)
+		if !found {
+			return nil, fmt.Errorf("page token %q does not point to a row of the namespace", pageToken)
+		}
+	}
+	pageRows := rows[first:min(first+maxRows, len(rows))]
+
+	m.prefetcher.Touch(namespace)
+	// the original halves of the rows are fetched at once and extended locally to prove them
+	odsWidth := len(header.DAH.RowRoots) / 2
+	rowIdxs := make([]int, 0, len(pageRows)*odsWidth)
+	colIdxs := make([]int, 0, len(pageRows)*odsWidth)
+	for _, rowIdx := range pageRows {
+		for colIdx := range odsWidth {
+			rowIdxs = append(rowIdxs, rowIdx)
+			colIdxs = append(colIdxs, colIdx)
+		}
+	}
+	shares, err := m.Getter.GetShares(ctx, header, rowIdxs, colIdxs)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &NamespacedSharesPage{Shares: make(NamespacedShares, 0, len(pageRows))}
+	for i, rowIdx := range pageRows {
+		rowShares, err := eds.AxisHalf{Shares: shares[i*odsWidth : (i+1)*odsWidth]}.Extended()
+		if err != nil {
+			return nil, fmt.Errorf("extending row %d: %w", rowIdx, err)
+		}
+		rnd, err := shwap.RowNamespaceDataFromShares(rowShares, namespace, rowIdx)
+		if err != nil {
+			return nil, err
+		}
+		if err := rnd.Verify(header.DAH, namespace, rowIdx); err != nil {
+			return nil, fmt.Errorf("verifying namespace data of row %d: %w", rowIdx, err)
+		}
+		page.Shares = append(page.Shares, NamespacedRow{
+			Shares: rnd.Shares,
+			Proof:  rnd.Proof,
+			Blobs:  blobBoundaries(rnd.Shares),
+		})
+	}
+	if next := first + len(pageRows); next < len(rows) {
+		page.NextPageToken = strconv.Itoa(rows[next])
+	}
+	return page, nil
+}
+
+func (m module) GetSharesByNamespaceColMajor(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (NamespacedColumns, error) {
+	if err := namespace.ValidateForData(); err != nil {
+		return nil, err
+	}
+	extendedDataSquare, err := m.GetEDS(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	nd, err := eds.NamespaceDataColMajor(ctx, &eds.Rsmt2D{ExtendedDataSquare: extendedDataSquare}, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err := nd.VerifyColumns(header.DAH, namespace); err != nil {
+		return nil, fmt.Errorf("verifying column-major namespace data: %w", err)
+	}
 	return convertToNamespacedShares(nd), nil
 }
 
+func (m module) HasNamespaceData(
+	_ context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (bool, error) {
+	if err := namespace.ValidateForData(); err != nil {
+		return false, err
+	}
+	return len(share.RowsWithNamespace(header.DAH, namespace)) != 0, nil
+}
+
+func (m module) HeightsForNamespace(
+	ctx context.Context,
+	namespace share.Namespace,
+	from, to uint64,
+) ([]uint64, error) {
+	if m.store == nil {
+		return nil, errors.New("namespace index requires an EDS store, which light nodes do not keep")
+	}
+	return m.store.HeightsForNamespace(ctx, namespace, from, to)
+}
+
+func (m module) SetHotWindow(_ context.Context, window uint64) error {
+	if m.store == nil {
+		return errors.New("storage tiering requires an EDS store, which light nodes do not keep")
+	}
+	return m.store.SetHotWindow(window)
+}
+
+func (m module) StartBackfill(ctx context.Context, from, to uint64) error {
+	if m.backfiller == nil {
+		return errors.New("backfill requires an EDS store, which light nodes do not keep")
+	}
+	return m.backfiller.Backfill(ctx, from, to)
+}
+
+func (m module) BackfillProgress(context.Context) (*store.BackfillProgress, error) {
+	if m.backfiller == nil {
+		return nil, errors.New("backfill requires an EDS store, which light nodes do not keep")
+	}
+	progress := m.backfiller.Progress()
+	return &progress, nil
+}
+
+// Coordinate identifies a share by its row and column in the EDS.
+type Coordinate struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
 // NamespacedShares represents all shares with proofs within a specific namespace of an EDS.
 // This is a copy of the share.NamespacedShares type, that is used to avoid breaking changes
 // in the API.
+//
+// Items are ordered by axis index. For GetSharesByNamespace each item is a row, while for
+// GetSharesByNamespaceColMajor each item is a column. See NamespacedColumns.
 type NamespacedShares []NamespacedRow
 
-// NamespacedRow represents all shares with proofs within a specific namespace of a single EDS row.
+// NamespacedSharesPage is a page of NamespacedShares returned by GetSharesByNamespacePage.
+type NamespacedSharesPage struct {
+	Shares NamespacedShares `json:"shares"`
+	// NextPageToken is the token of the next page, or empty for the last page.
+	NextPageToken string `json:"next_page_token"`
+}
+
+// NamespacedColumns is NamespacedShares in column-major order, as returned by
+// GetSharesByNamespaceColMajor. Each item holds the shares of a single column ordered top to
+// bottom together with a proof against the column root.
+type NamespacedColumns = NamespacedShares
+
+// NamespacedRow represents all shares with proofs within a specific namespace of a single EDS
+// axis. Despite the name, it is also used for columns by GetSharesByNamespaceColMajor, in which
+// case the shares are ordered top to bottom and the proof is against the column root.
 type NamespacedRow struct {
 	Shares []share.Share `json:"shares"`
 	Proof  *nmt.Proof    `json:"proof"`
+	// Blobs marks the blobs starting within Shares. It is only set for rows, as column-major order
+	// does not preserve blob sequences. Blobs continuing from the previous row are not marked.
+	Blobs []BlobBoundary `json:"blobs,omitempty"`
+}
+
+// BlobBoundary marks the start of a blob within the shares of a NamespacedRow.
+type BlobBoundary struct {
+	// Start is the index of the sequence start share of the blob within the row shares.
+	Start int `json:"start"`
+	// SequenceLen is the length of the blob in bytes, as read from its sequence start share.
+	SequenceLen uint32 `json:"sequence_len"`
+}
+
+// blobBoundaries marks the blobs starting within the given shares. Padding shares start no blob
+// and shares that can not be parsed are skipped, as the markers are only a convenience on top of
+// the shares.
+func blobBoundaries(shares []share.Share) []BlobBoundary {
+	var boundaries []BlobBoundary
+	for i, shr := range shares {
+		appShr, err := appshares.NewShare(shr)
+		if err != nil {
+			continue
+		}
+		isStart, err := appShr.IsSequenceStart()
+		if err != nil || !isStart {
+			continue
+		}
+		isPadding, err := appShr.IsPadding()
+		if err != nil || isPadding {
+			continue
+		}
+		sequenceLen, err := appShr.SequenceLen()
+		if err != nil {
+			continue
+		}
+		boundaries = append(boundaries, BlobBoundary{Start: i, SequenceLen: sequenceLen})
+	}
+	return boundaries
 }
 
 // Flatten returns the concatenated slice of all NamespacedRow shares.
@@ -169,6 +1327,49 @@ func (ns NamespacedShares) Flatten() []share.Share {
 	return shares
 }
 
+// PaddingBlobIndex is the blob index BlobIndexes assigns to namespace padding shares, which do
+// not belong to any blob.
+const PaddingBlobIndex = -1
+
+// BlobIndexes tags every share returned by Flatten with the sequence index of the blob it belongs
+// to within the namespace. The first blob of the namespace has index 0, and the index is
+// incremented on every sequence start share, so blobs continuing across row boundaries keep a
+// single index. Namespace padding shares are tagged with PaddingBlobIndex.
+//
+// Shares must be in the row-major order returned by GetSharesByNamespace, as column-major order
+// does not preserve blob sequences.
+func (ns NamespacedShares) BlobIndexes() ([]int, error) {
+	flattened := ns.Flatten()
+	idxs := make([]int, len(flattened))
+	blobIdx := -1
+	for i, shr := range flattened {
+		appShr, err := appshares.NewShare(shr)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		isPadding, err := appShr.IsPadding()
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		if isPadding {
+			idxs[i] = PaddingBlobIndex
+			continue
+		}
+
+		isStart, err := appShr.IsSequenceStart()
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		if isStart {
+			blobIdx++
+		} else if blobIdx < 0 {
+			return nil, fmt.Errorf("share %d: continuation share without a preceding sequence start", i)
+		}
+		idxs[i] = blobIdx
+	}
+	return idxs, nil
+}
+
 func convertToNamespacedShares(nd shwap.NamespaceData) NamespacedShares {
 	ns := make(NamespacedShares, 0, len(nd))
 	for _, row := range nd {
@@ -0,0 +1,711 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/wrapper"
+	"github.com/celestiaorg/go-square/blob"
+	appns "github.com/celestiaorg/go-square/namespace"
+	appshares "github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+	"github.com/celestiaorg/celestia-node/share"
+	availMock "github.com/celestiaorg/celestia-node/share/availability/mocks"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+// moduleFixture is a module serving a square with the shares of a namespace at height 1 over
+// mocked getters.
+type moduleFixture struct {
+	namespace share.Namespace
+	square    *rsmt2d.ExtendedDataSquare
+	roots     *share.AxisRoots
+	eh        *header.ExtendedHeader
+	getter    *mock.MockGetter
+	m         module
+}
+
+// newModuleFixture builds a square of the given size with amount shares of a random namespace.
+// The getter serves its EDS and namespace data and the header module serves its header.
+func newModuleFixture(t *testing.T, odsSize, amount int) *moduleFixture {
+	t.Helper()
+	namespace := sharetest.RandV0Namespace()
+	square, roots := edstest.RandEDSWithNamespace(t, namespace, amount, odsSize)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+	nd, err := eds.NamespaceData(context.Background(), &eds.Rsmt2D{ExtendedDataSquare: square}, namespace)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	hs := headerMock.NewMockModule(ctrl)
+	hs.EXPECT().GetByHeight(gomock.Any(), uint64(1)).Return(eh, nil).AnyTimes()
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetEDS(gomock.Any(), eh).Return(square, nil).AnyTimes()
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), eh, namespace).Return(nd, nil).AnyTimes()
+	return &moduleFixture{
+		namespace: namespace,
+		square:    square,
+		roots:     roots,
+		eh:        eh,
+		getter:    getter,
+		m:         module{Getter: getter, hs: hs},
+	}
+}
+
+// namespaceStart returns the index of the first share of the namespace in the ODS.
+func (f *moduleFixture) namespaceStart() int {
+	return slices.IndexFunc(f.square.FlattenedODS(), func(shr share.Share) bool {
+		return f.namespace.Equals(share.GetNamespace(shr))
+	})
+}
+
+func TestModule_EDSByteSize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const odsSize = 4
+	roots, err := share.NewAxisRoots(edstest.RandEDS(t, odsSize))
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+	errUnknown := errors.New("header not found")
+
+	ctrl := gomock.NewController(t)
+	hs := headerMock.NewMockModule(ctrl)
+	hs.EXPECT().GetByHeight(gomock.Any(), uint64(1)).Return(eh, nil).AnyTimes()
+	hs.EXPECT().GetByHeight(gomock.Any(), uint64(2)).Return(nil, errUnknown).AnyTimes()
+	m := module{hs: hs}
+
+	testCases := []struct {
+		name    string
+		height  uint64
+		want    int64
+		wantErr error
+	}{
+		{
+			name:   "known height",
+			height: 1,
+			want:   2 * odsSize * 2 * odsSize * share.Size,
+		},
+		{
+			name:    "unknown height",
+			height:  2,
+			wantErr: errUnknown,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			size, err := m.EDSByteSize(ctx, tc.height)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, size)
+		})
+	}
+}
+
+func TestModule_GetSharesByNamespaceColMajor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const (
+		odsSize = 8
+		amount  = 20
+	)
+	f := newModuleFixture(t, odsSize, amount)
+	// header committing to a different square with the same namespace
+	_, otherRoots := edstest.RandEDSWithNamespace(t, f.namespace, amount, odsSize)
+	otherEh := headertest.RandExtendedHeaderWithRoot(t, otherRoots)
+	f.getter.EXPECT().GetEDS(gomock.Any(), otherEh).Return(f.square, nil).AnyTimes()
+	errGet := errors.New("get eds failed")
+	failingEh := headertest.RandExtendedHeaderWithRoot(t, f.roots)
+	f.getter.EXPECT().GetEDS(gomock.Any(), failingEh).Return(nil, errGet).AnyTimes()
+
+	t.Run("success", func(t *testing.T) {
+		cols, err := f.m.GetSharesByNamespaceColMajor(ctx, f.eh, f.namespace)
+		require.NoError(t, err)
+		require.Len(t, cols, len(share.ColumnsWithNamespace(f.roots, f.namespace)))
+		require.Len(t, cols.Flatten(), amount)
+
+		// shares are ordered by column then row
+		var expected []share.Share
+		for col := 0; col < odsSize; col++ {
+			for row := 0; row < odsSize; row++ {
+				shr := f.square.GetCell(uint(row), uint(col))
+				if f.namespace.Equals(share.GetNamespace(shr)) {
+					expected = append(expected, shr)
+				}
+			}
+		}
+		require.Equal(t, expected, cols.Flatten())
+	})
+
+	testCases := []struct {
+		name      string
+		eh        *header.ExtendedHeader
+		namespace share.Namespace
+		wantErr   error
+	}{
+		{name: "invalid namespace", eh: f.eh, namespace: share.ParitySharesNamespace},
+		{name: "getter error", eh: failingEh, namespace: f.namespace, wantErr: errGet},
+		{name: "roots mismatch", eh: otherEh, namespace: f.namespace},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := f.m.GetSharesByNamespaceColMajor(ctx, tc.eh, tc.namespace)
+			require.Error(t, err)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNamespacedShares_BlobIndexes(t *testing.T) {
+	ns := appns.RandomBlobNamespace()
+	// the first blob spans 3 shares and the second one spans 2 shares
+	blobShares, err := appshares.SplitBlobs(
+		blob.New(ns, bytes.Repeat([]byte{1}, 1000), appshares.ShareVersionZero),
+		blob.New(ns, bytes.Repeat([]byte{2}, 600), appshares.ShareVersionZero),
+	)
+	require.NoError(t, err)
+	require.Len(t, blobShares, 5)
+	padding, err := appshares.NamespacePaddingShare(ns, appshares.ShareVersionZero)
+	require.NoError(t, err)
+
+	flattened := appshares.ToBytes(blobShares[:3])
+	flattened = append(flattened, padding.ToBytes())
+	flattened = append(flattened, appshares.ToBytes(blobShares[3:])...)
+	// the first blob continues into the second row
+	namespaced := NamespacedShares{{Shares: flattened[:2]}, {Shares: flattened[2:]}}
+
+	idxs, err := namespaced.BlobIndexes()
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 0, 0, PaddingBlobIndex, 1, 1}, idxs)
+
+	// data starting with a continuation share can't be tagged
+	_, err = NamespacedShares{{Shares: flattened[1:]}}.BlobIndexes()
+	require.Error(t, err)
+}
+
+func TestModule_GetSharesByNamespaceBlobBoundaries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ns := appns.RandomBlobNamespace()
+	// the first blob spans 3 shares and the second one spans 2 shares
+	blobShares, err := appshares.SplitBlobs(
+		blob.New(ns, bytes.Repeat([]byte{1}, 1000), appshares.ShareVersionZero),
+		blob.New(ns, bytes.Repeat([]byte{2}, 600), appshares.ShareVersionZero),
+	)
+	require.NoError(t, err)
+	padding, err := appshares.NamespacePaddingShare(ns, appshares.ShareVersionZero)
+	require.NoError(t, err)
+	flattened := appshares.ToBytes(blobShares[:3])
+	flattened = append(flattened, padding.ToBytes())
+	flattened = append(flattened, appshares.ToBytes(blobShares[3:])...)
+
+	namespace := share.Namespace(ns.Bytes())
+	eh := headertest.RandExtendedHeader(t)
+	// the first blob continues into the second row
+	nd := shwap.NamespaceData{{Shares: flattened[:2]}, {Shares: flattened[2:]}}
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), eh, namespace).Return(nd, nil)
+	m := module{Getter: getter}
+
+	shares, err := m.GetSharesByNamespace(ctx, eh, namespace)
+	require.NoError(t, err)
+	require.Len(t, shares, 2)
+	require.Equal(t, []BlobBoundary{{Start: 0, SequenceLen: 1000}}, shares[0].Blobs)
+	// the continuation of the first blob and the padding start no blob
+	require.Equal(t, []BlobBoundary{{Start: 2, SequenceLen: 600}}, shares[1].Blobs)
+}
+
+func TestModule_GetRangeBestEffortProof(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const odsSize = 4
+	// random shares of different namespaces, so no single namespace proof can be built over them
+	eds := edstest.RandEDS(t, odsSize)
+	roots, err := share.NewAxisRoots(eds)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	ctrl := gomock.NewController(t)
+	hs := headerMock.NewMockModule(ctrl)
+	hs.EXPECT().GetByHeight(gomock.Any(), uint64(1)).Return(eh, nil).AnyTimes()
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetEDS(gomock.Any(), eh).Return(eds, nil).AnyTimes()
+	m := module{Getter: getter, hs: hs}
+
+	_, err = m.GetRange(ctx, 1, 0, odsSize)
+	require.Error(t, err)
+
+	bestEffort := GetRangeOptions{BestEffortProof: true}
+	res, err := m.GetRangeWithOptions(ctx, 1, 0, odsSize, bestEffort)
+	require.NoError(t, err)
+	require.True(t, res.ProofUnavailable)
+	require.Nil(t, res.Proof)
+	require.Equal(t, eds.FlattenedODS()[:odsSize], res.Shares)
+
+	// out of bounds ranges still fail
+	res, err = m.GetRangeWithOptions(ctx, 1, 0, odsSize*odsSize+1, bestEffort)
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestModule_GetEDSHash(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const odsSize = 4
+	square := edstest.RandEDS(t, odsSize)
+	roots, err := share.NewAxisRoots(square)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+	// an independently built copy of the same square
+	sameSquare, err := rsmt2d.ImportExtendedDataSquare(
+		square.Flattened(), share.DefaultRSMT2DCodec(), wrapper.NewConstructor(odsSize),
+	)
+	require.NoError(t, err)
+	otherSquare := edstest.RandEDS(t, odsSize)
+
+	ctrl := gomock.NewController(t)
+	getter := mock.NewMockGetter(ctrl)
+	m := module{Getter: getter}
+
+	getter.EXPECT().GetEDS(gomock.Any(), eh).Return(square, nil)
+	hash, err := m.GetEDSHash(ctx, eh)
+	require.NoError(t, err)
+
+	getter.EXPECT().GetEDS(gomock.Any(), eh).Return(sameSquare, nil)
+	sameHash, err := m.GetEDSHash(ctx, eh)
+	require.NoError(t, err)
+	require.Equal(t, hash, sameHash)
+
+	getter.EXPECT().GetEDS(gomock.Any(), eh).Return(otherSquare, nil)
+	otherHash, err := m.GetEDSHash(ctx, eh)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherHash)
+
+	errGet := errors.New("get eds failed")
+	getter.EXPECT().GetEDS(gomock.Any(), eh).Return(nil, errGet)
+	_, err = m.GetEDSHash(ctx, eh)
+	require.ErrorIs(t, err, errGet)
+}
+
+func TestModule_GetShares(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const odsSize = 4
+	f := newModuleFixture(t, odsSize, odsSize)
+	errGet := errors.New("get shares failed")
+
+	testCases := []struct {
+		name    string
+		coords  []Coordinate
+		calls   int
+		fetched int
+		wantErr bool
+		errIs   error
+	}{
+		{
+			// duplicated coordinates are fetched once, in a single batch
+			name:    "duplicated coordinates",
+			coords:  []Coordinate{{Row: 0, Col: 1}, {Row: 7, Col: 7}, {Row: 3, Col: 5}, {Row: 0, Col: 1}},
+			calls:   1,
+			fetched: 3,
+		},
+		{
+			name:    "out of bounds",
+			coords:  []Coordinate{{Row: 0, Col: 1}, {Row: 0, Col: 2 * odsSize}},
+			wantErr: true,
+		},
+		{
+			name:    "getter error",
+			coords:  []Coordinate{{Row: 1, Col: 1}, {Row: 2, Col: 2}},
+			calls:   1,
+			fetched: 2,
+			wantErr: true,
+			errIs:   errGet,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			getter := mock.NewMockGetter(gomock.NewController(t))
+			getter.EXPECT().GetShares(gomock.Any(), f.eh, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ *header.ExtendedHeader, rowIdxs, colIdxs []int) ([]share.Share, error) {
+					require.Len(t, rowIdxs, tc.fetched)
+					require.Len(t, colIdxs, tc.fetched)
+					if tc.errIs != nil {
+						return nil, tc.errIs
+					}
+					shares := make([]share.Share, len(rowIdxs))
+					for i := range rowIdxs {
+						shares[i] = f.square.GetCell(uint(rowIdxs[i]), uint(colIdxs[i]))
+					}
+					return shares, nil
+				}).Times(tc.calls)
+			m := module{Getter: getter}
+
+			shares, err := m.GetShares(ctx, f.eh, tc.coords)
+			if tc.wantErr {
+				require.Error(t, err)
+				if tc.errIs != nil {
+					require.ErrorIs(t, err, tc.errIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, shares, len(tc.coords))
+			for i, coord := range tc.coords {
+				require.Equal(t, f.square.GetCell(uint(coord.Row), uint(coord.Col)), shares[i])
+			}
+		})
+	}
+}
+
+func TestModule_GetSharesByNamespaces(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const amount = 10
+	// namespaces outside of every row are not requested, as the getter only serves the fixture one
+	f := newModuleFixture(t, 8, amount)
+	// the smallest data namespace, which is below every row of the square
+	outside, err := share.NewBlobNamespaceV0([]byte{1, 0})
+	require.NoError(t, err)
+
+	sharesByNamespace, err := f.m.GetSharesByNamespaces(ctx, f.eh, []share.Namespace{f.namespace, outside})
+	require.NoError(t, err)
+	require.Len(t, sharesByNamespace, 2)
+	require.Len(t, sharesByNamespace[f.namespace.String()].Flatten(), amount)
+	require.Empty(t, sharesByNamespace[outside.String()])
+
+	testCases := []struct {
+		name       string
+		namespaces []share.Namespace
+	}{
+		{name: "too many namespaces", namespaces: make([]share.Namespace, maxNamespacesPerQuery+1)},
+		{name: "invalid namespace", namespaces: []share.Namespace{share.ParitySharesNamespace}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := f.m.GetSharesByNamespaces(ctx, f.eh, tc.namespaces)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestModule_GetSharesByNamespaceRange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const (
+		odsSize = 8
+		amount  = 10
+		from    = 5
+		to      = 7
+	)
+	namespace := sharetest.RandV0Namespace()
+
+	ctrl := gomock.NewController(t)
+	hs := headerMock.NewMockModule(ctrl)
+	getter := mock.NewMockGetter(ctrl)
+	m := module{Getter: getter, hs: hs}
+
+	for height := uint64(from); height <= to; height++ {
+		square, roots := edstest.RandEDSWithNamespace(t, namespace, amount, odsSize)
+		eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+		nd, err := eds.NamespaceData(ctx, &eds.Rsmt2D{ExtendedDataSquare: square}, namespace)
+		require.NoError(t, err)
+		hs.EXPECT().GetByHeight(gomock.Any(), height).Return(eh, nil)
+		getter.EXPECT().GetSharesByNamespace(gomock.Any(), eh, namespace).Return(nd, nil)
+	}
+
+	sharesByHeight, err := m.GetSharesByNamespaceRange(ctx, namespace, from, to)
+	require.NoError(t, err)
+	require.Len(t, sharesByHeight, to-from+1)
+	for height := uint64(from); height <= to; height++ {
+		require.Len(t, sharesByHeight[height].Flatten(), amount)
+	}
+
+	_, err = m.GetSharesByNamespaceRange(ctx, namespace, to, from)
+	require.Error(t, err)
+	_, err = m.GetSharesByNamespaceRange(ctx, namespace, 1, maxNamespaceRangeHeights+1)
+	require.Error(t, err)
+
+	errGet := errors.New("get header failed")
+	hs.EXPECT().GetByHeight(gomock.Any(), uint64(1)).Return(nil, errGet)
+	_, err = m.GetSharesByNamespaceRange(ctx, namespace, 1, 1)
+	require.ErrorIs(t, err, errGet)
+}
+
+func TestModule_GetRangeByCoords(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const (
+		odsSize = 8
+		amount  = 20
+	)
+	// proven ranges must be within a single namespace
+	f := newModuleFixture(t, odsSize, amount)
+	ods := f.square.FlattenedODS()
+	start := f.namespaceStart()
+	last := start + amount - 1
+
+	res, err := f.m.GetRangeByCoords(ctx, 1, start/odsSize, start%odsSize, last/odsSize, last%odsSize)
+	require.NoError(t, err)
+	require.Equal(t, ods[start:last+1], res.Shares)
+	require.NoError(t, res.Proof.Validate(f.eh.DataHash))
+	require.Equal(t, 2*odsSize, res.EDSWidth)
+	require.Equal(t, start, res.StartIndex)
+	require.Equal(t, start/odsSize, res.FromRow)
+	require.Equal(t, start%odsSize, res.FromCol)
+	require.Equal(t, last/odsSize, res.ToRow)
+	require.Equal(t, last%odsSize, res.ToCol)
+	require.EqualValues(t, res.FromRow, res.Proof.RowProof.StartRow)
+	require.EqualValues(t, res.ToRow, res.Proof.RowProof.EndRow)
+
+	// a single share
+	res, err = f.m.GetRangeByCoords(ctx, 1, 2, 2, 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, []share.Share{f.square.GetCell(2, 2)}, res.Shares)
+
+	// coordinates must be within the original square and ordered
+	testCases := []struct {
+		name                           string
+		fromRow, fromCol, toRow, toCol int
+	}{
+		{name: "out of the original square", fromRow: 0, fromCol: 0, toRow: 0, toCol: odsSize},
+		{name: "negative", fromRow: -1, fromCol: 0, toRow: 0, toCol: 1},
+		{name: "unordered", fromRow: 3, fromCol: 1, toRow: 2, toCol: 7},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := f.m.GetRangeByCoords(ctx, 1, tc.fromRow, tc.fromCol, tc.toRow, tc.toCol)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestModule_GetRangeByNamespace(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const amount = 20
+	f := newModuleFixture(t, 8, amount)
+
+	res, err := f.m.GetRangeByNamespace(ctx, 1, f.namespace, 3, 17)
+	require.NoError(t, err)
+	require.Len(t, res.Shares, 14)
+	for _, shr := range res.Shares {
+		require.True(t, f.namespace.Equals(share.GetNamespace(shr)))
+	}
+	require.NoError(t, res.Proof.Validate(f.eh.DataHash))
+
+	absent, err := f.namespace.AddInt(1)
+	require.NoError(t, err)
+	testCases := []struct {
+		name       string
+		namespace  share.Namespace
+		start, end int
+	}{
+		// the range can't exceed the namespace
+		{name: "past the namespace", namespace: f.namespace, start: 10, end: amount + 1},
+		{name: "empty range", namespace: f.namespace, start: 5, end: 5},
+		{name: "absent namespace", namespace: absent, start: 0, end: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := f.m.GetRangeByNamespace(ctx, 1, tc.namespace, tc.start, tc.end)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestModule_ProofEncoding(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const amount = 20
+	f := newModuleFixture(t, 8, amount)
+	// proven ranges must be within a single namespace
+	start := f.namespaceStart()
+	end := start + amount
+
+	res, err := f.m.GetRange(ctx, 1, start, end)
+	require.NoError(t, err)
+	require.NotNil(t, res.Proof)
+	require.False(t, res.ProofUnavailable)
+
+	noProof := GetRangeOptions{ProofEncoding: ProofEncodingNone}
+	res, err = f.m.GetRangeWithOptions(ctx, 1, start, end, noProof)
+	require.NoError(t, err)
+	require.Equal(t, f.square.FlattenedODS()[start:end], res.Shares)
+	require.Nil(t, res.Proof)
+	require.False(t, res.ProofUnavailable)
+	_, err = f.m.GetRangeWithOptions(ctx, 1, end, start, noProof)
+	require.Error(t, err)
+}
+
+func TestModule_GetSharesByNamespacePage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const (
+		odsSize = 8
+		maxRows = 2
+	)
+	f := newModuleFixture(t, odsSize, 40)
+	// pages fetch only their own rows
+	f.getter.EXPECT().GetShares(gomock.Any(), f.eh, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *header.ExtendedHeader, rowIdxs, colIdxs []int) ([]share.Share, error) {
+			require.LessOrEqual(t, len(rowIdxs), maxRows*odsSize)
+			shares := make([]share.Share, len(rowIdxs))
+			for i := range rowIdxs {
+				shares[i] = f.square.GetCell(uint(rowIdxs[i]), uint(colIdxs[i]))
+			}
+			return shares, nil
+		}).AnyTimes()
+
+	all, err := f.m.GetSharesByNamespace(ctx, f.eh, f.namespace)
+	require.NoError(t, err)
+
+	var (
+		paged NamespacedShares
+		token string
+		pages int
+	)
+	for {
+		page, err := f.m.GetSharesByNamespacePage(ctx, f.eh, f.namespace, token, maxRows)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page.Shares), maxRows)
+		paged = append(paged, page.Shares...)
+		pages++
+		if page.NextPageToken == "" {
+			break
+		}
+		token = page.NextPageToken
+	}
+	require.Equal(t, all, paged)
+	require.Equal(t, (len(all)+maxRows-1)/maxRows, pages)
+
+	testCases := []struct {
+		name    string
+		token   string
+		maxRows int
+	}{
+		{name: "no rows", token: "", maxRows: 0},
+		{name: "invalid token", token: "invalid", maxRows: maxRows},
+		{name: "token past the square", token: "100", maxRows: maxRows},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := f.m.GetSharesByNamespacePage(ctx, f.eh, f.namespace, tc.token, tc.maxRows)
+			require.Error(t, err)
+		})
+	}
+
+	// shares failing the proof are rejected
+	f.square, _ = edstest.RandEDSWithNamespace(t, f.namespace, 40, odsSize)
+	_, err = f.m.GetSharesByNamespacePage(ctx, f.eh, f.namespace, "", maxRows)
+	require.Error(t, err)
+}
+
+func TestModule_GetODS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const odsSize = 4
+	f := newModuleFixture(t, odsSize, odsSize)
+
+	ods, err := f.m.GetODS(ctx, f.eh)
+	require.NoError(t, err)
+	require.Len(t, ods, odsSize)
+	var flattened []share.Share
+	for _, row := range ods {
+		require.Len(t, row, odsSize)
+		flattened = append(flattened, row...)
+	}
+	require.Equal(t, f.square.FlattenedODS(), flattened)
+
+	// the EDS can be recomputed from the ODS
+	recomputed, err := rsmt2d.ComputeExtendedDataSquare(
+		flattened, share.DefaultRSMT2DCodec(), wrapper.NewConstructor(odsSize),
+	)
+	require.NoError(t, err)
+	recomputedRoots, err := share.NewAxisRoots(recomputed)
+	require.NoError(t, err)
+	require.True(t, f.roots.Equals(recomputedRoots))
+}
+
+func TestModule_AvailabilityStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	eh := headertest.RandExtendedHeaderWithRoot(t, edstest.RandomAxisRoots(t, 4))
+	want := share.AvailabilityStatus{Validated: true, Available: true, Samples: 16}
+
+	ctrl := gomock.NewController(t)
+	hs := headerMock.NewMockModule(ctrl)
+	hs.EXPECT().GetByHeight(gomock.Any(), eh.Height()).Return(eh, nil)
+	avail := availMock.NewMockAvailability(ctrl)
+	avail.EXPECT().Status(gomock.Any(), eh).Return(want, nil)
+	m := module{Availability: avail, hs: hs}
+
+	status, err := m.AvailabilityStatus(ctx, eh.Height())
+	require.NoError(t, err)
+	require.Equal(t, want, status)
+}
+
+func TestModule_InvalidateAvailability(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// light availability doesn't persist verdicts
+	m := module{Availability: availMock.NewMockAvailability(gomock.NewController(t))}
+	err := m.InvalidateAvailability(ctx, 1)
+	require.ErrorIs(t, err, shwap.ErrOperationNotSupported)
+}
+
+func TestModule_HasNamespaceData(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	namespace := sharetest.RandV0Namespace()
+	_, roots := edstest.RandEDSWithNamespace(t, namespace, 4, 8)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+	// the getter must not be reached
+	m := module{Getter: mock.NewMockGetter(gomock.NewController(t))}
+
+	has, err := m.HasNamespaceData(ctx, eh, namespace)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	// random namespaces sort after the reserved ones, so no row can contain them
+	has, err = m.HasNamespaceData(ctx, eh, share.TxNamespace)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	_, err = m.HasNamespaceData(ctx, eh, share.ParitySharesNamespace)
+	require.Error(t, err)
+}
@@ -0,0 +1,288 @@
+package share
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/pruner/full"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+const (
+	snapshotVersion      = 1
+	snapshotManifestName = "manifest.json"
+	snapshotHeadersDir   = "headers"
+	snapshotEDSDir       = "eds"
+)
+
+// SnapshotManifest describes a snapshot archive.
+type SnapshotManifest struct {
+	Version uint8  `json:"version"`
+	From    uint64 `json:"from"`
+	To      uint64 `json:"to"`
+}
+
+// HeaderGetter gets the headers of the heights written to snapshots.
+type HeaderGetter interface {
+	GetByHeight(context.Context, uint64) (*header.ExtendedHeader, error)
+}
+
+// VerifyHeaderFn verifies a header read from a snapshot and returns the trusted header at its
+// height, which the EDS of the height is verified against.
+type VerifyHeaderFn func(context.Context, *header.ExtendedHeader) (*header.ExtendedHeader, error)
+
+// WriteSnapshot writes a snapshot of the headers and EDSes of the heights in [from, to] to w.
+//
+// The snapshot is a tar archive starting with the manifest, followed by the header and the ODS of
+// every height, in ascending order of the heights. Headers are stored in their binary encoding and
+// ODSes as their shares in row-major order, the way ExportEDS writes them.
+func WriteSnapshot(
+	ctx context.Context,
+	w io.Writer,
+	headers HeaderGetter,
+	getter shwap.Getter,
+	from, to uint64,
+) error {
+	if from == 0 || from > to {
+		return fmt.Errorf("invalid height range [%d, %d]", from, to)
+	}
+
+	tw := tar.NewWriter(w)
+	manifest, err := json.Marshal(SnapshotManifest{Version: snapshotVersion, From: from, To: to})
+	if err != nil {
+		return err
+	}
+	if err := writeSnapshotEntry(tw, snapshotManifestName, manifest); err != nil {
+		return err
+	}
+
+	for height := from; height <= to; height++ {
+		extendedHeader, err := headers.GetByHeight(ctx, height)
+		if err != nil {
+			return fmt.Errorf("getting header at height %d: %w", height, err)
+		}
+		data, err := extendedHeader.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("marshaling header at height %d: %w", height, err)
+		}
+		if err := writeSnapshotEntry(tw, snapshotEntryName(snapshotHeadersDir, height), data); err != nil {
+			return err
+		}
+
+		extendedDataSquare, err := getter.GetEDS(ctx, extendedHeader)
+		if err != nil {
+			return fmt.Errorf("getting EDS at height %d: %w", height, err)
+		}
+		data = bytes.Join(extendedDataSquare.FlattenedODS(), nil)
+		if err := writeSnapshotEntry(tw, snapshotEntryName(snapshotEDSDir, height), data); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot from r. Every header is passed to verify,
+// and the EDS of the height is verified against the trusted header it returns before it is put into
+// the store.
+func ReadSnapshot(
+	ctx context.Context,
+	r io.Reader,
+	edsStore *store.Store,
+	verify VerifyHeaderFn,
+) (*SnapshotManifest, error) {
+	tr := tar.NewReader(r)
+	data, err := readSnapshotEntry(tr, snapshotManifestName)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &SnapshotManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("unmarshaling manifest: %w", err)
+	}
+	if manifest.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", manifest.Version)
+	}
+
+	for height := manifest.From; height <= manifest.To; height++ {
+		data, err := readSnapshotEntry(tr, snapshotEntryName(snapshotHeadersDir, height))
+		if err != nil {
+			return nil, err
+		}
+		extendedHeader := &header.ExtendedHeader{}
+		if err := extendedHeader.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("unmarshaling header at height %d: %w", height, err)
+		}
+		if extendedHeader.Height() != height {
+			return nil, fmt.Errorf("header at height %d has height %d", height, extendedHeader.Height())
+		}
+		trusted, err := verify(ctx, extendedHeader)
+		if err != nil {
+			return nil, fmt.Errorf("verifying header at height %d: %w", height, err)
+		}
+
+		if _, err := nextSnapshotEntry(tr, snapshotEntryName(snapshotEDSDir, height)); err != nil {
+			return nil, err
+		}
+		accessor, err := eds.ReadAccessor(ctx, tr, trusted.DAH)
+		if err != nil {
+			return nil, fmt.Errorf("reading EDS at height %d: %w", height, err)
+		}
+		if err := putEDS(ctx, edsStore, height, trusted, accessor.ExtendedDataSquare); err != nil {
+			return nil, fmt.Errorf("storing EDS at height %d: %w", height, err)
+		}
+	}
+	return manifest, nil
+}
+
+// ExportSnapshot writes a snapshot of the heights in [from, to] to the file at the given path within
+// the export directory.
+func (m module) ExportSnapshot(ctx context.Context, from, to uint64, path string) error {
+	filePath, err := m.exportPath(path)
+	if err != nil {
+		return err
+	}
+	head, err := m.hs.LocalHead(ctx)
+	if err != nil {
+		return err
+	}
+	if to > head.Height() {
+		return fmt.Errorf("height %d is above the local head %d", to, head.Height())
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), exportDirPerm); err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+	// ensure existing files are never overwritten
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, exportFilePerm)
+	if err != nil {
+		return fmt.Errorf("creating snapshot file: %w", err)
+	}
+
+	w := bufio.NewWriter(f)
+	err = WriteSnapshot(ctx, w, m.hs, m.Getter, from, to)
+	if err == nil {
+		err = w.Flush()
+	}
+	if errClose := f.Close(); errClose != nil {
+		err = errors.Join(err, errClose)
+	}
+	if err != nil {
+		// don't leave partially written files behind
+		return errors.Join(fmt.Errorf("writing snapshot file: %w", err), os.Remove(filePath))
+	}
+	return nil
+}
+
+// ImportSnapshot reads a snapshot from the file at the given path within the export directory and
+// puts its EDSes into the store. The headers of the snapshot must match the ones the node already
+// synced, which the EDSes are verified against.
+func (m module) ImportSnapshot(ctx context.Context, path string) (*SnapshotManifest, error) {
+	if m.store == nil {
+		return nil, errors.New("importing snapshots requires an EDS store, which light nodes do not keep")
+	}
+	filePath, err := m.exportPath(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("opening snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	head, err := m.hs.LocalHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	verify := func(ctx context.Context, eh *header.ExtendedHeader) (*header.ExtendedHeader, error) {
+		// the header service waits for heights above the local head, so they are rejected first
+		if eh.Height() > head.Height() {
+			return nil, fmt.Errorf("height %d is above the local head %d", eh.Height(), head.Height())
+		}
+		trusted, err := m.hs.GetByHeight(ctx, eh.Height())
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(trusted.Hash(), eh.Hash()) {
+			return nil, fmt.Errorf("hash %s does not match the synced header %s", eh.Hash(), trusted.Hash())
+		}
+		return trusted, nil
+	}
+	return ReadSnapshot(ctx, bufio.NewReader(f), m.store, verify)
+}
+
+// putEDS puts the EDS of the header into the store at the height. Archival nodes do not store Q4
+// outside the availability window.
+func putEDS(
+	ctx context.Context,
+	edsStore *store.Store,
+	height uint64,
+	extendedHeader *header.ExtendedHeader,
+	extendedDataSquare *rsmt2d.ExtendedDataSquare,
+) error {
+	if pruner.IsWithinAvailabilityWindow(extendedHeader.Time(), full.Window) {
+		return edsStore.PutODSQ4(ctx, extendedHeader.DAH, height, extendedDataSquare)
+	}
+	return edsStore.PutODS(ctx, extendedHeader.DAH, height, extendedDataSquare)
+}
+
+func snapshotEntryName(dir string, height uint64) string {
+	return dir + "/" + strconv.FormatUint(height, 10)
+}
+
+func writeSnapshotEntry(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:     name,
+		Mode:     exportFilePerm,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// nextSnapshotEntry advances to the next entry of the archive, which must have the given name.
+func nextSnapshotEntry(tr *tar.Reader, name string) (*tar.Header, error) {
+	hdr, err := tr.Next()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("snapshot ends before %s", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	if hdr.Name != name {
+		return nil, fmt.Errorf("unexpected snapshot entry %s, expected %s", hdr.Name, name)
+	}
+	return hdr, nil
+}
+
+func readSnapshotEntry(tr *tar.Reader, name string) ([]byte, error) {
+	if _, err := nextSnapshotEntry(tr, name); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(tr)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,73 @@
+package share
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+func TestModule_Snapshot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	squares := []*rsmt2d.ExtendedDataSquare{edstest.RandEDS(t, 4), share.EmptyEDS(), edstest.RandEDS(t, 8)}
+	headers := headertest.ExtendedHeadersFromEdsses(t, squares)
+
+	ctrl := gomock.NewController(t)
+	newHeaderModule := func(headers []*header.ExtendedHeader) *headerMock.MockModule {
+		hs := headerMock.NewMockModule(ctrl)
+		hs.EXPECT().LocalHead(gomock.Any()).Return(headers[len(headers)-1], nil).AnyTimes()
+		for _, eh := range headers {
+			hs.EXPECT().GetByHeight(gomock.Any(), eh.Height()).Return(eh, nil).AnyTimes()
+		}
+		return hs
+	}
+	getter := mock.NewMockGetter(ctrl)
+	for i, eh := range headers {
+		getter.EXPECT().GetEDS(gomock.Any(), eh).Return(squares[i], nil).AnyTimes()
+	}
+	exportDir := t.TempDir()
+	m := module{Getter: getter, hs: newHeaderModule(headers), exportDir: exportDir}
+
+	require.NoError(t, m.ExportSnapshot(ctx, 1, 3, "snapshot.tar"))
+	// heights above the local head can't be exported
+	require.Error(t, m.ExportSnapshot(ctx, 1, 4, "above.tar"))
+
+	edsStore, err := store.NewStore(store.DefaultParameters(), t.TempDir())
+	require.NoError(t, err)
+	m = module{hs: newHeaderModule(headers), store: edsStore, exportDir: exportDir}
+	manifest, err := m.ImportSnapshot(ctx, "snapshot.tar")
+	require.NoError(t, err)
+	require.Equal(t, SnapshotManifest{Version: snapshotVersion, From: 1, To: 3}, *manifest)
+	for _, eh := range headers {
+		f, err := edsStore.GetByHeight(ctx, eh.Height())
+		require.NoError(t, err)
+		datahash, err := f.DataHash(ctx)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		require.EqualValues(t, eh.DAH.Hash(), datahash)
+	}
+
+	// snapshots of other chains fail the verification against the synced headers
+	otherHeaders := headertest.ExtendedHeadersFromEdsses(t, squares)
+	m.hs = newHeaderModule(otherHeaders)
+	_, err = m.ImportSnapshot(ctx, "snapshot.tar")
+	require.Error(t, err)
+
+	// light nodes keep no store
+	m.store = nil
+	_, err = m.ImportSnapshot(ctx, "snapshot.tar")
+	require.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package share
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+// NamespacedRowResponse is the response type for the StreamSharesByNamespace method.
+type NamespacedRowResponse struct {
+	Row      NamespacedRow
+	RowIndex int
+}
+
+func (m module) StreamSharesByNamespace(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (<-chan *NamespacedRowResponse, error) {
+	if err := namespace.ValidateForData(); err != nil {
+		return nil, err
+	}
+
+	rowIdxs := share.RowsWithNamespace(header.DAH, namespace)
+	respCh := make(chan *NamespacedRowResponse, subscriptionBufferSize)
+	go func() {
+		defer close(respCh)
+
+		for _, rowIdx := range rowIdxs {
+			row, err := m.getNamespacedRow(ctx, header, namespace, rowIdx)
+			if err != nil {
+				log.Errorw("closing namespace stream due to failed row retrieval",
+					"namespace", namespace.String(), "height", header.Height(), "row", rowIdx, "err", err)
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				log.Debugw("canceling namespace stream due to user ctx closing",
+					"namespace", namespace.String(), "height", header.Height())
+				return
+			case respCh <- &NamespacedRowResponse{Row: row, RowIndex: rowIdx}:
+			}
+		}
+	}()
+	return respCh, nil
+}
+
+// getNamespacedRow fetches and verifies the row with the given index and extracts the shares of
+// the namespace from it, proving them against the DAH row root.
+func (m module) getNamespacedRow(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+	rowIdx int,
+) (NamespacedRow, error) {
+	shares, _, err := m.getAxis(ctx, header, rsmt2d.Row, rowIdx)
+	if err != nil {
+		return NamespacedRow{}, err
+	}
+	rnd, err := shwap.RowNamespaceDataFromShares(shares, namespace, rowIdx)
+	if err != nil {
+		return NamespacedRow{}, fmt.Errorf("extracting namespace data of row %d: %w", rowIdx, err)
+	}
+	return NamespacedRow{Shares: rnd.Shares, Proof: rnd.Proof, Blobs: blobBoundaries(rnd.Shares)}, nil
+}
@@ -0,0 +1,58 @@
+package share
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestModule_StreamSharesByNamespace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	const (
+		odsSize = 8
+		amount  = 20
+	)
+	namespace := sharetest.RandV0Namespace()
+	square, roots := edstest.RandEDSWithNamespace(t, namespace, amount, odsSize)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+	nd, err := eds.NamespaceData(ctx, &eds.Rsmt2D{ExtendedDataSquare: square}, namespace)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetShare(gomock.Any(), eh, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *header.ExtendedHeader, row, col int) (share.Share, error) {
+			return square.GetCell(uint(row), uint(col)), nil
+		}).AnyTimes()
+	m := module{Getter: getter}
+
+	respCh, err := m.StreamSharesByNamespace(ctx, eh, namespace)
+	require.NoError(t, err)
+
+	rowIdxs := share.RowsWithNamespace(roots, namespace)
+	var received []int
+	for resp := range respCh {
+		require.Equal(t, nd[len(received)].Shares, resp.Row.Shares)
+		rnd := shwap.RowNamespaceData{Shares: resp.Row.Shares, Proof: resp.Row.Proof}
+		require.NoError(t, rnd.Verify(roots, namespace, resp.RowIndex))
+		received = append(received, resp.RowIndex)
+	}
+	require.Equal(t, rowIdxs, received)
+
+	_, err = m.StreamSharesByNamespace(ctx, eh, share.ParitySharesNamespace)
+	require.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package share
+
+import (
+	"context"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var log = logging.Logger("module/share")
+
+const (
+	// subscriptionBufferSize is the amount of responses buffered for a subscriber. The
+	// subscription is closed once the buffer overflows due to a slow reader.
+	subscriptionBufferSize = 16
+	// subscriptionRetryInterval is the interval between retries of failed requests within a
+	// subscription.
+	subscriptionRetryInterval = time.Second
+)
+
+// EDSSubscriptionResponse is the response type for the SubscribeEDS method.
+type EDSSubscriptionResponse struct {
+	EDS    *rsmt2d.ExtendedDataSquare
+	Height uint64
+}
+
+func (m module) SubscribeEDS(ctx context.Context) (<-chan *EDSSubscriptionResponse, error) {
+	return subscribe(ctx, m, "eds",
+		func(ctx context.Context, header *header.ExtendedHeader) (*EDSSubscriptionResponse, bool, error) {
+			eds, err := m.GetEDS(ctx, header)
+			if err != nil {
+				return nil, false, err
+			}
+			return &EDSSubscriptionResponse{EDS: eds, Height: header.Height()}, true, nil
+		})
+}
+
+// NamespaceSubscriptionResponse is the response type for the SubscribeSharesByNamespace method.
+type NamespaceSubscriptionResponse struct {
+	Shares NamespacedShares
+	Height uint64
+}
+
+func (m module) SubscribeSharesByNamespace(
+	ctx context.Context,
+	namespace share.Namespace,
+) (<-chan *NamespaceSubscriptionResponse, error) {
+	if err := namespace.ValidateForData(); err != nil {
+		return nil, err
+	}
+
+	// keep the namespace prefetched for as long as the subscription lives
+	release := m.prefetcher.Watch(namespace)
+	context.AfterFunc(ctx, release)
+
+	respCh, err := subscribe(ctx, m, "namespace/"+namespace.String(),
+		func(ctx context.Context, header *header.ExtendedHeader) (*NamespaceSubscriptionResponse, bool, error) {
+			// skip blocks that can't contain the namespace without fetching anything
+			if len(share.RowsWithNamespace(header.DAH, namespace)) == 0 {
+				return nil, false, nil
+			}
+			shares, err := m.GetSharesByNamespace(ctx, header, namespace)
+			if err != nil {
+				return nil, false, err
+			}
+			if len(shares.Flatten()) == 0 {
+				return nil, false, nil
+			}
+			return &NamespaceSubscriptionResponse{Shares: shares, Height: header.Height()}, true, nil
+		})
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return respCh, nil
+}
+
+// subscribe fetches a response with the given function for every new header and sends it to the
+// returned channel. Responses the function opts out from are skipped. Failed requests are retried
+// until successful, so that no height is missed. The channel is closed once the context is done,
+// the header subscription ends or the buffer overflows.
+func subscribe[T any](
+	ctx context.Context,
+	m module,
+	name string,
+	fetch func(context.Context, *header.ExtendedHeader) (T, bool, error),
+) (<-chan T, error) {
+	headerCh, err := m.hs.Subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	respCh := make(chan T, subscriptionBufferSize)
+	go func() {
+		defer close(respCh)
+
+		for {
+			var eh *header.ExtendedHeader
+			select {
+			case <-ctx.Done():
+				log.Debugw("canceling subscription due to user ctx closing", "subscription", name)
+				return
+			case h, ok := <-headerCh:
+				if !ok {
+					log.Errorw("header channel closed for subscription", "subscription", name)
+					return
+				}
+				eh = h
+			}
+			// close subscription before buffer overflows
+			if len(respCh) == cap(respCh) {
+				log.Debugw("canceling subscription due to buffer overflow from slow reader",
+					"subscription", name)
+				return
+			}
+
+			var (
+				resp T
+				ok   bool
+				err  error
+			)
+			for {
+				resp, ok, err = fetch(ctx, eh)
+				if err == nil {
+					break
+				}
+				log.Warnw("failed to fetch subscription response, retrying",
+					"subscription", name, "height", eh.Height(), "err", err)
+				select {
+				case <-ctx.Done():
+					// continuing would lead to unexpected missed heights for the client
+					log.Debugw("canceling subscription due to user ctx closing", "subscription", name)
+					return
+				case <-time.After(subscriptionRetryInterval):
+				}
+			}
+			if !ok {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				log.Debugw("pending response canceled due to user ctx closing", "subscription", name)
+				return
+			case respCh <- resp:
+			}
+		}
+	}()
+	return respCh, nil
+}
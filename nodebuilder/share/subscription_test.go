@@ -0,0 +1,117 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestModule_SubscribeEDS(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	const odsSize = 4
+	square := edstest.RandEDS(t, odsSize)
+	roots, err := share.NewAxisRoots(square)
+	require.NoError(t, err)
+	first := headertest.RandExtendedHeaderWithRoot(t, roots)
+	second := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	headerCh := make(chan *header.ExtendedHeader, 2)
+	headerCh <- first
+	headerCh <- second
+
+	ctrl := gomock.NewController(t)
+	hs := headerMock.NewMockModule(ctrl)
+	hs.EXPECT().Subscribe(gomock.Any()).Return(headerCh, nil)
+	getter := mock.NewMockGetter(ctrl)
+	// a failed retrieval is retried, so that the height is not missed
+	getter.EXPECT().GetEDS(gomock.Any(), first).Return(nil, errors.New("get eds failed"))
+	getter.EXPECT().GetEDS(gomock.Any(), first).Return(square, nil)
+	getter.EXPECT().GetEDS(gomock.Any(), second).Return(square, nil)
+	m := module{Getter: getter, hs: hs}
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	respCh, err := m.SubscribeEDS(subCtx)
+	require.NoError(t, err)
+
+	for _, eh := range []*header.ExtendedHeader{first, second} {
+		select {
+		case resp := <-respCh:
+			require.Equal(t, eh.Height(), resp.Height)
+			require.Equal(t, square, resp.EDS)
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for subscription response")
+		}
+	}
+
+	subCancel()
+	select {
+	case _, ok := <-respCh:
+		require.False(t, ok)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for subscription to close")
+	}
+}
+
+func TestModule_SubscribeSharesByNamespace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	const (
+		odsSize = 8
+		amount  = 20
+	)
+	namespace := sharetest.RandV0Namespace()
+	square, roots := edstest.RandEDSWithNamespace(t, namespace, amount, odsSize)
+	withNamespace := headertest.RandExtendedHeaderWithRoot(t, roots)
+	nd, err := eds.NamespaceData(ctx, &eds.Rsmt2D{ExtendedDataSquare: square}, namespace)
+	require.NoError(t, err)
+
+	otherSquare := edstest.RandEDS(t, odsSize)
+	otherRoots, err := share.NewAxisRoots(otherSquare)
+	require.NoError(t, err)
+	withoutNamespace := headertest.RandExtendedHeaderWithRoot(t, otherRoots)
+	absent, err := eds.NamespaceData(ctx, &eds.Rsmt2D{ExtendedDataSquare: otherSquare}, namespace)
+	require.NoError(t, err)
+
+	headerCh := make(chan *header.ExtendedHeader, 2)
+	headerCh <- withoutNamespace
+	headerCh <- withNamespace
+
+	ctrl := gomock.NewController(t)
+	hs := headerMock.NewMockModule(ctrl)
+	hs.EXPECT().Subscribe(gomock.Any()).Return(headerCh, nil)
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), withoutNamespace, namespace).Return(absent, nil).AnyTimes()
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), withNamespace, namespace).Return(nd, nil)
+	m := module{Getter: getter, hs: hs}
+
+	_, err = m.SubscribeSharesByNamespace(ctx, share.ParitySharesNamespace)
+	require.Error(t, err)
+
+	respCh, err := m.SubscribeSharesByNamespace(ctx, namespace)
+	require.NoError(t, err)
+
+	// the block without the namespace is skipped
+	select {
+	case resp := <-respCh:
+		require.Equal(t, withNamespace.Height(), resp.Height)
+		require.Len(t, resp.Shares.Flatten(), amount)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for subscription response")
+	}
+}
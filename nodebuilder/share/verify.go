@@ -0,0 +1,73 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+// ErrInvalidProof is returned by VerifyShareProof and VerifyNamespaceProof when the proof does
+// not prove the shares against the header.
+var ErrInvalidProof = errors.New("invalid proof")
+
+func (m module) VerifyShareProof(
+	_ context.Context,
+	header *header.ExtendedHeader,
+	proof *types.ShareProof,
+	shares []share.Share,
+) error {
+	if err := verifyDAH(header); err != nil {
+		return err
+	}
+	if proof == nil {
+		return fmt.Errorf("%w: nil proof", ErrInvalidProof)
+	}
+	if len(proof.Data) != len(shares) {
+		return fmt.Errorf("%w: proof is over %d shares, got %d", ErrInvalidProof, len(proof.Data), len(shares))
+	}
+	for i, shr := range shares {
+		if !bytes.Equal(proof.Data[i], shr) {
+			return fmt.Errorf("%w: share %d differs from the proven one", ErrInvalidProof, i)
+		}
+	}
+	if err := proof.Validate(header.DataHash); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	return nil
+}
+
+func (m module) VerifyNamespaceProof(
+	_ context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+	rowIdx int,
+	row NamespacedRow,
+) error {
+	if err := verifyDAH(header); err != nil {
+		return err
+	}
+	rnd := shwap.RowNamespaceData{Shares: row.Shares, Proof: row.Proof}
+	if err := rnd.Verify(header.DAH, namespace, rowIdx); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	return nil
+}
+
+// verifyDAH checks that the DAH of the header is committed to by its data hash.
+func verifyDAH(header *header.ExtendedHeader) error {
+	if header == nil || header.DAH == nil {
+		return errors.New("nil header")
+	}
+	if !bytes.Equal(header.DAH.Hash(), header.DataHash) {
+		return fmt.Errorf("%w: DAH does not match the data hash of header at height %d",
+			ErrInvalidProof, header.Height())
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package share
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+)
+
+func TestModule_VerifyProofs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	const (
+		odsSize = 8
+		amount  = 20
+	)
+	namespace := sharetest.RandV0Namespace()
+	square, roots := edstest.RandEDSWithNamespace(t, namespace, amount, odsSize)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+	// header of the same height committing to a different square
+	_, otherRoots := edstest.RandEDSWithNamespace(t, namespace, amount, odsSize)
+	otherEh := headertest.RandExtendedHeaderWithRoot(t, otherRoots)
+	m := module{}
+
+	t.Run("share proof", func(t *testing.T) {
+		rowIdx := share.RowsWithNamespace(roots, namespace)[0]
+		start := rowIdx * odsSize
+		for !namespace.Equals(share.GetNamespace(square.FlattenedODS()[start])) {
+			start++
+		}
+		res, err := proveRange(square, start, start+2, ProofEncodingFull)
+		require.NoError(t, err)
+
+		require.NoError(t, m.VerifyShareProof(ctx, eh, res.Proof, res.Shares))
+		require.ErrorIs(t, m.VerifyShareProof(ctx, otherEh, res.Proof, res.Shares), ErrInvalidProof)
+		require.ErrorIs(t, m.VerifyShareProof(ctx, eh, res.Proof, res.Shares[:1]), ErrInvalidProof)
+		require.ErrorIs(t, m.VerifyShareProof(ctx, eh, res.Proof, []share.Share{res.Shares[1], res.Shares[0]}),
+			ErrInvalidProof)
+		require.ErrorIs(t, m.VerifyShareProof(ctx, eh, nil, res.Shares), ErrInvalidProof)
+	})
+
+	t.Run("namespace proof", func(t *testing.T) {
+		nd, err := eds.NamespaceData(ctx, &eds.Rsmt2D{ExtendedDataSquare: square}, namespace)
+		require.NoError(t, err)
+		rowIdx := share.RowsWithNamespace(roots, namespace)[0]
+		row := NamespacedRow{Shares: nd[0].Shares, Proof: nd[0].Proof}
+
+		require.NoError(t, m.VerifyNamespaceProof(ctx, eh, namespace, rowIdx, row))
+		require.ErrorIs(t, m.VerifyNamespaceProof(ctx, otherEh, namespace, rowIdx, row), ErrInvalidProof)
+		require.ErrorIs(t, m.VerifyNamespaceProof(ctx, eh, namespace, rowIdx+1, row), ErrInvalidProof)
+
+		tampered := *eh
+		tampered.DataHash = otherEh.DataHash
+		require.ErrorIs(t, m.VerifyNamespaceProof(ctx, &tampered, namespace, rowIdx, row), ErrInvalidProof)
+	})
+}
@@ -0,0 +1,183 @@
+package nodebuilder
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gofrs/flock"
+	"github.com/ipfs/go-datastore"
+	dsbadger "github.com/ipfs/go-ds-badger4"
+
+	libhead "github.com/celestiaorg/go-header"
+	headerstore "github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	modshare "github.com/celestiaorg/celestia-node/nodebuilder/share"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+// ExportSnapshot writes a snapshot of the headers and EDSes of the heights in [from, to] kept by
+// the stopped node under the given path to w.
+func ExportSnapshot(ctx context.Context, path string, tp node.Type, from, to uint64, w io.Writer) error {
+	return withStoppedStore(path, tp, func(cfg *Config, edsStore *store.Store, ds datastore.Batching) error {
+		hstore, err := headerstore.NewStore[*header.ExtendedHeader](ds, headerstore.WithParams(cfg.Header.Store))
+		if err != nil {
+			return fmt.Errorf("opening header store: %w", err)
+		}
+		head, err := hstore.Head(ctx)
+		if err != nil {
+			return fmt.Errorf("reading head: %w", err)
+		}
+		// the header store waits for heights above the head, so they are rejected first
+		if to > head.Height() {
+			return fmt.Errorf("height %d is above the head %d", to, head.Height())
+		}
+		return modshare.WriteSnapshot(ctx, w, hstore, store.NewGetter(edsStore), from, to)
+	})
+}
+
+// ImportSnapshot reads a snapshot from r into the stopped node under the given path, bootstrapping
+// its header and EDS stores.
+//
+// Headers of the snapshot already kept by the node must match the kept ones, and the following ones
+// must extend them. A node without headers is initialized with the first header of the snapshot,
+// which must have the given trusted hash, or the trusted hash of the node config, if it is empty.
+// The following headers are verified against their predecessors and every EDS against its header.
+func ImportSnapshot(
+	ctx context.Context,
+	path string,
+	tp node.Type,
+	trustedHash string,
+	r io.Reader,
+) (manifest *modshare.SnapshotManifest, err error) {
+	err = withStoppedStore(path, tp, func(cfg *Config, edsStore *store.Store, ds datastore.Batching) (err error) {
+		hstore, verify, err := snapshotVerifier(ctx, cfg, ds, trustedHash)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// flushes the appended headers
+			err = errors.Join(err, hstore.Stop(ctx))
+		}()
+		manifest, err = modshare.ReadSnapshot(ctx, r, edsStore, verify)
+		return err
+	})
+	return manifest, err
+}
+
+// snapshotVerifier starts the header store of the stopped node and returns the func verifying the
+// headers of the imported snapshots against it. Headers already kept by the node must match the
+// kept ones, and the following ones are appended to the store. A node without headers is
+// initialized with the first header, which must have the given trusted hash, or the trusted hash of
+// the node config, if it is empty. The header store must be stopped after the import.
+func snapshotVerifier(
+	ctx context.Context,
+	cfg *Config,
+	ds datastore.Batching,
+	trustedHash string,
+) (*headerstore.Store[*header.ExtendedHeader], modshare.VerifyHeaderFn, error) {
+	if trustedHash == "" {
+		trustedHash = cfg.Header.TrustedHash
+	}
+	trusted, err := hex.DecodeString(trustedHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding trusted hash: %w", err)
+	}
+
+	hstore, err := headerstore.NewStore[*header.ExtendedHeader](ds, headerstore.WithParams(cfg.Header.Store))
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening header store: %w", err)
+	}
+	if err := hstore.Start(ctx); err != nil {
+		return nil, nil, fmt.Errorf("starting header store: %w", err)
+	}
+
+	head, err := hstore.Head(ctx)
+	if err != nil && !errors.Is(err, libhead.ErrNoHead) {
+		return nil, nil, errors.Join(fmt.Errorf("reading head: %w", err), hstore.Stop(ctx))
+	}
+	verify := func(ctx context.Context, eh *header.ExtendedHeader) (*header.ExtendedHeader, error) {
+		switch {
+		case head == nil:
+			if len(trusted) == 0 {
+				return nil, errors.New("trusted hash is required to initialize the header store")
+			}
+			if !bytes.Equal(eh.Hash(), trusted) {
+				return nil, fmt.Errorf("hash %s does not match the trusted hash %X", eh.Hash(), trusted)
+			}
+			if err := hstore.Init(ctx, eh); err != nil {
+				return nil, fmt.Errorf("initializing header store: %w", err)
+			}
+		case eh.Height() <= head.Height():
+			stored, err := hstore.GetByHeight(ctx, eh.Height())
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(stored.Hash(), eh.Hash()) {
+				return nil, fmt.Errorf("hash %s does not match the stored header %s", eh.Hash(), stored.Hash())
+			}
+			return stored, nil
+		default:
+			// the store verifies the header against the head
+			if err := hstore.Append(ctx, eh); err != nil {
+				return nil, err
+			}
+		}
+		head = eh
+		return eh, nil
+	}
+	return hstore, verify, nil
+}
+
+// withStoppedStore locks the stopped node under the given path and opens its EDS store and
+// datastore for the duration of fn.
+func withStoppedStore(
+	path string,
+	tp node.Type,
+	fn func(*Config, *store.Store, datastore.Batching) error,
+) (err error) {
+	if tp == node.Light {
+		return errors.New("light nodes keep no EDS store")
+	}
+
+	path, err = storePath(path)
+	if err != nil {
+		return err
+	}
+
+	flk := flock.New(lockPath(path))
+	ok, err := flk.TryLock()
+	if err != nil {
+		return fmt.Errorf("locking file: %w", err)
+	}
+	if !ok {
+		return ErrOpened
+	}
+	defer flk.Unlock() //nolint:errcheck
+
+	cfg, err := LoadConfig(configPath(path))
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	edsStore, err := store.NewStore(cfg.Share.EDSStoreParams, cfg.Share.EDSStoreDir(path))
+	if err != nil {
+		return fmt.Errorf("opening eds store: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, edsStore.Stop(context.Background()))
+	}()
+
+	ds, err := dsbadger.NewDatastore(dataPath(path), constraintBadgerConfig())
+	if err != nil {
+		return fmt.Errorf("opening datastore: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, ds.Close())
+	}()
+	return fn(cfg, edsStore, ds)
+}
@@ -54,6 +54,21 @@ func (mr *MockModuleMockRecorder) AccountAddress(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountAddress", reflect.TypeOf((*MockModule)(nil).AccountAddress), arg0)
 }
 
+// AllBalancesForAddress mocks base method.
+func (m *MockModule) AllBalancesForAddress(arg0 context.Context, arg1 state.Address) (types.Coins, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllBalancesForAddress", arg0, arg1)
+	ret0, _ := ret[0].(types.Coins)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllBalancesForAddress indicates an expected call of AllBalancesForAddress.
+func (mr *MockModuleMockRecorder) AllBalancesForAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllBalancesForAddress", reflect.TypeOf((*MockModule)(nil).AllBalancesForAddress), arg0, arg1)
+}
+
 // Balance mocks base method.
 func (m *MockModule) Balance(arg0 context.Context) (*types.Coin, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +99,21 @@ func (mr *MockModuleMockRecorder) BalanceForAddress(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceForAddress", reflect.TypeOf((*MockModule)(nil).BalanceForAddress), arg0, arg1)
 }
 
+// BalanceForAddressAtHeight mocks base method.
+func (m *MockModule) BalanceForAddressAtHeight(arg0 context.Context, arg1 state.Address, arg2 uint64) (*types.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceForAddressAtHeight", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceForAddressAtHeight indicates an expected call of BalanceForAddressAtHeight.
+func (mr *MockModuleMockRecorder) BalanceForAddressAtHeight(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceForAddressAtHeight", reflect.TypeOf((*MockModule)(nil).BalanceForAddressAtHeight), arg0, arg1, arg2)
+}
+
 // BeginRedelegate mocks base method.
 func (m *MockModule) BeginRedelegate(arg0 context.Context, arg1, arg2 types.ValAddress, arg3 math.Int, arg4 *state.TxConfig) (*types.TxResponse, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +174,21 @@ func (mr *MockModuleMockRecorder) GrantFee(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantFee", reflect.TypeOf((*MockModule)(nil).GrantFee), arg0, arg1, arg2, arg3)
 }
 
+// QueryCommunityPool mocks base method.
+func (m *MockModule) QueryCommunityPool(arg0 context.Context) (types.DecCoins, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryCommunityPool", arg0)
+	ret0, _ := ret[0].(types.DecCoins)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryCommunityPool indicates an expected call of QueryCommunityPool.
+func (mr *MockModuleMockRecorder) QueryCommunityPool(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryCommunityPool", reflect.TypeOf((*MockModule)(nil).QueryCommunityPool), arg0)
+}
+
 // QueryDelegation mocks base method.
 func (m *MockModule) QueryDelegation(arg0 context.Context, arg1 types.ValAddress) (*types0.QueryDelegationResponse, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +204,21 @@ func (mr *MockModuleMockRecorder) QueryDelegation(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryDelegation", reflect.TypeOf((*MockModule)(nil).QueryDelegation), arg0, arg1)
 }
 
+// QueryInflation mocks base method.
+func (m *MockModule) QueryInflation(arg0 context.Context) (types.Dec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryInflation", arg0)
+	ret0, _ := ret[0].(types.Dec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryInflation indicates an expected call of QueryInflation.
+func (mr *MockModuleMockRecorder) QueryInflation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryInflation", reflect.TypeOf((*MockModule)(nil).QueryInflation), arg0)
+}
+
 // QueryRedelegations mocks base method.
 func (m *MockModule) QueryRedelegations(arg0 context.Context, arg1, arg2 types.ValAddress) (*types0.QueryRedelegationsResponse, error) {
 	m.ctrl.T.Helper()
@@ -29,6 +29,17 @@ type Module interface {
 	// the node's current head (head-1). This is due to the fact that for block N, the block's
 	// `AppHash` is the result of applying the previous block's transaction list.
 	BalanceForAddress(ctx context.Context, addr state.Address) (*state.Balance, error)
+	// BalanceForAddressAtHeight retrieves the Celestia coin balance for the given address from the
+	// state at the given height. It fails with state.ErrHeightPruned if the core endpoint no
+	// longer holds the state for that height.
+	//
+	// NOTE: unlike BalanceForAddress, the balance returned is not verified against the AppHash.
+	BalanceForAddressAtHeight(ctx context.Context, addr state.Address, height uint64) (*state.Balance, error)
+	// AllBalancesForAddress retrieves the balances of all the denoms held by the given address.
+	// An unfunded address has empty balances.
+	//
+	// NOTE: unlike BalanceForAddress, the balances returned are not verified against the AppHash.
+	AllBalancesForAddress(ctx context.Context, addr state.Address) (state.Coins, error)
 	// Transfer sends the given amount of coins from default wallet of the node to the given account
 	// address.
 	Transfer(
@@ -81,6 +92,10 @@ type Module interface {
 		srcValAddr,
 		dstValAddr state.ValAddress,
 	) (*types.QueryRedelegationsResponse, error)
+	// QueryCommunityPool retrieves the balance of the community pool.
+	QueryCommunityPool(ctx context.Context) (state.DecCoins, error)
+	// QueryInflation retrieves the current inflation rate.
+	QueryInflation(ctx context.Context) (state.Dec, error)
 
 	GrantFee(
 		ctx context.Context,
@@ -101,10 +116,19 @@ type Module interface {
 //nolint:dupl
 type API struct {
 	Internal struct {
-		AccountAddress    func(ctx context.Context) (state.Address, error)                      `perm:"read"`
-		Balance           func(ctx context.Context) (*state.Balance, error)                     `perm:"read"`
-		BalanceForAddress func(ctx context.Context, addr state.Address) (*state.Balance, error) `perm:"read"`
-		Transfer          func(
+		AccountAddress            func(ctx context.Context) (state.Address, error)                      `perm:"read"`
+		Balance                   func(ctx context.Context) (*state.Balance, error)                     `perm:"read"`
+		BalanceForAddress         func(ctx context.Context, addr state.Address) (*state.Balance, error) `perm:"read"`
+		BalanceForAddressAtHeight func(
+			ctx context.Context,
+			addr state.Address,
+			height uint64,
+		) (*state.Balance, error) `perm:"read"`
+		AllBalancesForAddress func(
+			ctx context.Context,
+			addr state.Address,
+		) (state.Coins, error) `perm:"read"`
+		Transfer func(
 			ctx context.Context,
 			to state.AccAddress,
 			amount state.Int,
@@ -153,6 +177,12 @@ type API struct {
 			srcValAddr,
 			dstValAddr state.ValAddress,
 		) (*types.QueryRedelegationsResponse, error) `perm:"read"`
+		QueryCommunityPool func(
+			ctx context.Context,
+		) (state.DecCoins, error) `perm:"read"`
+		QueryInflation func(
+			ctx context.Context,
+		) (state.Dec, error) `perm:"read"`
 		GrantFee func(
 			ctx context.Context,
 			grantee state.AccAddress,
@@ -175,6 +205,18 @@ func (api *API) BalanceForAddress(ctx context.Context, addr state.Address) (*sta
 	return api.Internal.BalanceForAddress(ctx, addr)
 }
 
+func (api *API) BalanceForAddressAtHeight(
+	ctx context.Context,
+	addr state.Address,
+	height uint64,
+) (*state.Balance, error) {
+	return api.Internal.BalanceForAddressAtHeight(ctx, addr, height)
+}
+
+func (api *API) AllBalancesForAddress(ctx context.Context, addr state.Address) (state.Coins, error) {
+	return api.Internal.AllBalancesForAddress(ctx, addr)
+}
+
 func (api *API) Transfer(
 	ctx context.Context,
 	to state.AccAddress,
@@ -246,6 +288,14 @@ func (api *API) QueryRedelegations(
 	return api.Internal.QueryRedelegations(ctx, srcValAddr, dstValAddr)
 }
 
+func (api *API) QueryCommunityPool(ctx context.Context) (state.DecCoins, error) {
+	return api.Internal.QueryCommunityPool(ctx)
+}
+
+func (api *API) QueryInflation(ctx context.Context) (state.Dec, error) {
+	return api.Internal.QueryInflation(ctx)
+}
+
 func (api *API) Balance(ctx context.Context) (*state.Balance, error) {
 	return api.Internal.Balance(ctx)
 }
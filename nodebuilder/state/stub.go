@@ -31,6 +31,21 @@ func (s stubbedStateModule) BalanceForAddress(
 	return nil, ErrNoStateAccess
 }
 
+func (s stubbedStateModule) BalanceForAddressAtHeight(
+	context.Context,
+	state.Address,
+	uint64,
+) (*state.Balance, error) {
+	return nil, ErrNoStateAccess
+}
+
+func (s stubbedStateModule) AllBalancesForAddress(
+	context.Context,
+	state.Address,
+) (state.Coins, error) {
+	return nil, ErrNoStateAccess
+}
+
 func (s stubbedStateModule) Transfer(
 	_ context.Context,
 	_ state.AccAddress,
@@ -105,6 +120,14 @@ func (s stubbedStateModule) QueryRedelegations(
 	return nil, ErrNoStateAccess
 }
 
+func (s stubbedStateModule) QueryCommunityPool(context.Context) (state.DecCoins, error) {
+	return nil, ErrNoStateAccess
+}
+
+func (s stubbedStateModule) QueryInflation(context.Context) (state.Dec, error) {
+	return state.Dec{}, ErrNoStateAccess
+}
+
 func (s stubbedStateModule) GrantFee(
 	_ context.Context,
 	_ state.AccAddress,
@@ -196,6 +196,23 @@ func TestConvertFromPrunedToArchival(t *testing.T) {
 		err = store.PutConfig(archivalCfg)
 		require.NoError(t, err)
 		_, err = sw.NewNodeWithStore(nt, store)
-		require.ErrorIs(t, err, pruner.ErrDisallowRevertToArchival, nt.String())
+		require.NoError(t, err, nt.String())
+
+		// the pruned heights are left for backfill
+		ds, err := store.Datastore()
+		require.NoError(t, err)
+		backfill, err := pruner.PendingBackfill(ctx, ds)
+		require.NoError(t, err)
+		require.NotNil(t, backfill, nt.String())
+		assert.Equal(t, uint64(1), backfill.From)
+
+		// converting back to pruned drops the backfill
+		err = store.PutConfig(pruningCfg)
+		require.NoError(t, err)
+		_, err = sw.NewNodeWithStore(nt, store)
+		require.NoError(t, err, nt.String())
+		backfill, err = pruner.PendingBackfill(ctx, ds)
+		require.NoError(t, err)
+		require.Nil(t, backfill, nt.String())
 	}
 }
@@ -0,0 +1,85 @@
+package pruner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+)
+
+var backfillKey = datastore.NewKey("backfill")
+
+// Backfill is the range of heights pruned before the node was converted to an archival one, which
+// have to be downloaded again.
+type Backfill struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// ConvertToArchival converts the node run with pruning enabled before to an archival one. It
+// removes the pruner checkpoint and records the pruned heights as pending backfill. Nodes that
+// have never pruned are left as is.
+func ConvertToArchival(ctx context.Context, ds datastore.Datastore) error {
+	ds = namespace.Wrap(ds, storePrefix)
+	cp, err := getCheckpoint(ctx, ds)
+	if errors.Is(err, errCheckpointNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	backfill := &Backfill{From: 1, To: cp.LastPrunedHeight}
+	bin, err := json.Marshal(backfill)
+	if err != nil {
+		return err
+	}
+	// the backfill is recorded first, so that the conversion is retried if interrupted
+	if err := ds.Put(ctx, backfillKey, bin); err != nil {
+		return fmt.Errorf("failed to store backfill: %w", err)
+	}
+	if err := ds.Delete(ctx, checkpointKey); err != nil {
+		return fmt.Errorf("failed to delete checkpoint: %w", err)
+	}
+
+	log.Infow("converted pruned node to archival", "backfill_from", backfill.From,
+		"backfill_to", backfill.To)
+	return nil
+}
+
+// ConvertToPruned prepares the node for pruning. The pending backfill, if any, is dropped, as the
+// pruner removes the blocks outside the window anyway. The first pruning pass of a node run as an
+// archival one before prunes all of its blocks outside the window.
+func ConvertToPruned(ctx context.Context, ds datastore.Datastore) error {
+	return ClearPendingBackfill(ctx, ds)
+}
+
+// ClearPendingBackfill removes the pending backfill, once it is handed over to the backfiller.
+func ClearPendingBackfill(ctx context.Context, ds datastore.Datastore) error {
+	err := namespace.Wrap(ds, storePrefix).Delete(ctx, backfillKey)
+	if err != nil && !errors.Is(err, datastore.ErrNotFound) {
+		return fmt.Errorf("failed to delete backfill: %w", err)
+	}
+	return nil
+}
+
+// PendingBackfill returns the heights pruned before the node was converted to an archival one,
+// which are yet to be backfilled, or nil if there are none.
+func PendingBackfill(ctx context.Context, ds datastore.Datastore) (*Backfill, error) {
+	bin, err := namespace.Wrap(ds, storePrefix).Get(ctx, backfillKey)
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backfill: %w", err)
+	}
+
+	var backfill *Backfill
+	if err := json.Unmarshal(bin, &backfill); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal backfill: %w", err)
+	}
+	return backfill, nil
+}
@@ -0,0 +1,43 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	ctx := context.Background()
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+
+	// archival nodes that have never pruned have nothing to backfill
+	err := ConvertToArchival(ctx, ds)
+	require.NoError(t, err)
+	backfill, err := PendingBackfill(ctx, ds)
+	require.NoError(t, err)
+	require.Nil(t, backfill)
+
+	err = storeCheckpoint(ctx, namespace.Wrap(ds, storePrefix), &checkpoint{
+		LastPrunedHeight: 100,
+		FailedHeaders:    map[uint64]struct{}{},
+	})
+	require.NoError(t, err)
+
+	err = ConvertToArchival(ctx, ds)
+	require.NoError(t, err)
+	backfill, err = PendingBackfill(ctx, ds)
+	require.NoError(t, err)
+	require.Equal(t, &Backfill{From: 1, To: 100}, backfill)
+	_, err = getCheckpoint(ctx, namespace.Wrap(ds, storePrefix))
+	require.ErrorIs(t, err, errCheckpointNotFound)
+
+	err = ConvertToPruned(ctx, ds)
+	require.NoError(t, err)
+	backfill, err = PendingBackfill(ctx, ds)
+	require.NoError(t, err)
+	require.Nil(t, backfill)
+}
@@ -2,31 +2,60 @@ package full
 
 import (
 	"context"
+	"errors"
 
 	logging "github.com/ipfs/go-log/v2"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/pruner"
 	"github.com/celestiaorg/celestia-node/store"
 )
 
 var log = logging.Logger("pruner/full")
 
+var _ pruner.Previewer = (*Pruner)(nil)
+
 type Pruner struct {
 	store *store.Store
+	pins  *pruner.Pins
 }
 
-func NewPruner(store *store.Store) *Pruner {
+// NewPruner creates a new Pruner of the store, which retains the blocks of the pinned namespaces.
+// The pins may be nil.
+func NewPruner(store *store.Store, pins *pruner.Pins) *Pruner {
 	return &Pruner{
 		store: store,
+		pins:  pins,
 	}
 }
 
+// Prune removes the block from the store. Blocks of the pinned namespaces keep their whole ODS,
+// not only the namespace data, while their Q4 is removed, as it only serves the blocks within the
+// availability window.
 func (p *Pruner) Prune(ctx context.Context, eh *header.ExtendedHeader) error {
-	log.Debugf("pruning header %s", eh.DAH.Hash())
-
-	err := p.store.RemoveODSQ4(ctx, eh.Height(), eh.DAH.Hash())
-	if err != nil {
+	var err error
+	if p.pins.Pinned(eh.DAH) {
+		log.Debugw("retaining ods of pinned namespace", "height", eh.Height())
+		err = p.store.RemoveQ4(ctx, eh.Height(), eh.DAH.Hash())
+	} else {
+		log.Debugf("pruning header %s", eh.DAH.Hash())
+		err = p.store.RemoveODSQ4(ctx, eh.Height(), eh.DAH.Hash())
+	}
+	// read-only stores are pruned by their writer
+	if err != nil && !errors.Is(err, store.ErrReadOnly) {
 		return err
 	}
 	return nil
 }
+
+// PreviewPrune reports whether the block would be pruned and the size of its files in the store.
+func (p *Pruner) PreviewPrune(ctx context.Context, eh *header.ExtendedHeader) (bool, int64, error) {
+	if p.pins.Pinned(eh.DAH) {
+		return false, 0, nil
+	}
+	size, err := p.store.SizeByHeight(ctx, eh.Height())
+	if err != nil {
+		return false, 0, err
+	}
+	return true, size, nil
+}
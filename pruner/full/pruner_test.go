@@ -0,0 +1,48 @@
+package full
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+func TestPruner_Pinned(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	edsStore, err := store.NewStore(store.DefaultParameters(), t.TempDir())
+	require.NoError(t, err)
+	ns := sharetest.RandV0Namespace()
+	eds, roots := edstest.RandEDSWithNamespace(t, ns, 8, 4)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+	require.NoError(t, edsStore.PutODSQ4(ctx, roots, eh.Height(), eds))
+	sizeODSQ4, err := edsStore.SizeByHeight(ctx, eh.Height())
+	require.NoError(t, err)
+
+	// the ODS of the pinned block is kept, while its Q4 is removed
+	pinned := NewPruner(edsStore, pruner.NewPins(datastore.NewMapDatastore(), ns))
+	require.NoError(t, pinned.Prune(ctx, eh))
+	has, err := edsStore.HasByHeight(ctx, eh.Height())
+	require.NoError(t, err)
+	require.True(t, has)
+	sizeODS, err := edsStore.SizeByHeight(ctx, eh.Height())
+	require.NoError(t, err)
+	require.Positive(t, sizeODS)
+	require.Less(t, sizeODS, sizeODSQ4)
+
+	// the block is removed once unpinned
+	unpinned := NewPruner(edsStore, pruner.NewPins(datastore.NewMapDatastore()))
+	require.NoError(t, unpinned.Prune(ctx, eh))
+	has, err = edsStore.HasByHeight(ctx, eh.Height())
+	require.NoError(t, err)
+	require.False(t, has)
+}
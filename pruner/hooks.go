@@ -0,0 +1,73 @@
+package pruner
+
+import (
+	"context"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// PrePruneHook is called with the blocks about to be pruned, giving external indexers the last
+// chance to extract their data. The blocks are pruned after the grace delay, not waiting for the
+// hook to finish.
+type PrePruneHook func(ctx context.Context, headers []*header.ExtendedHeader)
+
+// SubscribePrePrune subscribes to the headers of the blocks about to be pruned, which are sent
+// the grace delay before the blocks are pruned. Headers are dropped for the subscribers not
+// keeping up. The subscription is cancelled with the context.
+func (s *Service) SubscribePrePrune(ctx context.Context) <-chan *header.ExtendedHeader {
+	ch := make(chan *header.ExtendedHeader, maxHeadersPerLoop)
+	s.subsLk.Lock()
+	s.subs[ch] = struct{}{}
+	s.subsLk.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.subsLk.Lock()
+		delete(s.subs, ch)
+		close(ch)
+		s.subsLk.Unlock()
+	}()
+	return ch
+}
+
+// notifyPrePrune notifies the hooks and the subscribers about the blocks about to be pruned and
+// waits for the grace delay.
+func (s *Service) notifyPrePrune(ctx context.Context, headers []*header.ExtendedHeader) error {
+	s.notify(ctx, headers)
+
+	if s.params.pruneGrace == 0 {
+		return nil
+	}
+	log.Debugw("waiting for grace delay before pruning", "delay", s.params.pruneGrace)
+	timer := time.NewTimer(s.params.pruneGrace)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// notify notifies the hooks and the subscribers about the blocks about to be pruned.
+func (s *Service) notify(ctx context.Context, headers []*header.ExtendedHeader) {
+	for _, hook := range s.params.prePruneHooks {
+		hook(ctx, headers)
+	}
+
+	s.subsLk.Lock()
+	for ch := range s.subs {
+		for i, eh := range headers {
+			select {
+			case ch <- eh:
+				continue
+			default:
+			}
+			log.Warnw("pre-prune subscriber is not keeping up, dropping headers",
+				"from", eh.Height(), "amount", len(headers)-i)
+			break
+		}
+	}
+	s.subsLk.Unlock()
+}
@@ -2,6 +2,7 @@ package pruner
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -13,8 +14,13 @@ var meter = otel.Meter("storage_pruner")
 type metrics struct {
 	prunedCounter metric.Int64Counter
 
+	runPruned   metric.Int64Histogram
+	runDuration metric.Float64Histogram
+	reclaimed   metric.Int64Counter
+
 	lastPruned   metric.Int64ObservableGauge
 	failedPrunes metric.Int64ObservableGauge
+	pending      metric.Int64ObservableGauge
 
 	clientReg metric.Registration
 }
@@ -26,6 +32,26 @@ func (s *Service) WithMetrics() error {
 		return err
 	}
 
+	runPruned, err := meter.Int64Histogram("prnr_run_pruned_blocks",
+		metric.WithDescription("pruner number of blocks pruned per run"))
+	if err != nil {
+		return err
+	}
+
+	runDuration, err := meter.Float64Histogram("prnr_run_duration",
+		metric.WithDescription("pruner duration of pruning runs"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+
+	reclaimed, err := meter.Int64Counter("prnr_reclaimed_bytes",
+		metric.WithDescription("pruner estimated disk space reclaimed by pruning"),
+		metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+
 	failedPrunes, err := meter.Int64ObservableGauge("prnr_failed_counter",
 		metric.WithDescription("pruner failed prunes counter"))
 	if err != nil {
@@ -38,26 +64,56 @@ func (s *Service) WithMetrics() error {
 		return err
 	}
 
+	pending, err := meter.Int64ObservableGauge("prnr_pending_blocks",
+		metric.WithDescription("pruner estimated number of blocks waiting to be pruned"))
+	if err != nil {
+		return err
+	}
+
 	callback := func(_ context.Context, observer metric.Observer) error {
-		observer.ObserveInt64(lastPruned, int64(s.checkpoint.LastPrunedHeight))
-		observer.ObserveInt64(failedPrunes, int64(len(s.checkpoint.FailedHeaders)))
+		s.cpLk.Lock()
+		lastPrunedHeight, failed := s.checkpoint.LastPrunedHeight, len(s.checkpoint.FailedHeaders)
+		s.cpLk.Unlock()
+		observer.ObserveInt64(lastPruned, int64(lastPrunedHeight))
+		observer.ObserveInt64(failedPrunes, int64(failed))
+		observer.ObserveInt64(pending, s.pendingPrune())
 		return nil
 	}
 
-	clientReg, err := meter.RegisterCallback(callback, lastPruned, failedPrunes)
+	clientReg, err := meter.RegisterCallback(callback, lastPruned, failedPrunes, pending)
 	if err != nil {
 		return err
 	}
 
 	s.metrics = &metrics{
 		prunedCounter: prunedCounter,
+		runPruned:     runPruned,
+		runDuration:   runDuration,
+		reclaimed:     reclaimed,
 		lastPruned:    lastPruned,
 		failedPrunes:  failedPrunes,
+		pending:       pending,
 		clientReg:     clientReg,
 	}
 	return nil
 }
 
+// pendingPrune estimates the number of blocks outside the availability window waiting to be pruned
+// from the block time, including the ones that failed to be pruned.
+func (s *Service) pendingPrune() int64 {
+	s.cpLk.Lock()
+	pending := int64(len(s.checkpoint.FailedHeaders))
+	s.cpLk.Unlock()
+	lastPruned := s.lastPrunedHeader.Load()
+	if lastPruned == nil || s.blockTime <= 0 {
+		return pending
+	}
+	if behind := time.Now().Add(-s.window.Duration()).Sub(lastPruned.Time()); behind > 0 {
+		pending += int64(behind / s.blockTime)
+	}
+	return pending
+}
+
 func (m *metrics) close() error {
 	if m == nil {
 		return nil
@@ -76,3 +132,23 @@ func (m *metrics) observePrune(ctx context.Context, failed bool) {
 	m.prunedCounter.Add(ctx, 1, metric.WithAttributes(
 		attribute.Bool("failed", failed)))
 }
+
+// runStats are the statistics of a pruning run.
+type runStats struct {
+	// pruned is the number of blocks removed
+	pruned int64
+	// reclaimed is the estimated number of bytes reclaimed
+	reclaimed int64
+}
+
+func (m *metrics) observeRun(ctx context.Context, stats *runStats, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	m.runPruned.Record(ctx, stats.pruned)
+	m.runDuration.Record(ctx, duration.Seconds())
+	m.reclaimed.Add(ctx, stats.reclaimed)
+}
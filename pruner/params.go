@@ -11,12 +11,25 @@ type Params struct {
 	// pruneCycle is the frequency at which the pruning Service
 	// runs the ticker. If set to 0, the Service will not run.
 	pruneCycle time.Duration
+	// pruneGrace is the delay between notifying about the blocks about to be pruned and pruning
+	// them.
+	pruneGrace time.Duration
+	// prePruneHooks are called with the blocks about to be pruned.
+	prePruneHooks []PrePruneHook
+	// quota limits the disk usage of the pruned store.
+	quota *quota
 }
 
 func (p *Params) Validate() error {
 	if p.pruneCycle == time.Duration(0) {
 		return fmt.Errorf("invalid GC cycle given, value should be positive and non-zero")
 	}
+	if p.pruneGrace < 0 {
+		return fmt.Errorf("invalid prune grace given, value should not be negative")
+	}
+	if p.quota != nil && p.quota.maxSize <= 0 {
+		return fmt.Errorf("invalid quota given, value should be positive and non-zero")
+	}
 	return nil
 }
 
@@ -34,6 +47,30 @@ func WithPruneCycle(cycle time.Duration) Option {
 	}
 }
 
+// WithPruneGrace configures the delay between notifying the pre-prune hooks and subscribers about
+// the blocks about to be pruned and pruning them.
+func WithPruneGrace(grace time.Duration) Option {
+	return func(p *Params) {
+		p.pruneGrace = grace
+	}
+}
+
+// WithPrePruneHook adds a hook called with the blocks about to be pruned.
+func WithPrePruneHook(hook PrePruneHook) Option {
+	return func(p *Params) {
+		p.prePruneHooks = append(p.prePruneHooks, hook)
+	}
+}
+
+// WithQuota limits the disk usage of the pruned store to maxSize bytes. Once exceeded, the
+// oldest blocks are pruned past the retention window, but never within the given availability
+// window.
+func WithQuota(maxSize int64, window AvailabilityWindow, usage UsageFn) Option {
+	return func(p *Params) {
+		p.quota = &quota{maxSize: maxSize, window: window, usage: usage}
+	}
+}
+
 // WithPrunerMetrics is a utility function to turn on pruner metrics and that is
 // expected to be "invoked" by the fx lifecycle.
 func WithPrunerMetrics(s *Service) error {
@@ -0,0 +1,129 @@
+package pruner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var pinsPrefix = storePrefix.ChildString("pins")
+
+// ErrPinnedInConfig is returned when unpinning a namespace pinned in the node config.
+var ErrPinnedInConfig = errors.New("namespace is pinned in the config")
+
+// Pins is the set of the namespaces pinned by the node. Blocks containing pinned namespaces are
+// retained past the retention window. Namespaces pinned at runtime are persisted in the datastore,
+// while the ones pinned in the config can't be unpinned at runtime.
+type Pins struct {
+	ds datastore.Datastore
+
+	lk         sync.RWMutex
+	namespaces map[string]share.Namespace
+	config     map[string]bool
+}
+
+// NewPins creates the Pins with the namespaces pinned in the config.
+func NewPins(ds datastore.Datastore, configured ...share.Namespace) *Pins {
+	p := &Pins{
+		ds:         namespace.Wrap(ds, pinsPrefix),
+		namespaces: make(map[string]share.Namespace, len(configured)),
+		config:     make(map[string]bool, len(configured)),
+	}
+	for _, ns := range configured {
+		p.namespaces[ns.String()] = ns
+		p.config[ns.String()] = true
+	}
+	return p
+}
+
+// Load loads the namespaces pinned at runtime from the datastore.
+func (p *Pins) Load(ctx context.Context) error {
+	results, err := p.ds.Query(ctx, query.Query{KeysOnly: true})
+	if err != nil {
+		return fmt.Errorf("querying pins: %w", err)
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return fmt.Errorf("reading pins: %w", err)
+	}
+
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	for _, entry := range entries {
+		ns, err := share.NamespaceFromString(datastore.NewKey(entry.Key).BaseNamespace())
+		if err != nil {
+			return fmt.Errorf("decoding pin %s: %w", entry.Key, err)
+		}
+		p.namespaces[ns.String()] = ns
+	}
+	return nil
+}
+
+// Pin pins the namespace.
+func (p *Pins) Pin(ctx context.Context, ns share.Namespace) error {
+	if err := ns.ValidateForData(); err != nil {
+		return err
+	}
+
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	if err := p.ds.Put(ctx, datastore.NewKey(ns.String()), nil); err != nil {
+		return fmt.Errorf("persisting pin: %w", err)
+	}
+	p.namespaces[ns.String()] = ns
+	return nil
+}
+
+// Unpin unpins the namespace. Blocks retained for it so far are kept, as the pruner never goes back
+// in height.
+func (p *Pins) Unpin(ctx context.Context, ns share.Namespace) error {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	if p.config[ns.String()] {
+		return ErrPinnedInConfig
+	}
+	if err := p.ds.Delete(ctx, datastore.NewKey(ns.String())); err != nil {
+		return fmt.Errorf("deleting pin: %w", err)
+	}
+	delete(p.namespaces, ns.String())
+	return nil
+}
+
+// List returns the pinned namespaces in ascending order.
+func (p *Pins) List() []share.Namespace {
+	p.lk.RLock()
+	defer p.lk.RUnlock()
+	namespaces := make([]share.Namespace, 0, len(p.namespaces))
+	for _, ns := range p.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	slices.SortFunc(namespaces, func(a, b share.Namespace) int {
+		return slices.Compare(a, b)
+	})
+	return namespaces
+}
+
+// Pinned reports whether the square with the given roots may contain any of the pinned
+// namespaces. It errs on the side of retaining the block, as the roots only commit to the range
+// of the namespaces of every row.
+func (p *Pins) Pinned(roots *share.AxisRoots) bool {
+	if p == nil {
+		return false
+	}
+	p.lk.RLock()
+	defer p.lk.RUnlock()
+	for _, ns := range p.namespaces {
+		if len(share.RowsWithNamespace(roots, ns)) != 0 {
+			return true
+		}
+	}
+	return false
+}
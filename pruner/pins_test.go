@@ -0,0 +1,47 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+)
+
+func TestPins(t *testing.T) {
+	ctx := context.Background()
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+
+	configured, pinned := sharetest.RandV0Namespace(), sharetest.RandV0Namespace()
+	// every share of the square is of the pinned namespace
+	_, roots := edstest.RandEDSWithNamespace(t, pinned, 16, 4)
+
+	pins := NewPins(ds, configured)
+	require.False(t, pins.Pinned(roots))
+
+	err := pins.Pin(ctx, pinned)
+	require.NoError(t, err)
+	require.True(t, pins.Pinned(roots))
+
+	// runtime pins survive restarts
+	pins = NewPins(ds, configured)
+	err = pins.Load(ctx)
+	require.NoError(t, err)
+	require.True(t, pins.Pinned(roots))
+	require.Len(t, pins.List(), 2)
+
+	err = pins.Unpin(ctx, configured)
+	require.ErrorIs(t, err, ErrPinnedInConfig)
+
+	err = pins.Unpin(ctx, pinned)
+	require.NoError(t, err)
+	require.False(t, pins.Pinned(roots))
+	require.Equal(t, configured, pins.List()[0])
+
+	var nilPins *Pins
+	require.False(t, nilPins.Pinned(roots))
+}
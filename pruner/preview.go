@@ -0,0 +1,81 @@
+package pruner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// Preview describes the blocks the next pruning pass would remove.
+type Preview struct {
+	// Heights are the heights of the blocks to be pruned in ascending order, including the ones
+	// that failed to be pruned before.
+	Heights []uint64 `json:"heights"`
+	// Bytes approximates the disk space pruning the blocks would reclaim. It is zero if the pruner
+	// can't estimate it.
+	Bytes int64 `json:"bytes"`
+}
+
+// Preview returns the blocks the next pruning pass would remove, without removing anything.
+func (s *Service) Preview(ctx context.Context) (*Preview, error) {
+	lastPruned := s.lastPrunedHeader.Load()
+	if lastPruned == nil {
+		// the first pass has not finished yet
+		var err error
+		lastPruned, err = s.lastPruned(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("getting last pruned header: %w", err)
+		}
+	}
+
+	preview := &Preview{}
+	for _, height := range s.failedHeights() {
+		eh, err := s.getter.GetByHeight(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("getting failed header %d: %w", height, err)
+		}
+		if err := preview.add(ctx, s.pruner, eh); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		headers, err := s.findPruneableHeaders(ctx, lastPruned)
+		if err != nil {
+			return nil, fmt.Errorf("finding pruneable headers: %w", err)
+		}
+		for _, eh := range headers {
+			if err := preview.add(ctx, s.pruner, eh); err != nil {
+				return nil, err
+			}
+		}
+		if len(headers) < maxHeadersPerLoop {
+			break
+		}
+		lastPruned = headers[len(headers)-1]
+	}
+
+	slices.Sort(preview.Heights)
+	return preview, nil
+}
+
+// add adds the block to the Preview, unless the pruner would retain it.
+func (p *Preview) add(ctx context.Context, pruner Pruner, eh *header.ExtendedHeader) error {
+	previewer, ok := pruner.(Previewer)
+	if !ok {
+		p.Heights = append(p.Heights, eh.Height())
+		return nil
+	}
+
+	removed, size, err := previewer.PreviewPrune(ctx, eh)
+	if err != nil {
+		return fmt.Errorf("previewing height %d: %w", eh.Height(), err)
+	}
+	if removed {
+		p.Heights = append(p.Heights, eh.Height())
+		p.Bytes += size
+	}
+	return nil
+}
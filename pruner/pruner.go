@@ -11,3 +11,11 @@ import (
 type Pruner interface {
 	Prune(context.Context, *header.ExtendedHeader) error
 }
+
+// Previewer is implemented by the Pruners, which can tell what pruning a block would remove
+// without removing anything.
+type Previewer interface {
+	// PreviewPrune reports whether pruning the block would remove it and approximately how many
+	// bytes it would reclaim.
+	PreviewPrune(context.Context, *header.ExtendedHeader) (bool, int64, error)
+}
@@ -0,0 +1,70 @@
+package pruner
+
+import (
+	"context"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// UsageFn returns the disk usage of the pruned store in bytes.
+type UsageFn func(ctx context.Context) (int64, error)
+
+// quota limits the disk usage of the pruned store.
+type quota struct {
+	maxSize int64
+	// window is the availability window, within which blocks are never pruned
+	window AvailabilityWindow
+	usage  UsageFn
+}
+
+// enforceQuota prunes the oldest blocks following the last pruned one until the store fits the
+// quota, as long as they are outside the availability window. The pre-prune hooks and subscribers
+// are notified, but the grace delay is not awaited, as the disk is running out.
+func (s *Service) enforceQuota(
+	ctx context.Context,
+	lastPrunedHeader *header.ExtendedHeader,
+	stats *runStats,
+) *header.ExtendedHeader {
+	usage, err := s.params.quota.usage(ctx)
+	if err != nil {
+		log.Errorw("failed to get store usage", "err", err)
+		return lastPrunedHeader
+	}
+	if usage <= s.params.quota.maxSize {
+		return lastPrunedHeader
+	}
+	log.Warnw("store exceeds quota, pruning blocks within the retention window",
+		"usage", usage, "quota", s.params.quota.maxSize)
+
+	lastPruned := lastPrunedHeader
+	for usage > s.params.quota.maxSize && ctx.Err() == nil {
+		eh, err := s.getter.GetByHeight(ctx, lastPruned.Height()+1)
+		if err != nil {
+			log.Errorw("failed to get header by height", "height", lastPruned.Height()+1, "err", err)
+			break
+		}
+		if IsWithinAvailabilityWindow(eh.Time(), s.params.quota.window) {
+			log.Warnw("store exceeds quota, but the remaining blocks are within the availability window",
+				"usage", usage, "quota", s.params.quota.maxSize, "height", eh.Height())
+			break
+		}
+
+		s.notify(ctx, []*header.ExtendedHeader{eh})
+		reclaimed := stats.reclaimed
+		if err := s.pruneBlock(ctx, eh, stats); err != nil {
+			log.Errorw("failed to prune block over quota", "height", eh.Height(), "err", err)
+			break
+		}
+		usage -= stats.reclaimed - reclaimed
+		lastPruned = eh
+	}
+
+	if lastPruned == lastPrunedHeader {
+		return lastPrunedHeader
+	}
+	log.Infow("pruned blocks over quota", "to", lastPruned.Height(), "usage", usage)
+	if err := s.updateCheckpoint(s.ctx, lastPruned.Height(), nil); err != nil {
+		log.Errorw("failed to update checkpoint", "err", err)
+	}
+	return lastPruned
+}
@@ -0,0 +1,46 @@
+package pruner
+
+import (
+	"context"
+)
+
+// HeightRange is an inclusive range of heights.
+type HeightRange struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// PrunedRanges returns the ranges of heights pruned so far in ascending order. The heights that
+// failed to be pruned are left out, while the blocks retained for the pinned namespaces are
+// reported as pruned.
+func (s *Service) PrunedRanges(context.Context) ([]HeightRange, error) {
+	failed := s.failedHeights()
+	s.cpLk.Lock()
+	lastPruned := s.checkpoint.LastPrunedHeight
+	s.cpLk.Unlock()
+	// the checkpoint starts at the genesis height, so it is only known to be pruned along with the
+	// heights following it
+	if lastPruned <= 1 {
+		return nil, nil
+	}
+	return splitRange(HeightRange{From: 1, To: lastPruned}, failed), nil
+}
+
+// splitRange splits the range into the ranges between the given sorted heights.
+func splitRange(r HeightRange, heights []uint64) []HeightRange {
+	var ranges []HeightRange
+	from := r.From
+	for _, height := range heights {
+		if height < from || height > r.To {
+			continue
+		}
+		if height > from {
+			ranges = append(ranges, HeightRange{From: from, To: height - 1})
+		}
+		from = height + 1
+	}
+	if from <= r.To {
+		ranges = append(ranges, HeightRange{From: from, To: r.To})
+	}
+	return ranges
+}
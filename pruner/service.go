@@ -3,6 +3,9 @@ package pruner
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ipfs/go-datastore"
@@ -25,6 +28,10 @@ type Service struct {
 
 	ds         datastore.Datastore
 	checkpoint *checkpoint
+	// cpLk guards the checkpoint updates, as it is read by Preview and PrunedRanges
+	cpLk sync.Mutex
+	// lastPrunedHeader is the last pruned header, reported by the metrics
+	lastPrunedHeader atomic.Pointer[header.ExtendedHeader]
 
 	blockTime time.Duration
 
@@ -32,6 +39,10 @@ type Service struct {
 	cancel context.CancelFunc
 	doneCh chan struct{}
 
+	// subs are the channels of the pre-prune subscribers
+	subsLk sync.Mutex
+	subs   map[chan *header.ExtendedHeader]struct{}
+
 	params  Params
 	metrics *metrics
 }
@@ -61,6 +72,7 @@ func NewService(
 		ds:         namespace.Wrap(ds, storePrefix),
 		blockTime:  blockTime,
 		doneCh:     make(chan struct{}),
+		subs:       make(map[chan *header.ExtendedHeader]struct{}),
 		params:     params,
 	}, nil
 }
@@ -113,6 +125,7 @@ func (s *Service) run() {
 
 	for {
 		lastPrunedHeader = s.prune(s.ctx, lastPrunedHeader)
+		s.lastPrunedHeader.Store(lastPrunedHeader)
 		// pruning may take a while beyond ticker's time
 		// and this ensures we don't do idle spins right after the pruning
 		// and ensures there is always pruneCycle period between each run
@@ -130,15 +143,30 @@ func (s *Service) prune(
 	ctx context.Context,
 	lastPrunedHeader *header.ExtendedHeader,
 ) *header.ExtendedHeader {
-	// prioritize retrying previously-failed headers
-	s.retryFailed(s.ctx)
-
 	now := time.Now()
+	stats := &runStats{}
 	log.Debug("pruning round start")
 	defer func() {
-		log.Debugw("pruning round finished", "took", time.Since(now))
+		s.metrics.observeRun(ctx, stats, time.Since(now))
+		log.Debugw("pruning round finished", "took", time.Since(now), "pruned", stats.pruned)
 	}()
 
+	// prioritize retrying previously-failed headers
+	s.retryFailed(s.ctx, stats)
+
+	lastPrunedHeader = s.pruneOutsideWindow(ctx, lastPrunedHeader, stats)
+	if s.params.quota != nil {
+		lastPrunedHeader = s.enforceQuota(ctx, lastPrunedHeader, stats)
+	}
+	return lastPrunedHeader
+}
+
+// pruneOutsideWindow prunes the blocks outside the window following the last pruned one.
+func (s *Service) pruneOutsideWindow(
+	ctx context.Context,
+	lastPrunedHeader *header.ExtendedHeader,
+	stats *runStats,
+) *header.ExtendedHeader {
 	for {
 		select {
 		case <-s.ctx.Done():
@@ -153,13 +181,17 @@ func (s *Service) prune(
 
 		failed := make(map[uint64]struct{})
 
+		if err := s.notifyPrePrune(ctx, headers); err != nil {
+			return lastPrunedHeader
+		}
+
 		log.Debugw("pruning headers", "from", headers[0].Height(), "to",
 			headers[len(headers)-1].Height())
 
 		for _, eh := range headers {
 			pruneCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 
-			err = s.pruner.Prune(pruneCtx, eh)
+			err = s.pruneBlock(pruneCtx, eh, stats)
 			if err != nil {
 				log.Errorw("failed to prune block", "height", eh.Height(), "err", err)
 				failed[eh.Height()] = struct{}{}
@@ -184,20 +216,58 @@ func (s *Service) prune(
 	}
 }
 
-func (s *Service) retryFailed(ctx context.Context) {
-	log.Debugw("retrying failed headers", "amount", len(s.checkpoint.FailedHeaders))
+func (s *Service) retryFailed(ctx context.Context, stats *runStats) {
+	failedHeights := s.failedHeights()
+	log.Debugw("retrying failed headers", "amount", len(failedHeights))
 
-	for failed := range s.checkpoint.FailedHeaders {
+	for _, failed := range failedHeights {
 		h, err := s.getter.GetByHeight(ctx, failed)
 		if err != nil {
 			log.Errorw("failed to load header from failed map", "height", failed, "err", err)
 			continue
 		}
-		err = s.pruner.Prune(ctx, h)
+		err = s.pruneBlock(ctx, h, stats)
 		if err != nil {
 			log.Errorw("failed to prune block from failed map", "height", failed, "err", err)
 			continue
 		}
+		s.cpLk.Lock()
 		delete(s.checkpoint.FailedHeaders, failed)
+		s.cpLk.Unlock()
+	}
+}
+
+// pruneBlock prunes the block and accounts it in the run statistics. The reclaimed bytes are only
+// estimated with metrics or the quota enabled, as it takes an extra lookup of every block.
+func (s *Service) pruneBlock(ctx context.Context, eh *header.ExtendedHeader, stats *runStats) error {
+	removed, size := true, int64(0)
+	if previewer, ok := s.pruner.(Previewer); ok && (s.metrics != nil || s.params.quota != nil) {
+		var err error
+		removed, size, err = previewer.PreviewPrune(ctx, eh)
+		if err != nil {
+			log.Debugw("failed to estimate block size", "height", eh.Height(), "err", err)
+			removed, size = true, 0
+		}
+	}
+
+	if err := s.pruner.Prune(ctx, eh); err != nil {
+		return err
+	}
+	if removed {
+		stats.pruned++
+		stats.reclaimed += size
+	}
+	return nil
+}
+
+// failedHeights returns the heights that failed to be pruned in ascending order.
+func (s *Service) failedHeights() []uint64 {
+	s.cpLk.Lock()
+	defer s.cpLk.Unlock()
+	heights := make([]uint64, 0, len(s.checkpoint.FailedHeaders))
+	for height := range s.checkpoint.FailedHeaders {
+		heights = append(heights, height)
 	}
+	slices.Sort(heights)
+	return heights
 }
@@ -298,6 +298,192 @@ type mockPruner struct {
 	failHeight map[uint64]int
 }
 
+func TestService_PendingPrune(t *testing.T) {
+	serv, err := NewService(
+		&mockPruner{},
+		AvailabilityWindow(time.Hour),
+		nil,
+		sync.MutexWrap(datastore.NewMapDatastore()),
+		time.Minute,
+	)
+	require.NoError(t, err)
+	// nothing is pruned yet
+	require.Zero(t, serv.pendingPrune())
+
+	// two hours of blocks are outside the window
+	lastPruned := headertest.RandExtendedHeader(t)
+	lastPruned.RawHeader.Time = time.Now().Add(-3 * time.Hour)
+	serv.lastPrunedHeader.Store(lastPruned)
+	serv.checkpoint.FailedHeaders[1] = struct{}{}
+	require.InDelta(t, 121, serv.pendingPrune(), 1)
+
+	// blocks within the window are not pending
+	lastPruned.RawHeader.Time = time.Now()
+	require.EqualValues(t, 1, serv.pendingPrune())
+}
+
+func TestService_Preview(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	blockTime := time.Millisecond
+	suite := headertest.NewTestSuite(t, 1, blockTime)
+	store := headertest.NewCustomStore(t, suite, 20)
+
+	mp := &mockPruner{}
+	serv, err := NewService(
+		mp,
+		AvailabilityWindow(time.Millisecond*2),
+		store,
+		sync.MutexWrap(datastore.NewMapDatastore()),
+		blockTime,
+	)
+	require.NoError(t, err)
+	serv.ctx = ctx
+
+	err = serv.loadCheckpoint(ctx)
+	require.NoError(t, err)
+	serv.checkpoint.FailedHeaders[7] = struct{}{}
+
+	time.Sleep(time.Millisecond * 2)
+
+	preview, err := serv.Preview(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, len(preview.Heights), 2)
+	assert.Equal(t, uint64(1), preview.Heights[0])
+	assert.Contains(t, preview.Heights, uint64(7))
+	// nothing is actually pruned
+	assert.Empty(t, mp.deletedHeaderHashes)
+	assert.Equal(t, uint64(1), serv.checkpoint.LastPrunedHeight)
+}
+
+func TestService_PrunedRanges(t *testing.T) {
+	serv, err := NewService(
+		&mockPruner{},
+		AvailabilityWindow(time.Hour),
+		nil,
+		sync.MutexWrap(datastore.NewMapDatastore()),
+		time.Minute,
+	)
+	require.NoError(t, err)
+
+	ranges, err := serv.PrunedRanges(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, ranges)
+
+	serv.checkpoint.LastPrunedHeight = 10
+	serv.checkpoint.FailedHeaders[1] = struct{}{}
+	serv.checkpoint.FailedHeaders[4] = struct{}{}
+	serv.checkpoint.FailedHeaders[5] = struct{}{}
+	ranges, err = serv.PrunedRanges(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []HeightRange{{From: 2, To: 3}, {From: 6, To: 10}}, ranges)
+}
+
+func TestService_PrePrune(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	blockTime := time.Millisecond
+	suite := headertest.NewTestSuite(t, 1, blockTime)
+	store := headertest.NewCustomStore(t, suite, 20)
+
+	mp := &mockPruner{}
+	var hooked []*header.ExtendedHeader
+	serv, err := NewService(
+		mp,
+		AvailabilityWindow(time.Millisecond*2),
+		store,
+		sync.MutexWrap(datastore.NewMapDatastore()),
+		blockTime,
+		WithPruneGrace(time.Millisecond*10),
+		WithPrePruneHook(func(_ context.Context, headers []*header.ExtendedHeader) {
+			// nothing is pruned before the hook is called
+			assert.Empty(t, mp.deletedHeaderHashes)
+			hooked = append(hooked, headers...)
+		}),
+	)
+	require.NoError(t, err)
+	serv.ctx = ctx
+
+	err = serv.loadCheckpoint(ctx)
+	require.NoError(t, err)
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	sub := serv.SubscribePrePrune(subCtx)
+
+	time.Sleep(time.Millisecond * 2)
+
+	lastPruned, err := serv.lastPruned(ctx)
+	require.NoError(t, err)
+	_ = serv.prune(ctx, lastPruned)
+
+	require.NotEmpty(t, mp.deletedHeaderHashes)
+	require.Len(t, hooked, len(mp.deletedHeaderHashes))
+	for _, pruned := range mp.deletedHeaderHashes {
+		eh := <-sub
+		assert.Equal(t, pruned.height, eh.Height())
+	}
+
+	subCancel()
+	_, ok := <-sub
+	assert.False(t, ok)
+}
+
+func TestService_Quota(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	blockTime := time.Millisecond
+	suite := headertest.NewTestSuite(t, 1, blockTime)
+	store := headertest.NewCustomStore(t, suite, 20)
+
+	mp := &sizedPruner{size: 10}
+	usage := func(context.Context) (int64, error) {
+		return 100, nil
+	}
+	serv, err := NewService(
+		mp,
+		// nothing is outside the retention window
+		AvailabilityWindow(time.Hour),
+		store,
+		sync.MutexWrap(datastore.NewMapDatastore()),
+		blockTime,
+		WithQuota(50, AvailabilityWindow(time.Millisecond*2), usage),
+	)
+	require.NoError(t, err)
+	serv.ctx = ctx
+
+	err = serv.loadCheckpoint(ctx)
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 5)
+
+	lastPruned, err := serv.lastPruned(ctx)
+	require.NoError(t, err)
+	lastPruned = serv.prune(ctx, lastPruned)
+
+	// five blocks are pruned to fit the quota
+	require.Len(t, mp.deletedHeaderHashes, 5)
+	assert.EqualValues(t, 6, lastPruned.Height())
+	assert.EqualValues(t, 6, serv.checkpoint.LastPrunedHeight)
+
+	// the availability window is never pruned
+	serv.params.quota.window = AvailabilityWindow(time.Hour)
+	_ = serv.prune(ctx, lastPruned)
+	require.Len(t, mp.deletedHeaderHashes, 5)
+}
+
+// sizedPruner is a mockPruner reporting the same size of every block.
+type sizedPruner struct {
+	mockPruner
+	size int64
+}
+
+func (sp *sizedPruner) PreviewPrune(context.Context, *header.ExtendedHeader) (bool, int64, error) {
+	return true, sp.size, nil
+}
+
 type pruned struct {
 	hash   string
 	height uint64
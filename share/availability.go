@@ -3,6 +3,7 @@ package share
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/celestiaorg/celestia-node/header"
 )
@@ -17,4 +18,20 @@ type Availability interface {
 	// SharesAvailable subjectively validates if Shares committed to the given Root are available on
 	// the Network.
 	SharesAvailable(context.Context, *header.ExtendedHeader) error
+	// Status reports the result of the last availability validation of the Shares committed to
+	// the given Root, without validating anything.
+	Status(context.Context, *header.ExtendedHeader) (AvailabilityStatus, error)
+}
+
+// AvailabilityStatus describes the result of the availability validation of a block.
+type AvailabilityStatus struct {
+	// Validated reports whether the availability of the block was validated.
+	Validated bool
+	// Available reports whether the validation succeeded.
+	Available bool
+	// ValidatedAt is the time the availability was validated. It is zero if unknown.
+	ValidatedAt time.Time
+	// Samples is the amount of samples the validation took. It is zero if the validation was not
+	// sampling based or the amount is unknown.
+	Samples int
 }
@@ -1,10 +1,14 @@
 package full
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
 	logging "github.com/ipfs/go-log/v2"
 
 	"github.com/celestiaorg/celestia-node/header"
@@ -16,7 +20,10 @@ import (
 	"github.com/celestiaorg/celestia-node/store"
 )
 
-var log = logging.Logger("share/full")
+var (
+	log           = logging.Logger("share/full")
+	verdictPrefix = datastore.NewKey("full_availability")
+)
 
 // ShareAvailability implements share.Availability using the full data square
 // recovery technique. It is considered "full" because it is required
@@ -24,16 +31,21 @@ var log = logging.Logger("share/full")
 type ShareAvailability struct {
 	store  *store.Store
 	getter shwap.Getter
+	// verdicts holds the data roots of the blocks found available by height, so that blocks are
+	// not reconstructed again after a restart, even when their data is no longer in the store.
+	verdicts datastore.Datastore
 }
 
 // NewShareAvailability creates a new full ShareAvailability.
 func NewShareAvailability(
 	store *store.Store,
 	getter shwap.Getter,
+	ds datastore.Batching,
 ) *ShareAvailability {
 	return &ShareAvailability{
-		store:  store,
-		getter: getter,
+		store:    store,
+		getter:   getter,
+		verdicts: namespace.Wrap(ds, verdictPrefix),
 	}
 }
 
@@ -58,6 +70,10 @@ func (fa *ShareAvailability) SharesAvailable(ctx context.Context, header *header
 		panic(err)
 	}
 
+	if fa.hasVerdict(ctx, header) {
+		return nil
+	}
+
 	// a hack to avoid loading the whole EDS in mem if we store it already.
 	if ok, _ := fa.store.HasByHeight(ctx, header.Height()); ok {
 		return nil
@@ -83,8 +99,59 @@ func (fa *ShareAvailability) SharesAvailable(ctx context.Context, header *header
 		err = fa.store.PutODS(ctx, dah, header.Height(), eds)
 	}
 
-	if err != nil {
+	// the writer of a read-only store stores the eds, which is available already
+	if err != nil && !errors.Is(err, store.ErrReadOnly) {
 		return fmt.Errorf("full availability: failed to store eds: %w", err)
 	}
+
+	// the block is available regardless of whether the verdict is persisted
+	err = fa.verdicts.Put(ctx, verdictKey(header.Height()), dah.Hash())
+	if err != nil {
+		log.Warnw("persisting availability verdict", "height", header.Height(), "err", err)
+	}
+	return nil
+}
+
+// Invalidate removes the persisted availability verdict of the block at the given height, so that
+// the next availability check reconstructs the block again unless its data is in the store.
+func (fa *ShareAvailability) Invalidate(ctx context.Context, height uint64) error {
+	err := fa.verdicts.Delete(ctx, verdictKey(height))
+	if err != nil {
+		return fmt.Errorf("full availability: removing verdict: %w", err)
+	}
 	return nil
 }
+
+// hasVerdict reports whether the block was found available before.
+func (fa *ShareAvailability) hasVerdict(ctx context.Context, header *header.ExtendedHeader) bool {
+	root, err := fa.verdicts.Get(ctx, verdictKey(header.Height()))
+	if err != nil {
+		if !errors.Is(err, datastore.ErrNotFound) {
+			log.Warnw("loading availability verdict", "height", header.Height(), "err", err)
+		}
+		return false
+	}
+	// verdicts of blocks with a different root at the same height are ignored
+	return bytes.Equal(root, header.DAH.Hash())
+}
+
+func verdictKey(height uint64) datastore.Key {
+	return datastore.NewKey(strconv.FormatUint(height, 10))
+}
+
+// Status reports the block as validated and available once it was found available before or its
+// EDS is in the store. Failed validations are not persisted, so the block is reported as not
+// validated instead.
+func (fa *ShareAvailability) Status(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (share.AvailabilityStatus, error) {
+	if fa.hasVerdict(ctx, header) {
+		return share.AvailabilityStatus{Validated: true, Available: true}, nil
+	}
+	has, err := fa.store.HasByHeight(ctx, header.Height())
+	if err != nil {
+		return share.AvailabilityStatus{}, fmt.Errorf("checking store: %w", err)
+	}
+	return share.AvailabilityStatus{Validated: has, Available: has}, nil
+}
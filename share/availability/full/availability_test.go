@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
 	"github.com/stretchr/testify/require"
 
 	"github.com/celestiaorg/celestia-node/header/headertest"
@@ -31,7 +33,7 @@ func TestSharesAvailable(t *testing.T) {
 
 	store, err := store.NewStore(store.DefaultParameters(), t.TempDir())
 	require.NoError(t, err)
-	avail := NewShareAvailability(store, getter)
+	avail := NewShareAvailability(store, getter, ds_sync.MutexWrap(datastore.NewMapDatastore()))
 	err = avail.SharesAvailable(ctx, eh)
 	require.NoError(t, err)
 
@@ -58,7 +60,7 @@ func TestSharesAvailable_StoredEds(t *testing.T) {
 
 	store, err := store.NewStore(store.DefaultParameters(), t.TempDir())
 	require.NoError(t, err)
-	avail := NewShareAvailability(store, nil)
+	avail := NewShareAvailability(store, nil, ds_sync.MutexWrap(datastore.NewMapDatastore()))
 
 	err = store.PutODSQ4(ctx, roots, eh.Height(), eds)
 	require.NoError(t, err)
@@ -88,7 +90,7 @@ func TestSharesAvailable_ErrNotAvailable(t *testing.T) {
 
 	store, err := store.NewStore(store.DefaultParameters(), t.TempDir())
 	require.NoError(t, err)
-	avail := NewShareAvailability(store, getter)
+	avail := NewShareAvailability(store, getter, ds_sync.MutexWrap(datastore.NewMapDatastore()))
 
 	errors := []error{shwap.ErrNotFound, context.DeadlineExceeded}
 	for _, getterErr := range errors {
@@ -97,3 +99,39 @@ func TestSharesAvailable_ErrNotAvailable(t *testing.T) {
 		require.ErrorIs(t, err, share.ErrNotAvailable)
 	}
 }
+
+func TestSharesAvailable_Verdict(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	eds := edstest.RandEDS(t, 4)
+	roots, err := share.NewAxisRoots(eds)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetEDS(gomock.Any(), eh).Return(eds, nil).Times(2)
+
+	store, err := store.NewStore(store.DefaultParameters(), t.TempDir())
+	require.NoError(t, err)
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	avail := NewShareAvailability(store, getter, ds)
+	err = avail.SharesAvailable(ctx, eh)
+	require.NoError(t, err)
+
+	// the verdict survives the data being removed from the store and a restart
+	err = store.RemoveODSQ4(ctx, eh.Height(), roots.Hash())
+	require.NoError(t, err)
+	avail = NewShareAvailability(store, getter, ds)
+	err = avail.SharesAvailable(ctx, eh)
+	require.NoError(t, err)
+	status, err := avail.Status(ctx, eh)
+	require.NoError(t, err)
+	require.True(t, status.Available)
+
+	// the block is reconstructed again once the verdict is invalidated
+	err = avail.Invalidate(ctx, eh.Height())
+	require.NoError(t, err)
+	err = avail.SharesAvailable(ctx, eh)
+	require.NoError(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/autobatch"
@@ -18,6 +19,7 @@ import (
 var (
 	log                     = logging.Logger("share/light")
 	cacheAvailabilityPrefix = datastore.NewKey("sampling_result")
+	statusPrefix            = datastore.NewKey("sampling_status")
 	writeBatchSize          = 2048
 )
 
@@ -34,6 +36,8 @@ type ShareAvailability struct {
 	// TODO: Striped locks? :D
 	dsLk sync.RWMutex
 	ds   *autobatch.Datastore
+	// statusDS holds the time and the amount of samples of successful sampling sessions.
+	statusDS *autobatch.Datastore
 }
 
 // NewShareAvailability creates a new light Availability.
@@ -43,17 +47,18 @@ func NewShareAvailability(
 	opts ...Option,
 ) *ShareAvailability {
 	params := *DefaultParameters()
-	ds = namespace.Wrap(ds, cacheAvailabilityPrefix)
-	autoDS := autobatch.NewAutoBatching(ds, writeBatchSize)
+	autoDS := autobatch.NewAutoBatching(namespace.Wrap(ds, cacheAvailabilityPrefix), writeBatchSize)
+	statusDS := autobatch.NewAutoBatching(namespace.Wrap(ds, statusPrefix), writeBatchSize)
 
 	for _, opt := range opts {
 		opt(&params)
 	}
 
 	return &ShareAvailability{
-		getter: getter,
-		params: params,
-		ds:     autoDS,
+		getter:   getter,
+		params:   params,
+		ds:       autoDS,
+		statusDS: statusDS,
 	}
 }
 
@@ -106,13 +111,23 @@ func (la *ShareAvailability) SharesAvailable(ctx context.Context, header *header
 	)
 
 	log.Debugw("starting sampling session", "root", dah.String())
+	// request every sample in a single batch first, which saves round trips to peers, and only
+	// fall back to sampling the ones the batch missed one by one to find out which of them failed
+	pending := la.sampleBatch(ctx, header, samples)
+
 	var wg sync.WaitGroup
-	for _, s := range samples {
+	for _, s := range pending {
 		wg.Add(1)
 		go func(s Sample) {
 			defer wg.Done()
+			sampleCtx := ctx
+			if la.params.SampleTimeout > 0 {
+				var cancel context.CancelFunc
+				sampleCtx, cancel = context.WithTimeout(ctx, la.params.SampleTimeout)
+				defer cancel()
+			}
 			// check if the sample is available
-			_, err := la.getter.GetShare(ctx, header, int(s.Row), int(s.Col))
+			_, err := la.getter.GetShare(sampleCtx, header, int(s.Row), int(s.Col))
 			if err != nil {
 				log.Debugw("error fetching share", "root", dah.String(), "row", s.Row, "col", s.Col)
 				failedSamplesLock.Lock()
@@ -133,6 +148,9 @@ func (la *ShareAvailability) SharesAvailable(ctx context.Context, header *header
 	bs := encodeSamples(failedSamples)
 	la.dsLk.Lock()
 	err = la.ds.Put(ctx, key, bs)
+	if err == nil && len(failedSamples) == 0 {
+		err = la.statusDS.Put(ctx, key, encodeStatus(time.Now(), len(samples)))
+	}
 	la.dsLk.Unlock()
 	if err != nil {
 		log.Errorw("Failed to store sampling result", "error", err)
@@ -149,11 +167,99 @@ func (la *ShareAvailability) SharesAvailable(ctx context.Context, header *header
 	return nil
 }
 
+// sampleBatch requests all the samples at once, bounded by the sample timeout, and returns the
+// ones the batch didn't retrieve.
+func (la *ShareAvailability) sampleBatch(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	samples []Sample,
+) []Sample {
+	if la.params.SampleTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, la.params.SampleTimeout)
+		defer cancel()
+	}
+	rowIdxs, colIdxs := make([]int, len(samples)), make([]int, len(samples))
+	for i, s := range samples {
+		rowIdxs[i], colIdxs[i] = int(s.Row), int(s.Col)
+	}
+
+	var (
+		retrievedLk sync.Mutex
+		retrieved   = make([]bool, len(samples))
+	)
+	ctx = shwap.WithGetOptions(ctx, shwap.WithOnShare(func(idx int, _ share.Share) {
+		retrievedLk.Lock()
+		retrieved[idx] = true
+		retrievedLk.Unlock()
+	}))
+	_, err := la.getter.GetShares(ctx, header, rowIdxs, colIdxs)
+	if err == nil {
+		return nil
+	}
+
+	retrievedLk.Lock()
+	defer retrievedLk.Unlock()
+	var missing []Sample
+	for i, s := range samples {
+		if !retrieved[i] {
+			missing = append(missing, s)
+		}
+	}
+	log.Debugw("batched sampling failed, sampling the missing samples one by one",
+		"root", header.DAH.String(),
+		"missing", len(missing),
+		"err", err)
+	return missing
+}
+
+// Status reports the result of the last sampling session over the given header. The time and the
+// amount of samples are only known for sessions that succeeded.
+func (la *ShareAvailability) Status(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (share.AvailabilityStatus, error) {
+	dah := header.DAH
+	// empty data squares are available by definition
+	if share.DataHash(dah.Hash()).IsEmptyEDS() {
+		return share.AvailabilityStatus{Validated: true, Available: true}, nil
+	}
+
+	key := rootKey(dah)
+	la.dsLk.RLock()
+	defer la.dsLk.RUnlock()
+	last, err := la.ds.Get(ctx, key)
+	switch {
+	case errors.Is(err, datastore.ErrNotFound):
+		return share.AvailabilityStatus{}, nil
+	case err != nil:
+		return share.AvailabilityStatus{}, err
+	case len(last) != 0:
+		// some of the samples failed
+		return share.AvailabilityStatus{Validated: true}, nil
+	}
+
+	status := share.AvailabilityStatus{Validated: true, Available: true}
+	bs, err := la.statusDS.Get(ctx, key)
+	switch {
+	case errors.Is(err, datastore.ErrNotFound):
+		// sampled before the statuses were recorded
+		return status, nil
+	case err != nil:
+		return share.AvailabilityStatus{}, err
+	}
+	status.ValidatedAt, status.Samples, err = decodeStatus(bs)
+	if err != nil {
+		return share.AvailabilityStatus{}, err
+	}
+	return status, nil
+}
+
 func rootKey(root *share.AxisRoots) datastore.Key {
 	return datastore.NewKey(root.String())
 }
 
 // Close flushes all queued writes to disk.
 func (la *ShareAvailability) Close(ctx context.Context) error {
-	return la.ds.Flush(ctx)
+	return errors.Join(la.ds.Flush(ctx), la.statusDS.Flush(ctx))
 }
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/ipfs/go-datastore"
@@ -30,14 +31,19 @@ func TestSharesAvailableCaches(t *testing.T) {
 	require.NoError(t, err)
 	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
 
+	// all the samples are requested in a single batch
 	getter := mock.NewMockGetter(gomock.NewController(t))
 	getter.EXPECT().
-		GetShare(gomock.Any(), eh, gomock.Any(), gomock.Any()).
+		GetShares(gomock.Any(), eh, gomock.Any(), gomock.Any()).
 		DoAndReturn(
-			func(_ context.Context, _ *header.ExtendedHeader, row, col int) (share.Share, error) {
-				return eds.GetCell(uint(row), uint(col)), nil
+			func(_ context.Context, _ *header.ExtendedHeader, rowIdxs, colIdxs []int) ([]share.Share, error) {
+				shares := make([]share.Share, len(rowIdxs))
+				for i := range rowIdxs {
+					shares[i] = eds.GetCell(uint(rowIdxs[i]), uint(colIdxs[i]))
+				}
+				return shares, nil
 			}).
-		AnyTimes()
+		Times(1)
 
 	ds := datastore.NewMapDatastore()
 	avail := NewShareAvailability(getter, ds)
@@ -64,6 +70,10 @@ func TestSharesAvailableHitsCache(t *testing.T) {
 
 	// create getter that always return ErrNotFound
 	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().
+		GetShares(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, shrex.ErrNotFound).
+		AnyTimes()
 	getter.EXPECT().
 		GetShare(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil, shrex.ErrNotFound).
@@ -89,6 +99,67 @@ func TestSharesAvailableHitsCache(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSharesAvailableStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eds := edstest.RandEDS(t, 16)
+	roots, err := share.NewAxisRoots(eds)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	// all the samples are requested in a single batch
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().
+		GetShares(gomock.Any(), eh, gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(_ context.Context, _ *header.ExtendedHeader, rowIdxs, colIdxs []int) ([]share.Share, error) {
+				shares := make([]share.Share, len(rowIdxs))
+				for i := range rowIdxs {
+					shares[i] = eds.GetCell(uint(rowIdxs[i]), uint(colIdxs[i]))
+				}
+				return shares, nil
+			}).
+		Times(1)
+
+	ds := datastore.NewMapDatastore()
+	avail := NewShareAvailability(getter, ds)
+
+	// nothing is known before sampling
+	status, err := avail.Status(ctx, eh)
+	require.NoError(t, err)
+	require.False(t, status.Validated)
+
+	before := time.Now()
+	err = avail.SharesAvailable(ctx, eh)
+	require.NoError(t, err)
+
+	status, err = avail.Status(ctx, eh)
+	require.NoError(t, err)
+	require.True(t, status.Validated)
+	require.True(t, status.Available)
+	require.False(t, status.ValidatedAt.Before(before))
+	require.Equal(t, int(avail.params.SampleAmount), status.Samples)
+
+	// failed sessions are reported as validated but not available
+	failedEh := headertest.RandExtendedHeaderWithRoot(t, edstest.RandomAxisRoots(t, 16))
+	getter.EXPECT().
+		GetShares(gomock.Any(), failedEh, gomock.Any(), gomock.Any()).
+		Return(nil, shrex.ErrNotFound).
+		Times(1)
+	getter.EXPECT().
+		GetShare(gomock.Any(), failedEh, gomock.Any(), gomock.Any()).
+		Return(nil, shrex.ErrNotFound).
+		AnyTimes()
+	err = avail.SharesAvailable(ctx, failedEh)
+	require.ErrorIs(t, err, share.ErrNotAvailable)
+
+	status, err = avail.Status(ctx, failedEh)
+	require.NoError(t, err)
+	require.True(t, status.Validated)
+	require.False(t, status.Available)
+}
+
 func TestSharesAvailableEmptyRoot(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -103,6 +174,93 @@ func TestSharesAvailableEmptyRoot(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSharesAvailableSampleTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// create getter that never responds
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().
+		GetShares(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, _ *header.ExtendedHeader, _, _ []int) ([]share.Share, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}).
+		Times(1)
+	getter.EXPECT().
+		GetShare(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, _ *header.ExtendedHeader, _, _ int) (share.Share, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}).
+		AnyTimes()
+
+	ds := datastore.NewMapDatastore()
+	avail := NewShareAvailability(getter, ds, WithSampleTimeout(10*time.Millisecond))
+
+	roots := edstest.RandomAxisRoots(t, 16)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	// samples time out without the whole session being canceled
+	err := avail.SharesAvailable(ctx, eh)
+	require.ErrorIs(t, err, share.ErrNotAvailable)
+}
+
+func TestSharesAvailableRetriesMissing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eds := edstest.RandEDS(t, 16)
+	roots, err := share.NewAxisRoots(eds)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	// the batch retrieves every other sample before failing
+	var (
+		lk      sync.Mutex
+		missing = make(map[Sample]bool)
+	)
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().
+		GetShares(gomock.Any(), eh, gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, _ *header.ExtendedHeader, rowIdxs, colIdxs []int) ([]share.Share, error) {
+				onShare := shwap.GetOptionsFromContext(ctx).OnShare
+				require.NotNil(t, onShare)
+				lk.Lock()
+				defer lk.Unlock()
+				for i := range rowIdxs {
+					if i%2 == 0 {
+						onShare(i, eds.GetCell(uint(rowIdxs[i]), uint(colIdxs[i])))
+						continue
+					}
+					missing[Sample{Row: uint16(rowIdxs[i]), Col: uint16(colIdxs[i])}] = true
+				}
+				return nil, shrex.ErrNotFound
+			}).
+		Times(1)
+	// only the missing samples are requested one by one
+	getter.EXPECT().
+		GetShare(gomock.Any(), eh, gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(_ context.Context, _ *header.ExtendedHeader, row, col int) (share.Share, error) {
+				lk.Lock()
+				defer lk.Unlock()
+				s := Sample{Row: uint16(row), Col: uint16(col)}
+				require.True(t, missing[s])
+				delete(missing, s)
+				return eds.GetCell(uint(row), uint(col)), nil
+			}).
+		AnyTimes()
+
+	avail := NewShareAvailability(getter, datastore.NewMapDatastore())
+	err = avail.SharesAvailable(ctx, eh)
+	require.NoError(t, err)
+	require.Empty(t, missing)
+}
+
 func TestSharesAvailableFailed(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -118,6 +276,10 @@ func TestSharesAvailableFailed(t *testing.T) {
 	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
 
 	// getter doesn't have the eds, so it should fail
+	getter.EXPECT().
+		GetShares(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, shrex.ErrNotFound).
+		AnyTimes()
 	getter.EXPECT().
 		GetShare(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil, shrex.ErrNotFound).
@@ -180,6 +342,24 @@ func (m onceGetter) GetShare(_ context.Context, _ *header.ExtendedHeader, row, c
 	return share.Share{}, share.ErrNotAvailable
 }
 
+func (m onceGetter) GetShares(
+	_ context.Context,
+	_ *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	m.Lock()
+	defer m.Unlock()
+	for i := range rowIdxs {
+		if _, ok := m.available[Sample{Row: uint16(rowIdxs[i]), Col: uint16(colIdxs[i])}]; !ok {
+			return nil, share.ErrNotAvailable
+		}
+	}
+	for i := range rowIdxs {
+		delete(m.available, Sample{Row: uint16(rowIdxs[i]), Col: uint16(colIdxs[i])})
+	}
+	return make([]share.Share, len(rowIdxs)), nil
+}
+
 func (m onceGetter) GetEDS(_ context.Context, _ *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
 	panic("not implemented")
 }
@@ -0,0 +1,27 @@
+package light
+
+// RequiredSamples returns the minimal amount of unique random samples a light node needs to
+// perform over the extended data square to be sure, with the given confidence, that the block
+// data is available. The squareSize is the width of the original data square.
+//
+// To make the block unrecoverable, an adversary has to withhold at least (k+1)^2 of the (2k)^2
+// shares of the extended square, where k is the squareSize. The confidence is the probability
+// that at least one of the samples, drawn without replacement, hits a withheld share. The
+// result is clamped to the total number of coordinates in the extended square.
+func RequiredSamples(squareSize int, confidence float64) int {
+	if squareSize <= 0 || confidence <= 0 {
+		return 0
+	}
+
+	total := 4 * squareSize * squareSize
+	available := total - (squareSize+1)*(squareSize+1)
+	// probability that all the samples taken so far hit available shares
+	miss := 1.0
+	for samples := 0; samples < total; samples++ {
+		if miss <= 1-confidence {
+			return samples
+		}
+		miss *= float64(available-samples) / float64(total-samples)
+	}
+	return total
+}
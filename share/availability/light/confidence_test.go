@@ -0,0 +1,31 @@
+package light
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredSamples(t *testing.T) {
+	tests := []struct {
+		name       string
+		squareSize int
+		confidence float64
+		expected   int
+	}{
+		{name: "zero confidence", squareSize: 16, confidence: 0, expected: 0},
+		{name: "empty square", squareSize: 0, confidence: 0.99, expected: 0},
+		{name: "single share square", squareSize: 1, confidence: 0.99, expected: 1},
+		{name: "small square", squareSize: 4, confidence: 0.99, expected: 9},
+		{name: "large square", squareSize: 128, confidence: 0.999999, expected: 48},
+		// full confidence requires sampling all the shares that may be available, plus one
+		{name: "full confidence", squareSize: 16, confidence: 1, expected: 736},
+		{name: "over confidence", squareSize: 2, confidence: 2, expected: 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RequiredSamples(tt.squareSize, tt.confidence))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package light
 
 import (
 	"fmt"
+	"time"
 )
 
 // SampleAmount specifies the minimum required amount of samples a light node must perform
@@ -14,6 +15,9 @@ var (
 // availability implementation
 type Parameters struct {
 	SampleAmount uint // The minimum required amount of samples to perform
+	// SampleTimeout bounds the time a single sample may take before it is considered failed.
+	// Zero leaves samples bounded by the context of SharesAvailable only.
+	SampleTimeout time.Duration
 }
 
 // Option is a function that configures light availability Parameters
@@ -38,6 +42,15 @@ func (p *Parameters) Validate() error {
 		)
 	}
 
+	if p.SampleTimeout < 0 {
+		return fmt.Errorf(
+			"light availability: invalid option: value %s was %s, where it should be %s",
+			"SampleTimeout",
+			"< 0",  // current value
+			">= 0", // what the value should be
+		)
+	}
+
 	return nil
 }
 
@@ -48,3 +61,11 @@ func WithSampleAmount(sampleAmount uint) Option {
 		p.SampleAmount = sampleAmount
 	}
 }
+
+// WithSampleTimeout is a functional option that the Availability interface
+// implementers use to set the SampleTimeout configuration param
+func WithSampleTimeout(sampleTimeout time.Duration) Option {
+	return func(p *Parameters) {
+		p.SampleTimeout = sampleTimeout
+	}
+}
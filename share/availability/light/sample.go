@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"math/big"
+	"time"
 )
 
 // Sample is a point in 2D space over square.
@@ -102,3 +103,22 @@ func decodeSamples(bs []byte) ([]Sample, error) {
 	}
 	return samples, nil
 }
+
+// encodeStatus encodes the time and the amount of samples of a successful sampling session
+// into a byte slice using little endian encoding.
+func encodeStatus(sampledAt time.Time, samples int) []byte {
+	bs := make([]byte, 0, 12)
+	bs = binary.LittleEndian.AppendUint64(bs, uint64(sampledAt.UnixNano()))
+	bs = binary.LittleEndian.AppendUint32(bs, uint32(samples))
+	return bs
+}
+
+// decodeStatus decodes a byte slice into the time and the amount of samples of a successful
+// sampling session.
+func decodeStatus(bs []byte) (time.Time, int, error) {
+	if len(bs) != 12 {
+		return time.Time{}, 0, errors.New("invalid byte slice length")
+	}
+	sampledAt := time.Unix(0, int64(binary.LittleEndian.Uint64(bs[:8])))
+	return sampledAt, int(binary.LittleEndian.Uint32(bs[8:])), nil
+}
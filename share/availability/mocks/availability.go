@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	header "github.com/celestiaorg/celestia-node/header"
+	share "github.com/celestiaorg/celestia-node/share"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -48,3 +49,18 @@ func (mr *MockAvailabilityMockRecorder) SharesAvailable(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharesAvailable", reflect.TypeOf((*MockAvailability)(nil).SharesAvailable), arg0, arg1)
 }
+
+// Status mocks base method.
+func (m *MockAvailability) Status(arg0 context.Context, arg1 *header.ExtendedHeader) (share.AvailabilityStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", arg0, arg1)
+	ret0, _ := ret[0].(share.AvailabilityStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockAvailabilityMockRecorder) Status(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockAvailability)(nil).Status), arg0, arg1)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/celestiaorg/rsmt2d"
+
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/shwap"
 )
@@ -31,3 +33,36 @@ func NamespaceData(
 
 	return rows, nil
 }
+
+// NamespaceDataColMajor extracts shares for a specific namespace from an EDS in column-major
+// order. Each returned item holds the namespace shares of a single column, ordered top to bottom,
+// with a proof against the column root. Columns are ordered left to right and only columns whose
+// roots can contain the namespace are included.
+func NamespaceDataColMajor(
+	ctx context.Context,
+	eds Accessor,
+	namespace share.Namespace,
+) (shwap.NamespaceData, error) {
+	roots, err := eds.AxisRoots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AxisRoots: %w", err)
+	}
+	colIdxs := share.ColumnsWithNamespace(roots, namespace)
+	cols := make(shwap.NamespaceData, len(colIdxs))
+	for i, idx := range colIdxs {
+		half, err := eds.AxisHalf(ctx, rsmt2d.Col, idx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get half of column %d: %w", idx, err)
+		}
+		shares, err := half.Extended()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extend column %d: %w", idx, err)
+		}
+		cols[i], err = shwap.RowNamespaceDataFromShares(shares, namespace, idx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process column %d: %w", idx, err)
+		}
+	}
+
+	return cols, nil
+}
@@ -30,3 +30,25 @@ func TestNamespaceData(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestNamespaceDataColMajor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	const odsSize = 8
+	sharesAmount := odsSize * odsSize
+	namespace := sharetest.RandV0Namespace()
+	for amount := 1; amount < sharesAmount; amount++ {
+		eds, root := edstest.RandEDSWithNamespace(t, namespace, amount, odsSize)
+		rsmt2d := &Rsmt2D{ExtendedDataSquare: eds}
+		nd, err := NamespaceDataColMajor(ctx, rsmt2d, namespace)
+		require.NoError(t, err)
+		require.True(t, len(nd) > 0)
+		require.Len(t, nd.Flatten(), amount)
+
+		err = nd.VerifyColumns(root, namespace)
+		require.NoError(t, err)
+		// column proofs must not verify against row roots
+		require.Error(t, nd.Verify(root, namespace))
+	}
+}
@@ -0,0 +1,143 @@
+package eds
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var (
+	// ErrTxNotFound is returned when the transaction is not included in the EDS.
+	ErrTxNotFound = errors.New("tx not found")
+	// ErrNotBlobTx is returned when the transaction is included in the EDS, but does not pay for
+	// any blobs.
+	ErrNotBlobTx = errors.New("tx does not pay for blobs")
+)
+
+// BlobTxRange locates the PayForBlobs transaction with the given hash in the ODS and returns the
+// range of shares occupied by the blobs it pays for. The range, defined by start and end, is
+// end-exclusive and spans from the first share of the first blob to the last share of the last
+// blob.
+func BlobTxRange(eds *rsmt2d.ExtendedDataSquare, txHash []byte) (start, end int, err error) {
+	ods := eds.FlattenedODS()
+	pfbTxs, err := parseTxs(ods, share.PayForBlobNamespace)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing pfb txs: %w", err)
+	}
+
+	for _, tx := range pfbTxs {
+		if !bytes.Equal(tx.Hash(), txHash) {
+			continue
+		}
+		wrapper, ok := types.UnmarshalIndexWrapper(tx)
+		if !ok || len(wrapper.ShareIndexes) == 0 {
+			return 0, 0, fmt.Errorf("pfb tx %X has no share indexes", txHash)
+		}
+		return blobsRange(ods, wrapper.ShareIndexes)
+	}
+
+	txs, err := parseTxs(ods, share.TxNamespace)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing txs: %w", err)
+	}
+	for _, tx := range txs {
+		if bytes.Equal(tx.Hash(), txHash) {
+			return 0, 0, ErrNotBlobTx
+		}
+	}
+	return 0, 0, ErrTxNotFound
+}
+
+// BlobTxNamespaces maps the hash of every PayForBlobs transaction in the ODS to the namespaces of
+// the blobs it pays for. Hashes are upper-case hex encoded, as reported by the core, and
+// namespaces are listed in the order of the blobs without duplicates. Transactions that do not pay
+// for blobs are omitted.
+func BlobTxNamespaces(eds *rsmt2d.ExtendedDataSquare) (map[string][]share.Namespace, error) {
+	ods := eds.FlattenedODS()
+	pfbTxs, err := parseTxs(ods, share.PayForBlobNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("parsing pfb txs: %w", err)
+	}
+
+	namespaces := make(map[string][]share.Namespace, len(pfbTxs))
+	for _, tx := range pfbTxs {
+		wrapper, ok := types.UnmarshalIndexWrapper(tx)
+		if !ok || len(wrapper.ShareIndexes) == 0 {
+			return nil, fmt.Errorf("pfb tx %X has no share indexes", tx.Hash())
+		}
+
+		var txNamespaces []share.Namespace
+		for _, idx := range wrapper.ShareIndexes {
+			if int(idx) >= len(ods) {
+				return nil, fmt.Errorf("blob share index %d is out of the square bounds", idx)
+			}
+			ns := share.GetNamespace(ods[idx])
+			if !slices.ContainsFunc(txNamespaces, ns.Equals) {
+				txNamespaces = append(txNamespaces, bytes.Clone(ns))
+			}
+		}
+		namespaces[fmt.Sprintf("%X", tx.Hash())] = txNamespaces
+	}
+	return namespaces, nil
+}
+
+// parseTxs collects all the transactions of the given compact share namespace from the ODS.
+func parseTxs(ods []share.Share, namespace share.Namespace) (types.Txs, error) {
+	var nsShares []shares.Share
+	for _, shr := range ods {
+		if !namespace.Equals(share.GetNamespace(shr)) {
+			continue
+		}
+		appShr, err := shares.NewShare(shr)
+		if err != nil {
+			return nil, err
+		}
+		nsShares = append(nsShares, *appShr)
+	}
+	if len(nsShares) == 0 {
+		return nil, nil
+	}
+
+	rawTxs, err := shares.ParseTxs(nsShares)
+	if err != nil {
+		return nil, err
+	}
+	txs := make(types.Txs, len(rawTxs))
+	for i, rawTx := range rawTxs {
+		txs[i] = rawTx
+	}
+	return txs, nil
+}
+
+// blobsRange computes the share range covering all the blobs starting at the given share indexes.
+func blobsRange(ods []share.Share, shareIndexes []uint32) (start, end int, err error) {
+	start = len(ods)
+	for _, idx := range shareIndexes {
+		if int(idx) >= len(ods) {
+			return 0, 0, fmt.Errorf("blob share index %d is out of the square bounds", idx)
+		}
+		shr, err := shares.NewShare(ods[idx])
+		if err != nil {
+			return 0, 0, err
+		}
+		seqLen, err := shr.SequenceLen()
+		if err != nil {
+			return 0, 0, fmt.Errorf("reading blob sequence length at share %d: %w", idx, err)
+		}
+		blobEnd := int(idx) + shares.SparseSharesNeeded(seqLen)
+		if blobEnd > len(ods) {
+			return 0, 0, fmt.Errorf("blob at share %d exceeds the square bounds", idx)
+		}
+		start = min(start, int(idx))
+		end = max(end, blobEnd)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,73 @@
+package eds
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/v2/pkg/da"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/celestiaorg/go-square/square"
+
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+)
+
+func TestBlobTxRange(t *testing.T) {
+	_, blobs, _, _, blobTxs, _, _ := edstest.GenerateTestBlock(t, 500, 5)
+	plainTx := coretypes.Tx("not a blob tx")
+
+	txs := append(coretypes.Txs{plainTx}, blobTxs...)
+	dataSquare, err := square.Construct(
+		txs.ToSliceOfBytes(),
+		appconsts.SquareSizeUpperBound(appconsts.LatestVersion),
+		appconsts.SubtreeRootThreshold(appconsts.LatestVersion),
+	)
+	require.NoError(t, err)
+	eds, err := da.ExtendShares(shares.ToBytes(dataSquare))
+	require.NoError(t, err)
+
+	t.Run("blob tx", func(t *testing.T) {
+		for i, tx := range blobTxs {
+			start, end, err := BlobTxRange(eds, tx.Hash())
+			require.NoError(t, err)
+
+			rngShares, err := shares.FromBytes(eds.FlattenedODS()[start:end])
+			require.NoError(t, err)
+			parsed, err := shares.ParseBlobs(rngShares)
+			require.NoError(t, err)
+			require.Len(t, parsed, 1)
+			require.Equal(t, blobs[i].Namespace(), parsed[0].Namespace())
+			require.Equal(t, blobs[i].Data, parsed[0].Data)
+		}
+	})
+
+	t.Run("not blob tx", func(t *testing.T) {
+		_, _, err := BlobTxRange(eds, plainTx.Hash())
+		require.ErrorIs(t, err, ErrNotBlobTx)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, _, err := BlobTxRange(eds, coretypes.Tx("missing tx").Hash())
+		require.ErrorIs(t, err, ErrTxNotFound)
+	})
+}
+
+func TestBlobTxNamespaces(t *testing.T) {
+	_, _, nss, eds, blobTxs, _, _ := edstest.GenerateTestBlock(t, 500, 5)
+
+	namespaces, err := BlobTxNamespaces(eds)
+	require.NoError(t, err)
+	require.Len(t, namespaces, len(blobTxs))
+	for i, tx := range blobTxs {
+		txNamespaces := namespaces[fmt.Sprintf("%X", tx.Hash())]
+		require.Len(t, txNamespaces, 1)
+		require.Equal(t, nss[i].Bytes(), []byte(txNamespaces[0]))
+	}
+
+	namespaces, err = BlobTxNamespaces(edstest.RandEDS(t, 4))
+	require.NoError(t, err)
+	require.Empty(t, namespaces)
+}
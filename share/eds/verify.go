@@ -0,0 +1,69 @@
+package eds
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// ErrRootsMismatch is returned by VerifyEDS when the EDS does not match the header.
+var ErrRootsMismatch = errors.New("eds roots mismatch")
+
+// VerifyEDS verifies the integrity of an externally supplied EDS against a trusted header.
+// It re-derives the row and column roots of all four quadrants from the shares, ignoring any
+// roots cached in the given square, and compares them to the header's DAH. On mismatch, the
+// returned error wraps ErrRootsMismatch and lists every mismatching row and column.
+func VerifyEDS(eds *rsmt2d.ExtendedDataSquare, header *header.ExtendedHeader) error {
+	if eds == nil || header == nil || header.DAH == nil {
+		return errors.New("nil eds or header")
+	}
+	if !bytes.Equal(header.DAH.Hash(), header.DataHash) {
+		return fmt.Errorf("%w: DAH does not match the data hash of header at height %d",
+			ErrRootsMismatch, header.Height())
+	}
+
+	width := int(eds.Width())
+	if width != len(header.DAH.RowRoots) || width != len(header.DAH.ColumnRoots) {
+		return fmt.Errorf("%w: eds width %d, header square width %d",
+			ErrRootsMismatch, width, len(header.DAH.RowRoots))
+	}
+
+	// import the shares again, so that roots are computed from scratch
+	treeFn := wrapper.NewConstructor(uint64(width / 2))
+	imported, err := rsmt2d.ImportExtendedDataSquare(eds.Flattened(), share.DefaultRSMT2DCodec(), treeFn)
+	if err != nil {
+		return fmt.Errorf("importing eds: %w", err)
+	}
+	rowRoots, err := imported.RowRoots()
+	if err != nil {
+		return fmt.Errorf("computing row roots: %w", err)
+	}
+	colRoots, err := imported.ColRoots()
+	if err != nil {
+		return fmt.Errorf("computing column roots: %w", err)
+	}
+
+	rows := mismatchingRoots(rowRoots, header.DAH.RowRoots)
+	cols := mismatchingRoots(colRoots, header.DAH.ColumnRoots)
+	if len(rows) == 0 && len(cols) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w at height %d: rows %v, columns %v", ErrRootsMismatch, header.Height(), rows, cols)
+}
+
+// mismatchingRoots returns the indexes of the roots that differ from the expected ones.
+func mismatchingRoots(roots, expected [][]byte) []int {
+	var idxs []int
+	for i := range roots {
+		if !bytes.Equal(roots[i], expected[i]) {
+			idxs = append(idxs, i)
+		}
+	}
+	return idxs
+}
@@ -0,0 +1,60 @@
+package eds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+)
+
+func TestVerifyEDS(t *testing.T) {
+	const odsSize = 8
+	eds := edstest.RandEDS(t, odsSize)
+	roots, err := share.NewAxisRoots(eds)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, VerifyEDS(eds, eh))
+	})
+
+	t.Run("other square", func(t *testing.T) {
+		err := VerifyEDS(edstest.RandEDS(t, odsSize), eh)
+		require.ErrorIs(t, err, ErrRootsMismatch)
+	})
+
+	t.Run("other width", func(t *testing.T) {
+		err := VerifyEDS(edstest.RandEDS(t, odsSize/2), eh)
+		require.ErrorIs(t, err, ErrRootsMismatch)
+	})
+
+	t.Run("corrupted share", func(t *testing.T) {
+		shares := eds.Flattened()
+		corruptedShare := make(share.Share, share.Size)
+		copy(corruptedShare, shares[0])
+		corruptedShare[share.Size-1] ^= 0xFF
+		shares[0] = corruptedShare
+		corrupted, err := rsmt2d.ImportExtendedDataSquare(
+			shares,
+			share.DefaultRSMT2DCodec(),
+			wrapper.NewConstructor(odsSize),
+		)
+		require.NoError(t, err)
+
+		err = VerifyEDS(corrupted, eh)
+		require.ErrorIs(t, err, ErrRootsMismatch)
+		require.ErrorContains(t, err, "rows [0], columns [0]")
+	})
+
+	t.Run("tampered header", func(t *testing.T) {
+		tampered := headertest.RandExtendedHeaderWithRoot(t, roots)
+		tampered.DataHash = []byte("tampered")
+		require.ErrorIs(t, VerifyEDS(eds, tampered), ErrRootsMismatch)
+	})
+}
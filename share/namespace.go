@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 
 	appns "github.com/celestiaorg/go-square/namespace"
 	"github.com/celestiaorg/nmt/namespace"
@@ -58,6 +59,16 @@ func NamespaceFromBytes(b []byte) (Namespace, error) {
 	return n, n.Validate()
 }
 
+// NamespaceFromString decodes the canonical hex representation produced by Namespace.String
+// and validates the result. An optional "0x" prefix is accepted.
+func NamespaceFromString(s string) (Namespace, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decoding namespace hex: %w", err)
+	}
+	return NamespaceFromBytes(b)
+}
+
 // Version reports version of the Namespace.
 func (n Namespace) Version() byte {
 	return n[appns.NamespaceVersionSize-1]
@@ -86,7 +97,8 @@ func (n Namespace) Len() int {
 	return len(n)
 }
 
-// String stringifies the Namespace.
+// String stringifies the Namespace into its canonical lowercase hex representation.
+// It can be parsed back with NamespaceFromString.
 func (n Namespace) String() string {
 	return hex.EncodeToString(n)
 }
@@ -216,3 +216,59 @@ func secondaryReservedNamespace(lastByte byte) Namespace {
 	result = append(result, lastByte)
 	return result
 }
+
+func TestNamespaceFromString(t *testing.T) {
+	validNamespace, err := NewBlobNamespaceV0(bytes.Repeat([]byte{0x1}, appns.NamespaceVersionZeroIDSize))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		str     string
+		want    Namespace
+		wantErr bool
+	}{
+		{
+			name: "round trip blob namespace",
+			str:  validNamespace.String(),
+			want: validNamespace,
+		},
+		{
+			name: "round trip parity namespace",
+			str:  ParitySharesNamespace.String(),
+			want: ParitySharesNamespace,
+		},
+		{
+			name: "0x prefix",
+			str:  "0x" + validNamespace.String(),
+			want: validNamespace,
+		},
+		{
+			name:    "invalid hex",
+			str:     "zz",
+			wantErr: true,
+		},
+		{
+			name:    "invalid length",
+			str:     validNamespace[:NamespaceSize-1].String(),
+			wantErr: true,
+		},
+		{
+			name:    "unsupported version",
+			str:     append(Namespace{1}, validNamespace[1:]...).String(),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NamespaceFromString(tc.str)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.want.String(), got.String())
+		})
+	}
+}
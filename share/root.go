@@ -58,8 +58,10 @@ func NewAxisRoots(eds *rsmt2d.ExtendedDataSquare) (*AxisRoots, error) {
 
 // RowsWithNamespace inspects the AxisRoots for the Namespace and provides
 // a slices of Row indexes containing the namespace.
+// Only rows of the original data square are inspected, as the extended rows
+// commit to parity shares only and namespace data is never proven against them.
 func RowsWithNamespace(root *AxisRoots, namespace Namespace) (idxs []int) {
-	for i, row := range root.RowRoots {
+	for i, row := range root.RowRoots[:len(root.RowRoots)/2] {
 		if !namespace.IsOutsideRange(row, row) {
 			idxs = append(idxs, i)
 		}
@@ -67,6 +69,18 @@ func RowsWithNamespace(root *AxisRoots, namespace Namespace) (idxs []int) {
 	return
 }
 
+// ColumnsWithNamespace inspects the AxisRoots for the Namespace and provides
+// a slices of Column indexes containing the namespace.
+// Same as with rows, only columns of the original data square are inspected.
+func ColumnsWithNamespace(root *AxisRoots, namespace Namespace) (idxs []int) {
+	for i, col := range root.ColumnRoots[:len(root.ColumnRoots)/2] {
+		if !namespace.IsOutsideRange(col, col) {
+			idxs = append(idxs, i)
+		}
+	}
+	return
+}
+
 // RootHashForCoordinates returns the root hash for the given coordinates.
 func RootHashForCoordinates(r *AxisRoots, axisType rsmt2d.Axis, rowIdx, colIdx uint) []byte {
 	if axisType == rsmt2d.Row {
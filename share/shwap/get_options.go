@@ -0,0 +1,150 @@
+package shwap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// GetOptions is the set of per-request options understood by Getter implementations.
+// Options are carried through the context, so they pass unchanged through Getter wrappers
+// like the cascade and are ignored by the implementations they do not apply to.
+type GetOptions struct {
+	// SkipRootVerification skips re-deriving the roots of data read from the local store and
+	// comparing them against the header. It never applies to data fetched from the network.
+	SkipRootVerification bool
+	// LocalOnly restricts retrieval to the local store. Getters reaching out to the network fail
+	// with ErrNotFound instead.
+	LocalOnly bool
+	// Priority is the scheduling priority of network retrieval.
+	Priority Priority
+	// RetryPolicy overrides the retry policy of network retrieval configured for the node.
+	RetryPolicy *RetryPolicy
+	// OnShare is called by GetShares with every share as soon as it is retrieved, along with its
+	// index in the request, so that callers can tell which of the shares a failed request did
+	// retrieve. Getters not reporting the shares leave them all to be considered missing.
+	OnShare func(idx int, shr share.Share)
+}
+
+// RetryPolicy configures retries of failed network retrieval. Every attempt gets an equal share
+// of the time left to the request, and the delay before every next attempt doubles.
+type RetryPolicy struct {
+	// Attempts is the maximum amount of attempts. One disables retries.
+	Attempts int
+	// BackoffBase is the delay before the first retry.
+	BackoffBase time.Duration
+	// Jitter is the fraction by which the delays are randomized, between 0 and 1, so that
+	// requests failed at once don't retry at once.
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns the default RetryPolicy, which doesn't retry.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		Attempts:    1,
+		BackoffBase: 100 * time.Millisecond,
+		Jitter:      0.2,
+	}
+}
+
+// Validate validates the values in RetryPolicy.
+func (p *RetryPolicy) Validate() error {
+	if p.Attempts <= 0 {
+		return fmt.Errorf("invalid attempts: %d, value should be positive", p.Attempts)
+	}
+	if p.BackoffBase < 0 {
+		return fmt.Errorf("invalid backoff base: %v, value should be non-negative", p.BackoffBase)
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("invalid jitter: %v, value should be between 0 and 1", p.Jitter)
+	}
+	return nil
+}
+
+// Priority is the scheduling priority of a request competing with others for network retrieval.
+type Priority int8
+
+const (
+	// PriorityLow is meant for bulk requests like backfilling, which may only use a part of the
+	// network retrieval capacity and yield to other requests.
+	PriorityLow Priority = -1
+	// PriorityNormal is the default priority.
+	PriorityNormal Priority = 0
+	// PriorityHigh is meant for latency sensitive requests like the ones at the chain tip, which
+	// are never queued behind other requests.
+	PriorityHigh Priority = 1
+)
+
+// String returns the name of the priority.
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityHigh:
+		return "high"
+	default:
+		return "normal"
+	}
+}
+
+// GetOption configures GetOptions of a single request.
+type GetOption func(*GetOptions)
+
+// WithSkipRootVerification makes local store reads trust the validation performed when the
+// data was stored instead of verifying the roots again. Network retrieval always verifies.
+func WithSkipRootVerification() GetOption {
+	return func(opts *GetOptions) {
+		opts.SkipRootVerification = true
+	}
+}
+
+// WithLocalOnly restricts the request to the data available in the local store, so that data
+// missing locally is reported as ErrNotFound instead of being fetched from the network.
+func WithLocalOnly() GetOption {
+	return func(opts *GetOptions) {
+		opts.LocalOnly = true
+	}
+}
+
+// WithPriority sets the scheduling priority of network retrieval of the request.
+func WithPriority(priority Priority) GetOption {
+	return func(opts *GetOptions) {
+		opts.Priority = priority
+	}
+}
+
+// WithRetryPolicy overrides the retry policy of network retrieval of the request, e.g. to fail
+// fast on latency sensitive requests or to retry aggressively on unreliable networks.
+func WithRetryPolicy(policy RetryPolicy) GetOption {
+	return func(opts *GetOptions) {
+		opts.RetryPolicy = &policy
+	}
+}
+
+// WithOnShare sets the function GetShares reports the retrieved shares to. It may be called
+// concurrently.
+func WithOnShare(onShare func(idx int, shr share.Share)) GetOption {
+	return func(opts *GetOptions) {
+		opts.OnShare = onShare
+	}
+}
+
+type getOptionsKey struct{}
+
+// WithGetOptions returns a copy of the context carrying the given GetOptions on top of the ones
+// already set in the context.
+func WithGetOptions(ctx context.Context, opts ...GetOption) context.Context {
+	getOpts := GetOptionsFromContext(ctx)
+	for _, opt := range opts {
+		opt(&getOpts)
+	}
+	return context.WithValue(ctx, getOptionsKey{}, getOpts)
+}
+
+// GetOptionsFromContext returns the GetOptions carried by the context.
+func GetOptionsFromContext(ctx context.Context) GetOptions {
+	opts, _ := ctx.Value(getOptionsKey{}).(GetOptions)
+	return opts
+}
@@ -32,6 +32,11 @@ type Getter interface {
 	// GetShare gets a Share by coordinates in EDS.
 	GetShare(ctx context.Context, header *header.ExtendedHeader, row, col int) (share.Share, error)
 
+	// GetShares gets Shares by their coordinates in EDS, given as row and column indices of the same
+	// length, at once. Network implementations request all the shares in a single batch, saving
+	// the round trips of requesting them one by one.
+	GetShares(ctx context.Context, header *header.ExtendedHeader, rowIdxs, colIdxs []int) ([]share.Share, error)
+
 	// GetEDS gets the full EDS identified by the given extended header.
 	GetEDS(context.Context, *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error)
 
@@ -0,0 +1,252 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+// ErrEDSUnavailable is returned by BreakerGetter when network retrieval is suspended by the
+// CircuitBreaker.
+var ErrEDSUnavailable = errors.New("eds unavailable: network retrieval is suspended")
+
+var _ shwap.Getter = (*BreakerGetter)(nil)
+
+// BreakerParameters is the set of parameters that configures the CircuitBreaker.
+type BreakerParameters struct {
+	// FailureThreshold is the amount of consecutive network retrieval failures after which
+	// network retrieval gets suspended. Zero disables the breaker.
+	FailureThreshold int
+	// Cooldown is the period of time network retrieval stays suspended before a single probe
+	// request is let through to check for recovery.
+	Cooldown time.Duration
+}
+
+// DefaultBreakerParameters returns the default configuration values for the CircuitBreaker.
+func DefaultBreakerParameters() *BreakerParameters {
+	return &BreakerParameters{
+		FailureThreshold: 10,
+		Cooldown:         30 * time.Second,
+	}
+}
+
+// Validate validates the values in BreakerParameters.
+func (p *BreakerParameters) Validate() error {
+	if p.FailureThreshold < 0 {
+		return fmt.Errorf("invalid failure threshold: %d, value should be non-negative", p.FailureThreshold)
+	}
+	if p.FailureThreshold > 0 && p.Cooldown <= 0 {
+		return fmt.Errorf("invalid cooldown: %v, value should be positive and non-zero", p.Cooldown)
+	}
+	return nil
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// CircuitBreaker tracks consecutive network retrieval failures. Once FailureThreshold is
+// reached, it opens and rejects all requests for the Cooldown period. After the Cooldown, it
+// half-opens and lets a single probe request through: success closes the breaker, while failure
+// opens it for another Cooldown. CircuitBreaker is safe for concurrent use and is meant to be
+// shared across all network getters.
+//
+// Every state transition starts a new generation. Results are only accounted for requests
+// admitted within the current generation, so that requests started before the breaker
+// opened can neither extend the Cooldown nor close the breaker without a probe.
+type CircuitBreaker struct {
+	params BreakerParameters
+
+	lock       sync.Mutex
+	state      breakerState
+	generation uint64
+	failures   int
+	openedAt   time.Time
+	now        func() time.Time
+}
+
+// NewCircuitBreaker creates a new CircuitBreaker with the given parameters.
+func NewCircuitBreaker(params BreakerParameters) *CircuitBreaker {
+	return &CircuitBreaker{
+		params: params,
+		now:    time.Now,
+	}
+}
+
+// allow reports whether a request should be let through. The returned generation must be
+// passed to done together with the result of the request.
+func (cb *CircuitBreaker) allow() (uint64, bool) {
+	if cb.params.FailureThreshold == 0 {
+		return 0, true
+	}
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	switch cb.state {
+	case breakerOpen:
+		if cb.now().Sub(cb.openedAt) < cb.params.Cooldown {
+			return 0, false
+		}
+		// let a single probe through
+		cb.transition(breakerHalfOpen)
+		return cb.generation, true
+	case breakerHalfOpen:
+		// the probe is in flight
+		return 0, false
+	default:
+		return cb.generation, true
+	}
+}
+
+// done records the result of a request previously admitted by allow within the given generation.
+func (cb *CircuitBreaker) done(generation uint64, err error) {
+	if cb.params.FailureThreshold == 0 {
+		return
+	}
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	if generation != cb.generation {
+		// the request was admitted under a different state and is not representative anymore
+		return
+	}
+
+	switch {
+	case err == nil:
+		if cb.state == breakerHalfOpen {
+			log.Info("resuming network retrieval")
+			cb.transition(breakerClosed)
+		}
+		cb.failures = 0
+	case !isNetworkFailure(err):
+		if cb.state == breakerHalfOpen {
+			// the probe tells nothing about the network, so let the next request probe again
+			cb.transition(breakerOpen)
+		}
+	default:
+		cb.failures++
+		if cb.state == breakerHalfOpen || cb.failures >= cb.params.FailureThreshold {
+			log.Warnw("suspending network retrieval",
+				"consecutive_failures", cb.failures,
+				"cooldown", cb.params.Cooldown,
+			)
+			cb.transition(breakerOpen)
+			cb.openedAt = cb.now()
+		}
+	}
+}
+
+// transition moves the breaker into the given state starting a new generation.
+func (cb *CircuitBreaker) transition(state breakerState) {
+	cb.state = state
+	cb.generation++
+	if state == breakerClosed {
+		cb.failures = 0
+	}
+}
+
+// isNetworkFailure reports whether the error should be accounted as a failure of the network.
+func isNetworkFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	// errors caused by the caller, like its deadline, are not failures
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	// neither are the errors that prove the network is functional, like peers answering they
+	// don't have the data
+	if errors.Is(err, shwap.ErrNotFound) || errors.Is(err, shwap.ErrOperationNotSupported) {
+		return false
+	}
+	var byzantineErr *byzantine.ErrByzantine
+	return !errors.As(err, &byzantineErr)
+}
+
+// BreakerGetter wraps a network shwap.Getter with the CircuitBreaker. While the breaker is open,
+// it fails immediately with ErrEDSUnavailable instead of attempting network retrieval.
+type BreakerGetter struct {
+	getter  shwap.Getter
+	breaker *CircuitBreaker
+}
+
+// NewBreakerGetter wraps the given network getter with the given CircuitBreaker.
+func NewBreakerGetter(getter shwap.Getter, breaker *CircuitBreaker) *BreakerGetter {
+	return &BreakerGetter{
+		getter:  getter,
+		breaker: breaker,
+	}
+}
+
+// GetShare gets a share from the wrapped getter unless network retrieval is suspended.
+func (bg *BreakerGetter) GetShare(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	row, col int,
+) (share.Share, error) {
+	generation, ok := bg.breaker.allow()
+	if !ok {
+		return nil, ErrEDSUnavailable
+	}
+	shr, err := bg.getter.GetShare(ctx, header, row, col)
+	bg.breaker.done(generation, err)
+	return shr, err
+}
+
+// GetShares gets the shares from the wrapped getter unless network retrieval is suspended.
+func (bg *BreakerGetter) GetShares(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	generation, ok := bg.breaker.allow()
+	if !ok {
+		return nil, ErrEDSUnavailable
+	}
+	shares, err := bg.getter.GetShares(ctx, header, rowIdxs, colIdxs)
+	bg.breaker.done(generation, err)
+	return shares, err
+}
+
+// GetEDS gets a full EDS from the wrapped getter unless network retrieval is suspended.
+func (bg *BreakerGetter) GetEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	generation, ok := bg.breaker.allow()
+	if !ok {
+		return nil, ErrEDSUnavailable
+	}
+	eds, err := bg.getter.GetEDS(ctx, header)
+	bg.breaker.done(generation, err)
+	return eds, err
+}
+
+// GetSharesByNamespace gets NamespaceData from the wrapped getter unless network retrieval is
+// suspended.
+func (bg *BreakerGetter) GetSharesByNamespace(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (shwap.NamespaceData, error) {
+	generation, ok := bg.breaker.allow()
+	if !ok {
+		return nil, ErrEDSUnavailable
+	}
+	nd, err := bg.getter.GetSharesByNamespace(ctx, header, namespace)
+	bg.breaker.done(generation, err)
+	return nd, err
+}
@@ -0,0 +1,143 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestBreakerGetter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	netErr := errors.New("network failure")
+	failing := true
+	getter := mock.NewMockGetter(ctrl)
+	calls := 0
+	getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
+			calls++
+			if failing {
+				return nil, netErr
+			}
+			return nil, nil
+		}).AnyTimes()
+
+	const threshold = 3
+	now := time.Now()
+	breaker := NewCircuitBreaker(BreakerParameters{FailureThreshold: threshold, Cooldown: time.Minute})
+	breaker.now = func() time.Time { return now }
+	bg := NewBreakerGetter(getter, breaker)
+
+	// consecutive failures up to the threshold reach the network
+	for i := 0; i < threshold; i++ {
+		_, err := bg.GetEDS(ctx, nil)
+		require.ErrorIs(t, err, netErr)
+	}
+	require.Equal(t, threshold, calls)
+
+	// breaker is open, so the network is not touched
+	_, err := bg.GetEDS(ctx, nil)
+	require.ErrorIs(t, err, ErrEDSUnavailable)
+	require.Equal(t, threshold, calls)
+
+	// after the cooldown a failed probe opens the breaker again
+	now = now.Add(time.Minute)
+	_, err = bg.GetEDS(ctx, nil)
+	require.ErrorIs(t, err, netErr)
+	_, err = bg.GetEDS(ctx, nil)
+	require.ErrorIs(t, err, ErrEDSUnavailable)
+	require.Equal(t, threshold+1, calls)
+
+	// after the cooldown a successful probe closes the breaker
+	failing = false
+	now = now.Add(time.Minute)
+	_, err = bg.GetEDS(ctx, nil)
+	require.NoError(t, err)
+	_, err = bg.GetEDS(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, threshold+3, calls)
+}
+
+func TestBreakerGetter_IgnoresNonNetworkErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "canceled", err: context.Canceled},
+		{name: "deadline exceeded", err: context.DeadlineExceeded},
+		{name: "not found", err: shwap.ErrNotFound},
+		{name: "wrapped not found", err: fmt.Errorf("getting eds: %w", shwap.ErrNotFound)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			getter := mock.NewMockGetter(ctrl)
+			getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+				Return(nil, tt.err).Times(3)
+
+			breaker := NewCircuitBreaker(BreakerParameters{FailureThreshold: 1, Cooldown: time.Minute})
+			bg := NewBreakerGetter(getter, breaker)
+			for i := 0; i < 3; i++ {
+				_, err := bg.GetEDS(ctx, nil)
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker_IgnoresStaleResults(t *testing.T) {
+	netErr := errors.New("network failure")
+	now := time.Now()
+	breaker := NewCircuitBreaker(BreakerParameters{FailureThreshold: 1, Cooldown: time.Minute})
+	breaker.now = func() time.Time { return now }
+
+	// three requests are admitted while the breaker is closed
+	first, ok := breaker.allow()
+	require.True(t, ok)
+	second, ok := breaker.allow()
+	require.True(t, ok)
+	third, ok := breaker.allow()
+	require.True(t, ok)
+
+	// the first one fails and opens the breaker
+	breaker.done(first, netErr)
+	_, ok = breaker.allow()
+	require.False(t, ok)
+
+	// late failure must not extend the cooldown
+	now = now.Add(time.Minute / 2)
+	breaker.done(second, netErr)
+	// late success must not close the breaker without a probe
+	breaker.done(third, nil)
+	_, ok = breaker.allow()
+	require.False(t, ok)
+
+	// cooldown counts from the moment the breaker opened
+	now = now.Add(time.Minute / 2)
+	probe, ok := breaker.allow()
+	require.True(t, ok)
+	// stale results are ignored while the probe is in flight as well
+	breaker.done(second, nil)
+	_, ok = breaker.allow()
+	require.False(t, ok)
+
+	breaker.done(probe, nil)
+	_, ok = breaker.allow()
+	require.True(t, ok)
+}
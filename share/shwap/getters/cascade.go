@@ -3,6 +3,7 @@ package getters
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	logging "github.com/ipfs/go-log/v2"
@@ -64,6 +65,34 @@ func (cg *CascadeGetter) GetShare(
 	return cascadeGetters(ctx, cg.getters, get)
 }
 
+func (cg *CascadeGetter) GetShares(
+	ctx context.Context, header *header.ExtendedHeader, rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	ctx, span := tracer.Start(ctx, "cascade/get-shares", trace.WithAttributes(
+		attribute.Int("amount", len(rowIdxs)),
+	))
+	defer span.End()
+
+	if len(rowIdxs) != len(colIdxs) {
+		err := fmt.Errorf("row and column indices must be of the same length: %w", shwap.ErrInvalidID)
+		span.RecordError(err)
+		return nil, err
+	}
+	upperBound := len(header.DAH.RowRoots)
+	for i := range rowIdxs {
+		if rowIdxs[i] >= upperBound || colIdxs[i] >= upperBound {
+			err := shwap.ErrOutOfBounds
+			span.RecordError(err)
+			return nil, err
+		}
+	}
+	get := func(ctx context.Context, get shwap.Getter) ([]share.Share, error) {
+		return get.GetShares(ctx, header, rowIdxs, colIdxs)
+	}
+
+	return cascadeGetters(ctx, cg.getters, get)
+}
+
 // GetEDS gets a full EDS from any of registered shwap.Getters in cascading order.
 func (cg *CascadeGetter) GetEDS(
 	ctx context.Context, header *header.ExtendedHeader,
@@ -0,0 +1,99 @@
+package getters
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/semaphore"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+var _ shwap.Getter = (*MemoryLimitGetter)(nil)
+
+// MemoryParameters is the set of parameters that configures the MemoryLimitGetter.
+type MemoryParameters struct {
+	// EDSBudgetMB is the total amount of memory in megabytes that concurrent GetEDS requests may
+	// use to materialize EDSes. Zero disables the limit.
+	EDSBudgetMB uint64
+}
+
+// DefaultMemoryParameters returns the default configuration values for the MemoryLimitGetter.
+// The default budget fits 32 concurrent EDSes of the maximum square size.
+func DefaultMemoryParameters() *MemoryParameters {
+	return &MemoryParameters{
+		EDSBudgetMB: 1024,
+	}
+}
+
+// MemoryLimitGetter wraps a shwap.Getter bounding the total memory of the EDSes materialized by
+// concurrent GetEDS requests. Each request acquires a part of the budget proportional to the
+// size of the square before reaching the wrapped getter and releases it once the getter returns,
+// blocking until enough of the budget is free or the context is done. Squares exceeding the whole
+// budget acquire all of it. Other requests are passed through as is.
+type MemoryLimitGetter struct {
+	getter shwap.Getter
+	budget int64
+	sem    *semaphore.Weighted
+}
+
+// NewMemoryLimitGetter wraps the given getter with the memory budget from the given parameters.
+func NewMemoryLimitGetter(getter shwap.Getter, params MemoryParameters) *MemoryLimitGetter {
+	budget := int64(params.EDSBudgetMB) << 20
+	mg := &MemoryLimitGetter{
+		getter: getter,
+		budget: budget,
+	}
+	if budget > 0 {
+		mg.sem = semaphore.NewWeighted(budget)
+	}
+	return mg
+}
+
+// GetShare gets a share from the wrapped getter.
+func (mg *MemoryLimitGetter) GetShare(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	row, col int,
+) (share.Share, error) {
+	return mg.getter.GetShare(ctx, header, row, col)
+}
+
+func (mg *MemoryLimitGetter) GetShares(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	return mg.getter.GetShares(ctx, header, rowIdxs, colIdxs)
+}
+
+// GetEDS gets the EDS from the wrapped getter once the memory for it is available.
+func (mg *MemoryLimitGetter) GetEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	if mg.sem == nil {
+		return mg.getter.GetEDS(ctx, header)
+	}
+
+	width := int64(len(header.DAH.RowRoots))
+	weight := min(width*width*share.Size, mg.budget)
+	if err := mg.sem.Acquire(ctx, weight); err != nil {
+		return nil, fmt.Errorf("waiting for eds memory budget: %w", err)
+	}
+	defer mg.sem.Release(weight)
+	return mg.getter.GetEDS(ctx, header)
+}
+
+// GetSharesByNamespace gets the namespace data from the wrapped getter.
+func (mg *MemoryLimitGetter) GetSharesByNamespace(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (shwap.NamespaceData, error) {
+	return mg.getter.GetSharesByNamespace(ctx, header, namespace)
+}
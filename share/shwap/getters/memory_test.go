@@ -0,0 +1,86 @@
+package getters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestMemoryLimitGetter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	// 64x64 EDS takes exactly 2MB
+	const odsSize = 32
+	eds := edstest.RandEDS(t, odsSize)
+	roots, err := share.NewAxisRoots(eds)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetEDS(gomock.Any(), eh).
+		DoAndReturn(func(context.Context, *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
+			started <- struct{}{}
+			<-release
+			return eds, nil
+		}).Times(2)
+
+	mg := NewMemoryLimitGetter(getter, MemoryParameters{EDSBudgetMB: 3})
+
+	// the first request holds the budget until released
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := mg.GetEDS(ctx, eh)
+		errCh <- err
+	}()
+	<-started
+
+	// the second request doesn't fit into the remaining budget and times out
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer timeoutCancel()
+	_, err = mg.GetEDS(timeoutCtx, eh)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// once the first request completes, the budget is available again
+	close(release)
+	require.NoError(t, <-errCh)
+	go func() { <-started }()
+	got, err := mg.GetEDS(ctx, eh)
+	require.NoError(t, err)
+	require.Equal(t, eds, got)
+}
+
+func TestMemoryLimitGetter_OversizedSquare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	const odsSize = 32
+	eds := edstest.RandEDS(t, odsSize)
+	roots, err := share.NewAxisRoots(eds)
+	require.NoError(t, err)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetEDS(gomock.Any(), eh).Return(eds, nil)
+
+	// a square larger than the whole budget still gets served
+	mg := NewMemoryLimitGetter(getter, MemoryParameters{EDSBudgetMB: 1})
+	got, err := mg.GetEDS(ctx, eh)
+	require.NoError(t, err)
+	require.Equal(t, eds, got)
+}
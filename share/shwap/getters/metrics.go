@@ -0,0 +1,190 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+var meter = otel.Meter("share/getters")
+
+const (
+	sourceKey = "source"
+	methodKey = "method"
+	statusKey = "status"
+
+	methodGetShare             = "get_share"
+	methodGetShares            = "get_shares"
+	methodGetEDS               = "get_eds"
+	methodGetSharesByNamespace = "get_shares_by_namespace"
+
+	statusSuccess  = "success"
+	statusNotFound = "not_found"
+	statusFailed   = "failed"
+)
+
+var _ shwap.Getter = (*MetricsGetter)(nil)
+
+// SourceMetrics records the latency and the amount of retrieved bytes of every request served by
+// the MetricsGetters it is shared with, labeled by the source of the data. It records nothing
+// until metrics are enabled with WithMetrics.
+type SourceMetrics struct {
+	metrics *sourceMetrics
+}
+
+type sourceMetrics struct {
+	latency metric.Float64Histogram
+	bytes   metric.Int64Counter
+}
+
+// NewSourceMetrics creates SourceMetrics with metrics disabled.
+func NewSourceMetrics() *SourceMetrics {
+	return &SourceMetrics{}
+}
+
+// WithMetrics turns on metric collection.
+func (sm *SourceMetrics) WithMetrics() error {
+	return sm.register(meter)
+}
+
+func (sm *SourceMetrics) register(meter metric.Meter) error {
+	latency, err := meter.Float64Histogram(
+		"getters_source_request_duration",
+		metric.WithDescription("Duration of share retrieval requests by source"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	bytes, err := meter.Int64Counter(
+		"getters_source_retrieved_bytes",
+		metric.WithDescription("Amount of share bytes retrieved by source"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return err
+	}
+
+	sm.metrics = &sourceMetrics{
+		latency: latency,
+		bytes:   bytes,
+	}
+	return nil
+}
+
+func (sm *SourceMetrics) observe(
+	ctx context.Context,
+	source, method string,
+	start time.Time,
+	size int,
+	err error,
+) {
+	if sm == nil || sm.metrics == nil {
+		return
+	}
+	ctx = utils.ResetContextOnError(ctx)
+
+	status := statusSuccess
+	switch {
+	case errors.Is(err, shwap.ErrNotFound):
+		status = statusNotFound
+	case err != nil:
+		status = statusFailed
+	}
+	sm.metrics.latency.Record(ctx, time.Since(start).Seconds(),
+		metric.WithAttributes(
+			attribute.String(sourceKey, source),
+			attribute.String(methodKey, method),
+			attribute.String(statusKey, status),
+		))
+	if size > 0 {
+		sm.metrics.bytes.Add(ctx, int64(size),
+			metric.WithAttributes(
+				attribute.String(sourceKey, source),
+				attribute.String(methodKey, method),
+			))
+	}
+}
+
+// MetricsGetter wraps a shwap.Getter recording the latency and the size of its results to the
+// SourceMetrics under the name of its source.
+type MetricsGetter struct {
+	getter  shwap.Getter
+	source  string
+	metrics *SourceMetrics
+}
+
+// NewMetricsGetter wraps the given getter of the given source with the given metrics.
+func NewMetricsGetter(getter shwap.Getter, source string, metrics *SourceMetrics) *MetricsGetter {
+	return &MetricsGetter{
+		getter:  getter,
+		source:  source,
+		metrics: metrics,
+	}
+}
+
+// GetShare gets a share from the wrapped getter.
+func (mg *MetricsGetter) GetShare(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	row, col int,
+) (share.Share, error) {
+	start := time.Now()
+	sh, err := mg.getter.GetShare(ctx, header, row, col)
+	mg.metrics.observe(ctx, mg.source, methodGetShare, start, len(sh), err)
+	return sh, err
+}
+
+func (mg *MetricsGetter) GetShares(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	start := time.Now()
+	shares, err := mg.getter.GetShares(ctx, header, rowIdxs, colIdxs)
+	mg.metrics.observe(ctx, mg.source, methodGetShares, start, len(shares)*share.Size, err)
+	return shares, err
+}
+
+// GetEDS gets the EDS from the wrapped getter.
+func (mg *MetricsGetter) GetEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	start := time.Now()
+	eds, err := mg.getter.GetEDS(ctx, header)
+	var size int
+	if eds != nil {
+		size = int(eds.Width()*eds.Width()) * share.Size
+	}
+	mg.metrics.observe(ctx, mg.source, methodGetEDS, start, size, err)
+	return eds, err
+}
+
+// GetSharesByNamespace gets NamespaceData from the wrapped getter.
+func (mg *MetricsGetter) GetSharesByNamespace(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (shwap.NamespaceData, error) {
+	start := time.Now()
+	nd, err := mg.getter.GetSharesByNamespace(ctx, header, namespace)
+	var size int
+	for _, row := range nd {
+		size += len(row.Shares) * share.Size
+	}
+	mg.metrics.observe(ctx, mg.source, methodGetSharesByNamespace, start, size, err)
+	return nd, err
+}
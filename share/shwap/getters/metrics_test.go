@@ -0,0 +1,75 @@
+package getters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestMetricsGetter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	reader := sdkmetric.NewManualReader()
+	metrics := NewSourceMetrics()
+	err := metrics.register(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	require.NoError(t, err)
+
+	eh := headertest.RandExtendedHeader(t)
+	ns := sharetest.RandV0Namespace()
+	nd := shwap.NamespaceData{{Shares: sharetest.RandShares(t, 2)}}
+
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), eh, ns).Return(nd, nil).Times(1)
+	getter.EXPECT().GetShare(gomock.Any(), eh, 0, 0).Return(nil, shwap.ErrNotFound).Times(1)
+
+	mg := NewMetricsGetter(getter, "store", metrics)
+	got, err := mg.GetSharesByNamespace(ctx, eh, ns)
+	require.NoError(t, err)
+	require.Equal(t, nd, got)
+	_, err = mg.GetShare(ctx, eh, 0, 0)
+	require.ErrorIs(t, err, shwap.ErrNotFound)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Histogram[float64]:
+			// both requests are observed, labeled by their status
+			require.Len(t, data.DataPoints, 2)
+		case metricdata.Sum[int64]:
+			// only the found shares are counted
+			require.Len(t, data.DataPoints, 1)
+			require.EqualValues(t, 2*share.Size, data.DataPoints[0].Value)
+		default:
+			t.Fatalf("unexpected metric: %s", m.Name)
+		}
+	}
+}
+
+func TestMetricsGetter_Disabled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	eh := headertest.RandExtendedHeader(t)
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetShare(gomock.Any(), eh, 0, 0).Return(share.Share{1}, nil).Times(1)
+
+	// metrics are not turned on, so nothing is recorded
+	mg := NewMetricsGetter(getter, "store", NewSourceMetrics())
+	_, err := mg.GetShare(ctx, eh, 0, 0)
+	require.NoError(t, err)
+}
@@ -68,6 +68,21 @@ func (mr *MockGetterMockRecorder) GetShare(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShare", reflect.TypeOf((*MockGetter)(nil).GetShare), arg0, arg1, arg2, arg3)
 }
 
+// GetShares mocks base method.
+func (m *MockGetter) GetShares(arg0 context.Context, arg1 *header.ExtendedHeader, arg2, arg3 []int) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShares", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShares indicates an expected call of GetShares.
+func (mr *MockGetterMockRecorder) GetShares(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShares", reflect.TypeOf((*MockGetter)(nil).GetShares), arg0, arg1, arg2, arg3)
+}
+
 // GetSharesByNamespace mocks base method.
 func (m *MockGetter) GetSharesByNamespace(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 share.Namespace) (shwap.NamespaceData, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,122 @@
+package getters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+var _ shwap.Getter = (*NamespaceCacheGetter)(nil)
+
+// NamespaceCacheParameters is the set of parameters that configures the NamespaceCacheGetter.
+type NamespaceCacheParameters struct {
+	// Size is the maximum amount of cached (height, namespace) results. Zero disables the cache.
+	Size int
+	// TTL is the period of time a result stays cached.
+	TTL time.Duration
+}
+
+// DefaultNamespaceCacheParameters returns the default configuration values for the
+// NamespaceCacheGetter.
+func DefaultNamespaceCacheParameters() *NamespaceCacheParameters {
+	return &NamespaceCacheParameters{
+		Size: 1024,
+		TTL:  time.Minute,
+	}
+}
+
+// Validate validates the values in NamespaceCacheParameters.
+func (p *NamespaceCacheParameters) Validate() error {
+	if p.Size < 0 {
+		return fmt.Errorf("invalid size: %d, value should be non-negative", p.Size)
+	}
+	if p.Size > 0 && p.TTL <= 0 {
+		return fmt.Errorf("invalid ttl: %v, value should be positive and non-zero", p.TTL)
+	}
+	return nil
+}
+
+type namespaceCacheKey struct {
+	height    uint64
+	namespace string
+}
+
+// NamespaceCacheGetter wraps a shwap.Getter caching the namespace data it returns by height and
+// namespace, so that hot namespaces requested over and over again for the same block are
+// retrieved and proven only once. Concurrent requests for the same uncached data are coalesced
+// into one. Cached data is shared across callers and must not be modified.
+type NamespaceCacheGetter struct {
+	getter   shwap.Getter
+	cache    *expirable.LRU[namespaceCacheKey, shwap.NamespaceData]
+	inflight singleflight.Group
+}
+
+// NewNamespaceCacheGetter wraps the given getter with a namespace data cache configured by the
+// given parameters.
+func NewNamespaceCacheGetter(getter shwap.Getter, params NamespaceCacheParameters) *NamespaceCacheGetter {
+	return &NamespaceCacheGetter{
+		getter: getter,
+		cache:  expirable.NewLRU[namespaceCacheKey, shwap.NamespaceData](params.Size, nil, params.TTL),
+	}
+}
+
+// GetShare gets a share from the wrapped getter.
+func (ncg *NamespaceCacheGetter) GetShare(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	row, col int,
+) (share.Share, error) {
+	return ncg.getter.GetShare(ctx, header, row, col)
+}
+
+func (ncg *NamespaceCacheGetter) GetShares(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	return ncg.getter.GetShares(ctx, header, rowIdxs, colIdxs)
+}
+
+// GetEDS gets the EDS from the wrapped getter.
+func (ncg *NamespaceCacheGetter) GetEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	return ncg.getter.GetEDS(ctx, header)
+}
+
+// GetSharesByNamespace gets NamespaceData from the cache, falling back to the wrapped getter and
+// caching its result.
+func (ncg *NamespaceCacheGetter) GetSharesByNamespace(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (shwap.NamespaceData, error) {
+	key := namespaceCacheKey{height: header.Height(), namespace: string(namespace)}
+	if nd, ok := ncg.cache.Get(key); ok {
+		return nd, nil
+	}
+
+	flightKey := fmt.Sprintf("%d/%x", key.height, key.namespace)
+	res, err, _ := ncg.inflight.Do(flightKey, func() (any, error) {
+		nd, err := ncg.getter.GetSharesByNamespace(ctx, header, namespace)
+		if err != nil {
+			return nil, err
+		}
+		ncg.cache.Add(key, nd)
+		return nd, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(shwap.NamespaceData), nil
+}
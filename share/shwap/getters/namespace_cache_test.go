@@ -0,0 +1,62 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestNamespaceCacheGetter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	eh := headertest.RandExtendedHeader(t)
+	ns1, ns2 := sharetest.RandV0Namespace(), sharetest.RandV0Namespace()
+	nd := shwap.NamespaceData{{Shares: sharetest.RandShares(t, 2)}}
+
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	// every namespace is retrieved once, and failures are not cached
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), eh, ns1).Return(nd, nil).Times(1)
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), eh, ns2).Return(nil, errors.New("failure")).Times(2)
+
+	ncg := NewNamespaceCacheGetter(getter, NamespaceCacheParameters{Size: 8, TTL: time.Hour})
+	for range 3 {
+		got, err := ncg.GetSharesByNamespace(ctx, eh, ns1)
+		require.NoError(t, err)
+		require.Equal(t, nd, got)
+	}
+	for range 2 {
+		_, err := ncg.GetSharesByNamespace(ctx, eh, ns2)
+		require.Error(t, err)
+	}
+}
+
+func TestNamespaceCacheGetter_TTL(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	eh := headertest.RandExtendedHeader(t)
+	ns := sharetest.RandV0Namespace()
+	nd := shwap.NamespaceData{{Shares: sharetest.RandShares(t, 2)}}
+
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), eh, ns).Return(nd, nil).Times(2)
+
+	ncg := NewNamespaceCacheGetter(getter, NamespaceCacheParameters{Size: 8, TTL: 10 * time.Millisecond})
+	_, err := ncg.GetSharesByNamespace(ctx, eh, ns)
+	require.NoError(t, err)
+
+	// the result expires and is retrieved again
+	time.Sleep(50 * time.Millisecond)
+	_, err = ncg.GetSharesByNamespace(ctx, eh, ns)
+	require.NoError(t, err)
+}
@@ -0,0 +1,72 @@
+package getters
+
+import (
+	"context"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+var _ shwap.Getter = (*NetworkGetter)(nil)
+
+// NetworkGetter marks a shwap.Getter as one retrieving data from the network. Requests restricted
+// to the local store with shwap.WithLocalOnly fail with shwap.ErrNotFound without reaching the
+// wrapped getter.
+type NetworkGetter struct {
+	getter shwap.Getter
+}
+
+// NewNetworkGetter wraps the given network getter.
+func NewNetworkGetter(getter shwap.Getter) *NetworkGetter {
+	return &NetworkGetter{getter: getter}
+}
+
+// GetShare gets a share from the wrapped getter unless the request is local only.
+func (ng *NetworkGetter) GetShare(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	row, col int,
+) (share.Share, error) {
+	if shwap.GetOptionsFromContext(ctx).LocalOnly {
+		return nil, shwap.ErrNotFound
+	}
+	return ng.getter.GetShare(ctx, header, row, col)
+}
+
+func (ng *NetworkGetter) GetShares(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	if shwap.GetOptionsFromContext(ctx).LocalOnly {
+		return nil, shwap.ErrNotFound
+	}
+	return ng.getter.GetShares(ctx, header, rowIdxs, colIdxs)
+}
+
+// GetEDS gets a full EDS from the wrapped getter unless the request is local only.
+func (ng *NetworkGetter) GetEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	if shwap.GetOptionsFromContext(ctx).LocalOnly {
+		return nil, shwap.ErrNotFound
+	}
+	return ng.getter.GetEDS(ctx, header)
+}
+
+// GetSharesByNamespace gets NamespaceData from the wrapped getter unless the request is local
+// only.
+func (ng *NetworkGetter) GetSharesByNamespace(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (shwap.NamespaceData, error) {
+	if shwap.GetOptionsFromContext(ctx).LocalOnly {
+		return nil, shwap.ErrNotFound
+	}
+	return ng.getter.GetSharesByNamespace(ctx, header, namespace)
+}
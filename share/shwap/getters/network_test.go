@@ -0,0 +1,33 @@
+package getters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestNetworkGetter_LocalOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	getter := mock.NewMockGetter(ctrl)
+	getter.EXPECT().GetShare(gomock.Any(), gomock.Any(), 0, 0).Return(nil, nil).Times(1)
+	ng := NewNetworkGetter(getter)
+
+	_, err := ng.GetShare(ctx, nil, 0, 0)
+	require.NoError(t, err)
+
+	// local only requests never reach the network
+	localCtx := shwap.WithGetOptions(ctx, shwap.WithLocalOnly())
+	_, err = ng.GetShare(localCtx, nil, 0, 0)
+	require.ErrorIs(t, err, shwap.ErrNotFound)
+	_, err = ng.GetEDS(localCtx, nil)
+	require.ErrorIs(t, err, shwap.ErrNotFound)
+	_, err = ng.GetSharesByNamespace(localCtx, nil, nil)
+	require.ErrorIs(t, err, shwap.ErrNotFound)
+}
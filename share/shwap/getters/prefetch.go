@@ -0,0 +1,248 @@
+package getters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+// PrefetchParameters is the set of parameters that configures the NamespacePrefetcher.
+type PrefetchParameters struct {
+	// MaxNamespaces is the maximum amount of namespaces prefetched for every new header. Zero
+	// disables prefetching.
+	MaxNamespaces int
+	// WatchPeriod is the period of time a namespace keeps being prefetched after it was last
+	// requested.
+	WatchPeriod time.Duration
+	// Timeout bounds the time spent on prefetching a single namespace.
+	Timeout time.Duration
+}
+
+// DefaultPrefetchParameters returns the default configuration values for the NamespacePrefetcher.
+func DefaultPrefetchParameters() *PrefetchParameters {
+	return &PrefetchParameters{
+		MaxNamespaces: 16,
+		WatchPeriod:   10 * time.Minute,
+		Timeout:       30 * time.Second,
+	}
+}
+
+// Validate validates the values in PrefetchParameters.
+func (p *PrefetchParameters) Validate() error {
+	if p.MaxNamespaces < 0 {
+		return fmt.Errorf("invalid max namespaces: %d, value should be non-negative", p.MaxNamespaces)
+	}
+	if p.MaxNamespaces == 0 {
+		return nil
+	}
+	if p.WatchPeriod <= 0 {
+		return fmt.Errorf("invalid watch period: %v, value should be positive and non-zero", p.WatchPeriod)
+	}
+	if p.Timeout <= 0 {
+		return fmt.Errorf("invalid timeout: %v, value should be positive and non-zero", p.Timeout)
+	}
+	return nil
+}
+
+// SubscribeFn subscribes to new headers until the given context is done.
+type SubscribeFn func(context.Context) (<-chan *header.ExtendedHeader, error)
+
+type watchedNamespace struct {
+	namespace share.Namespace
+	// subscriptions is the number of subscriptions to the namespace.
+	subscriptions int
+	// requested is the last time the namespace was requested.
+	requested time.Time
+}
+
+// NamespacePrefetcher requests the namespace data of watched namespaces through the getter for
+// every new header, so that clients following the chain tip find it in the namespace cache.
+// Namespaces are watched for as long as they are subscribed to, and for the watch period after
+// they were last requested. The prefetcher only follows new headers while there are namespaces to
+// watch.
+type NamespacePrefetcher struct {
+	getter    shwap.Getter
+	subscribe SubscribeFn
+	params    PrefetchParameters
+
+	lk      sync.Mutex
+	watched map[string]*watchedNamespace
+	// cancel stops following new headers, nil when not following.
+	cancel context.CancelFunc
+	now    func() time.Time
+}
+
+// NewNamespacePrefetcher creates a NamespacePrefetcher prefetching through the given getter for
+// every header coming from the subscription.
+func NewNamespacePrefetcher(
+	getter shwap.Getter,
+	subscribe SubscribeFn,
+	params PrefetchParameters,
+) *NamespacePrefetcher {
+	return &NamespacePrefetcher{
+		getter:    getter,
+		subscribe: subscribe,
+		params:    params,
+		watched:   make(map[string]*watchedNamespace),
+		now:       time.Now,
+	}
+}
+
+// Watch makes the namespace prefetched until the returned function is called.
+func (p *NamespacePrefetcher) Watch(namespace share.Namespace) (release func()) {
+	if p == nil {
+		return func() {}
+	}
+
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	w := p.watch(namespace)
+	if w == nil {
+		return func() {}
+	}
+	w.subscriptions++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.lk.Lock()
+			defer p.lk.Unlock()
+			w.subscriptions--
+		})
+	}
+}
+
+// Touch makes the namespace prefetched for the watch period.
+func (p *NamespacePrefetcher) Touch(namespace share.Namespace) {
+	if p == nil {
+		return
+	}
+
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	if w := p.watch(namespace); w != nil {
+		w.requested = p.now()
+	}
+}
+
+// Stop stops following new headers.
+func (p *NamespacePrefetcher) Stop(context.Context) error {
+	if p == nil {
+		return nil
+	}
+
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+	return nil
+}
+
+// watch gets or adds the namespace to the watched ones, starting to follow new headers if needed.
+// It returns nil once there are too many namespaces watched.
+func (p *NamespacePrefetcher) watch(namespace share.Namespace) *watchedNamespace {
+	key := string(namespace)
+	w, ok := p.watched[key]
+	if !ok {
+		p.purge()
+		if len(p.watched) >= p.params.MaxNamespaces {
+			return nil
+		}
+		w = &watchedNamespace{namespace: namespace}
+		p.watched[key] = w
+	}
+
+	if p.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		p.cancel = cancel
+		go p.run(ctx)
+	}
+	return w
+}
+
+// purge removes the namespaces that are neither subscribed to nor recently requested.
+func (p *NamespacePrefetcher) purge() {
+	deadline := p.now().Add(-p.params.WatchPeriod)
+	for key, w := range p.watched {
+		if w.subscriptions <= 0 && w.requested.Before(deadline) {
+			delete(p.watched, key)
+		}
+	}
+}
+
+// namespaces returns the watched namespaces, stopping the run with the given context once there
+// are none.
+func (p *NamespacePrefetcher) namespaces(ctx context.Context) []share.Namespace {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+
+	p.purge()
+	if len(p.watched) == 0 {
+		p.stop(ctx)
+	}
+	namespaces := make([]share.Namespace, 0, len(p.watched))
+	for _, w := range p.watched {
+		namespaces = append(namespaces, w.namespace)
+	}
+	return namespaces
+}
+
+func (p *NamespacePrefetcher) run(ctx context.Context) {
+	headerCh, err := p.subscribe(ctx)
+	if err != nil {
+		log.Warnw("prefetcher: subscribing to headers", "err", err)
+		p.lk.Lock()
+		p.stop(ctx)
+		p.lk.Unlock()
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case eh, ok := <-headerCh:
+			if !ok {
+				return
+			}
+			for _, namespace := range p.namespaces(ctx) {
+				p.prefetch(ctx, eh, namespace)
+			}
+		}
+	}
+}
+
+// stop stops the run with the given context, unless it is already stopped. A stopped run always
+// has its context canceled, so a live context means the run is the current one.
+func (p *NamespacePrefetcher) stop(ctx context.Context) {
+	if ctx.Err() == nil && p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+}
+
+func (p *NamespacePrefetcher) prefetch(
+	ctx context.Context,
+	eh *header.ExtendedHeader,
+	namespace share.Namespace,
+) {
+	// skip blocks that can't contain the namespace without fetching anything
+	if len(share.RowsWithNamespace(eh.DAH, namespace)) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.params.Timeout)
+	defer cancel()
+	_, err := p.getter.GetSharesByNamespace(ctx, eh, namespace)
+	if err != nil {
+		log.Debugw("prefetcher: getting shares by namespace",
+			"height", eh.Height(), "namespace", namespace.String(), "err", err)
+	}
+}
@@ -0,0 +1,101 @@
+package getters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestNamespacePrefetcher(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	ns := sharetest.RandV0Namespace()
+	eds, _ := edstest.RandEDSWithNamespace(t, ns, 4, 4)
+	eh := headertest.ExtendedHeaderFromEDS(t, 1, eds)
+
+	prefetched := make(chan struct{})
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), eh, ns).
+		DoAndReturn(func(context.Context, *header.ExtendedHeader, share.Namespace) (shwap.NamespaceData, error) {
+			close(prefetched)
+			return shwap.NamespaceData{}, nil
+		}).Times(1)
+
+	headerCh := make(chan *header.ExtendedHeader, 1)
+	subscribed := make(chan struct{})
+	subscribe := func(context.Context) (<-chan *header.ExtendedHeader, error) {
+		close(subscribed)
+		return headerCh, nil
+	}
+
+	params := DefaultPrefetchParameters()
+	prefetcher := NewNamespacePrefetcher(getter, subscribe, *params)
+	release := prefetcher.Watch(ns)
+
+	// headers are only followed once there is a namespace to watch
+	select {
+	case <-subscribed:
+	case <-ctx.Done():
+		t.Fatal("prefetcher did not subscribe to headers")
+	}
+	headerCh <- eh
+	select {
+	case <-prefetched:
+	case <-ctx.Done():
+		t.Fatal("namespace was not prefetched")
+	}
+
+	// the namespace is not watched anymore, so the prefetcher stops following headers
+	release()
+	release()
+	headerCh <- headertest.RandExtendedHeader(t)
+	require.Eventually(t, func() bool {
+		prefetcher.lk.Lock()
+		defer prefetcher.lk.Unlock()
+		return prefetcher.cancel == nil && len(prefetcher.watched) == 0
+	}, time.Second, time.Millisecond)
+	require.NoError(t, prefetcher.Stop(ctx))
+}
+
+func TestNamespacePrefetcher_Touch(t *testing.T) {
+	now := time.Now()
+	subscribe := func(context.Context) (<-chan *header.ExtendedHeader, error) {
+		return make(chan *header.ExtendedHeader), nil
+	}
+	params := PrefetchParameters{MaxNamespaces: 1, WatchPeriod: time.Minute, Timeout: time.Second}
+	prefetcher := NewNamespacePrefetcher(nil, subscribe, params)
+	prefetcher.now = func() time.Time { return now }
+	t.Cleanup(func() { prefetcher.Stop(context.Background()) }) //nolint:errcheck
+
+	ns1, ns2 := sharetest.RandV0Namespace(), sharetest.RandV0Namespace()
+	prefetcher.Touch(ns1)
+	// there is no room for another namespace
+	prefetcher.Touch(ns2)
+	require.Len(t, prefetcher.namespaces(context.Background()), 1)
+
+	// the first namespace expires and makes room for the second one
+	now = now.Add(2 * time.Minute)
+	prefetcher.Touch(ns2)
+	namespaces := prefetcher.namespaces(context.Background())
+	require.Len(t, namespaces, 1)
+	require.Equal(t, ns2, namespaces[0])
+}
+
+func TestNamespacePrefetcher_Nil(t *testing.T) {
+	var prefetcher *NamespacePrefetcher
+	prefetcher.Watch(sharetest.RandV0Namespace())()
+	prefetcher.Touch(sharetest.RandV0Namespace())
+	require.NoError(t, prefetcher.Stop(context.Background()))
+}
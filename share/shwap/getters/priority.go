@@ -0,0 +1,163 @@
+package getters
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/semaphore"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+var _ shwap.Getter = (*PriorityGetter)(nil)
+
+// PriorityParameters is the set of parameters that configures the PriorityScheduler.
+type PriorityParameters struct {
+	// Concurrency is the maximum amount of concurrent network requests of normal and low priority.
+	// Zero disables scheduling.
+	Concurrency int
+	// LowPriorityConcurrency is the maximum amount of concurrent network requests of low priority.
+	// It must be positive and must not exceed Concurrency, so that low priority requests leave room
+	// for the others.
+	LowPriorityConcurrency int
+}
+
+// DefaultPriorityParameters returns the default configuration values for the PriorityScheduler.
+func DefaultPriorityParameters() *PriorityParameters {
+	return &PriorityParameters{
+		Concurrency:            128,
+		LowPriorityConcurrency: 32,
+	}
+}
+
+// Validate validates the values in PriorityParameters.
+func (p *PriorityParameters) Validate() error {
+	if p.Concurrency < 0 {
+		return fmt.Errorf("invalid concurrency: %d, value should be non-negative", p.Concurrency)
+	}
+	if p.Concurrency > 0 && (p.LowPriorityConcurrency <= 0 || p.LowPriorityConcurrency > p.Concurrency) {
+		return fmt.Errorf("invalid low priority concurrency: %d, value should be positive and not "+
+			"exceed the concurrency of %d", p.LowPriorityConcurrency, p.Concurrency)
+	}
+	return nil
+}
+
+// PriorityScheduler schedules network requests by their shwap.Priority. High priority requests
+// are never queued. Normal and low priority requests share Concurrency slots, while low priority
+// requests may only occupy LowPriorityConcurrency of them at once. PriorityScheduler is safe for
+// concurrent use and is meant to be shared across all network getters.
+type PriorityScheduler struct {
+	normal *semaphore.Weighted
+	low    *semaphore.Weighted
+}
+
+// NewPriorityScheduler creates a new PriorityScheduler with the given parameters.
+func NewPriorityScheduler(params PriorityParameters) *PriorityScheduler {
+	if params.Concurrency == 0 {
+		return &PriorityScheduler{}
+	}
+	return &PriorityScheduler{
+		normal: semaphore.NewWeighted(int64(params.Concurrency)),
+		low:    semaphore.NewWeighted(int64(params.LowPriorityConcurrency)),
+	}
+}
+
+// acquire waits for a slot for the request carried by the context. The returned function
+// releases the slot.
+func (ps *PriorityScheduler) acquire(ctx context.Context) (func(), error) {
+	priority := shwap.GetOptionsFromContext(ctx).Priority
+	if ps.normal == nil || priority >= shwap.PriorityHigh {
+		return func() {}, nil
+	}
+
+	if priority <= shwap.PriorityLow {
+		if err := ps.low.Acquire(ctx, 1); err != nil {
+			return nil, fmt.Errorf("waiting for low priority slot: %w", err)
+		}
+	}
+	if err := ps.normal.Acquire(ctx, 1); err != nil {
+		if priority <= shwap.PriorityLow {
+			ps.low.Release(1)
+		}
+		return nil, fmt.Errorf("waiting for %s priority slot: %w", priority, err)
+	}
+	return func() {
+		ps.normal.Release(1)
+		if priority <= shwap.PriorityLow {
+			ps.low.Release(1)
+		}
+	}, nil
+}
+
+// PriorityGetter wraps a network shwap.Getter with the PriorityScheduler, so that requests reach
+// the wrapped getter only once the scheduler lets them through.
+type PriorityGetter struct {
+	getter    shwap.Getter
+	scheduler *PriorityScheduler
+}
+
+// NewPriorityGetter wraps the given network getter with the given PriorityScheduler.
+func NewPriorityGetter(getter shwap.Getter, scheduler *PriorityScheduler) *PriorityGetter {
+	return &PriorityGetter{
+		getter:    getter,
+		scheduler: scheduler,
+	}
+}
+
+// GetShare gets a share from the wrapped getter once scheduled.
+func (pg *PriorityGetter) GetShare(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	row, col int,
+) (share.Share, error) {
+	release, err := pg.scheduler.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return pg.getter.GetShare(ctx, header, row, col)
+}
+
+func (pg *PriorityGetter) GetShares(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	release, err := pg.scheduler.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return pg.getter.GetShares(ctx, header, rowIdxs, colIdxs)
+}
+
+// GetEDS gets the EDS from the wrapped getter once scheduled.
+func (pg *PriorityGetter) GetEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	release, err := pg.scheduler.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return pg.getter.GetEDS(ctx, header)
+}
+
+// GetSharesByNamespace gets NamespaceData from the wrapped getter once scheduled.
+func (pg *PriorityGetter) GetSharesByNamespace(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (shwap.NamespaceData, error) {
+	release, err := pg.scheduler.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return pg.getter.GetSharesByNamespace(ctx, header, namespace)
+}
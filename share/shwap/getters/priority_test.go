@@ -0,0 +1,78 @@
+package getters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestPriorityGetter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	// requests hold their slot until unblocked
+	unblock := make(chan struct{})
+	started := make(chan struct{}, 10)
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
+			started <- struct{}{}
+			<-unblock
+			return nil, nil
+		}).AnyTimes()
+
+	scheduler := NewPriorityScheduler(PriorityParameters{Concurrency: 2, LowPriorityConcurrency: 1})
+	pg := NewPriorityGetter(getter, scheduler)
+	withPriority := func(priority shwap.Priority) context.Context {
+		return shwap.WithGetOptions(ctx, shwap.WithPriority(priority))
+	}
+	tryGet := func(priority shwap.Priority) error {
+		ctx, cancel := context.WithTimeout(withPriority(priority), 50*time.Millisecond)
+		defer cancel()
+		_, err := pg.GetEDS(ctx, nil)
+		return err
+	}
+
+	errCh := make(chan error, 10)
+	getAsync := func(priority shwap.Priority) {
+		go func() {
+			_, err := pg.GetEDS(withPriority(priority), nil)
+			errCh <- err
+		}()
+		<-started
+	}
+
+	// low priority requests can only take a part of the slots
+	getAsync(shwap.PriorityLow)
+	require.ErrorIs(t, tryGet(shwap.PriorityLow), context.DeadlineExceeded)
+
+	// normal priority requests take the rest
+	getAsync(shwap.PriorityNormal)
+	require.ErrorIs(t, tryGet(shwap.PriorityNormal), context.DeadlineExceeded)
+
+	// high priority requests are never queued
+	getAsync(shwap.PriorityHigh)
+
+	close(unblock)
+	for range 3 {
+		require.NoError(t, <-errCh)
+	}
+	require.NoError(t, tryGet(shwap.PriorityLow))
+}
+
+func TestPriorityParameters_Validate(t *testing.T) {
+	require.NoError(t, DefaultPriorityParameters().Validate())
+	require.NoError(t, (&PriorityParameters{}).Validate())
+	require.Error(t, (&PriorityParameters{Concurrency: -1}).Validate())
+	require.Error(t, (&PriorityParameters{Concurrency: 2}).Validate())
+	require.Error(t, (&PriorityParameters{Concurrency: 2, LowPriorityConcurrency: 3}).Validate())
+}
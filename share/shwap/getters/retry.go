@@ -0,0 +1,126 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+var _ shwap.Getter = (*RetryGetter)(nil)
+
+// RetryGetter wraps a network shwap.Getter retrying its failed requests according to the
+// shwap.RetryPolicy. The policy set for the request with shwap.WithRetryPolicy takes precedence
+// over the one RetryGetter is created with.
+type RetryGetter struct {
+	getter shwap.Getter
+	policy shwap.RetryPolicy
+}
+
+// NewRetryGetter wraps the given network getter with the given default retry policy.
+func NewRetryGetter(getter shwap.Getter, policy shwap.RetryPolicy) *RetryGetter {
+	return &RetryGetter{
+		getter: getter,
+		policy: policy,
+	}
+}
+
+// GetShare gets a share from the wrapped getter, retrying on failure.
+func (rg *RetryGetter) GetShare(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	row, col int,
+) (share.Share, error) {
+	return retry(ctx, rg.policy, func(ctx context.Context) (share.Share, error) {
+		return rg.getter.GetShare(ctx, header, row, col)
+	})
+}
+
+func (rg *RetryGetter) GetShares(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	return retry(ctx, rg.policy, func(ctx context.Context) ([]share.Share, error) {
+		return rg.getter.GetShares(ctx, header, rowIdxs, colIdxs)
+	})
+}
+
+// GetEDS gets the EDS from the wrapped getter, retrying on failure.
+func (rg *RetryGetter) GetEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	return retry(ctx, rg.policy, func(ctx context.Context) (*rsmt2d.ExtendedDataSquare, error) {
+		return rg.getter.GetEDS(ctx, header)
+	})
+}
+
+// GetSharesByNamespace gets NamespaceData from the wrapped getter, retrying on failure.
+func (rg *RetryGetter) GetSharesByNamespace(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (shwap.NamespaceData, error) {
+	return retry(ctx, rg.policy, func(ctx context.Context) (shwap.NamespaceData, error) {
+		return rg.getter.GetSharesByNamespace(ctx, header, namespace)
+	})
+}
+
+// retry calls fn until it succeeds, fails with an error that is not worth retrying or runs out of
+// attempts. Every attempt gets an equal share of the time left to the request.
+func retry[T any](
+	ctx context.Context,
+	policy shwap.RetryPolicy,
+	fn func(context.Context) (T, error),
+) (T, error) {
+	if override := shwap.GetOptionsFromContext(ctx).RetryPolicy; override != nil {
+		policy = *override
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := utils.CtxWithSplitTimeout(ctx, policy.Attempts-attempt+1, 0)
+		res, err := fn(attemptCtx)
+		cancel()
+		if err == nil || attempt >= policy.Attempts || !isRetryable(ctx, err) {
+			return res, err
+		}
+
+		log.Debugw("retrying failed request", "attempt", attempt, "err", err)
+		trace.SpanFromContext(ctx).AddEvent("retrying failed request", trace.WithAttributes(
+			attribute.Int("attempt", attempt),
+			attribute.String("err", err.Error()),
+		))
+		select {
+		case <-time.After(backoff(policy, attempt)):
+		case <-ctx.Done():
+			return res, errors.Join(err, ctx.Err())
+		}
+	}
+}
+
+// isRetryable reports whether the request failed with the error is worth another attempt.
+func isRetryable(ctx context.Context, err error) bool {
+	// the request itself is done or network retrieval is suspended
+	if ctx.Err() != nil || errors.Is(err, ErrEDSUnavailable) {
+		return false
+	}
+	return isNetworkFailure(err)
+}
+
+// backoff returns the jittered delay before the attempt following the given one.
+func backoff(policy shwap.RetryPolicy, attempt int) time.Duration {
+	delay := float64(policy.BackoffBase) * float64(uint64(1)<<min(attempt-1, 32))
+	delay *= 1 + policy.Jitter*(2*rand.Float64()-1) //nolint:gosec
+	return time.Duration(delay)
+}
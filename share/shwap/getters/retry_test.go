@@ -0,0 +1,81 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestRetryGetter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	eds := edstest.RandEDS(t, 4)
+	netErr := errors.New("network failure")
+	policy := shwap.RetryPolicy{Attempts: 3, BackoffBase: time.Millisecond, Jitter: 0.5}
+
+	t.Run("retries until success", func(t *testing.T) {
+		getter := mock.NewMockGetter(gomock.NewController(t))
+		gomock.InOrder(
+			getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(nil, netErr).Times(2),
+			getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(eds, nil),
+		)
+
+		got, err := NewRetryGetter(getter, policy).GetEDS(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, eds, got)
+	})
+
+	t.Run("gives up after attempts", func(t *testing.T) {
+		getter := mock.NewMockGetter(gomock.NewController(t))
+		getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(nil, netErr).Times(3)
+
+		_, err := NewRetryGetter(getter, policy).GetEDS(ctx, nil)
+		require.ErrorIs(t, err, netErr)
+	})
+
+	t.Run("doesn't retry suspended retrieval", func(t *testing.T) {
+		getter := mock.NewMockGetter(gomock.NewController(t))
+		getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(nil, ErrEDSUnavailable).Times(1)
+
+		_, err := NewRetryGetter(getter, policy).GetEDS(ctx, nil)
+		require.ErrorIs(t, err, ErrEDSUnavailable)
+	})
+
+	t.Run("per request override", func(t *testing.T) {
+		getter := mock.NewMockGetter(gomock.NewController(t))
+		getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(nil, netErr).Times(1)
+
+		failFast := shwap.WithGetOptions(ctx, shwap.WithRetryPolicy(shwap.RetryPolicy{Attempts: 1}))
+		_, err := NewRetryGetter(getter, policy).GetEDS(failFast, nil)
+		require.ErrorIs(t, err, netErr)
+	})
+
+	t.Run("attempts share the timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+		t.Cleanup(cancel)
+
+		getter := mock.NewMockGetter(gomock.NewController(t))
+		getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}).Times(1)
+		getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(eds, nil).Times(1)
+
+		got, err := NewRetryGetter(getter, policy).GetEDS(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, eds, got)
+	})
+}
@@ -0,0 +1,180 @@
+package getters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+var _ shwap.Getter = (*SingleFlightGetter)(nil)
+
+// SingleFlightGetter wraps a network shwap.Getter coalescing concurrent identical requests into a
+// single one, whose result is shared by all the callers and must not be modified. Requests are
+// identical when they are for the same data of the same header with the same shwap.GetOptions.
+// The shared request runs until it is done or every caller waiting for it is gone.
+type SingleFlightGetter struct {
+	getter  shwap.Getter
+	flights *flightGroup
+}
+
+// NewSingleFlightGetter wraps the given network getter.
+func NewSingleFlightGetter(getter shwap.Getter) *SingleFlightGetter {
+	return &SingleFlightGetter{
+		getter:  getter,
+		flights: newFlightGroup(),
+	}
+}
+
+// GetShare gets a share from the wrapped getter, sharing it with concurrent identical requests.
+func (sfg *SingleFlightGetter) GetShare(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	row, col int,
+) (share.Share, error) {
+	key := flightKey(ctx, header, fmt.Sprintf("share/%d/%d", row, col))
+	res, err := sfg.flights.do(ctx, key, func(ctx context.Context) (any, error) {
+		return sfg.getter.GetShare(ctx, header, row, col)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(share.Share), nil
+}
+
+func (sfg *SingleFlightGetter) GetShares(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	key := flightKey(ctx, header, fmt.Sprintf("shares/%v/%v", rowIdxs, colIdxs))
+	res, err := sfg.flights.do(ctx, key, func(ctx context.Context) (any, error) {
+		return sfg.getter.GetShares(ctx, header, rowIdxs, colIdxs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]share.Share), nil
+}
+
+// GetEDS gets the EDS from the wrapped getter, sharing it with concurrent identical requests.
+func (sfg *SingleFlightGetter) GetEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	key := flightKey(ctx, header, "eds")
+	res, err := sfg.flights.do(ctx, key, func(ctx context.Context) (any, error) {
+		return sfg.getter.GetEDS(ctx, header)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*rsmt2d.ExtendedDataSquare), nil
+}
+
+// GetSharesByNamespace gets NamespaceData from the wrapped getter, sharing it with concurrent
+// identical requests.
+func (sfg *SingleFlightGetter) GetSharesByNamespace(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (shwap.NamespaceData, error) {
+	key := flightKey(ctx, header, fmt.Sprintf("namespace/%x", []byte(namespace)))
+	res, err := sfg.flights.do(ctx, key, func(ctx context.Context) (any, error) {
+		return sfg.getter.GetSharesByNamespace(ctx, header, namespace)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(shwap.NamespaceData), nil
+}
+
+// flightKey identifies the request for the given data of the header with the options of the
+// context.
+func flightKey(ctx context.Context, header *header.ExtendedHeader, data string) string {
+	opts := shwap.GetOptionsFromContext(ctx)
+	key := fmt.Sprintf("%d/%X/%s/%t/%t/%d", header.Height(), header.DataHash, data,
+		opts.SkipRootVerification, opts.LocalOnly, opts.Priority)
+	if opts.RetryPolicy != nil {
+		key += fmt.Sprintf("/%+v", *opts.RetryPolicy)
+	}
+	return key
+}
+
+// flight is a request shared by the callers waiting for it.
+type flight struct {
+	done chan struct{}
+	res  any
+	err  error
+
+	// waiters is the amount of callers waiting for the flight, guarded by the group lock.
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// flightGroup runs requests shared by concurrent callers with the same key.
+type flightGroup struct {
+	lk      sync.Mutex
+	flights map[string]*flight
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{flights: make(map[string]*flight)}
+}
+
+// do runs fn for the key, unless it already runs for another caller, and waits for its result.
+// fn runs with a context detached from the callers, keeping the values of the first one, which is
+// canceled once every caller is gone.
+func (g *flightGroup) do(
+	ctx context.Context,
+	key string,
+	fn func(context.Context) (any, error),
+) (any, error) {
+	g.lk.Lock()
+	f, ok := g.flights[key]
+	if !ok {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.flights[key] = f
+		go g.run(flightCtx, key, f, fn)
+	}
+	f.waiters++
+	g.lk.Unlock()
+
+	select {
+	case <-f.done:
+		return f.res, f.err
+	case <-ctx.Done():
+		g.lk.Lock()
+		defer g.lk.Unlock()
+		f.waiters--
+		if f.waiters == 0 {
+			// nobody waits for the flight anymore, so the following callers start a new one
+			f.cancel()
+			g.forget(key, f)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, f *flight, fn func(context.Context) (any, error)) {
+	defer f.cancel()
+	f.res, f.err = fn(ctx)
+
+	g.lk.Lock()
+	g.forget(key, f)
+	g.lk.Unlock()
+	close(f.done)
+}
+
+// forget removes the flight from the group, unless it was already replaced by another one.
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
@@ -0,0 +1,104 @@
+package getters
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestSingleFlightGetter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	const callers = 50
+	eh := headertest.RandExtendedHeader(t)
+	ns := sharetest.RandV0Namespace()
+	nd := shwap.NamespaceData{{Shares: sharetest.RandShares(t, 2)}}
+
+	release := make(chan struct{})
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), eh, ns).
+		DoAndReturn(func(context.Context, *header.ExtendedHeader, share.Namespace) (shwap.NamespaceData, error) {
+			<-release
+			return nd, nil
+		}).Times(1)
+
+	sfg := NewSingleFlightGetter(getter)
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := sfg.GetSharesByNamespace(ctx, eh, ns)
+			assert.NoError(t, err)
+			assert.Equal(t, nd, got)
+		}()
+	}
+
+	// all the callers join the single retrieval before it completes
+	require.Eventually(t, func() bool {
+		sfg.flights.lk.Lock()
+		defer sfg.flights.lk.Unlock()
+		for _, f := range sfg.flights.flights {
+			return f.waiters == callers
+		}
+		return false
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func TestSingleFlightGetter_Options(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	eh := headertest.RandExtendedHeader(t)
+	// requests with different options are not coalesced
+	key := flightKey(ctx, eh, "eds")
+	require.NotEqual(t, key, flightKey(shwap.WithGetOptions(ctx, shwap.WithLocalOnly()), eh, "eds"))
+	require.NotEqual(t, key, flightKey(shwap.WithGetOptions(ctx, shwap.WithPriority(shwap.PriorityHigh)), eh, "eds"))
+	require.Equal(t, key, flightKey(ctx, eh, "eds"))
+}
+
+func TestSingleFlightGetter_CallersGone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	eh := headertest.RandExtendedHeader(t)
+	canceled := make(chan struct{})
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetShare(gomock.Any(), eh, 0, 0).
+		DoAndReturn(func(ctx context.Context, _ *header.ExtendedHeader, _, _ int) (share.Share, error) {
+			<-ctx.Done()
+			close(canceled)
+			return nil, ctx.Err()
+		}).Times(1)
+
+	sfg := NewSingleFlightGetter(getter)
+	reqCtx, reqCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer reqCancel()
+	_, err := sfg.GetShare(reqCtx, eh, 0, 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the only caller is gone, so the retrieval is canceled and forgotten
+	select {
+	case <-canceled:
+	case <-ctx.Done():
+		t.Fatal("retrieval was not canceled")
+	}
+	sfg.flights.lk.Lock()
+	defer sfg.flights.lk.Unlock()
+	require.Empty(t, sfg.flights.flights)
+}
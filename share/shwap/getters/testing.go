@@ -47,6 +47,22 @@ func (seg *SingleEDSGetter) GetShare(
 	return seg.EDS.GetCell(uint(row), uint(col)), nil
 }
 
+func (seg *SingleEDSGetter) GetShares(
+	_ context.Context,
+	header *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	err := seg.checkRoots(header.DAH)
+	if err != nil {
+		return nil, err
+	}
+	shares := make([]share.Share, len(rowIdxs))
+	for i := range rowIdxs {
+		shares[i] = seg.EDS.GetCell(uint(rowIdxs[i]), uint(colIdxs[i]))
+	}
+	return shares, nil
+}
+
 // GetEDS returns a kept EDS if the correct root is given.
 func (seg *SingleEDSGetter) GetEDS(
 	_ context.Context,
@@ -0,0 +1,71 @@
+package getters
+
+import (
+	"context"
+	"time"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+var _ shwap.Getter = (*TimeoutGetter)(nil)
+
+// TimeoutGetter wraps a shwap.Getter bounding the time a single request may spend in it, so that
+// a slow getter leaves time for the next ones in the cascade.
+type TimeoutGetter struct {
+	getter  shwap.Getter
+	timeout time.Duration
+}
+
+// NewTimeoutGetter wraps the given getter with the given timeout.
+func NewTimeoutGetter(getter shwap.Getter, timeout time.Duration) *TimeoutGetter {
+	return &TimeoutGetter{
+		getter:  getter,
+		timeout: timeout,
+	}
+}
+
+// GetShare gets a share from the wrapped getter within the timeout.
+func (tg *TimeoutGetter) GetShare(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	row, col int,
+) (share.Share, error) {
+	ctx, cancel := context.WithTimeout(ctx, tg.timeout)
+	defer cancel()
+	return tg.getter.GetShare(ctx, header, row, col)
+}
+
+func (tg *TimeoutGetter) GetShares(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) ([]share.Share, error) {
+	ctx, cancel := context.WithTimeout(ctx, tg.timeout)
+	defer cancel()
+	return tg.getter.GetShares(ctx, header, rowIdxs, colIdxs)
+}
+
+// GetEDS gets the EDS from the wrapped getter within the timeout.
+func (tg *TimeoutGetter) GetEDS(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	ctx, cancel := context.WithTimeout(ctx, tg.timeout)
+	defer cancel()
+	return tg.getter.GetEDS(ctx, header)
+}
+
+// GetSharesByNamespace gets NamespaceData from the wrapped getter within the timeout.
+func (tg *TimeoutGetter) GetSharesByNamespace(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	namespace share.Namespace,
+) (shwap.NamespaceData, error) {
+	ctx, cancel := context.WithTimeout(ctx, tg.timeout)
+	defer cancel()
+	return tg.getter.GetSharesByNamespace(ctx, header, namespace)
+}
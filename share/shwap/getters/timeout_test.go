@@ -0,0 +1,33 @@
+package getters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share/shwap/getters/mock"
+)
+
+func TestTimeoutGetter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	getter := mock.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+
+	tg := NewTimeoutGetter(getter, 10*time.Millisecond)
+	_, err := tg.GetEDS(ctx, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	// the parent context is left intact
+	require.NoError(t, ctx.Err())
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/celestiaorg/rsmt2d"
+
 	"github.com/celestiaorg/celestia-node/share"
 )
 
@@ -28,14 +30,28 @@ func (nd NamespaceData) Flatten() []share.Share {
 
 // Verify checks the integrity of the NamespaceData against a provided root and namespace.
 func (nd NamespaceData) Verify(root *share.AxisRoots, namespace share.Namespace) error {
-	rowIdxs := share.RowsWithNamespace(root, namespace)
-	if len(rowIdxs) != len(nd) {
-		return fmt.Errorf("expected %d rows, found %d rows", len(rowIdxs), len(nd))
+	return nd.verifyAxes(root, namespace, rsmt2d.Row)
+}
+
+// VerifyColumns checks the integrity of the column-major NamespaceData against a provided root and
+// namespace. Each item is expected to hold the namespace shares of a single column, ordered by
+// column index, with proofs against the corresponding column roots.
+func (nd NamespaceData) VerifyColumns(root *share.AxisRoots, namespace share.Namespace) error {
+	return nd.verifyAxes(root, namespace, rsmt2d.Col)
+}
+
+func (nd NamespaceData) verifyAxes(root *share.AxisRoots, namespace share.Namespace, axisType rsmt2d.Axis) error {
+	axisName, axisIdxs, axisRoots := "rows", share.RowsWithNamespace(root, namespace), root.RowRoots
+	if axisType == rsmt2d.Col {
+		axisName, axisIdxs, axisRoots = "columns", share.ColumnsWithNamespace(root, namespace), root.ColumnRoots
+	}
+	if len(axisIdxs) != len(nd) {
+		return fmt.Errorf("expected %d %s, found %d %s", len(axisIdxs), axisName, len(nd), axisName)
 	}
 
-	for i, row := range nd {
-		if err := row.Verify(root, namespace, rowIdxs[i]); err != nil {
-			return fmt.Errorf("validating row: %w", err)
+	for i, rnd := range nd {
+		if err := rnd.verifyAxis(axisRoots, namespace, axisType, axisIdxs[i]); err != nil {
+			return fmt.Errorf("validating %s: %w", axisName[:len(axisName)-1], err)
 		}
 	}
 	return nil
@@ -29,6 +29,14 @@ func WithStore(store blockstore.Blockstore) FetchOption {
 	}
 }
 
+// WithFetched instructs [Fetch] to call the given function with the CID of every Block once it is
+// fetched and populated.
+func WithFetched(fetched func(cid.Cid)) FetchOption {
+	return func(options *fetchOptions) {
+		options.Fetched = fetched
+	}
+}
+
 // Fetch fetches and populates given Blocks using Fetcher wrapping Bitswap.
 //
 // Validates Block against the given AxisRoots and skips Blocks that are already populated.
@@ -118,6 +126,7 @@ func fetch(
 				// and if so something is really wrong
 				panic(fmt.Sprintf("unmarshaling duplicate block: %s", err))
 			}
+			options.fetched(bitswapBlk.Cid())
 			// NOTE: This approach has a downside that we redo deserialization and computationally
 			// expensive computation for as many duplicates. We tried solutions that doesn't have this
 			// problem, but they are *much* more complex. Considering this a rare edge-case the tradeoff
@@ -130,6 +139,7 @@ func fetch(
 		if err != nil {
 			log.Error("failed to store the new Bitswap block: %s", err)
 		}
+		options.fetched(bitswapBlk.Cid())
 	}
 
 	return ctx.Err()
@@ -252,6 +262,7 @@ type FetchOption func(*fetchOptions)
 type fetchOptions struct {
 	Session exchange.Fetcher
 	Store   blockstore.Blockstore
+	Fetched func(cid.Cid)
 }
 
 func (options *fetchOptions) getFetcher(exhng exchange.Interface) exchange.Fetcher {
@@ -269,3 +280,9 @@ func (options *fetchOptions) store(ctx context.Context, blk blocks.Block) error
 
 	return options.Store.Put(ctx, blk)
 }
+
+func (options *fetchOptions) fetched(cid cid.Cid) {
+	if options.Fetched != nil {
+		options.Fetched(cid)
+	}
+}
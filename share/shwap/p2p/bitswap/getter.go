@@ -1,15 +1,21 @@
 package bitswap
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"runtime"
+	"sync"
 
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/ipfs/boxo/blockstore"
 	"github.com/ipfs/boxo/exchange"
+	"github.com/ipfs/go-cid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/celestiaorg/celestia-app/v2/pkg/wrapper"
 	"github.com/celestiaorg/rsmt2d"
@@ -22,18 +28,31 @@ import (
 
 var tracer = otel.Tracer("shwap/bitswap")
 
+// maxHeightSessions is the amount of heights the Getter keeps fetching sessions for. It covers
+// the heights sampled concurrently by DASer with some headroom.
+const maxHeightSessions = 32
+
 // Getter implements share.Getter.
 type Getter struct {
 	exchange  exchange.SessionExchange
 	bstore    blockstore.Blockstore
 	availWndw pruner.AvailabilityWindow
 
-	availableSession exchange.Fetcher
-	archivalSession  exchange.Fetcher
+	sessionsLk     sync.Mutex
+	heightSessions *lru.Cache[uint64, *heightSession]
+	// archivalSession is shared by all heights outside the availability window
+	archivalSession exchange.Fetcher
 
+	ctx    context.Context
 	cancel context.CancelFunc
 }
 
+// heightSession is a fetching session for a single height.
+type heightSession struct {
+	fetcher exchange.Fetcher
+	cancel  context.CancelFunc
+}
+
 // NewGetter constructs a new Getter.
 func NewGetter(
 	exchange exchange.SessionExchange,
@@ -45,27 +64,40 @@ func NewGetter(
 
 // Start kicks off internal fetching sessions.
 //
-// We keep Bitswap sessions for the whole Getter lifespan:
+// We reuse Bitswap sessions across requests:
 //   - Sessions retain useful heuristics about peers, like TTFB
 //   - Sessions prefer peers that previously served us related content.
 //
 // So reusing session is expected to improve fetching performance.
 //
-// There are two sessions for archival and available data, so archival node peers aren't mixed
-// with regular full node peers.
+// Data within the availability window is fetched with a session per height, shared by all
+// GetShare, GetShares, GetEDS and GetSharesByNamespace calls for that height. This way provider
+// discovery and peer selection happen once per height and the same blocks aren't requested from
+// several peers discovered independently by concurrent requests. Sessions of the least recently
+// used heights are closed once more than maxHeightSessions heights are requested.
+//
+// Archival data has a single session for the whole Getter lifespan, so archival node peers aren't
+// mixed with regular full node peers.
 func (g *Getter) Start() {
 	ctx, cancel := context.WithCancel(context.Background())
-	g.availableSession = g.exchange.NewSession(ctx)
+	// the size is a positive constant, so creating the cache never fails
+	g.heightSessions, _ = lru.NewWithEvict(maxHeightSessions, func(_ uint64, s *heightSession) {
+		s.cancel()
+	})
 	g.archivalSession = g.exchange.NewSession(ctx)
+	g.ctx = ctx
 	g.cancel = cancel
 }
 
-// Stop shuts down Getter's internal fetching session.
+// Stop shuts down Getter's internal fetching sessions.
 func (g *Getter) Stop() {
 	g.cancel()
+	g.heightSessions.Purge()
 }
 
 // GetShares uses [SampleBlock] and [Fetch] to get and verify samples for given coordinates.
+// Samples are requested at once within the session of the block, so that peers get them batched
+// into want-lists instead of one request per sample.
 // TODO(@Wondertan): Rework API to get coordinates as a single param to make it ergonomic.
 func (g *Getter) GetShares(
 	ctx context.Context,
@@ -96,7 +128,20 @@ func (g *Getter) GetShares(
 	}
 
 	ses := g.session(ctx, hdr)
-	err := Fetch(ctx, g.exchange, hdr.DAH, blks, WithStore(g.bstore), WithFetcher(ses))
+	opts := []FetchOption{WithStore(g.bstore), WithFetcher(ses)}
+	if onShare := shwap.GetOptionsFromContext(ctx).OnShare; onShare != nil {
+		// report the shares as they arrive, so that the ones fetched before a failure are known
+		idxs := make(map[cid.Cid][]int, len(blks))
+		for i, blk := range blks {
+			idxs[blk.CID()] = append(idxs[blk.CID()], i)
+		}
+		opts = append(opts, WithFetched(func(cid cid.Cid) {
+			for _, i := range idxs[cid] {
+				onShare(i, blks[i].(*SampleBlock).Container.Share)
+			}
+		}))
+	}
+	err := Fetch(ctx, g.exchange, hdr.DAH, blks, opts...)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Fetch")
@@ -181,6 +226,9 @@ func (g *Getter) GetEDS(
 // GetSharesByNamespace uses [RowNamespaceDataBlock] and [Fetch] to get all the data
 // by the given namespace. If data spans over multiple rows, the request is split into
 // parallel RowNamespaceDataID requests per each row and then assembled back into NamespaceData.
+// Only rows whose roots can contain the namespace are requested, and every row responds with the
+// namespace shares and their proof only, so the amount of data downloaded is proportional to the
+// size of the namespace rather than to the size of the rows or the EDS.
 func (g *Getter) GetSharesByNamespace(
 	ctx context.Context,
 	hdr *header.ExtendedHeader,
@@ -232,28 +280,141 @@ func (g *Getter) session(ctx context.Context, hdr *header.ExtendedHeader) exchan
 
 	isWithinAvailability := pruner.IsWithinAvailabilityWindow(hdr.Time(), g.availWndw)
 	if isWithinAvailability {
-		session = g.availableSession
+		session = g.heightSession(hdr.Height())
 	}
 
 	trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("within_availability", isWithinAvailability))
 	return session
 }
 
+// heightSession returns the fetching session for the given height, creating one if needed.
+func (g *Getter) heightSession(height uint64) exchange.Fetcher {
+	g.sessionsLk.Lock()
+	defer g.sessionsLk.Unlock()
+
+	if s, ok := g.heightSessions.Get(height); ok {
+		return s.fetcher
+	}
+
+	ctx, cancel := context.WithCancel(g.ctx)
+	s := &heightSession{
+		fetcher: g.exchange.NewSession(ctx),
+		cancel:  cancel,
+	}
+	g.heightSessions.Add(height, s)
+	return s.fetcher
+}
+
 // edsFromRows imports given Rows and computes EDS out of them, assuming enough Rows were provided.
 // It is designed to reuse Row halves computed during verification on [Fetch] level.
+//
+// When the Rows are the top half of the square, the rows and then the columns are erasure decoded
+// concurrently by a bounded pool of workers and the resulting square is verified against the
+// roots concurrently as well. Otherwise, or if the verification fails, the square is repaired by
+// rsmt2d, which reports byzantine encoding the way the rest of the node expects it.
 func edsFromRows(roots *share.AxisRoots, rows []shwap.Row) (*rsmt2d.ExtendedDataSquare, error) {
-	shrs := make([]share.Share, len(roots.RowRoots)*len(roots.RowRoots))
+	width := len(roots.RowRoots)
+	shrs := make([]share.Share, width*width)
+	workers := errgroup.Group{}
+	workers.SetLimit(runtime.GOMAXPROCS(0))
 	for i, row := range rows {
-		rowShrs, err := row.Shares()
-		if err != nil {
-			return nil, fmt.Errorf("decoding Shares out of Row: %w", err)
-		}
+		workers.Go(func() error {
+			rowShrs, err := row.Shares()
+			if err != nil {
+				return fmt.Errorf("decoding Shares out of Row: %w", err)
+			}
+			copy(shrs[i*width:], rowShrs)
+			return nil
+		})
+	}
+	if err := workers.Wait(); err != nil {
+		return nil, err
+	}
 
-		for j, shr := range rowShrs {
-			shrs[j+(i*len(roots.RowRoots))] = shr
+	if len(rows) == width/2 {
+		square, err := edsFromTopRows(roots, shrs)
+		if err == nil {
+			return square, nil
 		}
+		log.Warnw("falling back to sequential EDS repair", "err", err)
 	}
+	return repairEDS(roots, shrs)
+}
+
+// edsFromTopRows concurrently recomputes the bottom half of the square out of the decoded top
+// rows and verifies the square against the roots. It does not modify the given shares.
+func edsFromTopRows(roots *share.AxisRoots, topShrs []share.Share) (*rsmt2d.ExtendedDataSquare, error) {
+	width := len(roots.RowRoots)
+	shrs := make([]share.Share, width*width)
+	copy(shrs, topShrs[:width*width/2])
+
+	workers := errgroup.Group{}
+	workers.SetLimit(runtime.GOMAXPROCS(0))
+	for col := range width {
+		workers.Go(func() error {
+			colShrs := make([]share.Share, width)
+			for row := range width / 2 {
+				colShrs[row] = shrs[row*width+col]
+			}
+			colShrs, err := share.DefaultRSMT2DCodec().Decode(colShrs)
+			if err != nil {
+				return fmt.Errorf("decoding column %d: %w", col, err)
+			}
+			// each worker only writes the cells of its own column
+			for row := width / 2; row < width; row++ {
+				shrs[row*width+col] = colShrs[row]
+			}
+			return nil
+		})
+	}
+	if err := workers.Wait(); err != nil {
+		return nil, err
+	}
+
+	for axisIdx := range 2 * width {
+		workers.Go(func() error {
+			tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(width/2), uint(axisIdx%width))
+			for i := range width {
+				idx := (axisIdx%width)*width + i
+				if axisIdx >= width {
+					idx = i*width + axisIdx%width
+				}
+				if err := tree.Push(shrs[idx]); err != nil {
+					return fmt.Errorf("building tree of axis %d: %w", axisIdx, err)
+				}
+			}
+			root, err := tree.Root()
+			if err != nil {
+				return fmt.Errorf("computing root of axis %d: %w", axisIdx, err)
+			}
+			expected := roots.RowRoots
+			if axisIdx >= width {
+				expected = roots.ColumnRoots
+			}
+			if !bytes.Equal(root, expected[axisIdx%width]) {
+				return fmt.Errorf("root of axis %d does not match", axisIdx)
+			}
+			return nil
+		})
+	}
+	if err := workers.Wait(); err != nil {
+		return nil, err
+	}
+
+	square, err := rsmt2d.ImportExtendedDataSquare(
+		shrs,
+		share.DefaultRSMT2DCodec(),
+		wrapper.NewConstructor(uint64(width/2)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("importing EDS: %w", err)
+	}
+	return square, nil
+}
 
+// repairEDS imports the given shares, some of which may be missing, and repairs the square out of
+// them using the roots.
+func repairEDS(roots *share.AxisRoots, shrs []share.Share) (*rsmt2d.ExtendedDataSquare, error) {
 	square, err := rsmt2d.ImportExtendedDataSquare(
 		shrs,
 		share.DefaultRSMT2DCodec(),
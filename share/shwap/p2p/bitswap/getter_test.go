@@ -1,12 +1,17 @@
 package bitswap
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/celestiaorg/celestia-node/header/headertest"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
 	"github.com/celestiaorg/celestia-node/share/shwap"
 )
 
@@ -25,3 +30,103 @@ func TestEDSFromRows(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, edsIn.Equals(edsOut))
 }
+
+func TestEDSFromRows_RootMismatch(t *testing.T) {
+	edsIn := edstest.RandEDS(t, 8)
+	roots, err := share.NewAxisRoots(edsIn)
+	require.NoError(t, err)
+	// the rows are valid, but a column root does not match them
+	roots.ColumnRoots[0] = roots.ColumnRoots[1]
+
+	rows := make([]shwap.Row, edsIn.Width()/2)
+	for i := range edsIn.Width() / 2 {
+		rowShrs := edsIn.Row(i)[:edsIn.Width()/2]
+		rows[i] = shwap.NewRow(rowShrs, shwap.Left)
+	}
+
+	_, err = edsFromRows(roots, rows)
+	require.Error(t, err)
+}
+
+func TestGetter_HeightSessions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	eds := edstest.RandEDS(t, 4)
+	exchange := newExchangeOverEDS(ctx, t, eds)
+	getter := NewGetter(exchange, nil, 0)
+	getter.Start()
+	t.Cleanup(getter.Stop)
+
+	// requests for the same height share the session
+	session := getter.heightSession(1)
+	require.Same(t, session, getter.heightSession(1))
+	require.NotSame(t, session, getter.heightSession(2))
+
+	// sessions of least recently used heights are dropped
+	for height := range uint64(maxHeightSessions) {
+		getter.heightSession(height + 2)
+	}
+	require.Equal(t, maxHeightSessions, getter.heightSessions.Len())
+	require.NotSame(t, session, getter.heightSession(1))
+}
+
+func TestGetter_GetSharesByNamespace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	const odsSize = 8
+	ns := sharetest.RandV0Namespace()
+	// the namespace takes a part of the square only
+	eds, _ := edstest.RandEDSWithNamespace(t, ns, odsSize+odsSize/2, odsSize)
+	hdr := headertest.ExtendedHeaderFromEDS(t, 1, eds)
+	rowIdxs := share.RowsWithNamespace(hdr.DAH, ns)
+	require.Less(t, len(rowIdxs), odsSize)
+
+	exchange := newExchangeOverEDS(ctx, t, eds)
+	getter := NewGetter(exchange, nil, 0)
+	getter.Start()
+	t.Cleanup(getter.Stop)
+
+	nd, err := getter.GetSharesByNamespace(ctx, hdr, ns)
+	require.NoError(t, err)
+	require.NoError(t, nd.Verify(hdr.DAH, ns))
+	// only rows that can contain the namespace are retrieved, with the namespace shares only
+	require.Len(t, nd, len(rowIdxs))
+	for _, row := range nd {
+		for _, shr := range row.Shares {
+			require.Equal(t, ns, share.GetNamespace(shr))
+		}
+	}
+}
+
+func TestGetter_GetSharesOnShare(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	eds := edstest.RandEDS(t, 4)
+	hdr := headertest.ExtendedHeaderFromEDS(t, 1, eds)
+	exchange := newExchangeOverEDS(ctx, t, eds)
+	getter := NewGetter(exchange, nil, 0)
+	getter.Start()
+	t.Cleanup(getter.Stop)
+
+	// the duplicated coordinate is reported for both of its indexes
+	rowIdxs, colIdxs := []int{0, 3, 7, 0}, []int{1, 2, 5, 1}
+	var (
+		lk       sync.Mutex
+		reported = make(map[int]share.Share)
+	)
+	ctx = shwap.WithGetOptions(ctx, shwap.WithOnShare(func(idx int, shr share.Share) {
+		lk.Lock()
+		defer lk.Unlock()
+		reported[idx] = shr
+	}))
+	shares, err := getter.GetShares(ctx, hdr, rowIdxs, colIdxs)
+	require.NoError(t, err)
+	require.Len(t, reported, len(rowIdxs))
+	for i, shr := range shares {
+		require.Equal(t, eds.GetCell(uint(rowIdxs[i]), uint(colIdxs[i])), shr)
+		require.Equal(t, shr, reported[i])
+	}
+}
@@ -0,0 +1,45 @@
+package shrex
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// compressedVersion is the first shrex protocol version with zstd compressed response bodies.
+// Response statuses are never compressed.
+const compressedVersion = "v0.3.0"
+
+// IsCompressed reports whether response bodies of the negotiated protocol are zstd compressed.
+func IsCompressed(protocolID protocol.ID) bool {
+	return ProtocolVersion(protocolID) == compressedVersion
+}
+
+// withoutCompression drops the versions with compressed response bodies from the protocol IDs.
+func withoutCompression(ids []protocol.ID) []protocol.ID {
+	filtered := make([]protocol.ID, 0, len(ids))
+	for _, id := range ids {
+		if !IsCompressed(id) {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered
+}
+
+// NewCompressor returns a writer compressing the response body written through it into w. The
+// writer must be closed to flush the body.
+func NewCompressor(w io.Writer) (io.WriteCloser, error) {
+	// responses are written by a single goroutine, so single threaded encoding avoids spawning
+	// goroutines per stream
+	return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+}
+
+// NewDecompressor returns a reader of the response body decompressed from r.
+func NewDecompressor(r io.Reader) (io.ReadCloser, error) {
+	decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return decoder.IOReadCloser(), nil
+}
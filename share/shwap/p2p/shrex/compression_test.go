@@ -0,0 +1,43 @@
+package shrex
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompression(t *testing.T) {
+	// padding-like data compresses well
+	data := bytes.Repeat([]byte{0, 0, 0, 1}, 1<<14)
+
+	var buf bytes.Buffer
+	compressor, err := NewCompressor(&buf)
+	require.NoError(t, err)
+	_, err = compressor.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, compressor.Close())
+	require.Less(t, buf.Len()*10, len(data))
+
+	decompressor, err := NewDecompressor(&buf)
+	require.NoError(t, err)
+	defer decompressor.Close()
+	got, err := io.ReadAll(decompressor)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+}
+
+func TestParameters_ProtocolIDs(t *testing.T) {
+	params := DefaultParameters()
+	params.WithNetworkID("test")
+	ids := params.ProtocolIDs("eds")
+	require.Equal(t, ProtocolIDs("test", "eds"), ids)
+	require.True(t, IsCompressed(ids[0]))
+
+	params.Compression = false
+	for _, id := range params.ProtocolIDs("eds") {
+		require.False(t, IsCompressed(id))
+	}
+	require.Len(t, params.ProtocolIDs("eds"), len(ids)-1)
+}
@@ -25,6 +25,8 @@ const (
 	StatusTimeout     status = "timeout"
 	StatusSuccess     status = "success"
 	StatusRateLimited status = "rate_limited"
+	// StatusPeerRateLimited is observed for requests rejected by the PeerRateLimiter.
+	StatusPeerRateLimited status = "peer_rate_limited"
 )
 
 type Metrics struct {
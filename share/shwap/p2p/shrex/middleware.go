@@ -1,27 +1,62 @@
 package shrex
 
 import (
+	"sync"
 	"sync/atomic"
+	"time"
 
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 var log = logging.Logger("shrex/middleware")
 
+// MiddlewareOption configures the Middleware.
+type MiddlewareOption func(*Middleware)
+
+// WithQueue makes the Middleware keep up to size streams waiting for a concurrency slot for at
+// most timeout, instead of rejecting them right away.
+func WithQueue(size int, timeout time.Duration) MiddlewareOption {
+	return func(m *Middleware) {
+		m.queueSize = int64(size)
+		m.queueTimeout = timeout
+	}
+}
+
+// WithPeerConcurrencyLimit limits the number of streams of a single peer handled or queued at once.
+func WithPeerConcurrencyLimit(limit int) MiddlewareOption {
+	return func(m *Middleware) {
+		m.peerLimit = limit
+	}
+}
+
 type Middleware struct {
-	// concurrencyLimit is the maximum number of requests that can be processed at once.
-	concurrencyLimit int64
-	// parallelRequests is the number of requests currently being processed.
-	parallelRequests atomic.Int64
+	// slots holds a token for every request being processed, up to the concurrency limit.
+	slots chan struct{}
+	// queueSize is the maximum number of requests waiting for a slot.
+	queueSize int64
+	// queueTimeout is the maximum time a request waits for a slot.
+	queueTimeout time.Duration
+	// queued is the number of requests currently waiting for a slot.
+	queued atomic.Int64
+	// peerLimit is the maximum number of in-flight requests of a single peer. Zero disables it.
+	peerLimit int
+	peersLk   sync.Mutex
+	inFlight  map[peer.ID]int
 	// numRateLimited is the number of requests that were rate limited.
 	numRateLimited atomic.Int64
 }
 
-func NewMiddleware(concurrencyLimit int) *Middleware {
-	return &Middleware{
-		concurrencyLimit: int64(concurrencyLimit),
+func NewMiddleware(concurrencyLimit int, opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{
+		slots:    make(chan struct{}, concurrencyLimit),
+		inFlight: make(map[peer.ID]int),
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m
 }
 
 // DrainCounter returns the current value of the rate limit counter and resets it to 0.
@@ -29,20 +64,81 @@ func (m *Middleware) DrainCounter() int64 {
 	return m.numRateLimited.Swap(0)
 }
 
+// RateLimitHandler wraps the handler limiting the number of concurrently processed streams in
+// total and per peer. Streams over the limits are closed without a response, which clients treat
+// as being rate limited.
 func (m *Middleware) RateLimitHandler(handler network.StreamHandler) network.StreamHandler {
 	return func(stream network.Stream) {
-		current := m.parallelRequests.Add(1)
-		defer m.parallelRequests.Add(-1)
-
-		if current > m.concurrencyLimit {
-			m.numRateLimited.Add(1)
-			log.Debug("concurrency limit reached")
-			err := stream.Close()
-			if err != nil {
-				log.Debugw("server: closing stream", "err", err)
-			}
+		remote := stream.Conn().RemotePeer()
+		if !m.acquirePeer(remote) {
+			m.reject(stream, "peer concurrency limit reached")
 			return
 		}
+		defer m.releasePeer(remote)
+
+		if !m.acquireSlot() {
+			m.reject(stream, "concurrency limit reached")
+			return
+		}
+		defer func() { <-m.slots }()
 		handler(stream)
 	}
 }
+
+// acquireSlot takes a concurrency slot, waiting in the queue if there is room in it.
+func (m *Middleware) acquireSlot() bool {
+	select {
+	case m.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if m.queued.Add(1) > m.queueSize {
+		m.queued.Add(-1)
+		return false
+	}
+	defer m.queued.Add(-1)
+
+	timer := time.NewTimer(m.queueTimeout)
+	defer timer.Stop()
+	select {
+	case m.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (m *Middleware) acquirePeer(id peer.ID) bool {
+	if m.peerLimit <= 0 {
+		return true
+	}
+	m.peersLk.Lock()
+	defer m.peersLk.Unlock()
+	if m.inFlight[id] >= m.peerLimit {
+		return false
+	}
+	m.inFlight[id]++
+	return true
+}
+
+func (m *Middleware) releasePeer(id peer.ID) {
+	if m.peerLimit <= 0 {
+		return
+	}
+	m.peersLk.Lock()
+	defer m.peersLk.Unlock()
+	m.inFlight[id]--
+	if m.inFlight[id] <= 0 {
+		delete(m.inFlight, id)
+	}
+}
+
+func (m *Middleware) reject(stream network.Stream, reason string) {
+	m.numRateLimited.Add(1)
+	log.Debug(reason)
+	err := stream.Close()
+	if err != nil {
+		log.Debugw("server: closing stream", "err", err)
+	}
+}
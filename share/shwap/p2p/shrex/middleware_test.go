@@ -0,0 +1,50 @@
+package shrex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware_Queue(t *testing.T) {
+	m := NewMiddleware(1, WithQueue(1, time.Second))
+	require.True(t, m.acquireSlot())
+
+	// the second request waits in the queue for the slot to free up
+	acquired := make(chan bool)
+	go func() {
+		acquired <- m.acquireSlot()
+	}()
+	require.Eventually(t, func() bool { return m.queued.Load() == 1 }, time.Second, time.Millisecond)
+
+	// the queue is full, so the third request is rejected right away
+	require.False(t, m.acquireSlot())
+
+	<-m.slots
+	require.True(t, <-acquired)
+}
+
+func TestMiddleware_QueueTimeout(t *testing.T) {
+	m := NewMiddleware(1, WithQueue(1, 10*time.Millisecond))
+	require.True(t, m.acquireSlot())
+	require.False(t, m.acquireSlot())
+	require.Zero(t, m.queued.Load())
+}
+
+func TestMiddleware_PeerConcurrencyLimit(t *testing.T) {
+	m := NewMiddleware(10, WithPeerConcurrencyLimit(1))
+
+	peerA, peerB := peer.ID("a"), peer.ID("b")
+	require.True(t, m.acquirePeer(peerA))
+	require.False(t, m.acquirePeer(peerA))
+	// other peers are not affected
+	require.True(t, m.acquirePeer(peerB))
+
+	m.releasePeer(peerA)
+	require.True(t, m.acquirePeer(peerA))
+	m.releasePeer(peerA)
+	m.releasePeer(peerB)
+	require.Empty(t, m.inFlight)
+}
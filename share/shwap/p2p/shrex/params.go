@@ -2,6 +2,7 @@ package shrex
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -10,6 +11,18 @@ import (
 // protocolString is the protocol string for the shrex protocol.
 const ProtocolString = "/shrex/v0.1.0/"
 
+// ProtocolVersions lists the versions of the shrex protocol supported by the node from the most
+// to the least preferred one. Servers serve every version, while clients negotiate the most
+// preferred version their peer supports on stream open, so that changes of the container formats
+// roll out as new versions without breaking older nodes. Handlers tell the negotiated version with
+// ProtocolVersion.
+//
+// Versions:
+//   - v0.3.0: response bodies are zstd compressed, see IsCompressed.
+//   - v0.2.0: shrex/eds requests carry the offset into the ODS byte stream to resume from.
+//   - v0.1.0: the initial version.
+var ProtocolVersions = []string{"v0.3.0", "v0.2.0", "v0.1.0"}
+
 // Parameters is the set of parameters that must be configured for the shrex/eds protocol.
 type Parameters struct {
 	// ServerReadTimeout sets the timeout for reading messages from the stream.
@@ -24,6 +37,33 @@ type Parameters struct {
 	// ConcurrencyLimit is the maximum number of concurrently handled streams
 	ConcurrencyLimit int
 
+	// QueueSize is the maximum number of streams waiting for a free concurrency slot. Streams
+	// beyond it are rejected right away.
+	QueueSize int
+
+	// QueueTimeout is the maximum time a stream waits in the queue before being rejected.
+	QueueTimeout time.Duration
+
+	// PeerConcurrencyLimit is the maximum number of streams of a single peer handled or queued at
+	// once. Zero disables the per peer limit.
+	PeerConcurrencyLimit int
+
+	// PeerRequestRate is the average number of requests per second a single peer may make. Zero
+	// disables the per peer limit. It is only enforced by the shrex/nd server.
+	PeerRequestRate float64
+
+	// PeerRequestBurst is the number of requests a single peer may make at once.
+	PeerRequestBurst int
+
+	// PreferQUIC makes clients open streams over QUIC connections to peers when there are any,
+	// falling back to the other transports otherwise.
+	PreferQUIC bool
+
+	// Compression enables zstd compression of response bodies. It is negotiated on stream open and
+	// only applies when both the client and the server enable it. Empty and sparse squares, which
+	// are mostly padding, compress well, which saves bandwidth at the cost of CPU.
+	Compression bool
+
 	// networkID is prepended to the protocolID and represents the network the protocol is
 	// running on.
 	networkID string
@@ -35,6 +75,13 @@ func DefaultParameters() *Parameters {
 		ServerWriteTimeout:   time.Minute, // based on max observed sample time for 256 blocks (~50s)
 		HandleRequestTimeout: time.Minute,
 		ConcurrencyLimit:     10,
+		QueueSize:            32,
+		QueueTimeout:         5 * time.Second,
+		PeerConcurrencyLimit: 4,
+		PeerRequestRate:      10,
+		PeerRequestBurst:     20,
+		PreferQUIC:           true,
+		Compression:          true,
 	}
 }
 
@@ -53,6 +100,21 @@ func (p *Parameters) Validate() error {
 	if p.ConcurrencyLimit <= 0 {
 		return fmt.Errorf("invalid concurrency limit: %s", errSuffix)
 	}
+	if p.QueueSize < 0 {
+		return fmt.Errorf("invalid queue size: %d, value should be non-negative", p.QueueSize)
+	}
+	if p.QueueSize > 0 && p.QueueTimeout <= 0 {
+		return fmt.Errorf("invalid queue timeout: %v, %s", p.QueueTimeout, errSuffix)
+	}
+	if p.PeerConcurrencyLimit < 0 {
+		return fmt.Errorf("invalid peer concurrency limit: %d, value should be non-negative", p.PeerConcurrencyLimit)
+	}
+	if p.PeerRequestRate < 0 {
+		return fmt.Errorf("invalid peer request rate: %v, value should be non-negative", p.PeerRequestRate)
+	}
+	if p.PeerRequestRate > 0 && p.PeerRequestBurst <= 0 {
+		return fmt.Errorf("invalid peer request burst: %s", errSuffix)
+	}
 	return nil
 }
 
@@ -70,3 +132,35 @@ func (p *Parameters) NetworkID() string {
 func ProtocolID(networkID, protocolString string) protocol.ID {
 	return protocol.ID(fmt.Sprintf("/%s%s", networkID, protocolString))
 }
+
+// ProtocolIDs creates the protocol IDs of every supported version of the shrex protocol with the
+// given name, from the most to the least preferred one.
+func ProtocolIDs(networkID, name string) []protocol.ID {
+	ids := make([]protocol.ID, len(ProtocolVersions))
+	for i, version := range ProtocolVersions {
+		ids[i] = ProtocolID(networkID, fmt.Sprintf("/shrex/%s/%s", version, name))
+	}
+	return ids
+}
+
+// ProtocolIDs creates the protocol IDs of the shrex protocol with the given name for the versions
+// enabled by the parameters, from the most to the least preferred one.
+func (p *Parameters) ProtocolIDs(name string) []protocol.ID {
+	ids := ProtocolIDs(p.networkID, name)
+	if !p.Compression {
+		ids = withoutCompression(ids)
+	}
+	return ids
+}
+
+// ProtocolVersion returns the shrex protocol version of the given protocol ID, or an empty string
+// if it is not a shrex one.
+func ProtocolVersion(id protocol.ID) string {
+	parts := strings.Split(string(id), "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "shrex" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package shrex
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+)
+
+// maxIdlePeerLimiters is the amount of tracked peers after which limiters of idle peers are
+// dropped.
+const maxIdlePeerLimiters = 1024
+
+// PeerRateLimiter limits the rate of requests of every remote peer with a token bucket per peer,
+// so that a single peer can not monopolize the server.
+type PeerRateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	lock     sync.Mutex
+	limiters map[peer.ID]*rate.Limiter
+	// numRateLimited is the number of requests that were rate limited.
+	numRateLimited atomic.Int64
+	now            func() time.Time
+}
+
+// NewPeerRateLimiter creates a new PeerRateLimiter letting every peer make requestsPerSecond
+// requests per second on average and up to burst requests at once. Zero requestsPerSecond
+// disables the limit.
+func NewPeerRateLimiter(requestsPerSecond float64, burst int) *PeerRateLimiter {
+	return &PeerRateLimiter{
+		limit:    rate.Limit(requestsPerSecond),
+		burst:    burst,
+		limiters: make(map[peer.ID]*rate.Limiter),
+		now:      time.Now,
+	}
+}
+
+// DrainCounter returns the current value of the rate limit counter and resets it to 0.
+func (l *PeerRateLimiter) DrainCounter() int64 {
+	return l.numRateLimited.Swap(0)
+}
+
+// Handler wraps the given handler, closing streams of peers exceeding their rate limit without
+// handling them.
+func (l *PeerRateLimiter) Handler(handler network.StreamHandler) network.StreamHandler {
+	if l.limit == 0 {
+		return handler
+	}
+	return func(stream network.Stream) {
+		remote := stream.Conn().RemotePeer()
+		if !l.allow(remote) {
+			l.numRateLimited.Add(1)
+			log.Debugw("peer rate limit reached", "peer", remote.String())
+			err := stream.Close()
+			if err != nil {
+				log.Debugw("server: closing stream", "err", err)
+			}
+			return
+		}
+		handler(stream)
+	}
+}
+
+// allow reports whether the peer is allowed to make a request now.
+func (l *PeerRateLimiter) allow(id peer.ID) bool {
+	now := l.now()
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	limiter, ok := l.limiters[id]
+	if !ok {
+		if len(l.limiters) >= maxIdlePeerLimiters {
+			l.dropIdle(now)
+		}
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[id] = limiter
+	}
+	return limiter.AllowN(now, 1)
+}
+
+// dropIdle drops the limiters with full buckets, as they are no different from new ones.
+func (l *PeerRateLimiter) dropIdle(now time.Time) {
+	for id, limiter := range l.limiters {
+		if limiter.TokensAt(now) >= float64(l.burst) {
+			delete(l.limiters, id)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package shrex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := NewPeerRateLimiter(1, 2)
+	limiter.now = func() time.Time { return now }
+
+	peerA, peerB := peer.ID("a"), peer.ID("b")
+	// burst is spent
+	require.True(t, limiter.allow(peerA))
+	require.True(t, limiter.allow(peerA))
+	require.False(t, limiter.allow(peerA))
+	// other peers are not affected
+	require.True(t, limiter.allow(peerB))
+
+	// bucket refills with time
+	now = now.Add(time.Second)
+	require.True(t, limiter.allow(peerA))
+	require.False(t, limiter.allow(peerA))
+}
+
+func TestPeerRateLimiter_DropIdle(t *testing.T) {
+	now := time.Now()
+	limiter := NewPeerRateLimiter(1, 1)
+	limiter.now = func() time.Time { return now }
+
+	for i := range maxIdlePeerLimiters {
+		require.True(t, limiter.allow(peer.ID(rune(i))))
+	}
+	require.Len(t, limiter.limiters, maxIdlePeerLimiters)
+
+	// all buckets are full again, so the limiters are dropped on the next new peer
+	now = now.Add(time.Second)
+	require.True(t, limiter.allow(peer.ID("new")))
+	require.Len(t, limiter.limiters, 1)
+}
@@ -0,0 +1,127 @@
+package peers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+type breakerState string
+
+const (
+	breakerStateKey              = "breaker_state"
+	breakerOpen     breakerState = "open"
+	breakerHalfOpen breakerState = "half_open"
+)
+
+// peerBreakers trips the breaker of a peer after the given amount of consecutive failed requests,
+// excluding the peer from selection. Once the cooldown passes, the breaker is half-open and lets a
+// single probe request through, which closes it on success and opens it again on failure.
+type peerBreakers struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	lock     sync.Mutex
+	breakers map[peer.ID]*peerBreaker
+}
+
+type peerBreaker struct {
+	// failures is the number of consecutive failed requests
+	failures int
+	// openedAt is the time the breaker was tripped at
+	openedAt time.Time
+	// probing is set while the probe request of a half-open breaker is in flight
+	probing bool
+}
+
+func newPeerBreakers(threshold int, cooldown time.Duration) *peerBreakers {
+	return &peerBreakers{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		breakers:  make(map[peer.ID]*peerBreaker),
+	}
+}
+
+// available reports whether the breaker of the peer lets a request through.
+func (pb *peerBreakers) available(peerID peer.ID) bool {
+	pb.lock.Lock()
+	defer pb.lock.Unlock()
+
+	state, ok := pb.state(peerID)
+	if !ok {
+		return true
+	}
+	return state == breakerHalfOpen && !pb.breakers[peerID].probing
+}
+
+// acquire marks the request of a half-open breaker of the peer as the probe.
+func (pb *peerBreakers) acquire(peerID peer.ID) {
+	pb.lock.Lock()
+	defer pb.lock.Unlock()
+
+	if state, ok := pb.state(peerID); ok && state == breakerHalfOpen {
+		pb.breakers[peerID].probing = true
+	}
+}
+
+// observe records the outcome of a request to the peer.
+func (pb *peerBreakers) observe(peerID peer.ID, failed bool) {
+	if pb.threshold <= 0 {
+		return
+	}
+
+	pb.lock.Lock()
+	defer pb.lock.Unlock()
+
+	if !failed {
+		delete(pb.breakers, peerID)
+		return
+	}
+
+	b, ok := pb.breakers[peerID]
+	if !ok {
+		b = &peerBreaker{}
+		pb.breakers[peerID] = b
+	}
+	b.failures++
+	if b.probing || b.failures == pb.threshold {
+		log.Debugw("opening peer breaker", "peer", peerID.String(), "failures", b.failures)
+		b.openedAt = pb.now()
+		b.probing = false
+	}
+}
+
+func (pb *peerBreakers) remove(peerID peer.ID) {
+	pb.lock.Lock()
+	defer pb.lock.Unlock()
+	delete(pb.breakers, peerID)
+}
+
+// states returns the amount of breakers in every state other than closed.
+func (pb *peerBreakers) states() map[breakerState]int64 {
+	pb.lock.Lock()
+	defer pb.lock.Unlock()
+
+	states := map[breakerState]int64{breakerOpen: 0, breakerHalfOpen: 0}
+	for peerID := range pb.breakers {
+		if state, ok := pb.state(peerID); ok {
+			states[state]++
+		}
+	}
+	return states
+}
+
+// state returns the state of the breaker of the peer, unless it is closed.
+func (pb *peerBreakers) state(peerID peer.ID) (breakerState, bool) {
+	b, ok := pb.breakers[peerID]
+	if !ok || b.failures < pb.threshold {
+		return "", false
+	}
+	if pb.now().Sub(b.openedAt) < pb.cooldown {
+		return breakerOpen, true
+	}
+	return breakerHalfOpen, true
+}
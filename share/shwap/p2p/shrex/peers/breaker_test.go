@@ -0,0 +1,52 @@
+package peers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerBreakers(t *testing.T) {
+	now := time.Now()
+	breakers := newPeerBreakers(2, time.Minute)
+	breakers.now = func() time.Time { return now }
+	peerID := peer.ID("peer")
+
+	// a success in between resets consecutive failures
+	breakers.observe(peerID, true)
+	breakers.observe(peerID, false)
+	breakers.observe(peerID, true)
+	require.True(t, breakers.available(peerID))
+
+	breakers.observe(peerID, true)
+	require.False(t, breakers.available(peerID))
+	require.Equal(t, map[breakerState]int64{breakerOpen: 1, breakerHalfOpen: 0}, breakers.states())
+
+	// after the cooldown a single probe is let through
+	now = now.Add(time.Minute)
+	require.True(t, breakers.available(peerID))
+	require.Equal(t, map[breakerState]int64{breakerOpen: 0, breakerHalfOpen: 1}, breakers.states())
+	breakers.acquire(peerID)
+	require.False(t, breakers.available(peerID))
+
+	// failed probe opens the breaker again
+	breakers.observe(peerID, true)
+	require.False(t, breakers.available(peerID))
+	now = now.Add(time.Minute)
+	breakers.acquire(peerID)
+
+	// successful probe closes the breaker
+	breakers.observe(peerID, false)
+	require.True(t, breakers.available(peerID))
+	require.Equal(t, map[breakerState]int64{breakerOpen: 0, breakerHalfOpen: 0}, breakers.states())
+}
+
+func TestPeerBreakers_Disabled(t *testing.T) {
+	breakers := newPeerBreakers(0, time.Minute)
+	for range 10 {
+		breakers.observe("peer", true)
+	}
+	require.True(t, breakers.available("peer"))
+}
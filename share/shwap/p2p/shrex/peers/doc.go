@@ -20,7 +20,9 @@
 //
 // This gives the peer manager an ability to block peers that gossip invalid shares, but also access a list of peers
 // that are known to have been gossiping valid shares.
-// The peers are then returned on request using a round-robin algorithm to return a different peer each time.
+// The peers are then returned on request preferring the ones with the lowest response latency and
+// failure rate, while a share of requests is routed round-robin to probe the other peers. The
+// strategy is pluggable with WithPeerSelector, e.g. to prefer an operator's own fleet.
 // If no peers are found, the peer manager will rely on full nodes retrieved from discovery.
 //
 // The peer manager is only concerned with recent heights, thus it retrieves peers that
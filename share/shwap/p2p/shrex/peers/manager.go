@@ -30,6 +30,10 @@ const (
 	// ResultCooldownPeer will put returned peer on cooldown, meaning it won't be available by Peer
 	// method for some time
 	ResultCooldownPeer = "result_cooldown_peer"
+	// ResultFailedPeer will put returned peer on cooldown, same as ResultCooldownPeer, and counts as
+	// a failure of the peer, which excludes it from selection for a while after
+	// PeerFailureThreshold consecutive failures
+	ResultFailedPeer = "result_failed_peer"
 	// ResultBlacklistPeer will blacklist peer. Blacklisted peers will be disconnected and blocked from
 	// any p2p communication in future by libp2p Gater
 	ResultBlacklistPeer = "result_blacklist_peer"
@@ -73,6 +77,12 @@ type Manager struct {
 
 	// nodes collects nodes' peer.IDs found via discovery
 	nodes *pool
+	// scores tracks latency and failure rate of peers to prefer the fast ones
+	scores *peerScores
+	// selector picks the peer to serve a request out of the available ones
+	selector PeerSelector
+	// breakers exclude peers that keep failing from selection
+	breakers *peerBreakers
 
 	// hashes that are not in the chain
 	blacklistedHashes map[string]bool
@@ -117,6 +127,8 @@ func NewManager(
 		host:                  host,
 		pools:                 make(map[string]*syncPool),
 		blacklistedHashes:     make(map[string]bool),
+		scores:                newPeerScores(),
+		breakers:              newPeerBreakers(params.PeerFailureThreshold, params.PeerBreakerCooldown),
 		headerSubDone:         make(chan struct{}),
 		disconnectedPeersDone: make(chan struct{}),
 		tag:                   tag,
@@ -129,6 +141,9 @@ func NewManager(
 		}
 	}
 
+	if s.selector == nil {
+		s.selector = NewLatencySelector(s.params.PeerProbeRate)
+	}
 	s.nodes = newPool(s.params.PeerCooldown)
 	return s, nil
 }
@@ -207,7 +222,7 @@ func (m *Manager) Peer(ctx context.Context, datahash share.DataHash, height uint
 	p := m.validatedPool(datahash.String(), height)
 
 	// first, check if a peer is available for the given datahash
-	peerID, ok := p.tryGet()
+	peerID, ok := m.tryGet(p.pool)
 	if ok {
 		if m.removeIfUnreachable(p, peerID) {
 			return m.Peer(ctx, datahash, height)
@@ -217,7 +232,7 @@ func (m *Manager) Peer(ctx context.Context, datahash share.DataHash, height uint
 
 	// if no peer for datahash is currently available, try to use node
 	// obtained from discovery
-	peerID, ok = m.nodes.tryGet()
+	peerID, ok = m.tryGet(m.nodes)
 	if ok {
 		return m.newPeer(ctx, datahash, peerID, sourceDiscoveredNodes, m.nodes.len(), 0)
 	}
@@ -251,6 +266,18 @@ func (m *Manager) UpdateNodePool(peerID peer.ID, isAdded bool) {
 
 	log.Debugw("removing peer from discovered nodes pool", "peer", peerID.String())
 	m.nodes.remove(peerID)
+	m.scores.remove(peerID)
+	m.breakers.remove(peerID)
+}
+
+// tryGet returns the peer chosen by the selector from the given pool, skipping peers with an open
+// breaker.
+func (m *Manager) tryGet(p *pool) (peer.ID, bool) {
+	peerID, ok := p.tryGetWith(m.breakers.available, m.scores.stats, m.selector)
+	if ok {
+		m.breakers.acquire(peerID)
+	}
+	return peerID, ok
 }
 
 func (m *Manager) newPeer(
@@ -268,11 +295,27 @@ func (m *Manager) newPeer(
 		"pool_size", poolSize,
 		"wait (s)", waitTime)
 	m.metrics.observeGetPeer(ctx, source, poolSize, waitTime)
-	return peerID, m.doneFunc(datahash, peerID, source), nil
+	return peerID, m.doneFunc(ctx, datahash, peerID, source), nil
 }
 
-func (m *Manager) doneFunc(datahash share.DataHash, peerID peer.ID, source peerSource) DoneFunc {
+// doneFunc returns the DoneFunc of the peer handed out to the caller with the given context.
+func (m *Manager) doneFunc(
+	ctx context.Context,
+	datahash share.DataHash,
+	peerID peer.ID,
+	source peerSource,
+) DoneFunc {
+	start := time.Now()
 	return func(result result) {
+		// cooldowns are not the peer's fault, e.g. it may just not have the data yet
+		failed := result == ResultFailedPeer || result == ResultBlacklistPeer
+		if ctx.Err() != nil {
+			// the latency is cut short by the caller giving up, so it tells nothing about the peer
+			m.scores.observeFailure(peerID, failed)
+		} else {
+			m.scores.observe(peerID, time.Since(start), failed)
+		}
+		m.breakers.observe(peerID, result == ResultFailedPeer)
 		log.Debugw("set peer result",
 			"hash", datahash.String(),
 			"peer", peerID.String(),
@@ -281,7 +324,7 @@ func (m *Manager) doneFunc(datahash share.DataHash, peerID peer.ID, source peerS
 		m.metrics.observeDoneResult(source, result)
 		switch result {
 		case ResultNoop:
-		case ResultCooldownPeer:
+		case ResultCooldownPeer, ResultFailedPeer:
 			if source == sourceDiscoveredNodes {
 				m.nodes.putOnCooldown(peerID)
 				return
@@ -343,6 +386,8 @@ func (m *Manager) subscribeDisconnectedPeers(ctx context.Context, sub event.Subs
 						"peer", peer.String())
 					m.nodes.remove(peer)
 				}
+				m.scores.remove(peer)
+				m.breakers.remove(peer)
 			}
 		}
 	}
@@ -420,6 +465,8 @@ func (m *Manager) blacklistPeers(reason blacklistPeerReason, peerIDs ...peer.ID)
 		}
 
 		m.nodes.remove(peerID)
+		m.scores.remove(peerID)
+		m.breakers.remove(peerID)
 		// add peer to the blacklist, so we can't connect to it in the future.
 		err := m.connGater.BlockPeer(peerID)
 		if err != nil {
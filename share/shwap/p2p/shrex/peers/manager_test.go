@@ -71,6 +71,37 @@ func TestManager(t *testing.T) {
 		require.True(t, manager.getPool(h.DataHash.String()).isValidatedDataHash.Load())
 	})
 
+	t.Run("done scores", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		t.Cleanup(cancel)
+
+		h := testHeader()
+		headerSub := newSubLock(h, nil)
+		manager, err := testManager(ctx, headerSub)
+		require.NoError(t, err)
+		peerID := peer.ID("peer1")
+
+		// cooldowns are not the peer's fault
+		manager.doneFunc(ctx, h.DataHash.Bytes(), peerID, sourceShrexSub)(ResultCooldownPeer)
+		stats := manager.scores.stats(peerID)
+		require.True(t, stats.Observed)
+		require.Zero(t, stats.FailureRate)
+
+		manager.doneFunc(ctx, h.DataHash.Bytes(), peerID, sourceShrexSub)(ResultFailedPeer)
+		require.Positive(t, manager.scores.stats(peerID).FailureRate)
+
+		// the latency of requests the caller gave up on is not observed
+		latency := manager.scores.stats(peerID).Latency
+		canceledCtx, cancelReq := context.WithCancel(ctx)
+		done := manager.doneFunc(canceledCtx, h.DataHash.Bytes(), peerID, sourceShrexSub)
+		cancelReq()
+		time.Sleep(10 * time.Millisecond)
+		done(ResultNoop)
+		require.Equal(t, latency, manager.scores.stats(peerID).Latency)
+
+		stopManager(t, manager)
+	})
+
 	t.Run("validator", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 		t.Cleanup(cancel)
@@ -68,6 +68,7 @@ type metrics struct {
 	discoveredPool           metric.Int64ObservableGauge // attributes: pool_status
 	blacklistedPeersByReason sync.Map
 	blacklistedPeers         metric.Int64ObservableGauge // attributes: blacklist_reason
+	peerBreakers             metric.Int64ObservableGauge // attributes: breaker_state
 
 	clientReg metric.Registration
 }
@@ -126,6 +127,13 @@ func initMetrics(manager *Manager) (*metrics, error) {
 		return nil, err
 	}
 
+	breakers, err := meter.Int64ObservableGauge(
+		manager.tag+"_peer_manager_peer_breakers_gauge",
+		metric.WithDescription("amount of peers with an open or half-open circuit breaker"))
+	if err != nil {
+		return nil, err
+	}
+
 	metrics := &metrics{
 		getPeer:                  getPeer,
 		getPeerWaitTimeHistogram: getPeerWaitTimeHistogram,
@@ -135,6 +143,7 @@ func initMetrics(manager *Manager) (*metrics, error) {
 		discoveredPool:           discoveredPool,
 		getPeerPoolSizeHistogram: getPeerPoolSizeHistogram,
 		blacklistedPeers:         blacklisted,
+		peerBreakers:             breakers,
 	}
 
 	callback := func(_ context.Context, observer metric.Observer) error {
@@ -159,9 +168,15 @@ func initMetrics(manager *Manager) (*metrics, error) {
 					attribute.String(blacklistPeerReasonKey, string(reason))))
 			return true
 		})
+
+		for state, count := range manager.breakers.states() {
+			observer.ObserveInt64(breakers, count,
+				metric.WithAttributes(
+					attribute.String(breakerStateKey, string(state))))
+		}
 		return nil
 	}
-	metrics.clientReg, err = meter.RegisterCallback(callback, shrexPools, discoveredPool, blacklisted)
+	metrics.clientReg, err = meter.RegisterCallback(callback, shrexPools, discoveredPool, blacklisted, breakers)
 	if err != nil {
 		return nil, fmt.Errorf("registering metrics callback: %w", err)
 	}
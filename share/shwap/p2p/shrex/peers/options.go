@@ -24,6 +24,20 @@ type Parameters struct {
 
 	// EnableBlackListing turns on blacklisting for misbehaved peers
 	EnableBlackListing bool
+
+	// PeerProbeRate is the share of requests routed round-robin to any available peer instead of the
+	// one with the best latency and failure rate, so that scores of other peers stay up to date.
+	// Setting it to 1 disables score based peer selection. It only applies to the default
+	// LatencySelector.
+	PeerProbeRate float64
+
+	// PeerFailureThreshold is the number of consecutive failed requests after which a peer is
+	// excluded from selection for PeerBreakerCooldown. Zero disables excluding failing peers.
+	PeerFailureThreshold int
+
+	// PeerBreakerCooldown is the time a failing peer is excluded from selection for, before a single
+	// probe request is routed to it again.
+	PeerBreakerCooldown time.Duration
 }
 
 type Option func(*Manager) error
@@ -42,6 +56,18 @@ func (p *Parameters) Validate() error {
 		return fmt.Errorf("peer-manager: garbage collection interval must be positive")
 	}
 
+	if p.PeerProbeRate < 0 || p.PeerProbeRate > 1 {
+		return fmt.Errorf("peer-manager: peer probe rate must be between 0 and 1")
+	}
+
+	if p.PeerFailureThreshold < 0 {
+		return fmt.Errorf("peer-manager: peer failure threshold must be non-negative")
+	}
+
+	if p.PeerFailureThreshold > 0 && p.PeerBreakerCooldown <= 0 {
+		return fmt.Errorf("peer-manager: peer breaker cooldown must be positive")
+	}
+
 	return nil
 }
 
@@ -60,6 +86,11 @@ func DefaultParameters() *Parameters {
 		// blacklisting is off by default //TODO(@walldiss): enable blacklisting once all related issues
 		// are resolved
 		EnableBlackListing: false,
+		PeerProbeRate:      0.2,
+		// PeerFailureThreshold and PeerBreakerCooldown exclude peers that keep timing out for long
+		// enough to not slow down sampling, while still noticing when they recover.
+		PeerFailureThreshold: 5,
+		PeerBreakerCooldown:  30 * time.Second,
 	}
 }
 
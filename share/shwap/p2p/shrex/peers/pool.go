@@ -79,6 +79,28 @@ func (p *pool) tryGet() (peer.ID, bool) {
 	}
 }
 
+// tryGetWith returns the active and available peer chosen by the selector along with bool flag
+// indicating success of operation.
+func (p *pool) tryGetWith(
+	available func(peer.ID) bool,
+	stats func(peer.ID) PeerStats,
+	selector PeerSelector,
+) (peer.ID, bool) {
+	p.m.RLock()
+	candidates := make([]PeerStats, 0, p.activeCount)
+	for _, peerID := range p.peersList {
+		if p.statuses[peerID] == active && available(peerID) {
+			candidates = append(candidates, stats(peerID))
+		}
+	}
+	p.m.RUnlock()
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+	return selector.Select(candidates), true
+}
+
 // next sends a peer to the returned channel when it becomes available.
 func (p *pool) next(ctx context.Context) <-chan peer.ID {
 	peerCh := make(chan peer.ID, 1)
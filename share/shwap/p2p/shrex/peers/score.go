@@ -0,0 +1,92 @@
+package peers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// scoreDecay is the weight of the latest observation in the moving averages of peer scores.
+	scoreDecay = 0.2
+	// minSuccessRate bounds the score of peers that keep failing, so that they still get probed.
+	minSuccessRate = 0.05
+)
+
+// peerScores tracks response latency and failure rate of peers to prefer fast and reliable ones.
+type peerScores struct {
+	lock   sync.Mutex
+	scores map[peer.ID]*peerScore
+}
+
+type peerScore struct {
+	// latency is the exponential moving average of the response latency
+	latency time.Duration
+	// failureRate is the exponential moving average of failed requests
+	failureRate float64
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{
+		scores: make(map[peer.ID]*peerScore),
+	}
+}
+
+// observe records the latency and the outcome of a request to the peer.
+func (ps *peerScores) observe(peerID peer.ID, latency time.Duration, failed bool) {
+	var failure float64
+	if failed {
+		failure = 1
+	}
+
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	s, ok := ps.scores[peerID]
+	if !ok {
+		ps.scores[peerID] = &peerScore{latency: latency, failureRate: failure}
+		return
+	}
+	s.latency = time.Duration((1-scoreDecay)*float64(s.latency) + scoreDecay*float64(latency))
+	s.failureRate = (1-scoreDecay)*s.failureRate + scoreDecay*failure
+}
+
+// observeFailure records the outcome of a request to the peer whose latency is unknown, e.g.
+// because the caller gave up on it. Peers without the latency observed yet are left unobserved.
+func (ps *peerScores) observeFailure(peerID peer.ID, failed bool) {
+	var failure float64
+	if failed {
+		failure = 1
+	}
+
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	if s, ok := ps.scores[peerID]; ok {
+		s.failureRate = (1-scoreDecay)*s.failureRate + scoreDecay*failure
+	}
+}
+
+// stats returns the observed performance of the peer.
+func (ps *peerScores) stats(peerID peer.ID) PeerStats {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	s, ok := ps.scores[peerID]
+	if !ok {
+		return PeerStats{ID: peerID}
+	}
+	return PeerStats{ID: peerID, Observed: true, Latency: s.latency, FailureRate: s.failureRate}
+}
+
+// score returns the score of the peer, see score.
+func (ps *peerScores) score(peerID peer.ID) float64 {
+	return score(ps.stats(peerID))
+}
+
+func (ps *peerScores) remove(peerID peer.ID) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	delete(ps.scores, peerID)
+}
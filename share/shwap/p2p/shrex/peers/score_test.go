@@ -0,0 +1,60 @@
+package peers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerScores(t *testing.T) {
+	scores := newPeerScores()
+	fast, slow, failing, unknown := peer.ID("fast"), peer.ID("slow"), peer.ID("failing"), peer.ID("unknown")
+
+	for range 5 {
+		scores.observe(fast, 10*time.Millisecond, false)
+		scores.observe(slow, 100*time.Millisecond, false)
+		scores.observe(failing, 10*time.Millisecond, true)
+	}
+	require.Less(t, scores.score(fast), scores.score(slow))
+	require.Less(t, scores.score(fast), scores.score(failing))
+	// unknown peers are preferred to get measured
+	require.Zero(t, scores.score(unknown))
+
+	scores.remove(fast)
+	require.Zero(t, scores.score(fast))
+
+	// outcomes without the latency only update observed peers
+	scores.observeFailure(unknown, true)
+	require.False(t, scores.stats(unknown).Observed)
+	scores.observeFailure(slow, true)
+	require.Positive(t, scores.stats(slow).FailureRate)
+}
+
+func TestPool_TryGetWith(t *testing.T) {
+	scores := newPeerScores()
+	p := newPool(time.Second)
+	p.add("peer1", "peer2", "peer3")
+
+	scores.observe("peer1", 100*time.Millisecond, false)
+	scores.observe("peer2", 10*time.Millisecond, false)
+	scores.observe("peer3", 50*time.Millisecond, false)
+
+	selector := NewLatencySelector(0)
+	for range 3 {
+		peerID, ok := p.tryGetWith(func(peer.ID) bool { return true }, scores.stats, selector)
+		require.True(t, ok)
+		require.Equal(t, peer.ID("peer2"), peerID)
+	}
+
+	// peers on cooldown are skipped
+	p.putOnCooldown("peer2")
+	peerID, ok := p.tryGetWith(func(peer.ID) bool { return true }, scores.stats, selector)
+	require.True(t, ok)
+	require.Equal(t, peer.ID("peer3"), peerID)
+
+	p.remove("peer1", "peer3")
+	_, ok = p.tryGetWith(func(peer.ID) bool { return true }, scores.stats, selector)
+	require.False(t, ok)
+}
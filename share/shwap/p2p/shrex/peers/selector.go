@@ -0,0 +1,130 @@
+package peers
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// PeerStats describes the observed performance of a peer.
+type PeerStats struct {
+	ID peer.ID
+	// Observed tells whether the peer served any request yet. Latency and FailureRate are zero
+	// otherwise.
+	Observed bool
+	// Latency is the moving average of the response latency of the peer.
+	Latency time.Duration
+	// FailureRate is the moving average of failed requests to the peer, between 0 and 1.
+	FailureRate float64
+}
+
+// PeerSelector picks the peer to serve a request out of the available ones. Implementations must
+// be safe for concurrent use.
+type PeerSelector interface {
+	// Select returns the ID of one of the given candidates, which are never empty.
+	Select(candidates []PeerStats) peer.ID
+}
+
+// WithPeerSelector makes the manager select peers with the given selector instead of the default
+// LatencySelector.
+func WithPeerSelector(selector PeerSelector) Option {
+	return func(m *Manager) error {
+		m.selector = selector
+		return nil
+	}
+}
+
+// RoundRobinSelector selects candidates in turns.
+type RoundRobinSelector struct {
+	next atomic.Uint64
+}
+
+// NewRoundRobinSelector creates a new RoundRobinSelector.
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{}
+}
+
+// Select returns the candidate whose turn it is.
+func (s *RoundRobinSelector) Select(candidates []PeerStats) peer.ID {
+	idx := (s.next.Add(1) - 1) % uint64(len(candidates))
+	return candidates[idx].ID
+}
+
+// LatencySelector selects the candidate expected to respond successfully the soonest, based on its
+// latency and failure rate. A share of requests is routed round-robin instead, so that stats of
+// other candidates stay up to date.
+type LatencySelector struct {
+	probeRate float64
+	probe     *RoundRobinSelector
+}
+
+// NewLatencySelector creates a new LatencySelector routing the given share of requests
+// round-robin.
+func NewLatencySelector(probeRate float64) *LatencySelector {
+	return &LatencySelector{
+		probeRate: probeRate,
+		probe:     NewRoundRobinSelector(),
+	}
+}
+
+// Select returns the candidate with the best score, or the next candidate when probing.
+func (s *LatencySelector) Select(candidates []PeerStats) peer.ID {
+	if rand.Float64() < s.probeRate { //nolint:gosec
+		return s.probe.Select(candidates)
+	}
+
+	best, bestScore := candidates[0].ID, score(candidates[0])
+	for _, candidate := range candidates[1:] {
+		if sc := score(candidate); sc < bestScore {
+			best, bestScore = candidate.ID, sc
+		}
+	}
+	return best
+}
+
+// AffinitySelector prefers a fixed set of peers, like the own fleet of an operator, selecting
+// among them with another selector. Other peers are only selected when none of the preferred ones
+// is available.
+type AffinitySelector struct {
+	preferred map[peer.ID]struct{}
+	selector  PeerSelector
+}
+
+// NewAffinitySelector creates a new AffinitySelector preferring the given peers and selecting
+// among candidates with the given selector.
+func NewAffinitySelector(preferred []peer.ID, selector PeerSelector) *AffinitySelector {
+	set := make(map[peer.ID]struct{}, len(preferred))
+	for _, id := range preferred {
+		set[id] = struct{}{}
+	}
+	return &AffinitySelector{
+		preferred: set,
+		selector:  selector,
+	}
+}
+
+// Select returns one of the preferred candidates, if there are any, or one of all candidates
+// otherwise.
+func (s *AffinitySelector) Select(candidates []PeerStats) peer.ID {
+	preferred := make([]PeerStats, 0, len(candidates))
+	for _, candidate := range candidates {
+		if _, ok := s.preferred[candidate.ID]; ok {
+			preferred = append(preferred, candidate)
+		}
+	}
+	if len(preferred) > 0 {
+		return s.selector.Select(preferred)
+	}
+	return s.selector.Select(candidates)
+}
+
+// score returns the expected time until a successful response from the peer. Lower is better.
+// Peers without observations score zero, so that every peer gets measured.
+func score(stats PeerStats) float64 {
+	if !stats.Observed {
+		return 0
+	}
+	return float64(stats.Latency) / max(1-stats.FailureRate, minSuccessRate)
+}
@@ -0,0 +1,44 @@
+package peers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundRobinSelector(t *testing.T) {
+	candidates := []PeerStats{{ID: "peer1"}, {ID: "peer2"}, {ID: "peer3"}}
+	selector := NewRoundRobinSelector()
+	for i := range 2 * len(candidates) {
+		require.Equal(t, candidates[i%len(candidates)].ID, selector.Select(candidates))
+	}
+}
+
+func TestLatencySelector(t *testing.T) {
+	candidates := []PeerStats{
+		{ID: "slow", Observed: true, Latency: 100 * time.Millisecond},
+		{ID: "fast", Observed: true, Latency: 10 * time.Millisecond},
+		{ID: "failing", Observed: true, Latency: 10 * time.Millisecond, FailureRate: 0.9},
+	}
+	require.Equal(t, peer.ID("fast"), NewLatencySelector(0).Select(candidates))
+
+	// unobserved peers are measured first
+	candidates = append(candidates, PeerStats{ID: "unknown"})
+	require.Equal(t, peer.ID("unknown"), NewLatencySelector(0).Select(candidates))
+}
+
+func TestAffinitySelector(t *testing.T) {
+	selector := NewAffinitySelector([]peer.ID{"own1", "own2"}, NewLatencySelector(0))
+
+	candidates := []PeerStats{
+		{ID: "other", Observed: true, Latency: time.Millisecond},
+		{ID: "own1", Observed: true, Latency: 100 * time.Millisecond},
+		{ID: "own2", Observed: true, Latency: 10 * time.Millisecond},
+	}
+	// preferred peers win even over faster others
+	require.Equal(t, peer.ID("own2"), selector.Select(candidates))
+	// others are selected once no preferred peer is available
+	require.Equal(t, peer.ID("other"), selector.Select(candidates[:1]))
+}
@@ -149,6 +149,10 @@ func (sg *Getter) GetShare(context.Context, *header.ExtendedHeader, int, int) (s
 	return nil, fmt.Errorf("getter/shrex: GetShare %w", shwap.ErrOperationNotSupported)
 }
 
+func (sg *Getter) GetShares(context.Context, *header.ExtendedHeader, []int, []int) ([]share.Share, error) {
+	return nil, fmt.Errorf("getter/shrex: GetShares %w", shwap.ErrOperationNotSupported)
+}
+
 func (sg *Getter) GetEDS(ctx context.Context, header *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
 	var err error
 	ctx, span := tracer.Start(ctx, "shrex/get-eds")
@@ -182,23 +186,28 @@ func (sg *Getter) GetEDS(ctx context.Context, header *header.ExtendedHeader) (*r
 
 		reqStart := time.Now()
 		reqCtx, cancel := utils.CtxWithSplitTimeout(ctx, sg.minAttemptsCount-attempt+1, sg.minRequestTimeout)
+		reqCtx, attemptSpan := startAttemptSpan(reqCtx, peer, attempt)
 		eds, getErr := sg.edsClient.RequestEDS(reqCtx, header.DAH, header.Height(), peer)
+		utils.SetStatusAndEnd(attemptSpan, getErr)
 		cancel()
 		switch {
 		case getErr == nil:
 			setStatus(peers.ResultNoop)
 			sg.metrics.recordEDSAttempt(ctx, attempt, true)
 			return eds, nil
+		case ctx.Err() != nil:
+			// the request itself is done, so the peer is not to blame
+			setStatus(peers.ResultCooldownPeer)
 		case errors.Is(getErr, context.DeadlineExceeded),
 			errors.Is(getErr, context.Canceled):
-			setStatus(peers.ResultCooldownPeer)
+			setStatus(peers.ResultFailedPeer)
 		case errors.Is(getErr, shrex.ErrNotFound):
 			getErr = shwap.ErrNotFound
 			setStatus(peers.ResultCooldownPeer)
 		case errors.Is(getErr, shrex.ErrInvalidResponse):
 			setStatus(peers.ResultBlacklistPeer)
 		default:
-			setStatus(peers.ResultCooldownPeer)
+			setStatus(peers.ResultFailedPeer)
 		}
 
 		if !shrex.ErrorContains(err, getErr) {
@@ -260,7 +269,9 @@ func (sg *Getter) GetSharesByNamespace(
 
 		reqStart := time.Now()
 		reqCtx, cancel := utils.CtxWithSplitTimeout(ctx, sg.minAttemptsCount-attempt+1, sg.minRequestTimeout)
+		reqCtx, attemptSpan := startAttemptSpan(reqCtx, peer, attempt)
 		nd, getErr := sg.ndClient.RequestND(reqCtx, header.Height(), namespace, peer)
+		utils.SetStatusAndEnd(attemptSpan, getErr)
 		cancel()
 		switch {
 		case getErr == nil:
@@ -273,16 +284,19 @@ func (sg *Getter) GetSharesByNamespace(
 			setStatus(peers.ResultNoop)
 			sg.metrics.recordNDAttempt(ctx, attempt, true)
 			return nd, nil
+		case ctx.Err() != nil:
+			// the request itself is done, so the peer is not to blame
+			setStatus(peers.ResultCooldownPeer)
 		case errors.Is(getErr, context.DeadlineExceeded),
 			errors.Is(getErr, context.Canceled):
-			setStatus(peers.ResultCooldownPeer)
+			setStatus(peers.ResultFailedPeer)
 		case errors.Is(getErr, shrex.ErrNotFound):
 			getErr = shwap.ErrNotFound
 			setStatus(peers.ResultCooldownPeer)
 		case errors.Is(getErr, shrex.ErrInvalidResponse):
 			setStatus(peers.ResultBlacklistPeer)
 		default:
-			setStatus(peers.ResultCooldownPeer)
+			setStatus(peers.ResultFailedPeer)
 		}
 
 		if !shrex.ErrorContains(err, getErr) {
@@ -298,6 +312,14 @@ func (sg *Getter) GetSharesByNamespace(
 	}
 }
 
+// startAttemptSpan starts the span of a single request attempt to the given peer.
+func startAttemptSpan(ctx context.Context, peer libpeer.ID, attempt int) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "shrex/attempt", trace.WithAttributes(
+		attribute.String("peer", peer.String()),
+		attribute.Int("attempt", attempt),
+	))
+}
+
 func (sg *Getter) getPeer(
 	ctx context.Context,
 	header *header.ExtendedHeader,
@@ -1,6 +1,7 @@
 package shrexeds
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -24,11 +25,17 @@ import (
 	shrexpb "github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/pb"
 )
 
+// errInvalidResume is returned when the EDS of a resumed transfer fails verification.
+var errInvalidResume = errors.New("resumed eds failed verification")
+
 // Client is responsible for requesting EDSs for blocksync over the ShrEx/EDS protocol.
 type Client struct {
-	params     *Parameters
-	protocolID protocol.ID
-	host       host.Host
+	params      *Parameters
+	protocolIDs []protocol.ID
+	host        host.Host
+	// partials keeps the ODS bytes of broken transfers by data hash, nil when resumption is
+	// disabled.
+	partials *partialCache
 
 	metrics *shrex.Metrics
 }
@@ -40,13 +47,16 @@ func NewClient(params *Parameters, host host.Host) (*Client, error) {
 	}
 
 	return &Client{
-		params:     params,
-		host:       host,
-		protocolID: shrex.ProtocolID(params.NetworkID(), protocolString),
+		params:      params,
+		host:        host,
+		protocolIDs: params.ProtocolIDs(protocolName),
+		partials:    newPartialCache(params.MaxPartialTransfersSize),
 	}, nil
 }
 
-// RequestEDS requests the ODS from the given peers and returns the EDS upon success.
+// RequestEDS requests the ODS from the given peers and returns the EDS upon success. A transfer
+// broken halfway is resumed from where it broke by the following request for the same EDS, even
+// to another peer, as long as the peer supports ranged requests.
 func (c *Client) RequestEDS(
 	ctx context.Context,
 	root *share.AxisRoots,
@@ -54,6 +64,15 @@ func (c *Client) RequestEDS(
 	peer peer.ID,
 ) (*rsmt2d.ExtendedDataSquare, error) {
 	eds, err := c.doRequest(ctx, root, height, peer)
+	if errors.Is(err, errInvalidResume) {
+		// the partial transfer is dropped by now, so the peer is only blamed if the EDS fails
+		// verification when transferred from the start
+		log.Debugw("client: resumed eds failed verification, retrying from the start",
+			"height", height,
+			"peer", peer.String(),
+			"error", err)
+		eds, err = c.doRequest(ctx, root, height, peer)
+	}
 	if err == nil {
 		return eds, nil
 	}
@@ -92,23 +111,32 @@ func (c *Client) doRequest(
 ) (*rsmt2d.ExtendedDataSquare, error) {
 	streamOpenCtx, cancel := context.WithTimeout(ctx, c.params.ServerReadTimeout)
 	defer cancel()
-	stream, err := c.host.NewStream(streamOpenCtx, to, c.protocolID)
+	stream, err := shrex.NewStream(streamOpenCtx, c.host, to, c.params.PreferQUIC, c.protocolIDs...)
 	if err != nil {
 		return nil, fmt.Errorf("open stream: %w", err)
 	}
 	defer utils.CloseAndLog(log, "client", stream)
 
 	c.setStreamDeadlines(ctx, stream)
-	// request ODS
-	log.Debugw("client: requesting ods",
-		"height", height,
-		"peer", to.String())
 	id, err := shwap.NewEdsID(height)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
-	_, err = id.WriteTo(stream)
+	// resume the broken transfer of the ODS, if any
+	ranged := isRanged(stream.Protocol())
+	var partial []byte
+	if ranged {
+		partial = c.takePartial(root)
+	}
+	// request ODS
+	log.Debugw("client: requesting ods",
+		"height", height,
+		"peer", to.String(),
+		"offset", len(partial))
+	req := request{id: id, offset: uint64(len(partial))}
+	err = req.writeTo(stream, ranged)
 	if err != nil {
+		c.putPartial(root, partial)
 		return nil, fmt.Errorf("write request to stream: %w", err)
 	}
 
@@ -125,6 +153,7 @@ func (c *Client) doRequest(
 	}
 	_, err = serde.Read(stream, resp)
 	if err != nil {
+		c.putPartial(root, partial)
 		// server closes the stream here if we are rate limited
 		if errors.Is(err, io.EOF) {
 			c.metrics.ObserveRequests(ctx, 1, shrex.StatusRateLimited)
@@ -136,14 +165,15 @@ func (c *Client) doRequest(
 	case shrexpb.Status_OK:
 		// reset stream deadlines to original values, since read deadline was changed during status read
 		c.setStreamDeadlines(ctx, stream)
-		// use header and ODS bytes to construct EDS and verify it against dataHash
-		eds, err := eds.ReadAccessor(ctx, stream, root)
+		// construct EDS from the ODS bytes and verify it against dataHash
+		square, err := c.readEDS(ctx, stream, root, partial)
 		if err != nil {
 			return nil, fmt.Errorf("read eds from stream: %w", err)
 		}
 		c.metrics.ObserveRequests(ctx, 1, shrex.StatusSuccess)
-		return eds.ExtendedDataSquare, nil
+		return square, nil
 	case shrexpb.Status_NOT_FOUND:
+		c.putPartial(root, partial)
 		c.metrics.ObserveRequests(ctx, 1, shrex.StatusNotFound)
 		return nil, shrex.ErrNotFound
 	case shrexpb.Status_INVALID:
@@ -157,6 +187,86 @@ func (c *Client) doRequest(
 	}
 }
 
+// readEDS reads the ODS bytes following the partial ones from the stream and verifies the EDS
+// they make up against the root as they arrive. If the stream breaks, the bytes read so far are
+// kept to resume the transfer from. If the resumed EDS fails verification, errInvalidResume is
+// returned, as the partial bytes may be the invalid ones.
+func (c *Client) readEDS(
+	ctx context.Context,
+	stream network.Stream,
+	root *share.AxisRoots,
+	partial []byte,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	odsWidth := len(root.RowRoots) / 2
+	maxSize := odsWidth * odsWidth * share.Size
+
+	var r io.Reader = stream
+	if shrex.IsCompressed(stream.Protocol()) {
+		decompressor, err := shrex.NewDecompressor(stream)
+		if err != nil {
+			c.putPartial(root, partial)
+			return nil, fmt.Errorf("creating decompressor: %w", err)
+		}
+		defer decompressor.Close() //nolint:errcheck
+		r = decompressor
+	}
+
+	odsReader := &odsReader{r: r}
+	if c.partials != nil {
+		odsReader.record = bytes.NewBuffer(partial)
+	}
+	// the size is checked on the decompressed bytes, so compressed responses can't exceed it either
+	square, err := eds.ReadAccessor(ctx, io.MultiReader(bytes.NewReader(partial), odsReader), root)
+	if odsReader.err != nil {
+		if odsReader.record != nil {
+			c.putPartial(root, odsReader.record.Bytes())
+		}
+		return nil, odsReader.err
+	}
+	if err != nil {
+		if len(partial) > 0 && ctx.Err() == nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidResume, err)
+		}
+		return nil, err
+	}
+	// the ODS must be followed by the end of the stream
+	if n, _ := io.ReadFull(odsReader, make([]byte, 1)); n > 0 {
+		return nil, fmt.Errorf("ods exceeds %d bytes", maxSize)
+	}
+	return square.ExtendedDataSquare, nil
+}
+
+// odsReader reads the ODS bytes from the stream, recording them, so that the transfer can be
+// resumed from where it broke. It keeps the error the stream broke with, if any.
+type odsReader struct {
+	r io.Reader
+	// record holds the ODS bytes read so far, nil when resumption is disabled.
+	record *bytes.Buffer
+	err    error
+}
+
+func (r *odsReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if r.record != nil {
+		r.record.Write(p[:n])
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		r.err = err
+	}
+	return n, err
+}
+
+// takePartial removes the partial transfer of the EDS with the given root from the cache and
+// returns it.
+func (c *Client) takePartial(root *share.AxisRoots) []byte {
+	return c.partials.take(string(root.Hash()))
+}
+
+// putPartial keeps the partial transfer of the EDS with the given root.
+func (c *Client) putPartial(root *share.AxisRoots, partial []byte) {
+	c.partials.add(string(root.Hash()), partial)
+}
+
 func (c *Client) setStreamDeadlines(ctx context.Context, stream network.Stream) {
 	// set read/write deadline to use context deadline if it exists
 	if dl, ok := ctx.Deadline(); ok {
@@ -2,6 +2,7 @@ package shrexeds
 
 import (
 	"context"
+	"io"
 	"sync"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/celestiaorg/celestia-node/share"
+	edsacc "github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/eds/edstest"
 	"github.com/celestiaorg/celestia-node/share/shwap"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex"
@@ -41,6 +43,65 @@ func TestExchange_RequestEDS(t *testing.T) {
 		assert.Equal(t, eds.Flattened(), requestedEDS.Flattened())
 	})
 
+	// Testcase: EDS is transferred uncompressed to clients with compression disabled
+	t.Run("EDS_Uncompressed", func(t *testing.T) {
+		eds := edstest.RandEDS(t, 4)
+		roots, err := share.NewAxisRoots(eds)
+		require.NoError(t, err)
+		height := uint64(3)
+		err = store.PutODSQ4(ctx, roots, height, eds)
+		require.NoError(t, err)
+
+		params := DefaultParameters()
+		params.Compression = false
+		client, err := NewClient(params, client.host)
+		require.NoError(t, err)
+
+		requestedEDS, err := client.RequestEDS(ctx, roots, height, server.host.ID())
+		assert.NoError(t, err)
+		assert.Equal(t, eds.Flattened(), requestedEDS.Flattened())
+	})
+
+	// Testcase: broken transfer is resumed from where it broke
+	t.Run("EDS_Resumed", func(t *testing.T) {
+		eds := edstest.RandEDS(t, 4)
+		roots, err := share.NewAxisRoots(eds)
+		require.NoError(t, err)
+		height := uint64(2)
+		err = store.PutODSQ4(ctx, roots, height, eds)
+		require.NoError(t, err)
+
+		reader, err := (&edsacc.Rsmt2D{ExtendedDataSquare: eds}).Reader()
+		require.NoError(t, err)
+		ods, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		// the transfer broke in the middle of a share
+		client.putPartial(roots, ods[:len(ods)/2+7])
+
+		requestedEDS, err := client.RequestEDS(ctx, roots, height, server.host.ID())
+		assert.NoError(t, err)
+		assert.Equal(t, eds.Flattened(), requestedEDS.Flattened())
+		assert.Zero(t, client.partials.len())
+	})
+
+	// Testcase: resumed transfer failing verification is retried from the start
+	t.Run("EDS_ResumedInvalid", func(t *testing.T) {
+		eds := edstest.RandEDS(t, 4)
+		roots, err := share.NewAxisRoots(eds)
+		require.NoError(t, err)
+		height := uint64(4)
+		err = store.PutODSQ4(ctx, roots, height, eds)
+		require.NoError(t, err)
+
+		// the partial transfer is corrupted, not the peer's response
+		client.putPartial(roots, make([]byte, 2*share.Size))
+
+		requestedEDS, err := client.RequestEDS(ctx, roots, height, server.host.ID())
+		assert.NoError(t, err)
+		assert.Equal(t, eds.Flattened(), requestedEDS.Flattened())
+		assert.Zero(t, client.partials.len())
+	})
+
 	// Testcase: EDS is unavailable initially, but is found after multiple requests
 	t.Run("EDS_AvailableAfterDelay", func(t *testing.T) {
 		eds := edstest.RandEDS(t, 4)
@@ -115,7 +176,7 @@ func TestExchange_RequestEDS(t *testing.T) {
 			}
 		}
 		middleware := shrex.NewMiddleware(rateLimit)
-		server.host.SetStreamHandler(server.protocolID,
+		server.host.SetStreamHandler(server.protocolIDs[0],
 			middleware.RateLimitHandler(mockHandler))
 
 		// take server concurrency slots with blocked requests
@@ -9,7 +9,7 @@ import (
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex"
 )
 
-const protocolString = shrex.ProtocolString + shwap.EDSName
+const protocolName = shwap.EDSName
 
 var log = logging.Logger("shrex/eds")
 
@@ -19,12 +19,18 @@ type Parameters struct {
 
 	// BufferSize defines the size of the buffer used for writing an ODS over the stream.
 	BufferSize uint64
+
+	// MaxPartialTransfersSize is the maximum amount of bytes of broken ODS transfers the client
+	// keeps, so that they can be resumed from another peer from where they broke. Zero disables
+	// resumption.
+	MaxPartialTransfersSize int
 }
 
 func DefaultParameters() *Parameters {
 	return &Parameters{
-		Parameters: shrex.DefaultParameters(),
-		BufferSize: 32 * 1024,
+		Parameters:              shrex.DefaultParameters(),
+		BufferSize:              32 * 1024,
+		MaxPartialTransfersSize: 256 << 20,
 	}
 }
 
@@ -32,6 +38,11 @@ func (p *Parameters) Validate() error {
 	if p.BufferSize <= 0 {
 		return fmt.Errorf("invalid buffer size: %v, value should be positive and non-zero", p.BufferSize)
 	}
+	if p.MaxPartialTransfersSize < 0 {
+		return fmt.Errorf(
+			"invalid max partial transfers size: %d, value should be non-negative", p.MaxPartialTransfersSize,
+		)
+	}
 
 	return p.Parameters.Validate()
 }
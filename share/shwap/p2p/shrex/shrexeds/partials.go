@@ -0,0 +1,71 @@
+package shrexeds
+
+import (
+	"math"
+	"sync"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+)
+
+// partialCache keeps the ODS bytes of broken transfers by data hash, so that they can be resumed
+// from where they broke. The cache evicts the least recently broken transfers once the total size
+// of the kept bytes exceeds the limit.
+type partialCache struct {
+	lock     sync.Mutex
+	size     int
+	maxSize  int
+	partials *simplelru.LRU[string, []byte]
+}
+
+// newPartialCache creates a partialCache bounded by the given amount of bytes. It returns nil for a
+// zero size, which disables resumption.
+func newPartialCache(maxSize int) *partialCache {
+	if maxSize <= 0 {
+		return nil
+	}
+	cache := &partialCache{maxSize: maxSize}
+	// size is bounded by bytes instead of the amount of transfers, which is checked on every add
+	cache.partials, _ = simplelru.NewLRU(math.MaxInt, func(_ string, partial []byte) {
+		cache.size -= len(partial)
+	})
+	return cache
+}
+
+// take removes the partial transfer with the given key from the cache and returns it.
+func (c *partialCache) take(key string) []byte {
+	if c == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	partial, _ := c.partials.Peek(key)
+	c.partials.Remove(key)
+	return partial
+}
+
+// add keeps the partial transfer with the given key, replacing the previous one, if any.
+func (c *partialCache) add(key string, partial []byte) {
+	if c == nil || len(partial) == 0 || len(partial) > c.maxSize {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.partials.Remove(key)
+	c.partials.Add(key, partial)
+	c.size += len(partial)
+	for c.size > c.maxSize {
+		c.partials.RemoveOldest()
+	}
+}
+
+// len returns the amount of kept partial transfers.
+func (c *partialCache) len() int {
+	if c == nil {
+		return 0
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.partials.Len()
+}
@@ -0,0 +1,38 @@
+package shrexeds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartialCache(t *testing.T) {
+	partial := make([]byte, 100)
+
+	// fits two transfers only
+	cache := newPartialCache(2 * len(partial))
+	cache.add("root1", partial)
+	cache.add("root2", partial)
+	// replacing a transfer doesn't count it twice
+	cache.add("root2", partial)
+	require.Equal(t, 2*len(partial), cache.size)
+
+	// the least recently broken transfer is evicted
+	cache.add("root3", partial)
+	require.Equal(t, 2, cache.len())
+	require.Nil(t, cache.take("root1"))
+	require.Equal(t, partial, cache.take("root2"))
+	// taken transfers are removed
+	require.Nil(t, cache.take("root2"))
+	require.Equal(t, len(partial), cache.size)
+
+	// transfers exceeding the limit on their own are not kept
+	cache.add("root4", make([]byte, 3*len(partial)))
+	require.Nil(t, cache.take("root4"))
+
+	// disabled cache
+	disabled := newPartialCache(0)
+	disabled.add("root1", partial)
+	require.Nil(t, disabled.take("root1"))
+	require.Zero(t, disabled.len())
+}
@@ -0,0 +1,68 @@
+package shrexeds
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex"
+)
+
+// unrangedVersion is the shrex protocol version with requests lacking the ODS offset, which always
+// transfer the whole ODS.
+const unrangedVersion = "v0.1.0"
+
+// offsetSize is the size of the ODS offset in ranged requests.
+const offsetSize = 8
+
+// isRanged reports whether requests of the negotiated protocol carry the ODS offset.
+func isRanged(protocolID protocol.ID) bool {
+	return shrex.ProtocolVersion(protocolID) != unrangedVersion
+}
+
+// request is the shrex/eds request for the ODS of the given height starting from the byte offset
+// into the ODS byte stream.
+type request struct {
+	id     shwap.EdsID
+	offset uint64
+}
+
+// writeTo writes the request in the format of the negotiated protocol. The request is written at
+// once, so a server closing the stream right away is noticed on reading the status.
+func (r request) writeTo(w io.Writer, ranged bool) error {
+	if !ranged {
+		_, err := r.id.WriteTo(w)
+		return err
+	}
+
+	data, err := r.id.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	data = binary.BigEndian.AppendUint64(data, r.offset)
+	_, err = w.Write(data)
+	return err
+}
+
+// readFrom reads the request in the format of the negotiated protocol.
+func (r *request) readFrom(reader io.Reader, ranged bool) error {
+	_, err := r.id.ReadFrom(reader)
+	if err != nil || !ranged {
+		return err
+	}
+
+	offset := make([]byte, offsetSize)
+	_, err = io.ReadFull(reader, offset)
+	if err != nil {
+		return fmt.Errorf("reading offset: %w", err)
+	}
+	r.offset = binary.BigEndian.Uint64(offset)
+	if maxOffset := uint64(share.MaxSquareSize * share.MaxSquareSize * share.Size); r.offset > maxOffset {
+		return fmt.Errorf("offset %d exceeds the maximum ODS size of %d bytes", r.offset, maxOffset)
+	}
+	return nil
+}
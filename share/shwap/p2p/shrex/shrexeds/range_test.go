@@ -0,0 +1,39 @@
+package shrexeds
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+func TestRequest(t *testing.T) {
+	id, err := shwap.NewEdsID(1)
+	require.NoError(t, err)
+	req := request{id: id, offset: 1024}
+
+	for _, ranged := range []bool{true, false} {
+		buf := new(bytes.Buffer)
+		require.NoError(t, req.writeTo(buf, ranged))
+
+		got := request{}
+		require.NoError(t, got.readFrom(buf, ranged))
+		require.Equal(t, id, got.id)
+		if ranged {
+			require.Equal(t, req.offset, got.offset)
+		} else {
+			// unranged requests always start from the beginning of the ODS
+			require.Zero(t, got.offset)
+		}
+	}
+}
+
+func TestRequest_OffsetTooLarge(t *testing.T) {
+	id, err := shwap.NewEdsID(1)
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	require.NoError(t, request{id: id, offset: 1 << 62}.writeTo(buf, true))
+	require.Error(t, (&request{}).readFrom(buf, true))
+}
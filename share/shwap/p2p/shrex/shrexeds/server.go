@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/host"
@@ -16,7 +17,6 @@ import (
 
 	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/share/eds"
-	"github.com/celestiaorg/celestia-node/share/shwap"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex"
 	shrexpb "github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex/pb"
 	"github.com/celestiaorg/celestia-node/store"
@@ -26,14 +26,18 @@ import (
 type Server struct {
 	cancel context.CancelFunc
 
-	host       host.Host
-	protocolID protocol.ID
+	host        host.Host
+	protocolIDs []protocol.ID
 
 	store *store.Store
 
 	params     *Parameters
 	middleware *shrex.Middleware
 	metrics    *shrex.Metrics
+
+	// buffers pools the buffers ODSs are copied to streams through, so that serving many
+	// concurrent requests doesn't allocate a buffer for each of them
+	buffers sync.Pool
 }
 
 // NewServer creates a new ShrEx/EDS server.
@@ -42,26 +46,41 @@ func NewServer(params *Parameters, host host.Host, store *store.Store) (*Server,
 		return nil, fmt.Errorf("shrex-eds: server creation failed: %w", err)
 	}
 
-	return &Server{
-		host:       host,
-		store:      store,
-		protocolID: shrex.ProtocolID(params.NetworkID(), protocolString),
-		params:     params,
-		middleware: shrex.NewMiddleware(params.ConcurrencyLimit),
-	}, nil
+	srv := &Server{
+		host:        host,
+		store:       store,
+		protocolIDs: params.ProtocolIDs(protocolName),
+		params:      params,
+		middleware: shrex.NewMiddleware(
+			params.ConcurrencyLimit,
+			shrex.WithQueue(params.QueueSize, params.QueueTimeout),
+			shrex.WithPeerConcurrencyLimit(params.PeerConcurrencyLimit),
+		),
+	}
+	srv.buffers.New = func() any {
+		buf := make([]byte, params.BufferSize)
+		return &buf
+	}
+	return srv, nil
 }
 
 func (s *Server) Start(context.Context) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	s.cancel = cancel
 
-	s.host.SetStreamHandler(s.protocolID, s.middleware.RateLimitHandler(s.streamHandler(ctx)))
+	// every supported protocol version is served by the same handler
+	handler := s.middleware.RateLimitHandler(s.streamHandler(ctx))
+	for _, protocolID := range s.protocolIDs {
+		s.host.SetStreamHandler(protocolID, handler)
+	}
 	return nil
 }
 
 func (s *Server) Stop(context.Context) error {
 	defer s.cancel()
-	s.host.RemoveStreamHandler(s.protocolID)
+	for _, protocolID := range s.protocolIDs {
+		s.host.RemoveStreamHandler(protocolID)
+	}
 	return nil
 }
 
@@ -95,13 +114,14 @@ func (s *Server) handleEDS(ctx context.Context, stream network.Stream) error {
 	s.observeRateLimitedRequests()
 
 	// read request from stream to get the dataHash for store lookup
-	id, err := s.readRequest(logger, stream)
+	req, err := s.readRequest(logger, stream)
 	if err != nil {
 		logger.Warnw("server: reading request from stream", "err", err)
 		return err
 	}
+	id := req.id
 
-	logger = logger.With("height", id.Height)
+	logger = logger.With("height", id.Height, "offset", req.offset)
 
 	ctx, cancel := context.WithTimeout(ctx, s.params.HandleRequestTimeout)
 	defer cancel()
@@ -136,7 +156,7 @@ func (s *Server) handleEDS(ctx context.Context, stream network.Stream) error {
 	}
 
 	// start streaming the ODS to the client
-	err = s.writeODS(logger, file, stream)
+	err = s.writeODS(logger, file, req.offset, stream)
 	if err != nil {
 		logger.Warnw("server: writing ods to stream", "err", err)
 		return err
@@ -144,22 +164,22 @@ func (s *Server) handleEDS(ctx context.Context, stream network.Stream) error {
 	return nil
 }
 
-func (s *Server) readRequest(logger *zap.SugaredLogger, stream network.Stream) (shwap.EdsID, error) {
+func (s *Server) readRequest(logger *zap.SugaredLogger, stream network.Stream) (request, error) {
 	err := stream.SetReadDeadline(time.Now().Add(s.params.ServerReadTimeout))
 	if err != nil {
 		logger.Debugw("server: set read deadline", "err", err)
 	}
 
-	edsID := shwap.EdsID{}
-	_, err = edsID.ReadFrom(stream)
+	req := request{}
+	err = req.readFrom(stream, isRanged(stream.Protocol()))
 	if err != nil {
-		return shwap.EdsID{}, fmt.Errorf("reading request: %w", err)
+		return request{}, fmt.Errorf("reading request: %w", err)
 	}
 	err = stream.CloseRead()
 	if err != nil {
 		logger.Warnw("server: closing read", "err", err)
 	}
-	return edsID, nil
+	return req, nil
 }
 
 func (s *Server) writeStatus(logger *zap.SugaredLogger, status shrexpb.Status, stream network.Stream) error {
@@ -173,22 +193,59 @@ func (s *Server) writeStatus(logger *zap.SugaredLogger, status shrexpb.Status, s
 	return err
 }
 
-func (s *Server) writeODS(logger *zap.SugaredLogger, streamer eds.Streamer, stream network.Stream) error {
+func (s *Server) writeODS(
+	logger *zap.SugaredLogger,
+	streamer eds.Streamer,
+	offset uint64,
+	stream network.Stream,
+) error {
 	reader, err := streamer.Reader()
 	if err != nil {
 		return fmt.Errorf("getting ODS reader: %w", err)
 	}
+	// skip the part of the ODS the client already has
+	if offset > 0 {
+		_, err = io.CopyN(io.Discard, reader, int64(offset))
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("skipping %d ODS bytes: %w", offset, err)
+		}
+	}
 	err = stream.SetWriteDeadline(time.Now().Add(s.params.ServerWriteTimeout))
 	if err != nil {
 		logger.Debugw("server: set read deadline", "err", err)
 	}
 
-	buf := make([]byte, s.params.BufferSize)
-	n, err := io.CopyBuffer(stream, reader, buf)
+	if !shrex.IsCompressed(stream.Protocol()) {
+		n, err := s.copyODS(stream, reader)
+		if err != nil {
+			return fmt.Errorf("written: %v, writing ODS bytes: %w", n, err)
+		}
+		logger.Debugw("server: wrote ODS", "bytes", n)
+		return nil
+	}
+
+	compressor, err := shrex.NewCompressor(stream)
+	if err != nil {
+		return fmt.Errorf("creating compressor: %w", err)
+	}
+	n, err := s.copyODS(compressor, reader)
 	if err != nil {
-		return fmt.Errorf("written: %v, writing ODS bytes: %w", n, err)
+		compressor.Close() //nolint:errcheck
+		return fmt.Errorf("written: %v, writing compressed ODS bytes: %w", n, err)
+	}
+	// closing flushes the rest of the compressed ODS
+	err = compressor.Close()
+	if err != nil {
+		return fmt.Errorf("flushing compressed ODS bytes: %w", err)
 	}
 
 	logger.Debugw("server: wrote ODS", "bytes", n)
 	return nil
 }
+
+// copyODS copies the ODS from the reader to the writer through a pooled buffer.
+func (s *Server) copyODS(w io.Writer, reader io.Reader) (int64, error) {
+	buf := s.buffers.Get().(*[]byte)
+	defer s.buffers.Put(buf)
+	return io.CopyBuffer(w, reader, *buf)
+}
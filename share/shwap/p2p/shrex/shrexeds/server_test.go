@@ -0,0 +1,30 @@
+package shrexeds
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+)
+
+// BenchmarkServer_CopyODS measures the allocations of copying ODSs to streams, which reuse pooled
+// buffers across requests.
+func BenchmarkServer_CopyODS(b *testing.B) {
+	srv, err := NewServer(DefaultParameters(), nil, nil)
+	require.NoError(b, err)
+	streamer := &eds.Rsmt2D{ExtendedDataSquare: edstest.RandEDS(b, 64)}
+	// hide io.Discard's ReaderFrom, so that the copy goes through the buffer
+	w := struct{ io.Writer }{io.Discard}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		reader, err := streamer.Reader()
+		require.NoError(b, err)
+		_, err = srv.copyODS(w, reader)
+		require.NoError(b, err)
+	}
+}
@@ -0,0 +1,80 @@
+package shrexnd
+
+import (
+	"math"
+	"sync"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+// rowCache keeps namespace data of rows along with their proofs, so that popular namespaces
+// requested by many peers are not read and proven over and over again. Rows are keyed by their
+// root and namespace, as both the shares and the proof only depend on them. The cache evicts the
+// least recently used rows once the total size of the cached shares and proof nodes exceeds the
+// limit.
+type rowCache struct {
+	lock    sync.Mutex
+	size    int
+	maxSize int
+	rows    *simplelru.LRU[string, shwap.RowNamespaceData]
+}
+
+// newRowCache creates a rowCache bounded by the given amount of bytes. It returns nil for a zero
+// size, which disables caching.
+func newRowCache(maxSize int) *rowCache {
+	if maxSize <= 0 {
+		return nil
+	}
+	cache := &rowCache{maxSize: maxSize}
+	// size is bounded by bytes instead of the amount of rows, which is checked on every add
+	cache.rows, _ = simplelru.NewLRU(math.MaxInt, func(_ string, row shwap.RowNamespaceData) {
+		cache.size -= rowSize(row)
+	})
+	return cache
+}
+
+func (c *rowCache) get(root []byte, namespace share.Namespace) (shwap.RowNamespaceData, bool) {
+	if c == nil {
+		return shwap.RowNamespaceData{}, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.rows.Get(rowKey(root, namespace))
+}
+
+func (c *rowCache) add(root []byte, namespace share.Namespace, row shwap.RowNamespaceData) {
+	size := rowSize(row)
+	if c == nil || size > c.maxSize {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.rows.Contains(rowKey(root, namespace)) {
+		return
+	}
+	c.rows.Add(rowKey(root, namespace), row)
+	c.size += size
+	for c.size > c.maxSize {
+		c.rows.RemoveOldest()
+	}
+}
+
+func rowKey(root []byte, namespace share.Namespace) string {
+	return string(root) + string(namespace)
+}
+
+// rowSize returns the approximate amount of memory taken by the row.
+func rowSize(row shwap.RowNamespaceData) int {
+	size := len(row.Shares) * share.Size
+	if row.Proof != nil {
+		for _, node := range row.Proof.Nodes() {
+			size += len(node)
+		}
+		size += len(row.Proof.LeafHash())
+	}
+	return size
+}
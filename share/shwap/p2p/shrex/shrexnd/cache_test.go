@@ -0,0 +1,47 @@
+package shrexnd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+func TestRowCache(t *testing.T) {
+	namespace := sharetest.RandV0Namespace()
+	row := shwap.RowNamespaceData{Shares: sharetest.RandShares(t, 2)}
+
+	// fits two rows only
+	cache := newRowCache(2 * rowSize(row))
+	cache.add([]byte("root1"), namespace, row)
+	cache.add([]byte("root2"), namespace, row)
+
+	got, ok := cache.get([]byte("root1"), namespace)
+	require.True(t, ok)
+	require.Equal(t, row, got)
+	_, ok = cache.get([]byte("root1"), sharetest.RandV0Namespace())
+	require.False(t, ok)
+
+	// the least recently used row is evicted
+	cache.add([]byte("root3"), namespace, row)
+	_, ok = cache.get([]byte("root2"), namespace)
+	require.False(t, ok)
+	_, ok = cache.get([]byte("root1"), namespace)
+	require.True(t, ok)
+	require.Equal(t, 2*rowSize(row), cache.size)
+
+	// rows exceeding the limit on their own are not cached
+	cache.add([]byte("root4"), namespace, shwap.RowNamespaceData{Shares: sharetest.RandShares(t, 8)})
+	_, ok = cache.get([]byte("root4"), namespace)
+	require.False(t, ok)
+
+	// disabled cache
+	disabled := newRowCache(0)
+	disabled.add([]byte("root1"), namespace, row)
+	_, ok = disabled.get([]byte("root1"), namespace)
+	require.False(t, ok)
+	require.Equal(t, len(row.Shares)*share.Size, rowSize(row))
+}
@@ -25,8 +25,8 @@ import (
 // Client implements client side of shrex/nd protocol to obtain namespaced shares data from remote
 // peers.
 type Client struct {
-	params     *Parameters
-	protocolID protocol.ID
+	params      *Parameters
+	protocolIDs []protocol.ID
 
 	host    host.Host
 	metrics *shrex.Metrics
@@ -39,9 +39,9 @@ func NewClient(params *Parameters, host host.Host) (*Client, error) {
 	}
 
 	return &Client{
-		host:       host,
-		protocolID: shrex.ProtocolID(params.NetworkID(), protocolString),
-		params:     params,
+		host:        host,
+		protocolIDs: params.ProtocolIDs(protocolName),
+		params:      params,
 	}, nil
 }
 
@@ -88,7 +88,7 @@ func (c *Client) doRequest(
 ) (shwap.NamespaceData, error) {
 	streamOpenCtx, cancel := context.WithTimeout(ctx, c.params.ServerReadTimeout)
 	defer cancel()
-	stream, err := c.host.NewStream(streamOpenCtx, peerID, c.protocolID)
+	stream, err := shrex.NewStream(streamOpenCtx, c.host, peerID, c.params.PreferQUIC, c.protocolIDs...)
 	if err != nil {
 		return nil, err
 	}
@@ -117,8 +117,18 @@ func (c *Client) doRequest(
 		return nil, err
 	}
 
+	var r io.Reader = stream
+	if shrex.IsCompressed(stream.Protocol()) {
+		decompressor, err := shrex.NewDecompressor(stream)
+		if err != nil {
+			return nil, fmt.Errorf("client-nd: creating decompressor: %w", err)
+		}
+		defer decompressor.Close() //nolint:errcheck
+		r = decompressor
+	}
+
 	nd := shwap.NamespaceData{}
-	_, err = nd.ReadFrom(stream)
+	_, err = nd.ReadFrom(r)
 	if err != nil {
 		c.metrics.ObserveRequests(ctx, 1, shrex.StatusReadRespErr)
 		return nil, err
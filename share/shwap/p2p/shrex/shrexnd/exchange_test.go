@@ -88,7 +88,7 @@ func TestExchange_RequestND(t *testing.T) {
 			}
 		}
 		middleware := shrex.NewMiddleware(rateLimit)
-		server.host.SetStreamHandler(server.protocolID,
+		server.host.SetStreamHandler(server.protocolIDs[0],
 			middleware.RateLimitHandler(mockHandler))
 
 		// take server concurrency slots with blocked requests
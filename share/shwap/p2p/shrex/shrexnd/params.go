@@ -9,15 +9,32 @@ import (
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex"
 )
 
-const protocolString = shrex.ProtocolString + shwap.NamespaceDataName
+const protocolName = shwap.NamespaceDataName
 
 var log = logging.Logger("shrex/nd")
 
-// Parameters is the set of parameters that must be configured for the shrex/eds protocol.
-type Parameters = shrex.Parameters
+// Parameters is the set of parameters that must be configured for the shrex/nd protocol.
+type Parameters struct {
+	*shrex.Parameters
+
+	// ProofCacheSize is the maximum amount of bytes of namespace shares and their proofs the server
+	// keeps cached by row root and namespace. Zero disables caching.
+	ProofCacheSize int
+}
 
 func DefaultParameters() *Parameters {
-	return shrex.DefaultParameters()
+	return &Parameters{
+		Parameters:     shrex.DefaultParameters(),
+		ProofCacheSize: 64 << 20, // 64 MiB
+	}
+}
+
+func (p *Parameters) Validate() error {
+	if p.ProofCacheSize < 0 {
+		return fmt.Errorf("invalid proof cache size: %d, value should be non-negative", p.ProofCacheSize)
+	}
+
+	return p.Parameters.Validate()
 }
 
 func (c *Client) WithMetrics() error {
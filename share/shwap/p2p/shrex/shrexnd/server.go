@@ -14,6 +14,7 @@ import (
 	"github.com/celestiaorg/go-libp2p-messenger/serde"
 
 	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/shwap"
 	"github.com/celestiaorg/celestia-node/share/shwap/p2p/shrex"
@@ -26,15 +27,17 @@ import (
 type Server struct {
 	cancel context.CancelFunc
 
-	host       host.Host
-	protocolID protocol.ID
+	host        host.Host
+	protocolIDs []protocol.ID
 
 	handler network.StreamHandler
 	store   *store.Store
+	cache   *rowCache
 
-	params     *Parameters
-	middleware *shrex.Middleware
-	metrics    *shrex.Metrics
+	params      *Parameters
+	middleware  *shrex.Middleware
+	peerLimiter *shrex.PeerRateLimiter
+	metrics     *shrex.Metrics
 }
 
 // NewServer creates new Server
@@ -44,30 +47,42 @@ func NewServer(params *Parameters, host host.Host, store *store.Store) (*Server,
 	}
 
 	srv := &Server{
-		store:      store,
-		host:       host,
-		params:     params,
-		protocolID: shrex.ProtocolID(params.NetworkID(), protocolString),
-		middleware: shrex.NewMiddleware(params.ConcurrencyLimit),
+		store:       store,
+		cache:       newRowCache(params.ProofCacheSize),
+		host:        host,
+		params:      params,
+		protocolIDs: params.ProtocolIDs(protocolName),
+		middleware: shrex.NewMiddleware(
+			params.ConcurrencyLimit,
+			shrex.WithQueue(params.QueueSize, params.QueueTimeout),
+			shrex.WithPeerConcurrencyLimit(params.PeerConcurrencyLimit),
+		),
+		peerLimiter: shrex.NewPeerRateLimiter(params.PeerRequestRate, params.PeerRequestBurst),
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	srv.cancel = cancel
 
-	srv.handler = srv.middleware.RateLimitHandler(srv.streamHandler(ctx))
+	// streams of peers exceeding their rate limit don't take concurrency slots
+	srv.handler = srv.peerLimiter.Handler(srv.middleware.RateLimitHandler(srv.streamHandler(ctx)))
 	return srv, nil
 }
 
 // Start starts the server
 func (srv *Server) Start(context.Context) error {
-	srv.host.SetStreamHandler(srv.protocolID, srv.handler)
+	// every supported protocol version is served by the same handler
+	for _, protocolID := range srv.protocolIDs {
+		srv.host.SetStreamHandler(protocolID, srv.handler)
+	}
 	return nil
 }
 
 // Stop stops the server
 func (srv *Server) Stop(context.Context) error {
 	srv.cancel()
-	srv.host.RemoveStreamHandler(srv.protocolID)
+	for _, protocolID := range srv.protocolIDs {
+		srv.host.RemoveStreamHandler(protocolID)
+	}
 	return nil
 }
 
@@ -94,6 +109,10 @@ func (srv *Server) observeRateLimitedRequests() {
 	if numRateLimited > 0 {
 		srv.metrics.ObserveRequests(context.Background(), numRateLimited, shrex.StatusRateLimited)
 	}
+	numPeerRateLimited := srv.peerLimiter.DrainCounter()
+	if numPeerRateLimited > 0 {
+		srv.metrics.ObserveRequests(context.Background(), numPeerRateLimited, shrex.StatusPeerRateLimited)
+	}
 }
 
 func (srv *Server) handleNamespaceData(ctx context.Context, stream network.Stream) error {
@@ -136,7 +155,7 @@ func (srv *Server) handleNamespaceData(ctx context.Context, stream network.Strea
 		return err
 	}
 
-	_, err = nd.WriteTo(stream)
+	err = srv.writeNamespaceData(stream, nd)
 	if err != nil {
 		logger.Errorw("send nd data", "err", err)
 		srv.metrics.ObserveRequests(ctx, 1, shrex.StatusSendRespErr)
@@ -145,6 +164,27 @@ func (srv *Server) handleNamespaceData(ctx context.Context, stream network.Strea
 	return nil
 }
 
+// writeNamespaceData writes the namespace data to the stream, compressed if the negotiated
+// protocol version says so.
+func (srv *Server) writeNamespaceData(stream network.Stream, nd shwap.NamespaceData) error {
+	if !shrex.IsCompressed(stream.Protocol()) {
+		_, err := nd.WriteTo(stream)
+		return err
+	}
+
+	compressor, err := shrex.NewCompressor(stream)
+	if err != nil {
+		return fmt.Errorf("creating compressor: %w", err)
+	}
+	_, err = nd.WriteTo(compressor)
+	if err != nil {
+		compressor.Close() //nolint:errcheck
+		return err
+	}
+	// closing flushes the rest of the compressed data
+	return compressor.Close()
+}
+
 func (srv *Server) readRequest(
 	logger *zap.SugaredLogger,
 	stream network.Stream,
@@ -181,7 +221,7 @@ func (srv *Server) getNamespaceData(
 	}
 	defer utils.CloseAndLog(log, "file", file)
 
-	nd, err := eds.NamespaceData(ctx, file, id.DataNamespace)
+	nd, err := srv.namespaceData(ctx, file, id.DataNamespace)
 	if err != nil {
 		return nil, shrexpb.Status_INVALID, fmt.Errorf("getting nd: %w", err)
 	}
@@ -189,6 +229,34 @@ func (srv *Server) getNamespaceData(
 	return nd, shrexpb.Status_OK, nil
 }
 
+// namespaceData is eds.NamespaceData that serves rows from the cache, when available, and caches
+// the rows it has read and proven.
+func (srv *Server) namespaceData(
+	ctx context.Context,
+	file eds.Accessor,
+	namespace share.Namespace,
+) (shwap.NamespaceData, error) {
+	roots, err := file.AxisRoots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AxisRoots: %w", err)
+	}
+	rowIdxs := share.RowsWithNamespace(roots, namespace)
+	rows := make(shwap.NamespaceData, len(rowIdxs))
+	for i, idx := range rowIdxs {
+		root := roots.RowRoots[idx]
+		if row, ok := srv.cache.get(root, namespace); ok {
+			rows[i] = row
+			continue
+		}
+		rows[i], err = file.RowNamespaceData(ctx, namespace, idx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process row %d: %w", idx, err)
+		}
+		srv.cache.add(root, namespace, rows[i])
+	}
+	return rows, nil
+}
+
 func (srv *Server) respondStatus(
 	ctx context.Context,
 	logger *zap.SugaredLogger,
@@ -0,0 +1,107 @@
+package shrex
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	ma "github.com/multiformats/go-multiaddr"
+	msmux "github.com/multiformats/go-multistream"
+)
+
+// StreamWrapper is implemented by hosts wrapping the streams they open, e.g. to limit their
+// bandwidth. NewStream applies it to the streams it opens on connections directly, as those bypass
+// the host.
+type StreamWrapper interface {
+	WrapStream(network.Stream) network.Stream
+}
+
+// NewStream opens a new stream to the peer negotiating the first of the given protocols the peer
+// supports, which the returned stream reports as its protocol. When preferQUIC is set and
+// there is a QUIC connection to the peer, the stream is opened over it, so that large transfers
+// don't suffer from TCP head-of-line blocking. Otherwise, or if opening the stream over QUIC
+// fails, it falls back to the connection chosen by the host, dialing the peer if needed.
+func NewStream(
+	ctx context.Context,
+	h host.Host,
+	peerID peer.ID,
+	preferQUIC bool,
+	protocolIDs ...protocol.ID,
+) (network.Stream, error) {
+	if preferQUIC {
+		if conn := quicConn(h.Network().ConnsToPeer(peerID)); conn != nil {
+			stream, err := newStreamOnConn(ctx, conn, protocolIDs)
+			if err == nil {
+				if wrapper, ok := h.(StreamWrapper); ok {
+					stream = wrapper.WrapStream(stream)
+				}
+				return stream, nil
+			}
+			log.Debugw("opening stream over QUIC, falling back", "peer", peerID.String(), "err", err)
+		}
+	}
+	return h.NewStream(ctx, peerID, protocolIDs...)
+}
+
+// quicConn returns an open QUIC connection out of the given ones, if any.
+func quicConn(conns []network.Conn) network.Conn {
+	for _, conn := range conns {
+		if conn.IsClosed() || conn.Stat().Limited {
+			continue
+		}
+		if isQUICAddr(conn.RemoteMultiaddr()) {
+			return conn
+		}
+	}
+	return nil
+}
+
+// isQUICAddr reports whether the address is a plain QUIC one.
+func isQUICAddr(addr ma.Multiaddr) bool {
+	var quic, other bool
+	ma.ForEach(addr, func(c ma.Component) bool {
+		switch c.Protocol().Code {
+		case ma.P_QUIC_V1:
+			quic = true
+		case ma.P_WEBTRANSPORT, ma.P_CIRCUIT:
+			other = true
+		}
+		return true
+	})
+	return quic && !other
+}
+
+// newStreamOnConn opens a stream on the given connection and negotiates one of the protocols.
+func newStreamOnConn(ctx context.Context, conn network.Conn, protocolIDs []protocol.ID) (network.Stream, error) {
+	stream, err := conn.NewStream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("opening stream: %w", err)
+	}
+
+	var protocolID protocol.ID
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		protocolID, err = msmux.SelectOneOf(protocolIDs, stream)
+		errCh <- err
+	}()
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		stream.Reset() //nolint:errcheck
+		// wait for the negotiation to error out because of resetting the stream
+		<-errCh
+		err = ctx.Err()
+	}
+	if err == nil {
+		err = stream.SetProtocol(protocolID)
+	}
+	if err != nil {
+		stream.Reset() //nolint:errcheck
+		return nil, fmt.Errorf("negotiating protocol: %w", err)
+	}
+	return stream, nil
+}
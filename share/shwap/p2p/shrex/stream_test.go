@@ -0,0 +1,76 @@
+package shrex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsQUICAddr(t *testing.T) {
+	tests := map[string]bool{
+		"/ip4/127.0.0.1/udp/2121/quic-v1":              true,
+		"/ip6/::1/udp/2121/quic-v1":                    true,
+		"/ip4/127.0.0.1/tcp/2121":                      false,
+		"/ip4/127.0.0.1/udp/2121/quic-v1/webtransport": false,
+	}
+	for addr, isQUIC := range tests {
+		require.Equal(t, isQUIC, isQUICAddr(ma.StringCast(addr)), addr)
+	}
+}
+
+func TestNewStream_Fallback(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	client, server := net.Hosts()[0], net.Hosts()[1]
+
+	protocolID := ProtocolID("test", "stream")
+	server.SetStreamHandler(protocolID, func(stream network.Stream) {
+		stream.Close() //nolint:errcheck
+	})
+
+	// mocknet has no QUIC connections, so the stream is opened by the host
+	stream, err := NewStream(ctx, client, server.ID(), true, protocolID)
+	require.NoError(t, err)
+	require.Equal(t, protocolID, stream.Protocol())
+	require.NoError(t, stream.Close())
+}
+
+func TestNewStream_Negotiation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	client, server := net.Hosts()[0], net.Hosts()[1]
+
+	// the server only supports the older version
+	oldID := ProtocolID("test", "/shrex/v0.1.0/stream")
+	newID := ProtocolID("test", "/shrex/v0.2.0/stream")
+	server.SetStreamHandler(oldID, func(stream network.Stream) {
+		stream.Close() //nolint:errcheck
+	})
+
+	stream, err := NewStream(ctx, client, server.ID(), true, newID, oldID)
+	require.NoError(t, err)
+	require.Equal(t, oldID, stream.Protocol())
+	require.Equal(t, "v0.1.0", ProtocolVersion(stream.Protocol()))
+	require.NoError(t, stream.Close())
+}
+
+func TestProtocolIDs(t *testing.T) {
+	ids := ProtocolIDs("test", "eds")
+	require.Len(t, ids, len(ProtocolVersions))
+	require.Equal(t, ProtocolID("test", ProtocolString+"eds"), ids[len(ids)-1])
+	for i, id := range ids {
+		require.Equal(t, ProtocolVersions[i], ProtocolVersion(id))
+	}
+	require.Empty(t, ProtocolVersion("/test/other/v1"))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/celestiaorg/go-libp2p-messenger/serde"
 	"github.com/celestiaorg/nmt"
 	nmt_pb "github.com/celestiaorg/nmt/pb"
+	"github.com/celestiaorg/rsmt2d"
 
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/shwap/pb"
@@ -145,34 +146,63 @@ func (rnd RowNamespaceData) IsEmpty() bool {
 
 // Verify checks validity of the RowNamespaceData against the AxisRoots, Namespace and Row index.
 func (rnd RowNamespaceData) Verify(roots *share.AxisRoots, namespace share.Namespace, rowIdx int) error {
+	return rnd.verifyAxis(roots.RowRoots, namespace, rsmt2d.Row, rowIdx)
+}
+
+// VerifyColumn checks validity of the RowNamespaceData built over a column against the AxisRoots,
+// Namespace and Column index. The shares are expected to be ordered top to bottom and the proof
+// to be against the column root.
+func (rnd RowNamespaceData) VerifyColumn(roots *share.AxisRoots, namespace share.Namespace, colIdx int) error {
+	return rnd.verifyAxis(roots.ColumnRoots, namespace, rsmt2d.Col, colIdx)
+}
+
+// verifyAxis checks validity of the RowNamespaceData against the root of the axis with the given
+// index. Namespace data only lives in the original half of the square, so it is only ever
+// verified against original roots, and axes of the extended half are rejected upfront instead of
+// being verified against roots committing to parity shares only.
+func (rnd RowNamespaceData) verifyAxis(
+	axisRoots [][]byte,
+	namespace share.Namespace,
+	axisType rsmt2d.Axis,
+	axisIdx int,
+) error {
+	axisName := "row"
+	if axisType == rsmt2d.Col {
+		axisName = "column"
+	}
+	if axisIdx < 0 || axisIdx >= len(axisRoots)/2 {
+		return fmt.Errorf("%s %d is outside of the original data square of width %d: %w",
+			axisName, axisIdx, len(axisRoots)/2, ErrOutOfBounds)
+	}
+	root := axisRoots[axisIdx]
+
 	if rnd.Proof == nil || rnd.Proof.IsEmptyProof() {
 		return fmt.Errorf("nil proof")
 	}
 	if len(rnd.Shares) == 0 && !rnd.Proof.IsOfAbsence() {
-		return fmt.Errorf("empty shares with non-absence proof for row %d", rowIdx)
+		return fmt.Errorf("empty shares with non-absence proof for %s %d", axisName, axisIdx)
 	}
 
 	if len(rnd.Shares) > 0 && rnd.Proof.IsOfAbsence() {
-		return fmt.Errorf("non-empty shares with absence proof for row %d", rowIdx)
+		return fmt.Errorf("non-empty shares with absence proof for %s %d", axisName, axisIdx)
 	}
 
 	if err := ValidateShares(rnd.Shares); err != nil {
 		return fmt.Errorf("invalid shares: %w", err)
 	}
 
-	rowRoot := roots.RowRoots[rowIdx]
-	if namespace.IsOutsideRange(rowRoot, rowRoot) {
-		return fmt.Errorf("namespace out of range for row %d", rowIdx)
+	if namespace.IsOutsideRange(root, root) {
+		return fmt.Errorf("namespace out of range for %s %d", axisName, axisIdx)
 	}
 
-	if !rnd.verifyInclusion(rowRoot, namespace) {
-		return fmt.Errorf("%w for row: %d", ErrFailedVerification, rowIdx)
+	if !rnd.verifyInclusion(root, namespace) {
+		return fmt.Errorf("%w for %s: %d", ErrFailedVerification, axisName, axisIdx)
 	}
 	return nil
 }
 
-// verifyInclusion checks the inclusion of the row's shares in the provided root using NMT.
-func (rnd RowNamespaceData) verifyInclusion(rowRoot []byte, namespace share.Namespace) bool {
+// verifyInclusion checks the inclusion of the shares in the provided axis root using NMT.
+func (rnd RowNamespaceData) verifyInclusion(root []byte, namespace share.Namespace) bool {
 	leaves := make([][]byte, 0, len(rnd.Shares))
 	for _, sh := range rnd.Shares {
 		namespaceBytes := share.GetNamespace(sh)
@@ -186,7 +216,7 @@ func (rnd RowNamespaceData) verifyInclusion(rowRoot []byte, namespace share.Name
 		share.NewSHA256Hasher(),
 		namespace.ToNMT(),
 		leaves,
-		rowRoot,
+		root,
 	)
 }
 
@@ -80,6 +80,25 @@ func TestValidateNamespacedRow(t *testing.T) {
 	}
 }
 
+func TestValidateNamespacedRow_ExtendedAxis(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	const odsSize = 8
+	namespace := sharetest.RandV0Namespace()
+	randEDS, root := edstest.RandEDSWithNamespace(t, namespace, odsSize, odsSize)
+	nd, err := eds.NamespaceData(ctx, &eds.Rsmt2D{ExtendedDataSquare: randEDS}, namespace)
+	require.NoError(t, err)
+	require.True(t, len(nd) > 0)
+
+	// namespace data is never proven against roots of the extended half
+	require.Empty(t, share.RowsWithNamespace(root, share.ParitySharesNamespace))
+	err = nd[0].Verify(root, namespace, odsSize)
+	require.ErrorIs(t, err, shwap.ErrOutOfBounds)
+	err = nd[0].VerifyColumn(root, namespace, 2*odsSize-1)
+	require.ErrorIs(t, err, shwap.ErrOutOfBounds)
+}
+
 func TestNamespacedRowProtoEncoding(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	t.Cleanup(cancel)
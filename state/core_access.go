@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,20 +14,27 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/proto/tendermint/crypto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/celestiaorg/celestia-app/v2/app"
 	"github.com/celestiaorg/celestia-app/v2/app/encoding"
 	apperrors "github.com/celestiaorg/celestia-app/v2/app/errors"
 	"github.com/celestiaorg/celestia-app/v2/pkg/user"
+	minttypes "github.com/celestiaorg/celestia-app/v2/x/mint/types"
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
@@ -37,6 +46,9 @@ const (
 
 var (
 	ErrInvalidAmount = errors.New("state: amount must be greater than zero")
+	// ErrHeightPruned is returned when historical state is queried for a height that the core
+	// endpoint has already pruned.
+	ErrHeightPruned = errors.New("state: height is pruned by the core endpoint")
 
 	log = logging.Logger("state")
 )
@@ -60,9 +72,12 @@ type CoreAccessor struct {
 
 	getter libhead.Head[*header.ExtendedHeader]
 
-	stakingCli   stakingtypes.QueryClient
-	feeGrantCli  feegrant.QueryClient
-	abciQueryCli tmservice.ServiceClient
+	bankCli         banktypes.QueryClient
+	stakingCli      stakingtypes.QueryClient
+	feeGrantCli     feegrant.QueryClient
+	distributionCli distributiontypes.QueryClient
+	mintCli         minttypes.QueryClient
+	abciQueryCli    tmservice.ServiceClient
 
 	prt *merkle.ProofRuntime
 
@@ -139,9 +154,12 @@ func (ca *CoreAccessor) Start(ctx context.Context) error {
 
 	ca.coreConn = client
 
-	// create the staking query client
+	// create the bank and staking query clients
+	ca.bankCli = banktypes.NewQueryClient(ca.coreConn)
 	ca.stakingCli = stakingtypes.NewQueryClient(ca.coreConn)
 	ca.feeGrantCli = feegrant.NewQueryClient(ca.coreConn)
+	ca.distributionCli = distributiontypes.NewQueryClient(ca.coreConn)
+	ca.mintCli = minttypes.NewQueryClient(ca.coreConn)
 
 	// create ABCI query client
 	ca.abciQueryCli = tmservice.NewServiceClient(ca.coreConn)
@@ -360,6 +378,54 @@ func (ca *CoreAccessor) BalanceForAddress(ctx context.Context, addr Address) (*B
 	}, nil
 }
 
+// BalanceForAddressAtHeight retrieves the Celestia coin balance for the given address from the
+// state at the given height. The query is served by the core endpoint's historical state, so
+// unlike BalanceForAddress, the balance is not verified against the AppHash.
+func (ca *CoreAccessor) BalanceForAddressAtHeight(
+	ctx context.Context,
+	addr Address,
+	height uint64,
+) (*Balance, error) {
+	if height == 0 {
+		return nil, errors.New("state: height must be greater than zero")
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatUint(height, 10))
+	resp, err := ca.bankCli.Balance(ctx, &banktypes.QueryBalanceRequest{
+		Address: sdktypes.AccAddress(addr.Bytes()).String(),
+		Denom:   app.BondDenom,
+	})
+	if err != nil {
+		if isPrunedHeightErr(err) {
+			return nil, fmt.Errorf("%w: %d", ErrHeightPruned, height)
+		}
+		return nil, fmt.Errorf("failed to query for balance at height %d: %w", height, err)
+	}
+	return resp.Balance, nil
+}
+
+// AllBalancesForAddress retrieves the balances of all the denoms held by the given address. All
+// the pages of the bank AllBalances query are collected, and an unfunded address has empty
+// balances. Unlike BalanceForAddress, the balances are not verified against the AppHash.
+func (ca *CoreAccessor) AllBalancesForAddress(ctx context.Context, addr Address) (Coins, error) {
+	balances := Coins{}
+	var nextKey []byte
+	for {
+		resp, err := ca.bankCli.AllBalances(ctx, &banktypes.QueryAllBalancesRequest{
+			Address:    sdktypes.AccAddress(addr.Bytes()).String(),
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query for all balances: %w", err)
+		}
+		balances = append(balances, resp.Balances...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return balances, nil
+		}
+		nextKey = resp.Pagination.NextKey
+	}
+}
+
 func (ca *CoreAccessor) Transfer(
 	ctx context.Context,
 	addr AccAddress,
@@ -497,6 +563,30 @@ func (ca *CoreAccessor) QueryRedelegations(
 	})
 }
 
+// QueryCommunityPool retrieves the balance of the community pool.
+func (ca *CoreAccessor) QueryCommunityPool(ctx context.Context) (DecCoins, error) {
+	resp, err := ca.distributionCli.CommunityPool(ctx, &distributiontypes.QueryCommunityPoolRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Pool == nil {
+		return DecCoins{}, nil
+	}
+	return resp.Pool, nil
+}
+
+// QueryInflation retrieves the current inflation rate.
+func (ca *CoreAccessor) QueryInflation(ctx context.Context) (Dec, error) {
+	resp, err := ca.mintCli.InflationRate(ctx, &minttypes.QueryInflationRateRequest{})
+	if err != nil {
+		return Dec{}, err
+	}
+	if resp.InflationRate.IsNil() {
+		return sdktypes.ZeroDec(), nil
+	}
+	return resp.InflationRate, nil
+}
+
 func (ca *CoreAccessor) GrantFee(
 	ctx context.Context,
 	grantee AccAddress,
@@ -566,6 +656,19 @@ func (ca *CoreAccessor) getMinGasPrice() float64 {
 	return ca.minGasPrice
 }
 
+// prunedHeightMsg starts the message of the error the core endpoint returns for a query against
+// state it no longer holds. The error is built by the query context of the cosmos-sdk baseapp as
+// an ErrInvalidRequest, which the endpoint reports with the InvalidArgument code. The status
+// carries no typed details, so the message is the only way to tell it apart.
+const prunedHeightMsg = "failed to load state at height"
+
+// isPrunedHeightErr reports whether the error is returned by the core endpoint for a query
+// against state it no longer holds.
+func isPrunedHeightErr(err error) bool {
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.InvalidArgument && strings.Contains(st.Message(), prunedHeightMsg)
+}
+
 // QueryMinimumGasPrice returns the minimum gas price required by the node.
 func (ca *CoreAccessor) queryMinimumGasPrice(
 	ctx context.Context,
@@ -13,6 +13,8 @@ import (
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/celestiaorg/celestia-app/v2/app"
 	appconsts "github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
@@ -149,6 +151,33 @@ func TestTransfer(t *testing.T) {
 	}
 }
 
+func TestIsPrunedHeightErr(t *testing.T) {
+	// the message as the cosmos-sdk baseapp formats it
+	prunedMsg := "failed to load state at height 5; version does not exist (latest height: 10): invalid request"
+
+	testCases := []struct {
+		name   string
+		err    error
+		pruned bool
+	}{
+		{name: "pruned", err: status.Error(codes.InvalidArgument, prunedMsg), pruned: true},
+		{
+			name:   "wrapped pruned",
+			err:    fmt.Errorf("querying balance: %w", status.Error(codes.InvalidArgument, prunedMsg)),
+			pruned: true,
+		},
+		{name: "other code", err: status.Error(codes.Unknown, prunedMsg)},
+		{name: "other invalid argument", err: status.Error(codes.InvalidArgument, "invalid address")},
+		{name: "not a status", err: errors.New(prunedMsg)},
+		{name: "nil", err: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.pruned, isPrunedHeightErr(tc.err))
+		})
+	}
+}
+
 func TestChainIDMismatch(t *testing.T) {
 	ctx := context.Background()
 	ca, _ := buildAccessor(t)
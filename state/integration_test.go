@@ -9,6 +9,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -19,6 +21,7 @@ import (
 	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
 	"github.com/celestiaorg/celestia-app/v2/test/util/genesis"
 	"github.com/celestiaorg/celestia-app/v2/test/util/testnode"
+	minttypes "github.com/celestiaorg/celestia-app/v2/x/mint/types"
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/core"
@@ -64,10 +67,14 @@ func (s *IntegrationTestSuite) SetupSuite() {
 
 func setClients(ca *CoreAccessor, conn *grpc.ClientConn) {
 	ca.coreConn = conn
-	// create the staking query client
+	// create the bank and staking query clients
+	ca.bankCli = banktypes.NewQueryClient(ca.coreConn)
 	stakingCli := stakingtypes.NewQueryClient(ca.coreConn)
 	ca.stakingCli = stakingCli
 
+	ca.distributionCli = distributiontypes.NewQueryClient(ca.coreConn)
+	ca.mintCli = minttypes.NewQueryClient(ca.coreConn)
+
 	ca.abciQueryCli = tmservice.NewServiceClient(ca.coreConn)
 }
 
@@ -114,6 +121,56 @@ func (s *IntegrationTestSuite) TestGetBalance() {
 	}
 }
 
+func (s *IntegrationTestSuite) TestGetBalanceAtHeight() {
+	require := s.Require()
+
+	for _, account := range s.accounts {
+		hexAddress := account.PubKey.Address().String()
+		sdkAddress, err := sdk.AccAddressFromHexUnsafe(hexAddress)
+		require.NoError(err)
+
+		bal, err := s.accessor.BalanceForAddressAtHeight(context.Background(), Address{sdkAddress}, 2)
+		require.NoError(err)
+		require.Equal(bal.Denom, appconsts.BondDenom)
+		require.True(bal.Amount.GT(sdk.NewInt(1))) // verify that each account has some balance
+	}
+
+	_, err := s.accessor.BalanceForAddressAtHeight(context.Background(), Address{}, 0)
+	require.Error(err)
+}
+
+func (s *IntegrationTestSuite) TestGetAllBalances() {
+	require := s.Require()
+
+	for _, account := range s.accounts {
+		hexAddress := account.PubKey.Address().String()
+		sdkAddress, err := sdk.AccAddressFromHexUnsafe(hexAddress)
+		require.NoError(err)
+
+		balances, err := s.accessor.AllBalancesForAddress(context.Background(), Address{sdkAddress})
+		require.NoError(err)
+		require.True(balances.AmountOf(appconsts.BondDenom).GT(sdk.NewInt(1)))
+	}
+
+	// unfunded accounts have empty balances
+	balances, err := s.accessor.AllBalancesForAddress(context.Background(), Address{sdk.AccAddress(make([]byte, 20))})
+	require.NoError(err)
+	require.True(balances.Empty())
+}
+
+func (s *IntegrationTestSuite) TestQueryCommunityPoolAndInflation() {
+	require := s.Require()
+
+	pool, err := s.accessor.QueryCommunityPool(context.Background())
+	require.NoError(err)
+	require.NotNil(pool)
+
+	inflation, err := s.accessor.QueryInflation(context.Background())
+	require.NoError(err)
+	require.False(inflation.IsNil())
+	require.False(inflation.IsNegative())
+}
+
 // This test can be used to generate a json encoded block for other test data,
 // such as that in share/availability/light/testdata
 func (s *IntegrationTestSuite) TestGenerateJSONBlock() {
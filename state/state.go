@@ -38,6 +38,15 @@ type AccAddress = sdk.AccAddress
 // Int is an alias to the Int type from Cosmos-SDK.
 type Int = math.Int
 
+// Dec is an alias to the Dec type from Cosmos-SDK.
+type Dec = sdk.Dec
+
+// DecCoins is an alias to the DecCoins type from Cosmos-SDK.
+type DecCoins = sdk.DecCoins
+
+// Coins is an alias to the Coins type from Cosmos-SDK.
+type Coins = sdk.Coins
+
 func (a *Address) UnmarshalJSON(data []byte) error {
 	// To convert the string back to a concrete type, we have to determine the correct implementation
 	var addr AccAddress
@@ -0,0 +1,175 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// Backend is the object storage the Store offloads EDS files to. Objects are written once and
+// never modified, so a Backend doesn't need to support partial writes. Implementations must
+// return ErrNotFound for missing objects.
+type Backend interface {
+	// Get returns the content of the object under the given key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Put writes the object under the given key.
+	Put(ctx context.Context, key string, r io.ReadSeeker) error
+	// Has reports whether the object under the given key exists.
+	Has(ctx context.Context, key string) (bool, error)
+	// Delete removes the object under the given key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Option configures the Store.
+type Option func(*Store)
+
+// WithBackend makes the Store upload every put EDS to the given Backend and fall back to it for
+// EDSes missing on local disk. Local files turn into a cache of the Backend: removals only drop
+// the local copies, while the Backend keeps the history.
+func WithBackend(backend Backend) Option {
+	return func(s *Store) {
+		s.backend = backend
+	}
+}
+
+// upload writes the files of the EDS and the link of the height to it to the backend. The height
+// link is written last, so that the EDS is only found by height once its files are uploaded.
+func (s *Store) upload(ctx context.Context, datahash share.DataHash, height uint64, withQ4 bool) error {
+	if !datahash.IsEmptyEDS() {
+		exts := []string{odsFileExt}
+		if withQ4 {
+			exts = append(exts, q4FileExt)
+		}
+		for _, ext := range exts {
+			key := hashToKey(datahash, ext)
+			// files are immutable, so the ones uploaded already are not uploaded again
+			has, err := s.backend.Has(ctx, key)
+			if err != nil {
+				return fmt.Errorf("checking %s: %w", key, err)
+			}
+			if has {
+				continue
+			}
+			if err := s.uploadFile(ctx, s.hashToPath(datahash, ext), key); err != nil {
+				return err
+			}
+		}
+	}
+
+	err := s.backend.Put(ctx, heightToKey(height), bytes.NewReader(datahash))
+	if err != nil {
+		return fmt.Errorf("uploading height link: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) uploadFile(ctx context.Context, path, key string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening file for upload: %w", err)
+	}
+	defer utils.CloseAndLog(log, "upload file", f)
+
+	if err := s.backend.Put(ctx, key, f); err != nil {
+		return fmt.Errorf("uploading %s: %w", key, err)
+	}
+	return nil
+}
+
+// restoreByHeight downloads the EDS at the given height from the backend to local disk.
+func (s *Store) restoreByHeight(ctx context.Context, height uint64) error {
+	rdr, err := s.backend.Get(ctx, heightToKey(height))
+	if err != nil {
+		return fmt.Errorf("getting height link: %w", err)
+	}
+	defer utils.CloseAndLog(log, "height link", rdr)
+	datahash, err := io.ReadAll(io.LimitReader(rdr, 32))
+	if err != nil {
+		return fmt.Errorf("reading height link: %w", err)
+	}
+	if err := share.DataHash(datahash).Validate(); err != nil {
+		return fmt.Errorf("invalid height link: %w", err)
+	}
+
+	lock := s.stripLock.byHashAndHeight(datahash, height)
+	lock.lock()
+	defer lock.unlock()
+
+	if !share.DataHash(datahash).IsEmptyEDS() {
+		if err := s.restoreFiles(ctx, datahash); err != nil {
+			return err
+		}
+	}
+	err = s.linkHeight(datahash, height)
+	if err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+	return nil
+}
+
+// restoreByHash downloads the EDS with the given DataHash from the backend to local disk.
+func (s *Store) restoreByHash(ctx context.Context, datahash share.DataHash) error {
+	lock := s.stripLock.byHash(datahash)
+	lock.Lock()
+	defer lock.Unlock()
+	return s.restoreFiles(ctx, datahash)
+}
+
+// restoreFiles downloads the ODS file and the Q4 file, if it was uploaded, unless the ODS file
+// is already on local disk.
+func (s *Store) restoreFiles(ctx context.Context, datahash share.DataHash) error {
+	has, err := s.hasByHash(datahash)
+	if err != nil || has {
+		return err
+	}
+
+	err = s.downloadFile(ctx, hashToKey(datahash, odsFileExt), s.hashToPath(datahash, odsFileExt))
+	if err != nil {
+		return err
+	}
+	err = s.downloadFile(ctx, hashToKey(datahash, q4FileExt), s.hashToPath(datahash, q4FileExt))
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return errors.Join(err, remove(s.hashToPath(datahash, odsFileExt)))
+	}
+	return nil
+}
+
+// downloadFile writes the object to a temporary file first and renames it once complete, so that
+// broken downloads never leave a partial file behind.
+func (s *Store) downloadFile(ctx context.Context, key, path string) error {
+	rdr, err := s.backend.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("downloading %s: %w", key, err)
+	}
+	defer utils.CloseAndLog(log, "download", rdr)
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*"+tmpFileExt)
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+	_, err = io.Copy(tmp, rdr)
+	err = errors.Join(err, tmp.Close())
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return errors.Join(fmt.Errorf("downloading %s: %w", key, err), remove(tmp.Name()))
+	}
+	return nil
+}
+
+func hashToKey(datahash share.DataHash, ext string) string {
+	return blocksPath + "/" + datahash.String() + ext
+}
+
+func heightToKey(height uint64) string {
+	return heightsPath + "/" + strconv.FormatUint(height, 10)
+}
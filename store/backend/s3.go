@@ -0,0 +1,146 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	"github.com/celestiaorg/celestia-node/store"
+)
+
+var _ store.Backend = (*S3)(nil)
+
+// S3Config configures the S3 backend. Credentials are taken from the standard AWS environment
+// variables and shared credentials file, so that they never end up in the node config.
+type S3Config struct {
+	// Bucket is the name of the bucket EDS files are kept in. Empty bucket disables the backend.
+	Bucket string
+	// Prefix is prepended to the keys of all objects, allowing multiple nodes to share a bucket.
+	Prefix string
+	// Region is the region of the bucket.
+	Region string
+	// Endpoint overrides the default AWS endpoint to use an S3-compatible object storage.
+	Endpoint string
+	// PathStyle addresses the bucket in the URL path instead of the host, as required by most
+	// S3-compatible object storages.
+	PathStyle bool
+}
+
+// DefaultS3Config returns the default S3Config, which leaves the backend disabled.
+func DefaultS3Config() *S3Config {
+	return &S3Config{
+		Region: "us-east-1",
+	}
+}
+
+// Enabled reports whether the backend is configured. Configs predating the backend are nil.
+func (cfg *S3Config) Enabled() bool {
+	return cfg != nil && cfg.Bucket != ""
+}
+
+func (cfg *S3Config) Validate() error {
+	if cfg.Enabled() && cfg.Region == "" {
+		return errors.New("s3 backend: region is required")
+	}
+	return nil
+}
+
+// S3 is a store.Backend keeping objects in an S3 or S3-compatible object storage.
+type S3 struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+// NewS3 creates a new S3 backend out of the given config.
+func NewS3(cfg *S3Config) (*S3, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, fmt.Errorf("s3 backend: loading config: %w", err)
+	}
+	client := s3.NewFromConfig(awsCfg, func(opts *s3.Options) {
+		opts.UsePathStyle = cfg.PathStyle
+		if cfg.Endpoint != "" {
+			opts.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+	})
+	return &S3{
+		client: client,
+		bucket: cfg.Bucket,
+		prefix: cfg.Prefix,
+	}, nil
+}
+
+func (b *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    b.key(key),
+	})
+	if err != nil {
+		return nil, convertErr(err)
+	}
+	return out.Body, nil
+}
+
+func (b *S3) Put(ctx context.Context, key string, r io.ReadSeeker) error {
+	_, err := b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    b.key(key),
+		Body:   r,
+	})
+	return convertErr(err)
+}
+
+func (b *S3) Has(ctx context.Context, key string) (bool, error) {
+	_, err := b.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    b.key(key),
+	})
+	err = convertErr(err)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, store.ErrNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (b *S3) Delete(ctx context.Context, key string) error {
+	// deleting a missing object succeeds in S3
+	_, err := b.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    b.key(key),
+	})
+	return convertErr(err)
+}
+
+func (b *S3) key(key string) *string {
+	return aws.String(path.Join(b.prefix, key))
+}
+
+// convertErr converts missing object errors into store.ErrNotFound. Both GetObject and HeadObject
+// respond with 404 for missing objects, though only the former sets an error code.
+func convertErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	var respErr *smithyhttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound {
+		return store.ErrNotFound
+	}
+	return fmt.Errorf("s3 backend: %w", err)
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestStore_Backend(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	backend := NewMemBackend()
+	dir := t.TempDir()
+	store, err := NewStore(DefaultParameters(), dir, WithBackend(backend))
+	require.NoError(t, err)
+
+	t.Run("restored from backend", func(t *testing.T) {
+		eds, roots := randomEDS(t)
+		height := uint64(1)
+		require.NoError(t, store.PutODSQ4(ctx, roots, height, eds))
+		require.Len(t, backend.objects, 3)
+
+		// drop the local copies, leaving the backend ones
+		require.NoError(t, store.RemoveODSQ4(ctx, height, roots.Hash()))
+		ensureAmountFileAndLinks(t, dir, 0, 0)
+		require.Len(t, backend.objects, 3)
+		hasByHashAndHeight(t, store, ctx, roots.Hash(), height, true, true)
+
+		f, err := store.GetByHeight(ctx, height)
+		require.NoError(t, err)
+		square, err := f.Shares(ctx)
+		require.NoError(t, err)
+		require.Equal(t, eds.FlattenedODS(), square)
+		require.NoError(t, f.Close())
+		ensureAmountFileAndLinks(t, dir, 2, 1)
+
+		require.NoError(t, store.RemoveODSQ4(ctx, height, roots.Hash()))
+		f, err = store.GetByHash(ctx, roots.Hash())
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		ensureAmountFileAndLinks(t, dir, 2, 0)
+	})
+
+	t.Run("ODS only", func(t *testing.T) {
+		eds, roots := randomEDS(t)
+		height := uint64(2)
+		require.NoError(t, store.PutODS(ctx, roots, height, eds))
+		require.NoError(t, store.RemoveODSQ4(ctx, height, roots.Hash()))
+
+		f, err := store.GetByHeight(ctx, height)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	})
+
+	t.Run("empty EDS", func(t *testing.T) {
+		height := uint64(3)
+		require.NoError(t, store.PutODSQ4(ctx, share.EmptyEDSRoots(), height, share.EmptyEDS()))
+		require.NoError(t, store.RemoveODSQ4(ctx, height, share.EmptyEDSDataHash()))
+
+		f, err := store.GetByHeight(ctx, height)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	})
+
+	t.Run("repeated put", func(t *testing.T) {
+		eds, roots := randomEDS(t)
+		height := uint64(4)
+		require.NoError(t, store.PutODSQ4(ctx, roots, height, eds))
+
+		// the upload of the previous put was cut short before the height link
+		require.NoError(t, backend.Delete(ctx, heightToKey(height)))
+		require.NoError(t, store.PutODSQ4(ctx, roots, height, eds))
+		has, err := backend.Has(ctx, heightToKey(height))
+		require.NoError(t, err)
+		require.True(t, has)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, roots := randomEDS(t)
+		_, err := store.GetByHeight(ctx, 100)
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = store.GetByHash(ctx, roots.Hash())
+		require.ErrorIs(t, err, ErrNotFound)
+		hasByHashAndHeight(t, store, ctx, roots.Hash(), 100, false, false)
+	})
+}
@@ -0,0 +1,302 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// backfillStateFile keeps the progress of the backfill, so that an interrupted backfill resumes
+// from it on restart.
+const backfillStateFile = ".backfill"
+
+// ErrBackfillRunning is returned when starting a backfill while another one is in progress.
+var ErrBackfillRunning = errors.New("backfill is already running")
+
+// BackfillParams configures the Backfiller.
+type BackfillParams struct {
+	// Concurrency is the number of heights fetched in parallel.
+	Concurrency int
+	// Rate limits the number of heights fetched per second. Zero means no limit.
+	Rate float64
+}
+
+// DefaultBackfillParams returns the default parameters of the Backfiller.
+func DefaultBackfillParams() *BackfillParams {
+	return &BackfillParams{
+		Concurrency: 8,
+	}
+}
+
+func (p *BackfillParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Concurrency <= 0 {
+		return errors.New("backfill concurrency must be positive")
+	}
+	if p.Rate < 0 {
+		return errors.New("backfill rate cannot be negative")
+	}
+	return nil
+}
+
+// BackfillProgress reports the progress of the backfill.
+type BackfillProgress struct {
+	// From is the lowest height of the backfilled range.
+	From uint64 `json:"from"`
+	// To is the highest height of the backfilled range.
+	To uint64 `json:"to"`
+	// Next is the height, below which all heights of the range are processed.
+	Next uint64 `json:"next"`
+	// Fetched is the number of heights fetched since the backfill was started or resumed.
+	Fetched uint64 `json:"fetched"`
+	// Failed are the heights that failed to be fetched. They are fetched again by backfilling the
+	// range once more.
+	Failed []uint64 `json:"failed"`
+	// Running reports whether the backfill is in progress.
+	Running bool `json:"running"`
+}
+
+// Backfiller downloads the EDSes of the heights missing in the Store in the background, e.g. after
+// converting a pruned node to an archival one or to repair gaps. Heights are fetched in parallel
+// with the optional rate limit and the ones kept by the Store already are skipped. The progress is
+// persisted, so that the backfill interrupted by a shutdown resumes on restart.
+type Backfiller struct {
+	store  *Store
+	fetch  RefetchFn
+	params BackfillParams
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lk       sync.Mutex
+	progress BackfillProgress
+	done     chan struct{}
+}
+
+// NewBackfiller creates a new Backfiller of the Store, which puts the EDSes into the Store through
+// the fetch func.
+func NewBackfiller(store *Store, fetch RefetchFn, params BackfillParams) *Backfiller {
+	return &Backfiller{
+		store:  store,
+		fetch:  fetch,
+		params: params,
+	}
+}
+
+// Start resumes the backfill interrupted by the previous shutdown, if any.
+func (b *Backfiller) Start(context.Context) error {
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+	state, err := b.readState()
+	if err != nil {
+		return fmt.Errorf("reading backfill state: %w", err)
+	}
+	if state == nil {
+		return nil
+	}
+
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	b.progress = *state
+	if state.Running {
+		log.Infow("resuming backfill", "from", state.Next, "to", state.To)
+		b.start()
+	}
+	return nil
+}
+
+func (b *Backfiller) Stop(ctx context.Context) error {
+	b.cancel()
+	b.lk.Lock()
+	done := b.done
+	b.lk.Unlock()
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Backfill starts backfilling the heights of the given range missing in the Store in the
+// background. Only one backfill runs at a time.
+func (b *Backfiller) Backfill(_ context.Context, from, to uint64) error {
+	if b.store.readOnly {
+		return ErrReadOnly
+	}
+	if from == 0 || from > to {
+		return fmt.Errorf("invalid backfill range [%d, %d]", from, to)
+	}
+
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	if b.progress.Running {
+		return ErrBackfillRunning
+	}
+	b.progress = BackfillProgress{From: from, To: to, Next: from, Running: true}
+	if err := b.writeState(); err != nil {
+		return fmt.Errorf("writing backfill state: %w", err)
+	}
+	log.Infow("starting backfill", "from", from, "to", to)
+	b.start()
+	return nil
+}
+
+// Progress returns the progress of the last backfill.
+func (b *Backfiller) Progress() BackfillProgress {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	progress := b.progress
+	progress.Failed = slices.Clone(progress.Failed)
+	return progress
+}
+
+// start runs the backfill of the progress in the background. It must be called under the lock.
+func (b *Backfiller) start() {
+	b.progress.Fetched = 0
+	b.done = make(chan struct{})
+	go b.run(b.ctx, b.progress.Next, b.progress.To, b.done)
+}
+
+type backfillResult struct {
+	height  uint64
+	fetched bool
+	err     error
+}
+
+func (b *Backfiller) run(ctx context.Context, from, to uint64, done chan struct{}) {
+	defer close(done)
+
+	var limiter *rate.Limiter
+	if b.params.Rate > 0 {
+		limiter = rate.NewLimiter(rate.Limit(b.params.Rate), 1)
+	}
+	heights := make(chan uint64)
+	go func() {
+		defer close(heights)
+		for height := from; height <= to; height++ {
+			if limiter != nil && limiter.Wait(ctx) != nil {
+				return
+			}
+			select {
+			case heights <- height:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make(chan backfillResult)
+	var wg sync.WaitGroup
+	for range b.params.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for height := range heights {
+				fetched, err := b.backfillHeight(ctx, height)
+				results <- backfillResult{height: height, fetched: fetched, err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	processed := make(map[uint64]bool)
+	for res := range results {
+		if ctx.Err() != nil {
+			// the height is fetched again once the backfill resumes
+			continue
+		}
+		b.lk.Lock()
+		switch {
+		case res.err != nil:
+			log.Warnw("backfill: fetching height", "height", res.height, "err", res.err)
+			b.progress.Failed = append(b.progress.Failed, res.height)
+		case res.fetched:
+			b.progress.Fetched++
+		}
+		processed[res.height] = true
+		for processed[b.progress.Next] {
+			delete(processed, b.progress.Next)
+			b.progress.Next++
+			if b.progress.Next%checkpointInterval == 0 {
+				if err := b.writeState(); err != nil {
+					log.Errorw("backfill: writing state", "err", err)
+				}
+			}
+		}
+		b.lk.Unlock()
+	}
+
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	if b.progress.Next > b.progress.To {
+		b.progress.Running = false
+		slices.Sort(b.progress.Failed)
+		log.Infow("backfill finished", "from", b.progress.From, "to", b.progress.To,
+			"fetched", b.progress.Fetched, "failed", len(b.progress.Failed))
+	}
+	if err := b.writeState(); err != nil {
+		log.Errorw("backfill: writing state", "err", err)
+	}
+	if ctx.Err() != nil {
+		// the backfill resumes on restart
+		b.progress.Running = false
+	}
+}
+
+// backfillHeight fetches the EDS of the height, unless the Store keeps it already.
+func (b *Backfiller) backfillHeight(ctx context.Context, height uint64) (bool, error) {
+	has, err := b.store.HasByHeight(ctx, height)
+	if err != nil {
+		return false, err
+	}
+	if has {
+		return false, nil
+	}
+	if err := b.fetch(ctx, height); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (b *Backfiller) readState() (*BackfillProgress, error) {
+	data, err := os.ReadFile(filepath.Join(b.store.basepath, blocksPath, backfillStateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state *BackfillProgress
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (b *Backfiller) writeState() error {
+	data, err := json.Marshal(b.progress)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(b.store.basepath, blocksPath, backfillStateFile)
+	tmpPath := path + tmpFileExt
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestBackfiller(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	store, err := NewStore(paramsNoCache(), dir)
+	require.NoError(t, err)
+
+	squares := make(map[uint64]*rsmt2d.ExtendedDataSquare)
+	roots := make(map[uint64]*share.AxisRoots)
+	for height := uint64(1); height <= 10; height++ {
+		squares[height], roots[height] = randomEDS(t)
+	}
+	// the first height is stored already
+	require.NoError(t, store.PutODSQ4(ctx, roots[1], 1, squares[1]))
+
+	var lk sync.Mutex
+	fetched := make(map[uint64]int)
+	fetch := func(ctx context.Context, height uint64) error {
+		lk.Lock()
+		fetched[height]++
+		lk.Unlock()
+		if height == 5 {
+			return errors.New("not found")
+		}
+		return store.PutODSQ4(ctx, roots[height], height, squares[height])
+	}
+
+	backfiller := NewBackfiller(store, fetch, BackfillParams{Concurrency: 3})
+	require.NoError(t, backfiller.Start(ctx))
+	require.NoError(t, backfiller.Backfill(ctx, 1, 10))
+	require.Eventually(t, func() bool {
+		return !backfiller.Progress().Running
+	}, time.Second*5, time.Millisecond*10)
+	require.NoError(t, backfiller.Stop(ctx))
+
+	progress := backfiller.Progress()
+	require.EqualValues(t, 11, progress.Next)
+	require.EqualValues(t, 8, progress.Fetched)
+	require.Equal(t, []uint64{5}, progress.Failed)
+	require.NotContains(t, fetched, uint64(1))
+	for height := uint64(2); height <= 10; height++ {
+		require.Equal(t, 1, fetched[height])
+		has, err := store.HasByHeight(ctx, height)
+		require.NoError(t, err)
+		require.Equal(t, height != 5, has)
+	}
+
+	// the finished backfill is not resumed
+	backfiller = NewBackfiller(store, fetch, BackfillParams{Concurrency: 3})
+	require.NoError(t, backfiller.Start(ctx))
+	require.False(t, backfiller.Progress().Running)
+	require.EqualValues(t, 11, backfiller.Progress().Next)
+	require.NoError(t, backfiller.Stop(ctx))
+}
+
+func TestBackfiller_Resume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	store, err := NewStore(paramsNoCache(), t.TempDir())
+	require.NoError(t, err)
+
+	// an interrupted backfill left by the previous run
+	b := NewBackfiller(store, nil, BackfillParams{Concurrency: 1})
+	b.progress = BackfillProgress{From: 1, To: 4, Next: 3, Running: true}
+	require.NoError(t, b.writeState())
+
+	squares := make(map[uint64]*rsmt2d.ExtendedDataSquare)
+	roots := make(map[uint64]*share.AxisRoots)
+	for height := uint64(1); height <= 4; height++ {
+		squares[height], roots[height] = randomEDS(t)
+	}
+	fetch := func(ctx context.Context, height uint64) error {
+		return store.PutODSQ4(ctx, roots[height], height, squares[height])
+	}
+
+	backfiller := NewBackfiller(store, fetch, BackfillParams{Concurrency: 1, Rate: 1000})
+	require.NoError(t, backfiller.Start(ctx))
+	require.Eventually(t, func() bool {
+		return !backfiller.Progress().Running
+	}, time.Second*5, time.Millisecond*10)
+	require.NoError(t, backfiller.Stop(ctx))
+	require.EqualValues(t, 2, backfiller.Progress().Fetched)
+
+	for height := uint64(1); height <= 4; height++ {
+		has, err := store.HasByHeight(ctx, height)
+		require.NoError(t, err)
+		require.Equal(t, height >= 3, has)
+	}
+}
@@ -2,14 +2,13 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	lru "github.com/hashicorp/golang-lru/v2"
-
 	"github.com/celestiaorg/celestia-node/share/eds"
 )
 
@@ -17,7 +16,8 @@ const defaultCloseTimeout = time.Minute
 
 var _ Cache = (*AccessorCache)(nil)
 
-// AccessorCache implements the Cache interface using an LRU cache backend.
+// AccessorCache implements the Cache interface on top of a cache backend with a configurable
+// eviction policy.
 type AccessorCache struct {
 	// The name is a prefix that will be used for cache metrics if they are enabled.
 	name string
@@ -27,7 +27,11 @@ type AccessorCache struct {
 	stripedLocks [256]*sync.RWMutex
 	// Caches the accessor for a given uint64 for accessor read affinity, i.e., further reads will
 	// likely be from the same accessor. Maps (Datahash -> accessor).
-	cache *lru.Cache[uint64, *accessor]
+	cache evictingCache
+	// loads tracks the accessors being loaded by height, so that misses of a height wait for its
+	// single load without blocking the loads of other heights.
+	loadsLk sync.Mutex
+	loads   map[uint64]*load
 
 	metrics *metrics
 }
@@ -43,21 +47,37 @@ type accessor struct {
 	isClosed bool
 }
 
+// load is a load of an accessor in progress.
+type load struct {
+	done chan struct{}
+	err  error
+	// removed is set if the height is removed from the cache while it is loaded
+	removed bool
+}
+
+// NewAccessorCache creates an AccessorCache of the given size with the LRU eviction policy.
 func NewAccessorCache(name string, cacheSize int) (*AccessorCache, error) {
+	return NewAccessorCacheWithPolicy(name, cacheSize, LRU)
+}
+
+// NewAccessorCacheWithPolicy creates an AccessorCache of the given size with the given eviction
+// policy.
+func NewAccessorCacheWithPolicy(name string, cacheSize int, policy EvictionPolicy) (*AccessorCache, error) {
 	bc := &AccessorCache{
 		name:         name,
 		stripedLocks: [256]*sync.RWMutex{},
+		loads:        make(map[uint64]*load),
 	}
 
 	for i := range bc.stripedLocks {
 		bc.stripedLocks[i] = &sync.RWMutex{}
 	}
 	// Instantiate the Accessor Cache.
-	bslru, err := lru.NewWithEvict[uint64, *accessor](cacheSize, bc.evictFn())
+	backend, err := newEvictingCache(policy, cacheSize, bc.evictFn())
 	if err != nil {
 		return nil, fmt.Errorf("creating accessor cache %s: %w", name, err)
 	}
-	bc.cache = bslru
+	bc.cache = backend
 	return bc, nil
 }
 
@@ -105,45 +125,114 @@ func (bc *AccessorCache) Get(height uint64) (eds.AccessorStreamer, error) {
 }
 
 // GetOrLoad attempts to get an item from the cache, and if not found, invokes
-// the provided loader function to load it.
+// the provided loader function to load it. Concurrent misses of the same height wait for a single
+// load, while loads of different heights run in parallel.
 func (bc *AccessorCache) GetOrLoad(
 	ctx context.Context,
 	height uint64,
 	loader OpenAccessorFn,
 ) (eds.AccessorStreamer, error) {
-	lk := bc.getLock(height)
-	lk.Lock()
-	defer lk.Unlock()
+	for {
+		if ac, ok := bc.get(height); ok {
+			return ac, nil
+		}
 
-	ac, ok := bc.cache.Get(height)
-	if ok {
-		// return accessor, only if it is not closed yet
-		accessorWithRef, err := newRefCloser(ac)
-		if err == nil {
-			bc.metrics.observeGet(true)
-			return accessorWithRef, nil
+		bc.loadsLk.Lock()
+		ld, loading := bc.loads[height]
+		if !loading {
+			// the previous load could have finished after the cache was checked
+			if ac, ok := bc.get(height); ok {
+				bc.loadsLk.Unlock()
+				return ac, nil
+			}
+			ld = &load{done: make(chan struct{})}
+			bc.loads[height] = ld
+		}
+		bc.loadsLk.Unlock()
+		if !loading {
+			return bc.load(ctx, height, loader, ld)
+		}
+
+		select {
+		case <-ld.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		// the load failed by the context of its caller is retried with ours
+		if ld.err != nil && !errors.Is(ld.err, context.Canceled) && !errors.Is(ld.err, context.DeadlineExceeded) {
+			return nil, ld.err
 		}
+		// otherwise, get the loaded accessor from the cache, unless it is evicted already
+	}
+}
+
+// get returns a reference to the cached accessor of the height, if it is cached and not closed.
+func (bc *AccessorCache) get(height uint64) (eds.AccessorStreamer, bool) {
+	lk := bc.getLock(height)
+	lk.RLock()
+	ac, ok := bc.cache.Get(height)
+	lk.RUnlock()
+	if !ok {
+		return nil, false
 	}
+	// return accessor, only if it is not closed yet
+	accessorWithRef, err := newRefCloser(ac)
+	if err != nil {
+		return nil, false
+	}
+	bc.metrics.observeGet(true)
+	return accessorWithRef, true
+}
 
-	// accessor not found in cache or closed, so load new one using loader
+// load loads the accessor of the height and caches it, finishing the given load.
+func (bc *AccessorCache) load(
+	ctx context.Context,
+	height uint64,
+	loader OpenAccessorFn,
+	ld *load,
+) (eds.AccessorStreamer, error) {
 	f, err := loader(ctx)
+
+	bc.loadsLk.Lock()
+	defer func() {
+		delete(bc.loads, height)
+		bc.loadsLk.Unlock()
+		close(ld.done)
+	}()
 	if err != nil {
-		return nil, fmt.Errorf("unable to load accessor: %w", err)
+		ld.err = fmt.Errorf("unable to load accessor: %w", err)
+		return nil, ld.err
+	}
+	if ld.removed {
+		// the height was removed while it was loaded, so the accessor is not cached and is closed by
+		// the caller
+		return f, nil
 	}
 
-	ac = &accessor{AccessorStreamer: f}
+	ac := &accessor{AccessorStreamer: f}
 	// Create a new accessor first to increment the reference count in it, so it cannot get evicted
 	// from the inner lru cache before it is used.
 	rc, err := newRefCloser(ac)
 	if err != nil {
+		ld.err = err
 		return nil, err
 	}
+	lk := bc.getLock(height)
+	lk.Lock()
 	bc.cache.Add(height, ac)
+	lk.Unlock()
 	return rc, nil
 }
 
 // Remove removes the Accessor for a given uint64 from the cache.
 func (bc *AccessorCache) Remove(height uint64) error {
+	// the accessor being loaded must not be cached after the removal
+	bc.loadsLk.Lock()
+	if ld, ok := bc.loads[height]; ok {
+		ld.removed = true
+	}
+	bc.loadsLk.Unlock()
+
 	lk := bc.getLock(height)
 	lk.RLock()
 	ac, ok := bc.cache.Get(height)
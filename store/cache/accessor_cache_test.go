@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -294,6 +295,150 @@ func TestAccessorCache(t *testing.T) {
 		require.NoError(t, err)
 		mock2.checkClosed(t, false)
 	})
+
+	t.Run("concurrent misses load once", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		cache, err := NewAccessorCache("test", 2)
+		require.NoError(t, err)
+
+		var loads atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ac, err := cache.GetOrLoad(ctx, 1, func(context.Context) (eds.AccessorStreamer, error) {
+					loads.Add(1)
+					time.Sleep(time.Millisecond * 10)
+					return &mockAccessor{}, nil
+				})
+				require.NoError(t, err)
+				require.NoError(t, ac.Close())
+			}()
+		}
+		wg.Wait()
+		require.EqualValues(t, 1, loads.Load())
+	})
+
+	t.Run("slow load does not block other heights", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		cache, err := NewAccessorCache("test", 2)
+		require.NoError(t, err)
+
+		started, release := make(chan struct{}), make(chan struct{})
+		slowDone := make(chan struct{})
+		go func() {
+			defer close(slowDone)
+			ac, err := cache.GetOrLoad(ctx, 1, func(context.Context) (eds.AccessorStreamer, error) {
+				close(started)
+				<-release
+				return &mockAccessor{}, nil
+			})
+			require.NoError(t, err)
+			require.NoError(t, ac.Close())
+		}()
+
+		<-started
+		// the height shares the lock stripe with the one being loaded
+		ac, err := cache.GetOrLoad(ctx, 257, func(context.Context) (eds.AccessorStreamer, error) {
+			return &mockAccessor{}, nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, ac.Close())
+
+		close(release)
+		<-slowDone
+		require.True(t, cache.Has(1))
+	})
+
+	t.Run("frequency policy keeps frequently read items", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		cache, err := NewAccessorCacheWithPolicy("test", 4, Frequency)
+		require.NoError(t, err)
+
+		mocks := make(map[uint64]*mockAccessor)
+		load := func(height uint64) {
+			mocks[height] = &mockAccessor{}
+			ac, err := cache.GetOrLoad(ctx, height, func(ctx context.Context) (eds.AccessorStreamer, error) {
+				return mocks[height], nil
+			})
+			require.NoError(t, err)
+			require.NoError(t, ac.Close())
+		}
+
+		// read first two items again, so that they become frequent
+		load(1)
+		load(2)
+		for _, height := range []uint64{1, 2} {
+			ac, err := cache.Get(height)
+			require.NoError(t, err)
+			require.NoError(t, ac.Close())
+		}
+
+		// items read once are evicted first
+		for height := uint64(3); height <= 6; height++ {
+			load(height)
+		}
+		for height := uint64(1); height <= 6; height++ {
+			evicted := height == 3 || height == 4
+			require.Equal(t, !evicted, cache.Has(height))
+			mocks[height].checkClosed(t, evicted)
+		}
+
+		// removal closes frequent items
+		require.NoError(t, cache.Remove(1))
+		require.False(t, cache.Has(1))
+		mocks[1].checkClosed(t, true)
+	})
+}
+
+// BenchmarkAccessorCache_MixedHitMiss measures the hits of the cached heights while slow loads
+// of the missing heights run in parallel, which must not block the hits.
+func BenchmarkAccessorCache_MixedHitMiss(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.Cleanup(cancel)
+
+	const (
+		cached = 256
+		// every missEvery-th get is a miss
+		missEvery = 10
+		loadDelay = time.Millisecond
+	)
+	loader := func(delay time.Duration) OpenAccessorFn {
+		return func(context.Context) (eds.AccessorStreamer, error) {
+			time.Sleep(delay)
+			return &mockAccessor{}, nil
+		}
+	}
+	// the cache is big enough for the misses not to evict the cached heights
+	cache, err := NewAccessorCache("test", cached+b.N)
+	require.NoError(b, err)
+	for height := uint64(0); height < cached; height++ {
+		ac, err := cache.GetOrLoad(ctx, height, loader(0))
+		require.NoError(b, err)
+		require.NoError(b, ac.Close())
+	}
+
+	var next atomic.Uint64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			i := next.Add(1)
+			height := i % cached
+			if i%missEvery == 0 {
+				height = cached + i
+			}
+			ac, err := cache.GetOrLoad(ctx, height, loader(loadDelay))
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			_ = ac.Close()
+		}
+	})
 }
 
 type mockAccessor struct {
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -17,6 +18,9 @@ type metrics struct {
 	getCounter     metric.Int64Counter
 	evictedCounter metric.Int64Counter
 	reg            metric.Registration
+
+	// gets and hits are counted for the hit ratio
+	gets, hits atomic.Int64
 }
 
 func newMetrics(bc *AccessorCache) (*metrics, error) {
@@ -41,17 +45,26 @@ func newMetrics(bc *AccessorCache) (*metrics, error) {
 		return nil, err
 	}
 
-	callback := func(_ context.Context, observer metric.Observer) error {
-		observer.ObserveInt64(cacheSize, int64(bc.cache.Len()))
-		return nil
+	hitRatio, err := meter.Float64ObservableGauge(metricsPrefix+"hit_ratio",
+		metric.WithDescription("share of cache gets that found the item in cache"),
+	)
+	if err != nil {
+		return nil, err
 	}
-	reg, err := meter.RegisterCallback(callback, cacheSize)
 
-	return &metrics{
+	m := &metrics{
 		getCounter:     getCounter,
 		evictedCounter: evictedCounter,
-		reg:            reg,
-	}, err
+	}
+	callback := func(_ context.Context, observer metric.Observer) error {
+		observer.ObserveInt64(cacheSize, int64(bc.cache.Len()))
+		if gets := m.gets.Load(); gets > 0 {
+			observer.ObserveFloat64(hitRatio, float64(m.hits.Load())/float64(gets))
+		}
+		return nil
+	}
+	m.reg, err = meter.RegisterCallback(callback, cacheSize, hitRatio)
+	return m, err
 }
 
 func (m *metrics) observeEvicted(failed bool) {
@@ -67,6 +80,10 @@ func (m *metrics) observeGet(found bool) {
 	if m == nil {
 		return
 	}
+	m.gets.Add(1)
+	if found {
+		m.hits.Add(1)
+	}
 	m.getCounter.Add(context.Background(), 1, metric.WithAttributes(
 		attribute.Bool(cacheFoundKey, found)))
 }
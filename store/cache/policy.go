@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+)
+
+// EvictionPolicy defines which accessor is evicted from a full AccessorCache.
+type EvictionPolicy string
+
+const (
+	// LRU evicts the least recently used accessor.
+	LRU EvictionPolicy = "lru"
+	// Frequency keeps accessors used more than once apart from the ones used only once and evicts
+	// the latter first, so that a burst of one-off reads does not flush frequently read blocks out of
+	// the cache.
+	Frequency EvictionPolicy = "frequency"
+)
+
+// recentRatio is the share of the Frequency cache dedicated to the accessors used only once.
+const recentRatio = 0.25
+
+// Validate checks that the policy is known. Empty policy stands for LRU.
+func (p EvictionPolicy) Validate() error {
+	switch p {
+	case "", LRU, Frequency:
+		return nil
+	default:
+		return fmt.Errorf("unknown cache eviction policy %q, expected %q or %q", p, LRU, Frequency)
+	}
+}
+
+// evictingCache is the backend of AccessorCache, which calls back on every removed item.
+type evictingCache interface {
+	Add(height uint64, ac *accessor) (evicted bool)
+	Get(height uint64) (*accessor, bool)
+	Contains(height uint64) bool
+	Remove(height uint64) (present bool)
+	Len() int
+}
+
+func newEvictingCache(
+	policy EvictionPolicy,
+	size int,
+	onEvict func(uint64, *accessor),
+) (evictingCache, error) {
+	switch policy {
+	case "", LRU:
+		return lru.NewWithEvict[uint64, *accessor](size, onEvict)
+	case Frequency:
+		return newFrequencyCache(size, onEvict)
+	default:
+		return nil, policy.Validate()
+	}
+}
+
+// frequencyCache is a simplified 2Q cache. New accessors are put to the recent queue and moved to
+// the frequent one once they are used again. When the cache is full, the recent queue is evicted
+// first, unless it is smaller than its share of the cache.
+type frequencyCache struct {
+	lock       sync.Mutex
+	size       int
+	recentSize int
+	recent     *simplelru.LRU[uint64, *accessor]
+	frequent   *simplelru.LRU[uint64, *accessor]
+
+	onEvict func(uint64, *accessor)
+	// promoting suppresses the eviction callback while an accessor moves between the queues
+	promoting bool
+}
+
+func newFrequencyCache(size int, onEvict func(uint64, *accessor)) (*frequencyCache, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("must provide a positive size")
+	}
+
+	c := &frequencyCache{
+		size:       size,
+		recentSize: max(1, int(float64(size)*recentRatio)),
+		onEvict:    onEvict,
+	}
+	var err error
+	// queues are sized to the whole cache, as the eviction between them is handled by the cache
+	c.recent, err = simplelru.NewLRU[uint64, *accessor](size, c.onRecentEvict)
+	if err != nil {
+		return nil, err
+	}
+	c.frequent, err = simplelru.NewLRU[uint64, *accessor](size, onEvict)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *frequencyCache) onRecentEvict(height uint64, ac *accessor) {
+	if !c.promoting {
+		c.onEvict(height, ac)
+	}
+}
+
+func (c *frequencyCache) Add(height uint64, ac *accessor) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.frequent.Contains(height) {
+		c.frequent.Add(height, ac)
+		return false
+	}
+	if c.recent.Contains(height) {
+		c.promote(height, ac)
+		return false
+	}
+
+	evicted := c.ensureSpace()
+	c.recent.Add(height, ac)
+	return evicted
+}
+
+func (c *frequencyCache) Get(height uint64) (*accessor, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if ac, ok := c.frequent.Get(height); ok {
+		return ac, true
+	}
+	ac, ok := c.recent.Peek(height)
+	if ok {
+		c.promote(height, ac)
+	}
+	return ac, ok
+}
+
+func (c *frequencyCache) Contains(height uint64) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.frequent.Contains(height) || c.recent.Contains(height)
+}
+
+func (c *frequencyCache) Remove(height uint64) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.frequent.Remove(height) || c.recent.Remove(height)
+}
+
+func (c *frequencyCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.recent.Len() + c.frequent.Len()
+}
+
+// promote moves the accessor from the recent queue to the frequent one.
+func (c *frequencyCache) promote(height uint64, ac *accessor) {
+	c.promoting = true
+	c.recent.Remove(height)
+	c.promoting = false
+	c.frequent.Add(height, ac)
+}
+
+// ensureSpace evicts an accessor if the cache is full.
+func (c *frequencyCache) ensureSpace() bool {
+	recentLen := c.recent.Len()
+	if recentLen+c.frequent.Len() < c.size {
+		return false
+	}
+	if recentLen > 0 && (recentLen >= c.recentSize || c.frequent.Len() == 0) {
+		c.recent.RemoveOldest()
+		return true
+	}
+	c.frequent.RemoveOldest()
+	return true
+}
@@ -0,0 +1,182 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/store/file"
+)
+
+// CheckResult reports the integrity of the EDS files of the Store.
+type CheckResult struct {
+	// Checked is the number of checked heights.
+	Checked int `json:"checked"`
+	// Unverified is the number of heights without known roots, which were only checked against
+	// the roots kept in their files.
+	Unverified int `json:"unverified"`
+	// Corrupted lists the heights with missing, truncated or corrupted files.
+	Corrupted []CheckFailure `json:"corrupted"`
+	// Removed is the number of corrupted heights removed by the repair.
+	Removed int `json:"removed"`
+}
+
+// CheckFailure describes a corrupted height.
+type CheckFailure struct {
+	Height uint64 `json:"height"`
+	Reason string `json:"reason"`
+}
+
+// Check walks the stored heights and verifies their EDS files: the files must be complete, the
+// shares must hash to the roots of the height and the Q4 file, if any, must match the parity
+// computed from the ODS. Roots are taken from the given getter, falling back to the roots kept in
+// the files for unknown heights. With repair, the corrupted heights are removed from the Store.
+func (s *Store) Check(ctx context.Context, getRoots RootsGetter, repair bool) (*CheckResult, error) {
+	if repair && s.readOnly {
+		return nil, ErrReadOnly
+	}
+	heights, err := s.heights()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CheckResult{}
+	for _, height := range heights {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		roots, err := getRoots(ctx, height)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			roots = nil
+			result.Unverified++
+		case err != nil:
+			return result, fmt.Errorf("getting roots of height %d: %w", height, err)
+		}
+
+		result.Checked++
+		lock := s.stripLock.byHeight(height)
+		lock.RLock()
+		datahash, checkErr := s.checkHeight(ctx, height, roots)
+		lock.RUnlock()
+		if checkErr == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		log.Warnw("corrupted EDS", "height", height, "err", checkErr)
+		result.Corrupted = append(result.Corrupted, CheckFailure{Height: height, Reason: checkErr.Error()})
+		if !repair {
+			continue
+		}
+		if err := s.removeCorrupted(height, datahash); err != nil {
+			return result, fmt.Errorf("removing height %d: %w", height, err)
+		}
+		result.Removed++
+	}
+	return result, nil
+}
+
+// checkHeight verifies the EDS files of the height against the roots and returns the datahash the
+// files are kept under. The roots of the file are used when the given roots are nil.
+func (s *Store) checkHeight(ctx context.Context, height uint64, roots *share.AxisRoots) (share.DataHash, error) {
+	var datahash share.DataHash
+	if roots != nil {
+		datahash = roots.Hash()
+	}
+
+	ods, err := file.OpenODS(s.heightToPath(height, odsFileExt))
+	if err != nil {
+		return datahash, fmt.Errorf("opening ODS: %w", err)
+	}
+	defer utils.CloseAndLog(log, "ods", ods)
+
+	fileHash, err := ods.DataHash(ctx)
+	if err != nil {
+		return datahash, fmt.Errorf("reading datahash: %w", err)
+	}
+	if datahash == nil {
+		datahash = fileHash
+	}
+	if !bytes.Equal(fileHash, datahash) {
+		return datahash, fmt.Errorf("file datahash %s does not match the height", fileHash.String())
+	}
+	if roots == nil {
+		roots, err = ods.AxisRoots(ctx)
+		if err != nil {
+			return datahash, fmt.Errorf("reading roots: %w", err)
+		}
+	}
+
+	if err := ods.Validate(ctx); err != nil {
+		return datahash, err
+	}
+	shares, err := ods.Shares(ctx)
+	if err != nil {
+		return datahash, fmt.Errorf("reading shares: %w", err)
+	}
+	square, err := eds.Rsmt2DFromShares(shares, len(roots.RowRoots)/2)
+	if err != nil {
+		return datahash, err
+	}
+	computed, err := share.NewAxisRoots(square.ExtendedDataSquare)
+	if err != nil {
+		return datahash, fmt.Errorf("computing roots: %w", err)
+	}
+	if !bytes.Equal(computed.Hash(), roots.Hash()) {
+		return datahash, errors.New("shares do not match the roots")
+	}
+
+	pathQ4 := s.hashToPath(datahash, q4FileExt)
+	if _, err := os.Stat(pathQ4); errors.Is(err, os.ErrNotExist) {
+		return datahash, nil
+	}
+	return datahash, checkQ4(ctx, file.ODSWithQ4(ods, pathQ4), square.ExtendedDataSquare)
+}
+
+// checkQ4 compares the rows of the Q4 file with the rows of the EDS.
+func checkQ4(ctx context.Context, odsq4 *file.ODSQ4, square *rsmt2d.ExtendedDataSquare) error {
+	width := int(square.Width())
+	for rowIdx := width / 2; rowIdx < width; rowIdx++ {
+		half, err := odsq4.AxisHalf(ctx, rsmt2d.Row, rowIdx)
+		if err != nil {
+			return fmt.Errorf("reading Q4 row %d: %w", rowIdx, err)
+		}
+		expected := square.Row(uint(rowIdx))[width/2:]
+		for i := range expected {
+			if !bytes.Equal(half.Shares[i], expected[i]) {
+				return fmt.Errorf("row %d of Q4 does not match the ODS", rowIdx)
+			}
+		}
+	}
+	return nil
+}
+
+// removeCorrupted removes the files of the corrupted height.
+func (s *Store) removeCorrupted(height uint64, datahash share.DataHash) error {
+	if datahash == nil {
+		// the file is unreadable, so only its height link is known
+		lock := s.stripLock.byHeight(height)
+		lock.Lock()
+		defer lock.Unlock()
+		if err := s.cache.Remove(height); err != nil {
+			return fmt.Errorf("removing from cache: %w", err)
+		}
+		return s.unlinkHeight(height, nil)
+	}
+
+	lock := s.stripLock.byHashAndHeight(datahash, height)
+	lock.lock()
+	defer lock.unlock()
+	return s.removeODSQ4(height, datahash)
+}
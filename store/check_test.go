@@ -0,0 +1,89 @@
+package store
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestStore_Check(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	store, err := NewStore(paramsNoCache(), t.TempDir())
+	require.NoError(t, err)
+
+	rootsByHeight := make(map[uint64]*share.AxisRoots)
+	put := func(height uint64) *share.AxisRoots {
+		eds, roots := randomEDS(t)
+		require.NoError(t, store.PutODSQ4(ctx, roots, height, eds))
+		rootsByHeight[height] = roots
+		return roots
+	}
+
+	// intact blocks
+	put(1)
+	require.NoError(t, store.PutODSQ4(ctx, share.EmptyEDSRoots(), 2, share.EmptyEDS()))
+	rootsByHeight[2] = share.EmptyEDSRoots()
+	// truncated ODS
+	truncated := put(3)
+	pathODS := store.hashToPath(truncated.Hash(), odsFileExt)
+	stat, err := os.Stat(pathODS)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(pathODS, stat.Size()-1))
+	// corrupted Q4
+	corrupted := put(4)
+	pathQ4 := store.hashToPath(corrupted.Hash(), q4FileExt)
+	stat, err = os.Stat(pathQ4)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(pathQ4, make([]byte, stat.Size()), 0o600))
+	// block of another height
+	put(5)
+	rootsByHeight[5] = randomRoots(t)
+	// intact block of an unknown height
+	put(6)
+	delete(rootsByHeight, 6)
+
+	getRoots := func(_ context.Context, height uint64) (*share.AxisRoots, error) {
+		roots, ok := rootsByHeight[height]
+		if !ok {
+			return nil, ErrNotFound
+		}
+		return roots, nil
+	}
+
+	result, err := store.Check(ctx, getRoots, false)
+	require.NoError(t, err)
+	require.Equal(t, 6, result.Checked)
+	require.Equal(t, 1, result.Unverified)
+	require.Equal(t, []uint64{3, 4, 5}, failedHeights(result))
+	require.Zero(t, result.Removed)
+
+	result, err = store.Check(ctx, getRoots, true)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 4, 5}, failedHeights(result))
+	require.Equal(t, 3, result.Removed)
+	for _, height := range []uint64{3, 4, 5} {
+		has, err := store.HasByHeight(ctx, height)
+		require.NoError(t, err)
+		require.False(t, has)
+	}
+
+	result, err = store.Check(ctx, getRoots, false)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Checked)
+	require.Empty(t, result.Corrupted)
+}
+
+func failedHeights(result *CheckResult) []uint64 {
+	heights := make([]uint64, 0, len(result.Corrupted))
+	for _, failure := range result.Corrupted {
+		heights = append(heights, failure.Height)
+	}
+	return heights
+}
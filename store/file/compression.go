@@ -0,0 +1,73 @@
+package file
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstd encoder and decoder are expensive to create, but safe for concurrent use, so they are
+// shared by all files.
+var (
+	zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil)
+	})
+	zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil)
+	})
+)
+
+// compress appends the content compressed with the given codec to dst.
+func compress(compression Compression, dst, content []byte) ([]byte, error) {
+	switch compression {
+	case NoCompression:
+		return append(dst, content...), nil
+	case ZstdCompression:
+		enc, err := zstdEncoder()
+		if err != nil {
+			return nil, fmt.Errorf("creating zstd encoder: %w", err)
+		}
+		return enc.EncodeAll(content, dst), nil
+	default:
+		return nil, fmt.Errorf("unknown compression: %d", compression)
+	}
+}
+
+// decompress appends the content decompressed with the given codec to dst. It fails if the
+// decompressed content exceeds maxSize, as a corrupted file could otherwise make it allocate
+// arbitrary amounts of memory.
+func decompress(compression Compression, dst, content []byte, maxSize int) ([]byte, error) {
+	switch compression {
+	case NoCompression:
+		return append(dst, content...), nil
+	case ZstdCompression:
+		dec, err := zstdDecoder()
+		if err != nil {
+			return nil, fmt.Errorf("creating zstd decoder: %w", err)
+		}
+		size, err := zstdFrameSize(content)
+		if err != nil {
+			return nil, err
+		}
+		if size > uint64(maxSize) {
+			return nil, fmt.Errorf("decompressed size %d exceeds expected %d", size, maxSize)
+		}
+		return dec.DecodeAll(content, dst)
+	default:
+		return nil, fmt.Errorf("unknown compression: %d", compression)
+	}
+}
+
+// zstdFrameSize reads the decompressed size from the zstd frame header, which EncodeAll always
+// writes.
+func zstdFrameSize(content []byte) (uint64, error) {
+	var hdr zstd.Header
+	if err := hdr.Decode(content); err != nil {
+		return 0, fmt.Errorf("decoding zstd frame header: %w", err)
+	}
+	if !hdr.HasFCS {
+		return 0, fmt.Errorf("zstd frame misses content size")
+	}
+	return hdr.FrameContentSize, nil
+}
@@ -1,6 +1,11 @@
 package file
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
 	logging "github.com/ipfs/go-log/v2"
 )
 
@@ -11,4 +16,56 @@ const (
 	// TODO(@Wondertan): Consider making it configurable
 	writeBufferSize = 64 << 10
 	filePermissions = 0o600
+	// TmpFileExt is the extension of the temporary files the content is written to before it is
+	// moved under its final path.
+	TmpFileExt = ".tmp"
 )
+
+// createFile atomically creates a new file under the given path with the content written by the
+// write func. The content is written to a temporary file and synced to disk before the temporary
+// file is linked under the path, so that a crash never leaves a partially written file behind,
+// but only the temporary one. The directory is synced after the link, so that the file survives
+// a crash once createFile returns. It fails with os.ErrExist if the file already exists.
+func createFile(path string, write func(*os.File) error) error {
+	if _, err := os.Lstat(path); err == nil {
+		return &os.PathError{Op: "create", Path: path, Err: os.ErrExist}
+	}
+
+	// callers serialize writes of the same path, so the temporary file left by a crashed write
+	// can be truncated
+	tmpPath := path + TmpFileExt
+	f, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, filePermissions)
+	if err != nil {
+		return err
+	}
+
+	err = write(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if errClose := f.Close(); errClose != nil {
+		err = errors.Join(err, fmt.Errorf("closing file: %w", errClose))
+	}
+	if err == nil {
+		// unlike rename, link fails if the file exists
+		err = os.Link(tmpPath, path)
+	}
+	err = errors.Join(err, os.Remove(tmpPath))
+	if err == nil {
+		err = SyncDir(filepath.Dir(path))
+	}
+	return err
+}
+
+// SyncDir syncs the directory to disk, so that the entries created or removed in it survive a
+// crash.
+func SyncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	if err := dir.Sync(); err != nil {
+		return errors.Join(fmt.Errorf("syncing directory: %w", err), dir.Close())
+	}
+	return dir.Close()
+}
@@ -18,6 +18,7 @@ const headerVersionV0 headerVersion = iota + 1
 
 type headerV0 struct {
 	fileVersion fileVersion
+	compression Compression
 
 	// Taken directly from EDS
 	shareSize  uint16
@@ -28,6 +29,17 @@ type headerV0 struct {
 
 type fileVersion uint8
 
+// Compression is the codec the file content following the header is compressed with.
+type Compression uint8
+
+const (
+	// NoCompression keeps the content as is. Files written before compression was introduced have
+	// the zero value in its place.
+	NoCompression Compression = iota
+	// ZstdCompression compresses the content with zstd.
+	ZstdCompression
+)
+
 const (
 	fileV0 fileVersion = iota + 1
 )
@@ -85,6 +97,7 @@ func (h *headerV0) OffsetWithRoots() int {
 func (h *headerV0) WriteTo(w io.Writer) (int64, error) {
 	buf := make([]byte, headerVOSize)
 	buf[0] = byte(h.fileVersion)
+	buf[1] = byte(h.compression)
 	binary.LittleEndian.PutUint16(buf[28:30], h.shareSize)
 	binary.LittleEndian.PutUint16(buf[30:32], h.squareSize)
 	copy(buf[32:64], h.datahash)
@@ -100,6 +113,7 @@ func (h *headerV0) ReadFrom(r io.Reader) (int64, error) {
 	}
 
 	h.fileVersion = fileVersion(bytesHeader[0])
+	h.compression = Compression(bytesHeader[1])
 	h.shareSize = binary.LittleEndian.Uint16(bytesHeader[28:30])
 	h.squareSize = binary.LittleEndian.Uint16(bytesHeader[30:32])
 	h.datahash = bytesHeader[32:64]
@@ -27,6 +27,7 @@ func Fuzz_writeReadheader(f *testing.F) {
 
 		testHdr := headerV0{
 			fileVersion: fileVersion(ver),
+			compression: Compression(typ),
 			shareSize:   shs,
 			squareSize:  sqs,
 			datahash:    b,
@@ -43,6 +44,7 @@ func Fuzz_writeReadheader(f *testing.F) {
 			return
 		}
 
+		require.Equal(t, hdr.compression, testHdr.compression)
 		require.Equal(t, hdr.shareSize, testHdr.shareSize)
 		require.Equal(t, hdr.squareSize, testHdr.squareSize)
 		require.Equal(t, hdr.datahash, testHdr.datahash)
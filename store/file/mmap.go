@@ -0,0 +1,85 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// mapping is a read-only memory mapping of a file. Reads are served from the page cache without a
+// syscall per read, which is what random share reads under heavy load are bound by otherwise.
+// Reads after unmap fail instead of faulting.
+type mapping struct {
+	lk   sync.RWMutex
+	data []byte
+}
+
+// mapFile maps the whole file into memory. Samples are read at random, so the kernel read ahead is
+// disabled for the mapping, while sequential reads hint the pages they need with willNeed.
+func mapFile(f *os.File) (*mapping, error) {
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("getting file stats: %w", err)
+	}
+	if stat.Size() == 0 {
+		return nil, errors.New("mapping empty file")
+	}
+
+	data, err := mmap(f, int(stat.Size()))
+	if err != nil {
+		return nil, fmt.Errorf("mapping file: %w", err)
+	}
+	if err := madviseRandom(data); err != nil {
+		return nil, errors.Join(fmt.Errorf("advising random access: %w", err), munmap(data))
+	}
+	return &mapping{data: data}, nil
+}
+
+// ReadAt implements io.ReaderAt.
+func (m *mapping) ReadAt(p []byte, off int64) (int, error) {
+	m.lk.RLock()
+	defer m.lk.RUnlock()
+	if m.data == nil {
+		return 0, os.ErrClosed
+	}
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	if off >= int64(len(m.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, m.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// willNeed hints the kernel to read the given range ahead, as it is about to be read sequentially.
+func (m *mapping) willNeed(off, length int) {
+	m.lk.RLock()
+	defer m.lk.RUnlock()
+	if m.data == nil || off < 0 || off >= len(m.data) {
+		return
+	}
+	// madvise requires the range to start at the page boundary
+	start := off &^ (os.Getpagesize() - 1)
+	end := min(off+length, len(m.data))
+	if err := madviseWillNeed(m.data[start:end]); err != nil {
+		log.Debugw("advising sequential read", "offset", off, "length", length, "err", err)
+	}
+}
+
+// unmap releases the mapping. It waits for the reads in progress.
+func (m *mapping) unmap() error {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	if m.data == nil {
+		return nil
+	}
+	err := munmap(m.data)
+	m.data = nil
+	return err
+}
@@ -0,0 +1,26 @@
+//go:build !linux && !darwin
+
+package file
+
+import (
+	"errors"
+	"os"
+)
+
+var errMmapUnsupported = errors.New("mmap is not supported on this platform")
+
+func mmap(*os.File, int) ([]byte, error) {
+	return nil, errMmapUnsupported
+}
+
+func munmap([]byte) error {
+	return errMmapUnsupported
+}
+
+func madviseRandom([]byte) error {
+	return errMmapUnsupported
+}
+
+func madviseWillNeed([]byte) error {
+	return errMmapUnsupported
+}
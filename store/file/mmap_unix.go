@@ -0,0 +1,25 @@
+//go:build linux || darwin
+
+package file
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func mmap(f *os.File, size int) ([]byte, error) {
+	return unix.Mmap(int(f.Fd()), 0, size, unix.PROT_READ, unix.MAP_SHARED)
+}
+
+func munmap(data []byte) error {
+	return unix.Munmap(data)
+}
+
+func madviseRandom(data []byte) error {
+	return unix.Madvise(data, unix.MADV_RANDOM)
+}
+
+func madviseWillNeed(data []byte) error {
+	return unix.Madvise(data, unix.MADV_WILLNEED)
+}
@@ -2,6 +2,7 @@ package file
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -18,12 +19,20 @@ import (
 
 var _ eds.AccessorStreamer = (*ODS)(nil)
 
+// ErrIncompleteFile is returned for files missing a part of their content.
+var ErrIncompleteFile = errors.New("incomplete file")
+
 // ODS implements eds.Accessor as an FS file.
 // It stores the original data square(ODS), which is the first quadrant of EDS,
 // and it's metadata in file's header.
 type ODS struct {
 	hdr *headerV0
 	fl  *os.File
+	// content reads the file at the offsets of the uncompressed layout. It is loaded lazily, either
+	// mapping the file into memory or, for compressed files, decompressing it into memory.
+	content func() (io.ReaderAt, error)
+	// mapped is the memory mapping of the uncompressed file, if mapping succeeded.
+	mapped *mapping
 
 	lock sync.RWMutex
 	// ods stores an in-memory cache of the original data square to enhance read performance. This
@@ -38,36 +47,39 @@ type ODS struct {
 	// Used for testing and benchmarking purposes, this flag allows for the evaluation of the
 	// performance.
 	disableCache bool
+	// disableMmap is a flag that, when set to true, makes the file to be read with pread instead of
+	// being memory mapped. Used for testing and benchmarking purposes.
+	disableMmap bool
 }
 
 // CreateODS creates a new file under given FS path and
-// writes the ODS into it out of given EDS.
-// It may leave partially written file if any of the writes fail.
+// writes the ODS into it out of given EDS, compressing it with the given codec.
+// The file is created atomically, so a failed or interrupted write leaves no partial file behind.
 func CreateODS(
 	path string,
 	roots *share.AxisRoots,
 	eds *rsmt2d.ExtendedDataSquare,
+	compression Compression,
 ) error {
-	mod := os.O_RDWR | os.O_CREATE | os.O_EXCL // ensure we fail if already exist
-	f, err := os.OpenFile(path, mod, filePermissions)
-	if err != nil {
-		return fmt.Errorf("creating ODS file: %w", err)
-	}
-
 	shareSize := len(eds.GetCell(0, 0))
 	hdr := &headerV0{
 		fileVersion: fileV0,
+		compression: compression,
 		shareSize:   uint16(shareSize),
 		squareSize:  uint16(eds.Width()),
 		datahash:    roots.Hash(),
 	}
 
-	err = writeODSFile(f, roots, eds, hdr)
-	if errClose := f.Close(); errClose != nil {
-		err = errors.Join(err, fmt.Errorf("closing created ODS file: %w", errClose))
+	err := createFile(path, func(f *os.File) error {
+		if compression == NoCompression {
+			return writeODSFile(f, roots, eds, hdr)
+		}
+		return writeCompressedODSFile(f, roots, eds, hdr)
+	})
+	if err != nil {
+		return fmt.Errorf("creating ODS file: %w", err)
 	}
-
-	return err
+	return nil
 }
 
 // writeQ4File full ODS content into OS File.
@@ -94,6 +106,42 @@ func writeODSFile(f *os.File, axisRoots *share.AxisRoots, eds *rsmt2d.ExtendedDa
 	return nil
 }
 
+// writeCompressedODSFile writes the header followed by the compressed axis roots and ODS. The
+// content is compressed as a whole, so that the codec can make use of the repetitive padding shares.
+func writeCompressedODSFile(
+	f *os.File,
+	axisRoots *share.AxisRoots,
+	eds *rsmt2d.ExtendedDataSquare,
+	hdr *headerV0,
+) error {
+	content := bytes.NewBuffer(make([]byte, 0, hdr.RootsSize()+odsSize(hdr)))
+	if err := writeAxisRoots(content, axisRoots); err != nil {
+		return fmt.Errorf("writing axis roots: %w", err)
+	}
+	if err := writeODS(content, eds); err != nil {
+		return fmt.Errorf("writing ODS: %w", err)
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, hdr.Size()))
+	if err := writeHeader(buf, hdr); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	data, err := compress(hdr.compression, buf.Bytes(), content.Bytes())
+	if err != nil {
+		return fmt.Errorf("compressing ODS: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("writing compressed ODS: %w", err)
+	}
+	return nil
+}
+
+// odsSize returns the size of the ODS shares in bytes.
+func odsSize(hdr *headerV0) int {
+	odsWidth := hdr.SquareSize() / 2
+	return hdr.ShareSize() * odsWidth * odsWidth
+}
+
 // writeODS writes the first quadrant(ODS) of the square to the writer. It writes the quadrant in
 // row-major order. Write finishes once all the shares are written or on the first instance of tail
 // padding share. Tail padding share are constant and aren't stored.
@@ -144,13 +192,115 @@ func OpenODS(path string) (*ODS, error) {
 
 	h, err := readHeader(f)
 	if err != nil {
-		return nil, err
+		return nil, errors.Join(err, f.Close())
 	}
 
-	return &ODS{
+	o := &ODS{
 		hdr: h,
 		fl:  f,
-	}, nil
+	}
+	if h.compression == NoCompression {
+		o.content = sync.OnceValues(o.mapContent)
+	} else {
+		o.content = sync.OnceValues(o.decompressContent)
+	}
+	return o, nil
+}
+
+// mapContent maps the file into memory, so that reads don't take a syscall each. It falls back to
+// reading the file if mapping is disabled or fails, e.g. on unsupported platforms.
+func (o *ODS) mapContent() (io.ReaderAt, error) {
+	if o.disableMmap {
+		return o.fl, nil
+	}
+	mapped, err := mapFile(o.fl)
+	if err != nil {
+		log.Debugw("falling back to file reads", "file", o.fl.Name(), "err", err)
+		return o.fl, nil
+	}
+	o.mapped = mapped
+	return mapped, nil
+}
+
+// willNeed hints that the given range of the mapped file is about to be read sequentially.
+// It must be called after the content is loaded.
+func (o *ODS) willNeed(offset, length int) {
+	if o.mapped != nil {
+		o.mapped.willNeed(offset, length)
+	}
+}
+
+// decompressContent reads and decompresses the whole file. Header bytes are kept in front of the
+// decompressed content, so that offsets in the file layout stay the same.
+func (o *ODS) decompressContent() (io.ReaderAt, error) {
+	stat, err := o.fl.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("getting file stats: %w", err)
+	}
+	compressed := make([]byte, stat.Size()-int64(o.hdr.Size()))
+	if _, err := o.fl.ReadAt(compressed, int64(o.hdr.Size())); err != nil {
+		return nil, fmt.Errorf("reading compressed content: %w", err)
+	}
+
+	maxSize := o.hdr.RootsSize() + odsSize(o.hdr)
+	content := make([]byte, o.hdr.Size(), o.hdr.Size()+maxSize)
+	content, err = decompress(o.hdr.compression, content, compressed, maxSize)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing content: %w", err)
+	}
+	return bytes.NewReader(content), nil
+}
+
+// contentSize returns the size of the file in the uncompressed layout.
+func (o *ODS) contentSize() (int64, error) {
+	if o.hdr.compression != NoCompression {
+		content, err := o.content()
+		if err != nil {
+			// compressed files broken in the middle of a write fail to decompress
+			return 0, fmt.Errorf("%w: %w", ErrIncompleteFile, err)
+		}
+		return content.(*bytes.Reader).Size(), nil
+	}
+
+	stat, err := o.fl.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("getting file stats: %w", err)
+	}
+	return stat.Size(), nil
+}
+
+// Validate checks that the file holds the whole ODS, detecting files broken in the middle of a
+// write, e.g. by a crash. Tail padding shares are not stored, so a file shorter than the ODS is
+// only complete if the rest of the ODS is tail padding. It is checked by verifying the row with the
+// first missing share against its root, which fails unless the row ends with tail padding.
+func (o *ODS) Validate(ctx context.Context) error {
+	size, err := o.contentSize()
+	if err != nil {
+		return err
+	}
+	sharesSize := size - int64(o.hdr.OffsetWithRoots())
+	if sharesSize < 0 || sharesSize%int64(o.hdr.ShareSize()) != 0 {
+		return fmt.Errorf("file size %d is not aligned to shares: %w", size, ErrIncompleteFile)
+	}
+	odsWidth := o.size() / 2
+	stored := int(sharesSize / int64(o.hdr.ShareSize()))
+	if stored >= odsWidth*odsWidth {
+		return nil
+	}
+
+	roots, err := o.AxisRoots(ctx)
+	if err != nil {
+		return fmt.Errorf("reading axis roots: %w", err)
+	}
+	rowIdx := stored / odsWidth
+	half, err := o.readAxisHalf(rsmt2d.Row, rowIdx)
+	if err != nil {
+		return fmt.Errorf("reading row %d: %w", rowIdx, err)
+	}
+	if err := shwap.NewRow(half.Shares, shwap.Left).Verify(roots, rowIdx); err != nil {
+		return fmt.Errorf("%d of %d shares stored: %w: %w", stored, odsWidth*odsWidth, ErrIncompleteFile, err)
+	}
+	return nil
 }
 
 // Size returns EDS size stored in file's header.
@@ -170,8 +320,12 @@ func (o *ODS) DataHash(context.Context) (share.DataHash, error) {
 // AxisRoots reads AxisRoots stored in the file. AxisRoots are stored after the header and before
 // the ODS data.
 func (o *ODS) AxisRoots(context.Context) (*share.AxisRoots, error) {
+	content, err := o.content()
+	if err != nil {
+		return nil, err
+	}
 	roots := make([]byte, o.hdr.RootsSize())
-	n, err := o.fl.ReadAt(roots, int64(o.hdr.Size()))
+	n, err := content.ReadAt(roots, int64(o.hdr.Size()))
 	if err != nil {
 		return nil, fmt.Errorf("reading axis roots: %w", err)
 	}
@@ -193,6 +347,9 @@ func (o *ODS) AxisRoots(context.Context) (*share.AxisRoots, error) {
 
 // Close closes the file.
 func (o *ODS) Close() error {
+	if o.mapped != nil {
+		return errors.Join(o.mapped.unmap(), o.fl.Close())
+	}
 	return o.fl.Close()
 }
 
@@ -252,6 +409,14 @@ func (o *ODS) RowNamespaceData(
 	namespace share.Namespace,
 	rowIdx int,
 ) (shwap.RowNamespaceData, error) {
+	if rowIdx < o.size()/2 {
+		// namespaces span consecutive rows, so the following row is likely to be read next
+		if _, err := o.content(); err != nil {
+			return shwap.RowNamespaceData{}, err
+		}
+		rowSize := o.size() / 2 * o.hdr.ShareSize()
+		o.willNeed(o.hdr.OffsetWithRoots()+rowIdx*rowSize, 2*rowSize)
+	}
 	shares, err := o.axis(ctx, rsmt2d.Row, rowIdx)
 	if err != nil {
 		return shwap.RowNamespaceData{}, err
@@ -278,9 +443,14 @@ func (o *ODS) Reader() (io.Reader, error) {
 		return ods.reader()
 	}
 
+	content, err := o.content()
+	if err != nil {
+		return nil, err
+	}
 	offset := o.hdr.OffsetWithRoots()
 	total := int64(o.hdr.shareSize) * int64(o.size()*o.size()/4)
-	reader := io.NewSectionReader(o.fl, int64(offset), total)
+	o.willNeed(offset, int(total))
+	reader := io.NewSectionReader(content, int64(offset), total)
 	return reader, nil
 }
 
@@ -306,7 +476,11 @@ func (o *ODS) readAxisHalf(axisType rsmt2d.Axis, axisIdx int) (eds.AxisHalf, err
 		return o.ods.axisHalf(axisType, axisIdx)
 	}
 
-	axisHalf, err := readAxisHalf(o.fl, axisType, axisIdx, o.hdr, o.hdr.OffsetWithRoots())
+	content, err := o.content()
+	if err != nil {
+		return eds.AxisHalf{}, err
+	}
+	axisHalf, err := readAxisHalf(content, axisType, axisIdx, o.hdr, o.hdr.OffsetWithRoots())
 	if err != nil {
 		return eds.AxisHalf{}, fmt.Errorf("reading axis half: %w", err)
 	}
@@ -331,11 +505,14 @@ func (o *ODS) readODS() (square, error) {
 		defer o.lock.Unlock()
 	}
 
+	content, err := o.content()
+	if err != nil {
+		return nil, err
+	}
 	offset := o.hdr.OffsetWithRoots()
 	shareSize := o.hdr.ShareSize()
-	odsBytes := o.hdr.SquareSize() / 2
-	odsSizeInBytes := shareSize * odsBytes * odsBytes
-	reader := io.NewSectionReader(o.fl, int64(offset), int64(odsSizeInBytes))
+	o.willNeed(offset, odsSize(o.hdr))
+	reader := io.NewSectionReader(content, int64(offset), int64(odsSize(o.hdr)))
 	ods, err := readSquare(reader, shareSize, o.size())
 	if err != nil {
 		return nil, fmt.Errorf("reading ODS: %w", err)
@@ -391,11 +568,13 @@ func readRowHalf(r io.ReaderAt, rowIdx int, hdr *headerV0, offset int) ([]share.
 func readColHalf(r io.ReaderAt, colIdx int, hdr *headerV0, offset int) ([]share.Share, error) {
 	odsLn := hdr.SquareSize() / 2
 	shares := make([]share.Share, odsLn)
+	// shares are read into a single allocation to reduce GC pressure
+	axsData := make([]byte, odsLn*hdr.ShareSize())
 	for i := range shares {
 		pos := colIdx + i*odsLn
 		offset := offset + pos*hdr.ShareSize()
 
-		shr := make(share.Share, hdr.ShareSize())
+		shr := axsData[i*hdr.ShareSize() : (i+1)*hdr.ShareSize() : (i+1)*hdr.ShareSize()]
 		n, err := r.ReadAt(shr, int64(offset))
 		if err != nil && !errors.Is(err, io.EOF) {
 			// unknown error
@@ -92,7 +92,7 @@ func createODSQ4File(t testing.TB, eds *rsmt2d.ExtendedDataSquare) *ODSQ4 {
 	roots, err := share.NewAxisRoots(eds)
 	require.NoError(t, err)
 	pathODS, pathQ4 := path+".ods", path+".q4"
-	err = CreateODSQ4(pathODS, pathQ4, roots, eds)
+	err = CreateODSQ4(pathODS, pathQ4, roots, eds, NoCompression)
 	require.NoError(t, err)
 	ods, err := OpenODS(pathODS)
 	require.NoError(t, err)
@@ -2,6 +2,7 @@ package file
 
 import (
 	"context"
+	"os"
 	"strconv"
 	"testing"
 	"time"
@@ -38,6 +39,32 @@ func TestCreateODSFile(t *testing.T) {
 	require.NoError(t, f.Close())
 }
 
+func TestCreateODSFile_Atomic(t *testing.T) {
+	edsIn := edstest.RandEDS(t, 8)
+	roots, err := share.NewAxisRoots(edsIn)
+	require.NoError(t, err)
+	path := t.TempDir() + "/ods"
+
+	// temporary file left behind by a crashed write
+	require.NoError(t, os.WriteFile(path+TmpFileExt, []byte("partial"), filePermissions))
+	require.NoError(t, CreateODS(path, roots, edsIn, NoCompression))
+	_, err = os.Stat(path + TmpFileExt)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// existing files are never overwritten
+	err = CreateODS(path, roots, edstest.RandEDS(t, 8), NoCompression)
+	require.ErrorIs(t, err, os.ErrExist)
+	_, err = os.Stat(path + TmpFileExt)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	f, err := OpenODS(path)
+	require.NoError(t, err)
+	shares, err := f.Shares(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, edsIn.FlattenedODS(), shares)
+	require.NoError(t, f.Close())
+}
+
 func TestReadODSFromFile(t *testing.T) {
 	eds := edstest.RandEDS(t, 8)
 	f := createODSFile(t, eds)
@@ -51,6 +78,77 @@ func TestReadODSFromFile(t *testing.T) {
 	}
 }
 
+func TestODSFile_Validate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	t.Run("complete", func(t *testing.T) {
+		f := createODSFile(t, edstest.RandEDS(t, 8))
+		require.NoError(t, f.Validate(ctx))
+	})
+
+	t.Run("tail padding", func(t *testing.T) {
+		f := createODSFile(t, edstest.RandEDSWithTailPadding(t, 8, 11))
+		require.NoError(t, f.Validate(ctx))
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		f := createODSFile(t, edstest.RandEDS(t, 8))
+		stat, err := f.fl.Stat()
+		require.NoError(t, err)
+		// cut off the last rows at the share boundary and in the middle of a share
+		for _, size := range []int64{stat.Size() - 3*share.Size, stat.Size() - 100} {
+			require.NoError(t, os.Truncate(f.fl.Name(), size))
+			require.ErrorIs(t, f.Validate(ctx), ErrIncompleteFile)
+		}
+	})
+}
+
+func TestCompressedODSFile(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	t.Cleanup(cancel)
+
+	t.Run("accessor", func(t *testing.T) {
+		eds.TestSuiteAccessor(ctx, t, createCompressedODSAccessor, 16)
+	})
+
+	t.Run("smaller on disk", func(t *testing.T) {
+		// a square of repeated shares, like the padding of near-empty blocks
+		const odsSize = 16
+		shr := edstest.RandEDS(t, 1).GetCell(0, 0)
+		shares := make([]share.Share, odsSize*odsSize)
+		for i := range shares {
+			shares[i] = shr
+		}
+		square, err := eds.Rsmt2DFromShares(shares, odsSize)
+		require.NoError(t, err)
+
+		plain := createODSFile(t, square.ExtendedDataSquare)
+		compressed := createCompressedODSFile(t, square.ExtendedDataSquare)
+		plainStat, err := plain.fl.Stat()
+		require.NoError(t, err)
+		compressedStat, err := compressed.fl.Stat()
+		require.NoError(t, err)
+		require.Less(t, compressedStat.Size()*10, plainStat.Size())
+
+		got, err := compressed.Shares(ctx)
+		require.NoError(t, err)
+		require.Equal(t, shares, got)
+		require.NoError(t, compressed.Validate(ctx))
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		f := createCompressedODSFile(t, edstest.RandEDS(t, 8))
+		stat, err := f.fl.Stat()
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(f.fl.Name(), stat.Size()-100))
+
+		f, err = OpenODS(f.fl.Name())
+		require.NoError(t, err)
+		require.ErrorIs(t, f.Validate(ctx), ErrIncompleteFile)
+	})
+}
+
 func TestODSFile(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	t.Cleanup(cancel)
@@ -61,6 +159,26 @@ func TestODSFile(t *testing.T) {
 	eds.TestStreamer(ctx, t, createODSAccessorStreamer, ODSSize)
 }
 
+func TestODSFile_Mmap(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	t.Cleanup(cancel)
+
+	t.Run("pread accessor", func(t *testing.T) {
+		eds.TestSuiteAccessor(ctx, t, createODSFilePread, 16)
+	})
+
+	t.Run("read after close", func(t *testing.T) {
+		f := createODSFileDisabledCache(t, edstest.RandEDS(t, 8)).(*ODS)
+		_, err := f.Shares(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, f.mapped)
+
+		require.NoError(t, f.Close())
+		_, err = f.Shares(ctx)
+		require.ErrorIs(t, err, os.ErrClosed)
+	})
+}
+
 // BenchmarkAxisFromODSFile/Size:32/ProofType:row/squareHalf:0-16         	  382011	      3104 ns/op
 // BenchmarkAxisFromODSFile/Size:32/ProofType:row/squareHalf:1-16         	    9320	    122408 ns/op
 // BenchmarkAxisFromODSFile/Size:32/ProofType:col/squareHalf:0-16         	 4408911	       266.5 ns/op
@@ -142,6 +260,22 @@ func BenchmarkSampleFromODSFileDisabledCache(b *testing.B) {
 	eds.BenchGetSampleFromAccessor(ctx, b, createODSFileDisabledCache, minSize, maxSize)
 }
 
+func BenchmarkAxisFromODSFilePread(b *testing.B) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	b.Cleanup(cancel)
+
+	minSize, maxSize := 32, 128
+	eds.BenchGetHalfAxisFromAccessor(ctx, b, createODSFilePread, minSize, maxSize)
+}
+
+func BenchmarkSampleFromODSFilePread(b *testing.B) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	b.Cleanup(cancel)
+
+	minSize, maxSize := 32, 128
+	eds.BenchGetSampleFromAccessor(ctx, b, createODSFilePread, minSize, maxSize)
+}
+
 func createODSAccessorStreamer(t testing.TB, eds *rsmt2d.ExtendedDataSquare) eds.AccessorStreamer {
 	return createODSFile(t, eds)
 }
@@ -158,10 +292,22 @@ func createCachedStreamer(t testing.TB, eds *rsmt2d.ExtendedDataSquare) eds.Acce
 }
 
 func createODSFile(t testing.TB, eds *rsmt2d.ExtendedDataSquare) *ODS {
+	return createODSFileWithCompression(t, eds, NoCompression)
+}
+
+func createCompressedODSFile(t testing.TB, eds *rsmt2d.ExtendedDataSquare) *ODS {
+	return createODSFileWithCompression(t, eds, ZstdCompression)
+}
+
+func createCompressedODSAccessor(t testing.TB, eds *rsmt2d.ExtendedDataSquare) eds.Accessor {
+	return createCompressedODSFile(t, eds)
+}
+
+func createODSFileWithCompression(t testing.TB, eds *rsmt2d.ExtendedDataSquare, compression Compression) *ODS {
 	path := t.TempDir() + "/" + strconv.Itoa(rand.Intn(1000))
 	roots, err := share.NewAxisRoots(eds)
 	require.NoError(t, err)
-	err = CreateODS(path, roots, eds)
+	err = CreateODS(path, roots, eds, compression)
 	require.NoError(t, err)
 	ods, err := OpenODS(path)
 	require.NoError(t, err)
@@ -173,3 +319,11 @@ func createODSFileDisabledCache(t testing.TB, eds *rsmt2d.ExtendedDataSquare) ed
 	ods.disableCache = true
 	return ods
 }
+
+// createODSFilePread creates the file read with pread instead of mmap, to compare both.
+func createODSFilePread(t testing.TB, eds *rsmt2d.ExtendedDataSquare) eds.Accessor {
+	ods := createODSFile(t, eds)
+	ods.disableCache = true
+	ods.disableMmap = true
+	return ods
+}
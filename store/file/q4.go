@@ -2,7 +2,6 @@ package file
 
 import (
 	"bufio"
-	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -20,23 +19,18 @@ type q4 struct {
 
 // createQ4 creates a new file under given FS path and
 // writes the Q4 into it out of given EDS.
-// It may leave partially written file if any of the writes fail.
+// The file is created atomically, so a failed or interrupted write leaves no partial file behind.
 func createQ4(
 	path string,
 	eds *rsmt2d.ExtendedDataSquare,
 ) error {
-	mod := os.O_RDWR | os.O_CREATE | os.O_EXCL // ensure we fail if already exist
-	f, err := os.OpenFile(path, mod, filePermissions)
+	err := createFile(path, func(f *os.File) error {
+		return writeQ4File(f, eds)
+	})
 	if err != nil {
 		return fmt.Errorf("creating Q4 file: %w", err)
 	}
-
-	err = writeQ4File(f, eds)
-	if errClose := f.Close(); errClose != nil {
-		err = errors.Join(err, fmt.Errorf("closing created Q4 file: %w", errClose))
-	}
-
-	return err
+	return nil
 }
 
 // writeQ4File full Q4 content into OS File.
@@ -0,0 +1,233 @@
+package store
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/store/file"
+)
+
+// tmpFileExt is the extension of the temporary files writes and downloads are written to.
+const tmpFileExt = file.TmpFileExt
+
+// GCResult summarizes the garbage removed by the Store.GC.
+type GCResult struct {
+	// RemovedFiles is the number of removed files and height links.
+	RemovedFiles int `json:"removed_files"`
+	// ReclaimedBytes is the amount of disk space freed by removing the files.
+	ReclaimedBytes int64 `json:"reclaimed_bytes"`
+}
+
+// GC removes the garbage left behind by crashes and interrupted operations:
+//   - height links to missing or partially written ODS files
+//   - ODS and Q4 files no height links to
+//   - Q4 files of missing ODS files
+//   - temporary files of interrupted writes and downloads
+//
+// Files modified after GC started are left intact, so that GC can run alongside writes.
+func (s *Store) GC(ctx context.Context) (*GCResult, error) {
+	if s.readOnly {
+		return nil, ErrReadOnly
+	}
+	start := time.Now()
+	result := &GCResult{}
+
+	referenced, err := s.gcHeights(ctx, start, result)
+	if err != nil {
+		return result, fmt.Errorf("collecting height links: %w", err)
+	}
+	if err := s.gcBlocks(ctx, start, referenced, result); err != nil {
+		return result, fmt.Errorf("collecting block files: %w", err)
+	}
+	log.Infow("garbage collected",
+		"removed_files", result.RemovedFiles,
+		"reclaimed_bytes", result.ReclaimedBytes,
+		"took", time.Since(start))
+	return result, nil
+}
+
+// gcHeights removes broken height links and returns the data hashes of the files the rest of them
+// link to.
+func (s *Store) gcHeights(ctx context.Context, start time.Time, result *GCResult) (map[string]bool, error) {
+	entries, err := os.ReadDir(filepath.Join(s.basepath, heightsPath))
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		height, err := strconv.ParseUint(strings.TrimSuffix(entry.Name(), odsFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		lock := s.stripLock.byHeight(height)
+		lock.Lock()
+		datahash, broken, err := s.checkHeightLink(ctx, height, start)
+		if err == nil && broken {
+			// height links share the disk space with the block files, so their size is not accounted
+			err = s.unlinkHeight(height, nil)
+			if err == nil {
+				result.RemovedFiles++
+			}
+		}
+		lock.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		if datahash != nil {
+			referenced[datahash.String()] = true
+		}
+	}
+	return referenced, nil
+}
+
+// checkHeightLink returns the data hash of the ODS file the height links to, or whether the link
+// is broken.
+func (s *Store) checkHeightLink(ctx context.Context, height uint64, start time.Time) (share.DataHash, bool, error) {
+	path := s.heightToPath(height, odsFileExt)
+	stat, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// symlink to a missing file
+		return nil, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if stat.ModTime().After(start) {
+		return nil, false, nil
+	}
+
+	ods, err := file.OpenODS(path)
+	if err != nil {
+		log.Warnw("gc: broken height link", "height", height, "err", err)
+		return nil, true, nil
+	}
+	defer utils.CloseAndLog(log, "gc ods", ods)
+	if err := ods.Validate(ctx); err != nil {
+		log.Warnw("gc: broken height link", "height", height, "err", err)
+		return nil, true, nil
+	}
+	datahash, err := ods.DataHash(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return datahash, false, nil
+}
+
+// gcBlocks removes the files in the blocks directory no height links to.
+func (s *Store) gcBlocks(ctx context.Context, start time.Time, referenced map[string]bool, result *GCResult) error {
+	entries, err := os.ReadDir(filepath.Join(s.basepath, blocksPath))
+	if err != nil {
+		return err
+	}
+
+	empty := share.EmptyEDSDataHash().String()
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if entry.IsDir() {
+			continue
+		}
+		name, ext := entry.Name(), filepath.Ext(entry.Name())
+		if ext == tmpFileExt {
+			if err := s.collectIfOlder(filepath.Join(s.basepath, blocksPath, name), start, result); err != nil {
+				return err
+			}
+			continue
+		}
+		if ext != odsFileExt && ext != q4FileExt {
+			continue
+		}
+		hashStr := strings.TrimSuffix(name, ext)
+		if hashStr == empty || referenced[hashStr] {
+			continue
+		}
+		datahash, err := hex.DecodeString(hashStr)
+		if err != nil || share.DataHash(datahash).Validate() != nil {
+			continue
+		}
+
+		lock := s.stripLock.byHash(datahash)
+		lock.Lock()
+		err = s.collectBlock(datahash, ext, start, result)
+		lock.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectBlock removes the unreferenced ODS file along with its Q4 file, or the Q4 file of the
+// missing ODS file.
+func (s *Store) collectBlock(datahash share.DataHash, ext string, start time.Time, result *GCResult) error {
+	pathODS := s.hashToPath(datahash, odsFileExt)
+	if ext == q4FileExt {
+		has, err := exists(pathODS)
+		if err != nil || has {
+			return err
+		}
+		return s.collectIfOlder(s.hashToPath(datahash, q4FileExt), start, result)
+	}
+
+	stat, err := os.Stat(pathODS)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil || stat.ModTime().After(start) {
+		return err
+	}
+	// the ODS file could have been written before GC started, but linked to its height after GC
+	// listed the height links. Puts link under the hash lock, so the links are up to date here.
+	// Without the link count, the file is left intact, as it can't be told unreferenced.
+	if links, ok := linkCount(stat); !ok || links > 1 {
+		return nil
+	}
+	if err := s.collect(pathODS, result); err != nil {
+		return err
+	}
+	return s.collect(s.hashToPath(datahash, q4FileExt), result)
+}
+
+func (s *Store) collectIfOlder(path string, start time.Time, result *GCResult) error {
+	stat, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil || stat.ModTime().After(start) {
+		return err
+	}
+	return s.collect(path, result)
+}
+
+// collect removes the file, if it exists, and accounts it in the result.
+func (s *Store) collect(path string, result *GCResult) error {
+	stat, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := remove(path); err != nil {
+		return err
+	}
+	result.RemovedFiles++
+	result.ReclaimedBytes += stat.Size()
+	log.Debugw("gc: removed file", "path", path)
+	return nil
+}
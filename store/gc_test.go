@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestStore_GC(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	store, err := NewStore(DefaultParameters(), dir)
+	require.NoError(t, err)
+
+	// intact block
+	eds, roots := randomEDS(t)
+	require.NoError(t, store.PutODSQ4(ctx, roots, 1, eds))
+	require.NoError(t, store.PutODSQ4(ctx, share.EmptyEDSRoots(), 2, share.EmptyEDS()))
+
+	// orphaned block without the height link
+	orphanEDS, orphanRoots := randomEDS(t)
+	require.NoError(t, store.PutODSQ4(ctx, orphanRoots, 3, orphanEDS))
+	require.NoError(t, os.Remove(store.heightToPath(3, odsFileExt)))
+
+	// block partially written before a crash
+	partialEDS, partialRoots := randomEDS(t)
+	require.NoError(t, store.PutODS(ctx, partialRoots, 4, partialEDS))
+	pathPartial := store.hashToPath(partialRoots.Hash(), odsFileExt)
+	stat, err := os.Stat(pathPartial)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(pathPartial, stat.Size()/2))
+
+	// dangling symlink
+	require.NoError(t, os.Symlink("missing.ods", store.heightToPath(5, odsFileExt)))
+	// Q4 file without the ODS file
+	q4Roots := randomRoots(t)
+	require.NoError(t, os.WriteFile(store.hashToPath(q4Roots.Hash(), q4FileExt), []byte("q4"), 0o600))
+	// interrupted download
+	tmpPath := filepath.Join(dir, blocksPath, "download"+tmpFileExt)
+	require.NoError(t, os.WriteFile(tmpPath, []byte("tmp"), 0o600))
+
+	// drop the cached accessors to make sure GC is checked against the disk
+	for height := uint64(1); height <= 4; height++ {
+		require.NoError(t, store.cache.Remove(height))
+	}
+
+	result, err := store.GC(ctx)
+	require.NoError(t, err)
+	// orphaned ODS and Q4, partial ODS and its link, dangling link, Q4 and temporary file
+	require.Equal(t, 7, result.RemovedFiles)
+	require.Positive(t, result.ReclaimedBytes)
+	ensureAmountFileAndLinks(t, dir, 2, 2)
+	hasByHashAndHeight(t, store, ctx, roots.Hash(), 1, true, true)
+	hasByHashAndHeight(t, store, ctx, share.EmptyEDSDataHash(), 2, true, true)
+	hasByHashAndHeight(t, store, ctx, orphanRoots.Hash(), 3, false, false)
+	hasByHashAndHeight(t, store, ctx, partialRoots.Hash(), 4, false, false)
+
+	// nothing is left to collect
+	result, err = store.GC(ctx)
+	require.NoError(t, err)
+	require.Zero(t, result.RemovedFiles)
+}
+
+func TestStore_GCLinkedAfterListing(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	store, err := NewStore(paramsNoCache(), dir)
+	require.NoError(t, err)
+
+	// the block was written before GC started, but linked after GC listed the height links, so it is
+	// not among the referenced ones
+	eds, roots := randomEDS(t)
+	require.NoError(t, store.PutODSQ4(ctx, roots, 1, eds))
+	result := &GCResult{}
+	require.NoError(t, store.gcBlocks(ctx, time.Now().Add(time.Minute), map[string]bool{}, result))
+	require.Zero(t, result.RemovedFiles)
+	hasByHashAndHeight(t, store, ctx, roots.Hash(), 1, true, true)
+}
+
+func TestStore_RecoverJournal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	store, err := NewStore(paramsNoCache(), dir)
+	require.NoError(t, err)
+
+	eds, roots := randomEDS(t)
+	require.NoError(t, store.PutODSQ4(ctx, roots, 1, eds))
+	partialEDS, partialRoots := randomEDS(t)
+	require.NoError(t, store.PutODS(ctx, partialRoots, 2, partialEDS))
+	unjournaledEDS, unjournaledRoots := randomEDS(t)
+	require.NoError(t, store.PutODS(ctx, unjournaledRoots, 3, unjournaledEDS))
+	truncate := func(datahash share.DataHash) {
+		path := store.hashToPath(datahash, odsFileExt)
+		stat, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(path, stat.Size()/2))
+	}
+
+	// finished puts leave no journal entries behind
+	entries, err := os.ReadDir(filepath.Join(dir, journalPath))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// crash in the middle of the puts of heights 2 and 4
+	require.NoError(t, store.journal(2, partialRoots.Hash()))
+	truncate(partialRoots.Hash())
+	tmpRoots := randomRoots(t)
+	require.NoError(t, store.journal(4, tmpRoots.Hash()))
+	tmpPath := store.hashToPath(tmpRoots.Hash(), odsFileExt) + tmpFileExt
+	require.NoError(t, os.WriteFile(tmpPath, []byte("tmp"), 0o600))
+	// heights missing in the journal are not checked
+	truncate(unjournaledRoots.Hash())
+
+	store, err = NewStore(paramsNoCache(), dir)
+	require.NoError(t, err)
+	ensureAmountFileAndLinks(t, dir, 3, 2)
+	hasByHashAndHeight(t, store, ctx, roots.Hash(), 1, true, true)
+	hasByHashAndHeight(t, store, ctx, partialRoots.Hash(), 2, false, false)
+	hasByHashAndHeight(t, store, ctx, unjournaledRoots.Hash(), 3, true, true)
+	entries, err = os.ReadDir(filepath.Join(dir, journalPath))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// the block can be put again
+	require.NoError(t, store.PutODS(ctx, partialRoots, 2, partialEDS))
+	hasByHashAndHeight(t, store, ctx, partialRoots.Hash(), 2, true, true)
+}
+
+func randomRoots(t *testing.T) *share.AxisRoots {
+	_, roots := randomEDS(t)
+	return roots
+}
@@ -5,6 +5,10 @@ import (
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/celestiaorg/rsmt2d"
 
 	"github.com/celestiaorg/celestia-node/header"
@@ -16,15 +20,24 @@ import (
 
 var _ shwap.Getter = (*Getter)(nil)
 
+var tracer = otel.Tracer("store/getter")
+
 type Getter struct {
-	store *Store
+	store EDSStore
 }
 
-func NewGetter(store *Store) *Getter {
+func NewGetter(store EDSStore) *Getter {
 	return &Getter{store: store}
 }
 
-func (g *Getter) GetShare(ctx context.Context, h *header.ExtendedHeader, row, col int) (share.Share, error) {
+func (g *Getter) GetShare(ctx context.Context, h *header.ExtendedHeader, row, col int) (_ share.Share, err error) {
+	ctx, span := tracer.Start(ctx, "store/get-share", trace.WithAttributes(
+		attribute.Int64("height", int64(h.Height())),
+		attribute.Int("row", row),
+		attribute.Int("col", col),
+	))
+	defer func() { endSpan(span, err) }()
+
 	acc, err := g.store.GetByHeight(ctx, h.Height())
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
@@ -46,7 +59,47 @@ func (g *Getter) GetShare(ctx context.Context, h *header.ExtendedHeader, row, co
 	return sample.Share, nil
 }
 
-func (g *Getter) GetEDS(ctx context.Context, h *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
+func (g *Getter) GetShares(
+	ctx context.Context,
+	h *header.ExtendedHeader,
+	rowIdxs, colIdxs []int,
+) (_ []share.Share, err error) {
+	ctx, span := tracer.Start(ctx, "store/get-shares", trace.WithAttributes(
+		attribute.Int64("height", int64(h.Height())),
+		attribute.Int("amount", len(rowIdxs)),
+	))
+	defer func() { endSpan(span, err) }()
+
+	if len(rowIdxs) != len(colIdxs) {
+		return nil, fmt.Errorf("row and column indices must be of the same length: %w", shwap.ErrInvalidID)
+	}
+	acc, err := g.store.GetByHeight(ctx, h.Height())
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, shwap.ErrNotFound
+		}
+		return nil, fmt.Errorf("get accessor from store:%w", err)
+	}
+	logger := log.With("height", h.Height())
+	defer utils.CloseAndLog(logger, "getter/samples", acc)
+
+	shares := make([]share.Share, len(rowIdxs))
+	for i := range rowIdxs {
+		sample, err := acc.Sample(ctx, rowIdxs[i], colIdxs[i])
+		if err != nil {
+			return nil, fmt.Errorf("get sample (%d, %d) from accessor:%w", rowIdxs[i], colIdxs[i], err)
+		}
+		shares[i] = sample.Share
+	}
+	return shares, nil
+}
+
+func (g *Getter) GetEDS(ctx context.Context, h *header.ExtendedHeader) (_ *rsmt2d.ExtendedDataSquare, err error) {
+	ctx, span := tracer.Start(ctx, "store/get-eds", trace.WithAttributes(
+		attribute.Int64("height", int64(h.Height())),
+	))
+	defer func() { endSpan(span, err) }()
+
 	acc, err := g.store.GetByHeight(ctx, h.Height())
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
@@ -65,6 +118,17 @@ func (g *Getter) GetEDS(ctx context.Context, h *header.ExtendedHeader) (*rsmt2d.
 	if err != nil {
 		return nil, fmt.Errorf("build eds from shares:%w", err)
 	}
+	if shwap.GetOptionsFromContext(ctx).SkipRootVerification {
+		return rsmt2d.ExtendedDataSquare, nil
+	}
+
+	roots, err := share.NewAxisRoots(rsmt2d.ExtendedDataSquare)
+	if err != nil {
+		return nil, fmt.Errorf("compute roots of eds:%w", err)
+	}
+	if !roots.Equals(h.DAH) {
+		return nil, fmt.Errorf("roots of stored eds do not match the header at height %d", h.Height())
+	}
 	return rsmt2d.ExtendedDataSquare, nil
 }
 
@@ -72,7 +136,13 @@ func (g *Getter) GetSharesByNamespace(
 	ctx context.Context,
 	h *header.ExtendedHeader,
 	ns share.Namespace,
-) (shwap.NamespaceData, error) {
+) (_ shwap.NamespaceData, err error) {
+	ctx, span := tracer.Start(ctx, "store/get-shares-by-namespace", trace.WithAttributes(
+		attribute.Int64("height", int64(h.Height())),
+		attribute.String("namespace", ns.String()),
+	))
+	defer func() { endSpan(span, err) }()
+
 	acc, err := g.store.GetByHeight(ctx, h.Height())
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
@@ -92,3 +162,14 @@ func (g *Getter) GetSharesByNamespace(
 	}
 	return nd, nil
 }
+
+// endSpan ends the span of a store read, recording whether the data was found in the store.
+// Data missing from the store is not an error of the read itself.
+func endSpan(span trace.Span, err error) {
+	found := !errors.Is(err, shwap.ErrNotFound)
+	span.SetAttributes(attribute.Bool("found", found))
+	if !found {
+		err = nil
+	}
+	utils.SetStatusAndEnd(span, err)
+}
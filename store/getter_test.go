@@ -6,6 +6,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/celestiaorg/celestia-node/header/headertest"
 	"github.com/celestiaorg/celestia-node/share/eds/edstest"
@@ -46,6 +50,28 @@ func TestStoreGetter(t *testing.T) {
 		require.ErrorIs(t, err, shwap.ErrOutOfBounds)
 	})
 
+	t.Run("GetShares", func(t *testing.T) {
+		eds, roots := randomEDS(t)
+		eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+		height := height.Add(1)
+		eh.RawHeader.Height = int64(height)
+
+		err := edsStore.PutODSQ4(ctx, eh.DAH, height, eds)
+		require.NoError(t, err)
+
+		squareSize := int(eds.Width())
+		rowIdxs, colIdxs := []int{0, squareSize - 1, 1}, []int{squareSize - 1, 0, 1}
+		shares, err := sg.GetShares(ctx, eh, rowIdxs, colIdxs)
+		require.NoError(t, err)
+		require.Len(t, shares, len(rowIdxs))
+		for i := range rowIdxs {
+			require.Equal(t, eds.GetCell(uint(rowIdxs[i]), uint(colIdxs[i])), shares[i])
+		}
+
+		_, err = sg.GetShares(ctx, eh, []int{0}, nil)
+		require.ErrorIs(t, err, shwap.ErrInvalidID)
+	})
+
 	t.Run("GetEDS", func(t *testing.T) {
 		eds, roots := randomEDS(t)
 		eh := headertest.RandExtendedHeaderWithRoot(t, roots)
@@ -65,6 +91,29 @@ func TestStoreGetter(t *testing.T) {
 		require.ErrorIs(t, err, shwap.ErrNotFound)
 	})
 
+	t.Run("GetEDS skip root verification", func(t *testing.T) {
+		eds, roots := randomEDS(t)
+		eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+		height := height.Add(1)
+		eh.RawHeader.Height = int64(height)
+
+		err := edsStore.PutODSQ4(ctx, eh.DAH, height, eds)
+		require.NoError(t, err)
+
+		// header at the same height committing to different roots
+		_, otherRoots := randomEDS(t)
+		otherEh := headertest.RandExtendedHeaderWithRoot(t, otherRoots)
+		otherEh.RawHeader.Height = int64(height)
+
+		_, err = sg.GetEDS(ctx, otherEh)
+		require.Error(t, err)
+
+		skipCtx := shwap.WithGetOptions(ctx, shwap.WithSkipRootVerification())
+		retrievedEDS, err := sg.GetEDS(skipCtx, otherEh)
+		require.NoError(t, err)
+		require.True(t, eds.Equals(retrievedEDS))
+	})
+
 	t.Run("GetSharesByNamespace", func(t *testing.T) {
 		ns := sharetest.RandV0Namespace()
 		eds, roots := edstest.RandEDSWithNamespace(t, ns, 8, 16)
@@ -90,3 +139,37 @@ func TestStoreGetter(t *testing.T) {
 		require.ErrorIs(t, err, shwap.ErrNotFound)
 	})
 }
+
+func TestStoreGetter_Spans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := NewStore(DefaultParameters(), t.TempDir())
+	require.NoError(t, err)
+	sg := NewGetter(edsStore)
+
+	eds, roots := randomEDS(t)
+	eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+	eh.RawHeader.Height = 1
+	err = edsStore.PutODSQ4(ctx, eh.DAH, 1, eds)
+	require.NoError(t, err)
+	_, err = sg.GetEDS(ctx, eh)
+	require.NoError(t, err)
+
+	eh = headertest.RandExtendedHeaderWithRoot(t, roots)
+	eh.RawHeader.Height = 2
+	_, err = sg.GetEDS(ctx, eh)
+	require.ErrorIs(t, err, shwap.ErrNotFound)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	for i, found := range []bool{true, false} {
+		require.Equal(t, "store/get-eds", spans[i].Name())
+		require.Contains(t, spans[i].Attributes(), attribute.Bool("found", found))
+	}
+}
@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/store/file"
+)
+
+const (
+	// journalPath is the directory of the write-ahead journal of the puts in progress.
+	journalPath = blocksPath + "/.journal"
+	// cleanShutdownPath is the marker file the previous versions of the Store left behind on a
+	// clean shutdown.
+	cleanShutdownPath = blocksPath + "/.clean_shutdown"
+	journalFilePerm   = 0o600
+)
+
+// journal records the put of the height to the journal before its files are written. The entry
+// is synced to disk, so that it outlives the files written after it.
+func (s *Store) journal(height uint64, datahash share.DataHash) error {
+	path := s.journalToPath(height)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, journalFilePerm)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(datahash.String())
+	if err == nil {
+		err = f.Sync()
+	}
+	if err := errors.Join(err, f.Close()); err != nil {
+		return errors.Join(err, remove(path))
+	}
+	return file.SyncDir(filepath.Dir(path))
+}
+
+// unjournal removes the journal entry of the finished put. A failed put leaves no files behind,
+// so the entry is removed either way.
+func (s *Store) unjournal(height uint64) {
+	if err := remove(s.journalToPath(height)); err != nil {
+		log.Warnw("removing journal entry", "height", height, "err", err)
+	}
+}
+
+// recoverJournal rolls back the puts interrupted by a crash, like a power loss, which the journal
+// still lists. Only the files of the listed heights are checked, so that the recovery does not
+// depend on the size of the Store. The rest of the garbage, if any, is left to the GC.
+func (s *Store) recoverJournal(ctx context.Context) error {
+	// the marker of the previous versions is meaningless now
+	if err := remove(filepath.Join(s.basepath, cleanShutdownPath)); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(s.basepath, journalPath))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		path := filepath.Join(s.basepath, journalPath, entry.Name())
+		height, err := strconv.ParseUint(entry.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading journal entry: %w", err)
+		}
+		// the entry itself could have been written partially, but then no files were written yet
+		datahash, err := hex.DecodeString(string(data))
+		if err == nil && share.DataHash(datahash).Validate() == nil {
+			log.Warnw("recovering interrupted put", "height", height)
+			if err := s.recoverPut(ctx, height, datahash); err != nil {
+				return fmt.Errorf("recovering put of height %d: %w", height, err)
+			}
+		}
+		if err := remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recoverPut removes the files of the interrupted put of the height, which could have been left
+// behind partially written.
+func (s *Store) recoverPut(ctx context.Context, height uint64, datahash share.DataHash) error {
+	pathODS := s.hashToPath(datahash, odsFileExt)
+	pathQ4 := s.hashToPath(datahash, q4FileExt)
+	if err := errors.Join(remove(pathODS+tmpFileExt), remove(pathQ4+tmpFileExt)); err != nil {
+		return fmt.Errorf("removing temporary files: %w", err)
+	}
+
+	// the files are synced before they are linked, so they are only broken if the disk lost the
+	// writes
+	broken, err := isBrokenODS(ctx, pathODS)
+	if err != nil {
+		return err
+	}
+	if broken {
+		if err := errors.Join(remove(pathODS), remove(pathQ4)); err != nil {
+			return fmt.Errorf("removing broken files: %w", err)
+		}
+	}
+	has, err := exists(pathODS)
+	if err != nil {
+		return err
+	}
+	if !has {
+		if err := remove(pathQ4); err != nil {
+			return fmt.Errorf("removing Q4 file of missing ODS: %w", err)
+		}
+	}
+
+	pathLink := s.heightToPath(height, odsFileExt)
+	broken, err = isBrokenODS(ctx, pathLink)
+	if err != nil {
+		return err
+	}
+	if broken {
+		if err := remove(pathLink); err != nil {
+			return fmt.Errorf("removing broken height link: %w", err)
+		}
+	}
+	return nil
+}
+
+// isBrokenODS reports whether the ODS file exists, but can't be read.
+func isBrokenODS(ctx context.Context, path string) (bool, error) {
+	has, err := exists(path)
+	if err != nil || !has {
+		return false, err
+	}
+	ods, err := file.OpenODS(path)
+	if err != nil {
+		log.Warnw("broken ODS file", "path", path, "err", err)
+		return true, nil
+	}
+	defer utils.CloseAndLog(log, "recovered ods", ods)
+	if err := ods.Validate(ctx); err != nil {
+		log.Warnw("broken ODS file", "path", path, "err", err)
+		return true, nil
+	}
+	return false, nil
+}
+
+func (s *Store) journalToPath(height uint64) string {
+	return filepath.Join(s.basepath, journalPath, strconv.FormatUint(height, 10))
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+var _ Backend = (*MemBackend)(nil)
+
+// MemBackend is an in-memory Backend intended for tests and benchmarks, so that the offloading of
+// the Store is exercised without an object storage service. MemBackend is safe for concurrent
+// use.
+type MemBackend struct {
+	lock    sync.RWMutex
+	objects map[string][]byte
+}
+
+// NewMemBackend creates a new empty MemBackend.
+func NewMemBackend() *MemBackend {
+	return &MemBackend{objects: make(map[string][]byte)}
+}
+
+func (b *MemBackend) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	obj, ok := b.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(obj)), nil
+}
+
+func (b *MemBackend) Put(_ context.Context, key string, r io.ReadSeeker) error {
+	obj, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.objects[key] = obj
+	return nil
+}
+
+func (b *MemBackend) Has(_ context.Context, key string) (bool, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	_, ok := b.objects[key]
+	return ok, nil
+}
+
+func (b *MemBackend) Delete(_ context.Context, key string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.objects, key)
+	return nil
+}
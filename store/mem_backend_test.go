@@ -0,0 +1,48 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+)
+
+func TestMemBackend(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	backend := NewMemBackend()
+	_, err := backend.Get(ctx, "missing")
+	require.ErrorIs(t, err, ErrNotFound)
+	// deleting a missing object is not an error
+	require.NoError(t, backend.Delete(ctx, "missing"))
+
+	errGroup, ctx := errgroup.WithContext(ctx)
+	for i := 0; i < 8; i++ {
+		key, obj := fmt.Sprintf("key-%d", i), []byte(fmt.Sprintf("object-%d", i))
+		errGroup.Go(func() error {
+			if err := backend.Put(ctx, key, bytes.NewReader(obj)); err != nil {
+				return err
+			}
+			rdr, err := backend.Get(ctx, key)
+			if err != nil {
+				return err
+			}
+			defer rdr.Close()
+			got, err := io.ReadAll(rdr)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(obj, got) {
+				return fmt.Errorf("object %s does not match", key)
+			}
+			return backend.Delete(ctx, key)
+		})
+	}
+	require.NoError(t, errGroup.Wait())
+	require.Empty(t, backend.objects)
+}
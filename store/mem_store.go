@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+// EDSStore is the storage of EDSes indexed by DataHash and height.
+type EDSStore interface {
+	// PutODSQ4 stores the EDS with both the ODS and Q4 quadrants available.
+	PutODSQ4(ctx context.Context, roots *share.AxisRoots, height uint64, square *rsmt2d.ExtendedDataSquare) error
+	// PutODS stores the EDS with only the ODS quadrant available.
+	PutODS(ctx context.Context, roots *share.AxisRoots, height uint64, square *rsmt2d.ExtendedDataSquare) error
+	// GetByHash returns the Accessor of the EDS with the given DataHash.
+	GetByHash(ctx context.Context, datahash share.DataHash) (eds.AccessorStreamer, error)
+	// GetByHeight returns the Accessor of the EDS at the given height.
+	GetByHeight(ctx context.Context, height uint64) (eds.AccessorStreamer, error)
+	// HasByHash reports whether the EDS with the given DataHash is stored.
+	HasByHash(ctx context.Context, datahash share.DataHash) (bool, error)
+	// HasByHeight reports whether the EDS at the given height is stored.
+	HasByHeight(ctx context.Context, height uint64) (bool, error)
+	// RemoveODSQ4 removes the EDS at the given height entirely.
+	RemoveODSQ4(ctx context.Context, height uint64, datahash share.DataHash) error
+	// RemoveQ4 removes the Q4 quadrant of the EDS at the given height.
+	RemoveQ4(ctx context.Context, height uint64, datahash share.DataHash) error
+}
+
+var (
+	_ EDSStore = (*Store)(nil)
+	_ EDSStore = (*MemStore)(nil)
+)
+
+// MemStore is an in-memory EDSStore intended for tests and benchmarks. It validates that the
+// roots of every EDS put into it match the given roots. Squares are always kept whole, so RemoveQ4
+// is a no-op. MemStore is safe for concurrent use.
+type MemStore struct {
+	lock    sync.RWMutex
+	squares map[string]*rsmt2d.ExtendedDataSquare
+	heights map[uint64]share.DataHash
+}
+
+// NewMemStore creates a new empty MemStore.
+func NewMemStore() *MemStore {
+	return &MemStore{
+		squares: make(map[string]*rsmt2d.ExtendedDataSquare),
+		heights: make(map[uint64]share.DataHash),
+	}
+}
+
+func (s *MemStore) PutODSQ4(
+	ctx context.Context,
+	roots *share.AxisRoots,
+	height uint64,
+	square *rsmt2d.ExtendedDataSquare,
+) error {
+	return s.put(ctx, roots, height, square)
+}
+
+func (s *MemStore) PutODS(
+	ctx context.Context,
+	roots *share.AxisRoots,
+	height uint64,
+	square *rsmt2d.ExtendedDataSquare,
+) error {
+	return s.put(ctx, roots, height, square)
+}
+
+func (s *MemStore) put(
+	_ context.Context,
+	roots *share.AxisRoots,
+	height uint64,
+	square *rsmt2d.ExtendedDataSquare,
+) error {
+	datahash := share.DataHash(roots.Hash())
+	if !datahash.IsEmptyEDS() {
+		squareRoots, err := share.NewAxisRoots(square)
+		if err != nil {
+			return fmt.Errorf("computing roots: %w", err)
+		}
+		if !squareRoots.Equals(roots) {
+			return fmt.Errorf("roots of eds at height %d do not match the given roots", height)
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.heights[height] = datahash
+	if !datahash.IsEmptyEDS() {
+		s.squares[datahash.String()] = square
+	}
+	return nil
+}
+
+func (s *MemStore) GetByHash(_ context.Context, datahash share.DataHash) (eds.AccessorStreamer, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.getByHash(datahash)
+}
+
+func (s *MemStore) GetByHeight(_ context.Context, height uint64) (eds.AccessorStreamer, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	datahash, ok := s.heights[height]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return s.getByHash(datahash)
+}
+
+func (s *MemStore) getByHash(datahash share.DataHash) (eds.AccessorStreamer, error) {
+	if datahash.IsEmptyEDS() {
+		return eds.EmptyAccessor, nil
+	}
+	square, ok := s.squares[datahash.String()]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return wrapAccessor(&eds.Rsmt2D{ExtendedDataSquare: square}), nil
+}
+
+func (s *MemStore) HasByHash(_ context.Context, datahash share.DataHash) (bool, error) {
+	if datahash.IsEmptyEDS() {
+		return true, nil
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.squares[datahash.String()]
+	return ok, nil
+}
+
+func (s *MemStore) HasByHeight(_ context.Context, height uint64) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.heights[height]
+	return ok, nil
+}
+
+func (s *MemStore) RemoveODSQ4(_ context.Context, height uint64, datahash share.DataHash) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.heights, height)
+	delete(s.squares, datahash.String())
+	return nil
+}
+
+func (s *MemStore) RemoveQ4(context.Context, uint64, share.DataHash) error {
+	return nil
+}
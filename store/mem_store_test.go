@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/shwap"
+)
+
+func TestMemStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	memStore := NewMemStore()
+
+	t.Run("Put and Get", func(t *testing.T) {
+		eds, roots := randomEDS(t)
+		height := uint64(1)
+		require.NoError(t, memStore.PutODSQ4(ctx, roots, height, eds))
+		// repeated put is a no-op
+		require.NoError(t, memStore.PutODSQ4(ctx, roots, height, eds))
+
+		has, err := memStore.HasByHeight(ctx, height)
+		require.NoError(t, err)
+		require.True(t, has)
+		has, err = memStore.HasByHash(ctx, roots.Hash())
+		require.NoError(t, err)
+		require.True(t, has)
+
+		acc, err := memStore.GetByHeight(ctx, height)
+		require.NoError(t, err)
+		shares, err := acc.Shares(ctx)
+		require.NoError(t, err)
+		require.Equal(t, eds.FlattenedODS(), shares)
+		require.NoError(t, acc.Close())
+
+		acc, err = memStore.GetByHash(ctx, roots.Hash())
+		require.NoError(t, err)
+		accRoots, err := acc.AxisRoots(ctx)
+		require.NoError(t, err)
+		require.True(t, roots.Equals(accRoots))
+		require.NoError(t, acc.Close())
+	})
+
+	t.Run("Put mismatching roots", func(t *testing.T) {
+		eds, _ := randomEDS(t)
+		_, otherRoots := randomEDS(t)
+		err := memStore.PutODS(ctx, otherRoots, 2, eds)
+		require.Error(t, err)
+
+		has, err := memStore.HasByHeight(ctx, 2)
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("Empty EDS", func(t *testing.T) {
+		height := uint64(3)
+		require.NoError(t, memStore.PutODSQ4(ctx, share.EmptyEDSRoots(), height, share.EmptyEDS()))
+
+		acc, err := memStore.GetByHeight(ctx, height)
+		require.NoError(t, err)
+		size := acc.Size(ctx)
+		require.Equal(t, len(share.EmptyEDSRoots().RowRoots), size)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		eds, roots := randomEDS(t)
+		height := uint64(4)
+		require.NoError(t, memStore.PutODSQ4(ctx, roots, height, eds))
+
+		require.NoError(t, memStore.RemoveQ4(ctx, height, roots.Hash()))
+		has, err := memStore.HasByHeight(ctx, height)
+		require.NoError(t, err)
+		require.True(t, has)
+
+		require.NoError(t, memStore.RemoveODSQ4(ctx, height, roots.Hash()))
+		_, err = memStore.GetByHeight(ctx, height)
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = memStore.GetByHash(ctx, roots.Hash())
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Getter", func(t *testing.T) {
+		eds, roots := randomEDS(t)
+		eh := headertest.RandExtendedHeaderWithRoot(t, roots)
+		eh.RawHeader.Height = 5
+		require.NoError(t, memStore.PutODSQ4(ctx, roots, eh.Height(), eds))
+
+		getter := NewGetter(memStore)
+		retrievedEDS, err := getter.GetEDS(ctx, eh)
+		require.NoError(t, err)
+		require.True(t, eds.Equals(retrievedEDS))
+
+		eh.RawHeader.Height = 666
+		_, err = getter.GetEDS(ctx, eh)
+		require.ErrorIs(t, err, shwap.ErrNotFound)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		errGroup, ctx := errgroup.WithContext(ctx)
+		for i := 0; i < 8; i++ {
+			eds, roots := randomEDS(t)
+			height := uint64(100 + i)
+			errGroup.Go(func() error {
+				if err := memStore.PutODSQ4(ctx, roots, height, eds); err != nil {
+					return err
+				}
+				acc, err := memStore.GetByHeight(ctx, height)
+				if err != nil {
+					return err
+				}
+				return acc.Close()
+			})
+		}
+		require.NoError(t, errGroup.Wait())
+	})
+}
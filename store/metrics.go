@@ -2,7 +2,9 @@ package store
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -16,6 +18,8 @@ const (
 	failedKey = "failed"
 	withQ4Key = "with_q4"
 	sizeKey   = "eds_size"
+	tierKey   = "tier"
+	bucketKey = "from_height"
 )
 
 var meter = otel.Meter("store")
@@ -27,7 +31,9 @@ type metrics struct {
 	has         metric.Float64Histogram
 	removeODSQ4 metric.Float64Histogram
 	removeQ4    metric.Float64Histogram
-	unreg       func() error
+
+	usageReg metric.Registration
+	unreg    func() error
 }
 
 func (s *Store) WithMetrics() error {
@@ -67,7 +73,19 @@ func (s *Store) WithMetrics() error {
 		return err
 	}
 
-	s.metrics = &metrics{
+	bucketSize, err := meter.Int64ObservableGauge("eds_store_bucket_size_bytes",
+		metric.WithDescription("eds store disk usage of the heights in buckets of 10000 heights"))
+	if err != nil {
+		return err
+	}
+
+	bucketHeights, err := meter.Int64ObservableGauge("eds_store_bucket_heights",
+		metric.WithDescription("eds store number of stored heights in buckets of 10000 heights"))
+	if err != nil {
+		return err
+	}
+
+	m := &metrics{
 		put:         put,
 		putExists:   putExists,
 		get:         get,
@@ -75,9 +93,47 @@ func (s *Store) WithMetrics() error {
 		removeODSQ4: removeODSQ4,
 		removeQ4:    removeQ4,
 	}
+	callback := func(ctx context.Context, observer metric.Observer) error {
+		for tier, store := range s.tiers() {
+			buckets, err := store.usageByBucket(ctx)
+			if err != nil {
+				return fmt.Errorf("%s: %w", tier, err)
+			}
+			for bucket, u := range buckets {
+				attrs := metric.WithAttributes(
+					attribute.String(tierKey, tier),
+					attribute.String(bucketKey, strconv.FormatUint(bucket, 10)),
+				)
+				observer.ObserveInt64(bucketSize, u.size, attrs)
+				observer.ObserveInt64(bucketHeights, u.heights, attrs)
+			}
+		}
+		return nil
+	}
+	m.usageReg, err = meter.RegisterCallback(callback, bucketSize, bucketHeights)
+	if err != nil {
+		return err
+	}
+
+	s.metrics = m
 	return s.metrics.addCacheMetrics(s.cache)
 }
 
+// tiers returns the Store and its cold tier and shards, if any, by the names they are reported
+// under.
+func (s *Store) tiers() map[string]*Store {
+	tiers := map[string]*Store{"hot": s}
+	if s.tiering != nil {
+		tiers["cold"] = s.tiering.cold
+	}
+	if s.sharding != nil {
+		for i, shard := range s.sharding.shards {
+			tiers[fmt.Sprintf("shard%d", i+1)] = shard
+		}
+	}
+	return tiers
+}
+
 // addCacheMetrics adds cache metrics to store metrics
 func (m *metrics) addCacheMetrics(c cache.Cache) error {
 	if m == nil {
@@ -176,5 +232,5 @@ func (m *metrics) close() error {
 	if m == nil {
 		return nil
 	}
-	return m.unreg()
+	return errors.Join(m.usageReg.Unregister(), m.unreg())
 }
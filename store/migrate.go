@@ -0,0 +1,240 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+// migrationCheckpointFile keeps the last height converted by the migration, so that an
+// interrupted migration resumes from it.
+const migrationCheckpointFile = ".migration"
+
+// checkpointInterval is the number of heights between the checkpoints of the migration.
+const checkpointInterval = 1000
+
+// RootsGetter returns the axis roots of the EDS at the given height or ErrNotFound if the height
+// is unknown.
+type RootsGetter func(ctx context.Context, height uint64) (*share.AxisRoots, error)
+
+// MigrationProgress reports the progress of the migration.
+type MigrationProgress struct {
+	// Height is the last processed height.
+	Height uint64
+	// Head is the height the migration goes up to.
+	Head uint64
+	// Converted is the number of EDSes converted by this run.
+	Converted int
+}
+
+// MigrateCAR converts the EDSes kept in the CAR files of the legacy store under the same path into
+// the current file format in place. It walks the heights up to the head, puts the EDSes found in
+// CAR files at their heights and removes the converted CAR files once all the heights are
+// processed. The migration can be interrupted and resumed. The progress is reported through the
+// optional callback.
+func (s *Store) MigrateCAR(
+	ctx context.Context,
+	head uint64,
+	getRoots RootsGetter,
+	progress func(MigrationProgress),
+) (MigrationProgress, error) {
+	if s.readOnly {
+		return MigrationProgress{}, ErrReadOnly
+	}
+	legacy, err := s.legacyFiles()
+	if err != nil {
+		return MigrationProgress{}, fmt.Errorf("listing CAR files: %w", err)
+	}
+	if len(legacy) == 0 {
+		return MigrationProgress{Head: head}, nil
+	}
+
+	from, err := s.readCheckpoint()
+	if err != nil {
+		return MigrationProgress{}, fmt.Errorf("reading checkpoint: %w", err)
+	}
+
+	status := MigrationProgress{Height: from, Head: head}
+	for height := from + 1; height <= head; height++ {
+		if ctx.Err() != nil {
+			return status, ctx.Err()
+		}
+
+		converted, err := s.migrateHeight(ctx, height, getRoots)
+		if err != nil {
+			return status, fmt.Errorf("converting height %d: %w", height, err)
+		}
+		if converted {
+			status.Converted++
+		}
+		status.Height = height
+
+		if height%checkpointInterval == 0 || height == head {
+			if err := s.writeCheckpoint(height); err != nil {
+				return status, fmt.Errorf("writing checkpoint: %w", err)
+			}
+			if progress != nil {
+				progress(status)
+			}
+		}
+	}
+
+	// CAR files are removed only after all the heights are processed, as an EDS can be linked to
+	// multiple heights
+	for _, datahash := range legacy {
+		has, err := s.hasByHash(datahash)
+		if err != nil {
+			return status, err
+		}
+		if !has {
+			log.Warnw("CAR file is not referenced by any height, keeping it", "hash", datahash.String())
+			continue
+		}
+		if err := os.Remove(s.hashToPath(datahash, "")); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return status, fmt.Errorf("removing CAR file: %w", err)
+		}
+	}
+	err = os.Remove(filepath.Join(s.basepath, blocksPath, migrationCheckpointFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return status, fmt.Errorf("removing checkpoint: %w", err)
+	}
+	return status, nil
+}
+
+// migrateHeight puts the EDS of the height from its CAR file, if there is one.
+func (s *Store) migrateHeight(ctx context.Context, height uint64, getRoots RootsGetter) (bool, error) {
+	roots, err := getRoots(ctx, height)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting roots: %w", err)
+	}
+
+	datahash := share.DataHash(roots.Hash())
+	f, err := os.Open(s.hashToPath(datahash, ""))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("opening CAR file: %w", err)
+	}
+	defer f.Close()
+
+	square, err := readCAR(f, len(roots.RowRoots)/2)
+	if err != nil {
+		return false, fmt.Errorf("reading CAR file: %w", err)
+	}
+	fileRoots, err := share.NewAxisRoots(square.ExtendedDataSquare)
+	if err != nil {
+		return false, fmt.Errorf("computing roots: %w", err)
+	}
+	if !bytes.Equal(fileRoots.Hash(), datahash) {
+		return false, fmt.Errorf("CAR file %s does not match the roots of the height", datahash.String())
+	}
+
+	if err := s.PutODSQ4(ctx, roots, height, square.ExtendedDataSquare); err != nil {
+		return false, fmt.Errorf("putting EDS: %w", err)
+	}
+	return true, nil
+}
+
+// readCAR reads the EDS from the CARv1 file of the legacy store. The file starts with the shares
+// of the ODS, each wrapped into a block with the namespace of the share prepended.
+func readCAR(r io.Reader, odsSize int) (*eds.Rsmt2D, error) {
+	br := bufio.NewReader(r)
+	// skip the header, as the roots are known from the height
+	if _, err := readSection(br); err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	shares := make([]share.Share, odsSize*odsSize)
+	for i := range shares {
+		section, err := readSection(br)
+		if err != nil {
+			return nil, fmt.Errorf("reading share %d: %w", i, err)
+		}
+		n, _, err := cid.CidFromBytes(section)
+		if err != nil {
+			return nil, fmt.Errorf("reading CID of share %d: %w", i, err)
+		}
+		data := section[n:]
+		if len(data) != share.NamespaceSize+share.Size {
+			return nil, fmt.Errorf("invalid size of share %d: %d", i, len(data))
+		}
+		shares[i] = data[share.NamespaceSize:]
+	}
+	return eds.Rsmt2DFromShares(shares, odsSize)
+}
+
+// readSection reads the length-prefixed section of the CAR file.
+func readSection(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	// the largest section is a share block
+	if size > 1<<20 {
+		return nil, fmt.Errorf("section size is too big: %d", size)
+	}
+	section := make([]byte, size)
+	if _, err := io.ReadFull(r, section); err != nil {
+		return nil, err
+	}
+	return section, nil
+}
+
+// legacyFiles returns the datahashes of the CAR files of the legacy store.
+func (s *Store) legacyFiles() ([]share.DataHash, error) {
+	entries, err := os.ReadDir(filepath.Join(s.basepath, blocksPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes []share.DataHash
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != "" {
+			continue
+		}
+		datahash, err := hex.DecodeString(entry.Name())
+		if err != nil || share.DataHash(datahash).Validate() != nil {
+			continue
+		}
+		hashes = append(hashes, datahash)
+	}
+	return hashes, nil
+}
+
+func (s *Store) readCheckpoint() (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(s.basepath, blocksPath, migrationCheckpointFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+func (s *Store) writeCheckpoint(height uint64) error {
+	path := filepath.Join(s.basepath, blocksPath, migrationCheckpointFile)
+	tmpPath := path + tmpFileExt
+	if err := os.WriteFile(tmpPath, []byte(strconv.FormatUint(height, 10)), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
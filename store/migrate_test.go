@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestStore_MigrateCAR(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	store, err := NewStore(paramsNoCache(), dir)
+	require.NoError(t, err)
+
+	// heights 1 and 3 share the same EDS, height 2 is empty and height 4 is unknown
+	eds, roots := randomEDS(t)
+	otherEDS, otherRoots := randomEDS(t)
+	rootsByHeight := map[uint64]*share.AxisRoots{
+		1: roots,
+		2: share.EmptyEDSRoots(),
+		3: roots,
+		5: otherRoots,
+	}
+	writeLegacyCAR(t, store.hashToPath(roots.Hash(), ""), eds)
+	writeLegacyCAR(t, store.hashToPath(otherRoots.Hash(), ""), otherEDS)
+	getRoots := func(_ context.Context, height uint64) (*share.AxisRoots, error) {
+		roots, ok := rootsByHeight[height]
+		if !ok {
+			return nil, ErrNotFound
+		}
+		return roots, nil
+	}
+
+	// interrupted migration resumes from the checkpoint
+	require.NoError(t, store.writeCheckpoint(2))
+	var reported []MigrationProgress
+	status, err := store.MigrateCAR(ctx, 5, getRoots, func(p MigrationProgress) {
+		reported = append(reported, p)
+	})
+	require.NoError(t, err)
+	require.Equal(t, MigrationProgress{Height: 5, Head: 5, Converted: 2}, status)
+	require.Equal(t, []MigrationProgress{status}, reported)
+
+	hasByHashAndHeight(t, store, ctx, roots.Hash(), 3, true, true)
+	hasByHashAndHeight(t, store, ctx, otherRoots.Hash(), 5, true, true)
+	// heights before the checkpoint are skipped
+	has, err := store.HasByHeight(ctx, 1)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	f, err := store.GetByHeight(ctx, 5)
+	require.NoError(t, err)
+	shares, err := f.Shares(ctx)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, otherEDS.FlattenedODS(), shares)
+
+	// CAR files and the checkpoint are removed
+	_, err = os.Stat(store.hashToPath(roots.Hash(), ""))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(store.hashToPath(otherRoots.Hash(), ""))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(filepath.Join(dir, blocksPath, migrationCheckpointFile))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// nothing is left to migrate
+	status, err = store.MigrateCAR(ctx, 5, getRoots, nil)
+	require.NoError(t, err)
+	require.Zero(t, status.Converted)
+}
+
+func TestStore_MigrateCAR_Mismatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	store, err := NewStore(paramsNoCache(), t.TempDir())
+	require.NoError(t, err)
+
+	_, roots := randomEDS(t)
+	otherEDS, _ := randomEDS(t)
+	writeLegacyCAR(t, store.hashToPath(roots.Hash(), ""), otherEDS)
+
+	getRoots := func(context.Context, uint64) (*share.AxisRoots, error) {
+		return roots, nil
+	}
+	_, err = store.MigrateCAR(ctx, 1, getRoots, nil)
+	require.Error(t, err)
+	// the CAR file is kept
+	_, err = os.Stat(store.hashToPath(roots.Hash(), ""))
+	require.NoError(t, err)
+}
+
+// writeLegacyCAR writes the EDS the way the legacy store did: a CARv1 file with the shares in
+// quadrant order, each prepended with its namespace.
+func writeLegacyCAR(t *testing.T, path string, eds *rsmt2d.ExtendedDataSquare) {
+	var data []byte
+	writeSection := func(section []byte) {
+		data = binary.AppendUvarint(data, uint64(len(section)))
+		data = append(data, section...)
+	}
+	writeSection([]byte("header"))
+
+	width := int(eds.Width())
+	odsSize := width / 2
+	for quadrant := range 4 {
+		for i := range odsSize {
+			for j := range odsSize {
+				row, col := i+odsSize*(quadrant/2), j+odsSize*(quadrant%2)
+				shr := eds.GetCell(uint(row), uint(col))
+				ns := share.ParitySharesNamespace
+				if quadrant == 0 {
+					ns = share.GetNamespace(shr)
+				}
+				leaf := append(append([]byte{}, ns...), shr...)
+				hash, err := multihash.Sum(leaf, multihash.SHA2_256, -1)
+				require.NoError(t, err)
+				writeSection(append(cid.NewCidV1(cid.Raw, hash).Bytes(), leaf...))
+			}
+		}
+	}
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var namespaceIndexPrefix = datastore.NewKey("ns_index")
+
+// ErrNoNamespaceIndex is returned by the Store not maintaining the namespace index.
+var ErrNoNamespaceIndex = errors.New("namespace index is disabled")
+
+// WithNamespaceIndex makes the Store index the heights of every blob namespace in the put EDSes
+// in the given datastore.
+func WithNamespaceIndex(ds datastore.Batching) Option {
+	return func(s *Store) {
+		s.nsIndex = &namespaceIndex{ds: namespace.Wrap(ds, namespaceIndexPrefix)}
+	}
+}
+
+// namespaceIndex maps blob namespaces to the heights of the EDSes containing them. Keys are the
+// namespace followed by the zero-padded height, so that the heights of a namespace are listed in
+// order.
+type namespaceIndex struct {
+	ds datastore.Batching
+}
+
+// add indexes the blob namespaces of the EDS at the given height.
+func (idx *namespaceIndex) add(ctx context.Context, height uint64, square *rsmt2d.ExtendedDataSquare) error {
+	batch, err := idx.ds.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("creating batch: %w", err)
+	}
+
+	odsWidth := square.Width() / 2
+	var prev share.Namespace
+	for i := range odsWidth * odsWidth {
+		ns := share.GetNamespace(square.GetCell(i/odsWidth, i%odsWidth))
+		// shares are sorted by namespace, so every namespace is only put once
+		if ns.Equals(prev) {
+			continue
+		}
+		prev = ns
+		if ns.ValidateForBlob() != nil {
+			continue
+		}
+		if err := batch.Put(ctx, namespaceIndexKey(ns, height), nil); err != nil {
+			return fmt.Errorf("indexing namespace %s: %w", ns.String(), err)
+		}
+	}
+	return batch.Commit(ctx)
+}
+
+// heights returns the heights within the given inclusive range of the EDSes containing the
+// namespace in ascending order.
+func (idx *namespaceIndex) heights(ctx context.Context, ns share.Namespace, from, to uint64) ([]uint64, error) {
+	results, err := idx.ds.Query(ctx, query.Query{
+		Prefix:   datastore.NewKey(ns.String()).String(),
+		KeysOnly: true,
+		Orders:   []query.Order{query.OrderByKey{}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying index: %w", err)
+	}
+	defer utils.CloseAndLog(log, "index query", results)
+
+	var heights []uint64
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, fmt.Errorf("reading index: %w", result.Error)
+		}
+		key := datastore.RawKey(result.Key)
+		height, err := strconv.ParseUint(key.BaseNamespace(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing index key %s: %w", key, err)
+		}
+		if height < from {
+			continue
+		}
+		if height > to {
+			break
+		}
+		heights = append(heights, height)
+	}
+	return heights, nil
+}
+
+func namespaceIndexKey(ns share.Namespace, height uint64) datastore.Key {
+	return datastore.NewKey(ns.String()).ChildString(fmt.Sprintf("%020d", height))
+}
+
+// HeightsForNamespace returns the heights of the EDSes containing the blob namespace within the
+// given inclusive range in ascending order. The index keeps the heights of removed EDSes.
+func (s *Store) HeightsForNamespace(ctx context.Context, ns share.Namespace, from, to uint64) ([]uint64, error) {
+	if s.nsIndex == nil {
+		return nil, ErrNoNamespaceIndex
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid height range [%d, %d]", from, to)
+	}
+	if err := ns.ValidateForBlob(); err != nil {
+		return nil, err
+	}
+	return s.nsIndex.heights(ctx, ns, from, to)
+}
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+)
+
+func TestStore_HeightsForNamespace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	store, err := NewStore(DefaultParameters(), t.TempDir(), WithNamespaceIndex(dssync.MutexWrap(ds.NewMapDatastore())))
+	require.NoError(t, err)
+
+	namespace := sharetest.RandV0Namespace()
+	for _, height := range []uint64{2, 5, 9, 10} {
+		square, roots := edstest.RandEDSWithNamespace(t, namespace, 4, 4)
+		require.NoError(t, store.PutODSQ4(ctx, roots, height, square))
+	}
+	// blocks without the namespace
+	for _, height := range []uint64{3, 4} {
+		square, roots := randomEDS(t)
+		require.NoError(t, store.PutODS(ctx, roots, height, square))
+	}
+	require.NoError(t, store.PutODSQ4(ctx, share.EmptyEDSRoots(), 6, share.EmptyEDS()))
+
+	heights, err := store.HeightsForNamespace(ctx, namespace, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 5, 9, 10}, heights)
+
+	heights, err = store.HeightsForNamespace(ctx, namespace, 5, 9)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5, 9}, heights)
+
+	heights, err = store.HeightsForNamespace(ctx, sharetest.RandV0Namespace(), 0, 100)
+	require.NoError(t, err)
+	require.Empty(t, heights)
+
+	_, err = store.HeightsForNamespace(ctx, namespace, 10, 5)
+	require.Error(t, err)
+	_, err = store.HeightsForNamespace(ctx, share.TxNamespace, 0, 100)
+	require.Error(t, err)
+
+	// the index is disabled by default
+	store, err = NewStore(DefaultParameters(), t.TempDir())
+	require.NoError(t, err)
+	_, err = store.HeightsForNamespace(ctx, namespace, 0, 100)
+	require.ErrorIs(t, err, ErrNoNamespaceIndex)
+}
+
+func TestStore_HeightsForNamespace_FailedPut(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	backend := &failingBackend{MemBackend: NewMemBackend(), err: errors.New("upload failed")}
+	store, err := NewStore(
+		DefaultParameters(),
+		t.TempDir(),
+		WithNamespaceIndex(dssync.MutexWrap(ds.NewMapDatastore())),
+		WithBackend(backend),
+	)
+	require.NoError(t, err)
+
+	// heights that failed to be stored are not indexed
+	namespace := sharetest.RandV0Namespace()
+	square, roots := edstest.RandEDSWithNamespace(t, namespace, 4, 4)
+	require.Error(t, store.PutODSQ4(ctx, roots, 1, square))
+	heights, err := store.HeightsForNamespace(ctx, namespace, 0, 100)
+	require.NoError(t, err)
+	require.Empty(t, heights)
+
+	// and are indexed once the put is retried
+	backend.err = nil
+	require.NoError(t, store.PutODSQ4(ctx, roots, 1, square))
+	heights, err = store.HeightsForNamespace(ctx, namespace, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, heights)
+}
+
+// failingBackend is a MemBackend failing the uploads with err, if it is set.
+type failingBackend struct {
+	*MemBackend
+	err error
+}
+
+func (b *failingBackend) Put(ctx context.Context, key string, r io.ReadSeeker) error {
+	if b.err != nil {
+		return b.err
+	}
+	return b.MemBackend.Put(ctx, key, r)
+}
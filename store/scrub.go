@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+const corruptedKey = "corrupted"
+
+// ScrubParams configures the Scrubber.
+type ScrubParams struct {
+	// Rate is the number of heights verified per second. Zero disables the scrubber.
+	Rate float64
+	// Repair removes the corrupted heights from the store and fetches them again.
+	Repair bool
+}
+
+// DefaultScrubParams returns the default parameters of the Scrubber, which is disabled.
+func DefaultScrubParams() *ScrubParams {
+	return &ScrubParams{}
+}
+
+func (p *ScrubParams) Validate() error {
+	if p != nil && p.Rate < 0 {
+		return errors.New("scrub rate cannot be negative")
+	}
+	return nil
+}
+
+// Enabled reports whether the scrubber is enabled. Missing parameters disable it.
+func (p *ScrubParams) Enabled() bool {
+	return p != nil && p.Rate > 0
+}
+
+// RefetchFn fetches the EDS of the height removed by the Scrubber again and puts it into the Store.
+type RefetchFn func(ctx context.Context, height uint64) error
+
+// Scrubber slowly walks the heights of the Store in the background and verifies their EDS files
+// against the roots of the heights the way Check does, catching the files corrupted on disk before
+// a request fails to read them. It starts over once it reaches the highest height.
+type Scrubber struct {
+	store    *Store
+	getRoots RootsGetter
+	refetch  RefetchFn
+	params   ScrubParams
+
+	cancel  context.CancelFunc
+	done    chan struct{}
+	metrics *scrubMetrics
+}
+
+// NewScrubber creates a new Scrubber of the Store. The refetch func is called for the corrupted
+// heights removed by the repair and may be nil.
+func NewScrubber(store *Store, getRoots RootsGetter, refetch RefetchFn, params ScrubParams) *Scrubber {
+	return &Scrubber{
+		store:    store,
+		getRoots: getRoots,
+		refetch:  refetch,
+		params:   params,
+		done:     make(chan struct{}),
+	}
+}
+
+func (s *Scrubber) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.run(ctx)
+	return nil
+}
+
+func (s *Scrubber) Stop(ctx context.Context) error {
+	s.cancel()
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scrubber) run(ctx context.Context) {
+	defer close(s.done)
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / s.params.Rate))
+	defer ticker.Stop()
+	wait := func() bool {
+		select {
+		case <-ticker.C:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		heights, err := s.store.heights()
+		if err != nil {
+			log.Errorw("scrubber: reading heights", "err", err)
+		}
+		slices.Sort(heights)
+		if len(heights) == 0 && !wait() {
+			return
+		}
+		for _, height := range heights {
+			if !wait() {
+				return
+			}
+			s.scrub(ctx, height)
+		}
+	}
+}
+
+// scrub verifies the height and repairs it, if it is corrupted.
+func (s *Scrubber) scrub(ctx context.Context, height uint64) {
+	roots, err := s.getRoots(ctx, height)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		roots = nil
+	case err != nil:
+		if ctx.Err() == nil {
+			log.Warnw("scrubber: getting roots", "height", height, "err", err)
+		}
+		return
+	}
+
+	lock := s.store.stripLock.byHeight(height)
+	lock.RLock()
+	datahash, err := s.store.checkHeight(ctx, height, roots)
+	lock.RUnlock()
+	if errors.Is(err, os.ErrNotExist) || ctx.Err() != nil {
+		// removed in the meantime
+		return
+	}
+	s.metrics.observeScrub(ctx, err != nil)
+	if err == nil {
+		return
+	}
+
+	log.Errorw("scrubber: corrupted EDS", "height", height, "err", err)
+	if !s.params.Repair || s.store.readOnly {
+		return
+	}
+	if err := s.repair(ctx, height, datahash); err != nil {
+		log.Errorw("scrubber: repairing corrupted EDS", "height", height, "err", err)
+		return
+	}
+	log.Infow("scrubber: repaired corrupted EDS", "height", height)
+}
+
+func (s *Scrubber) repair(ctx context.Context, height uint64, datahash share.DataHash) error {
+	if err := s.store.removeCorrupted(height, datahash); err != nil {
+		return fmt.Errorf("removing: %w", err)
+	}
+	if s.refetch == nil {
+		return nil
+	}
+	if err := s.refetch(ctx, height); err != nil {
+		return fmt.Errorf("refetching: %w", err)
+	}
+	return nil
+}
+
+type scrubMetrics struct {
+	scrubbed metric.Int64Counter
+}
+
+func (s *Scrubber) WithMetrics() error {
+	scrubbed, err := meter.Int64Counter("eds_store_scrub_counter",
+		metric.WithDescription("eds store heights verified by the scrubber"))
+	if err != nil {
+		return err
+	}
+	s.metrics = &scrubMetrics{scrubbed: scrubbed}
+	return nil
+}
+
+func (m *scrubMetrics) observeScrub(ctx context.Context, corrupted bool) {
+	if m == nil {
+		return
+	}
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	m.scrubbed.Add(ctx, 1, metric.WithAttributes(attribute.Bool(corruptedKey, corrupted)))
+}
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestScrubber(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	store, err := NewStore(paramsNoCache(), t.TempDir())
+	require.NoError(t, err)
+
+	squares := make(map[uint64]*rsmt2d.ExtendedDataSquare)
+	roots := make(map[uint64]*share.AxisRoots)
+	for height := uint64(1); height <= 3; height++ {
+		squares[height], roots[height] = randomEDS(t)
+		require.NoError(t, store.PutODSQ4(ctx, roots[height], height, squares[height]))
+	}
+	getRoots := func(_ context.Context, height uint64) (*share.AxisRoots, error) {
+		return roots[height], nil
+	}
+
+	// flip the last stored byte of the ODS at height 2
+	path := store.hashToPath(roots[2].Hash(), odsFileExt)
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	require.NoError(t, err)
+	stat, err := f.Stat()
+	require.NoError(t, err)
+	b := make([]byte, 1)
+	_, err = f.ReadAt(b, stat.Size()-1)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{b[0] ^ 0xff}, stat.Size()-1)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	refetched := make(chan uint64, 1)
+	refetch := func(ctx context.Context, height uint64) error {
+		refetched <- height
+		return store.PutODSQ4(ctx, roots[height], height, squares[height])
+	}
+	scrubber := NewScrubber(store, getRoots, refetch, ScrubParams{Rate: 1000, Repair: true})
+	require.NoError(t, scrubber.Start(ctx))
+
+	select {
+	case height := <-refetched:
+		require.EqualValues(t, 2, height)
+	case <-ctx.Done():
+		t.Fatal("corrupted height was not refetched")
+	}
+	require.NoError(t, scrubber.Stop(ctx))
+
+	for height := uint64(1); height <= 3; height++ {
+		_, err := store.checkHeight(ctx, height, roots[height])
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,165 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/store/file"
+)
+
+// ShardPolicy selects the shard the EDS of a height is put to.
+type ShardPolicy string
+
+const (
+	// ShardByHeight distributes the EDSes over the shards by height modulo the number of shards.
+	ShardByHeight ShardPolicy = "height"
+	// ShardByFill puts every EDS to the shard with the least filled disk.
+	ShardByFill ShardPolicy = "fill"
+)
+
+func (p ShardPolicy) Validate() error {
+	switch p {
+	case ShardByHeight, ShardByFill:
+		return nil
+	default:
+		return fmt.Errorf("unknown shard policy %q", p)
+	}
+}
+
+// WithShards spreads the EDSes over the Store and the stores under the given paths, e.g. on
+// separate disks, by the given policy. Reads look the EDSes up in all the shards, so that the
+// shards can be added and the policy changed, leaving the stored EDSes in place. Sharding can't
+// be combined with the cold tier or the backend.
+func WithShards(policy ShardPolicy, paths ...string) Option {
+	return func(s *Store) {
+		s.sharding = &sharding{policy: policy, paths: paths}
+	}
+}
+
+// sharding spreads the EDSes over the Store and the shards.
+type sharding struct {
+	policy ShardPolicy
+	paths  []string
+	// shards are the stores under the paths. The Store itself is the first shard and is not listed.
+	shards []*Store
+}
+
+func (s *Store) startSharding() error {
+	if err := s.sharding.policy.Validate(); err != nil {
+		return err
+	}
+	params := &Parameters{ODSOnly: s.odsOnly, ReadOnly: s.readOnly}
+	params.CompressODS = s.compression != file.NoCompression
+	for _, path := range s.sharding.paths {
+		shard, err := NewStore(params, path)
+		if err != nil {
+			return errors.Join(fmt.Errorf("opening shard %s: %w", path, err), s.stopSharding(context.Background()))
+		}
+		s.sharding.shards = append(s.sharding.shards, shard)
+	}
+	if s.sharding.policy == ShardByFill && !s.readOnly {
+		// fail early on the platforms not reporting the disk usage
+		if _, err := diskFill(s.basepath); err != nil {
+			return errors.Join(fmt.Errorf("reading disk fill: %w", err), s.stopSharding(context.Background()))
+		}
+	}
+	return nil
+}
+
+func (s *Store) stopSharding(ctx context.Context) error {
+	var err error
+	for _, shard := range s.sharding.shards {
+		err = errors.Join(err, shard.Stop(ctx))
+	}
+	return err
+}
+
+// shardFor returns the shard the EDS of the height is put to. It is the shard keeping the height
+// already, if any, so that the EDSes put again are not duplicated after the policy changes.
+func (s *Store) shardFor(height uint64) (*Store, error) {
+	if s.sharding == nil {
+		return s, nil
+	}
+	for _, shard := range s.shards() {
+		// the files are checked, as the Store caches the EDS before it is put
+		has, err := exists(shard.heightToPath(height, odsFileExt))
+		if err != nil {
+			return nil, err
+		}
+		if has {
+			return shard, nil
+		}
+	}
+
+	shards := s.shards()
+	switch s.sharding.policy {
+	case ShardByHeight:
+		return shards[height%uint64(len(shards))], nil
+	case ShardByFill:
+		target, lowest := s, 1.0
+		for _, shard := range shards {
+			fill, err := diskFill(shard.basepath)
+			if err != nil {
+				return nil, fmt.Errorf("reading disk fill of %s: %w", shard.basepath, err)
+			}
+			if fill < lowest {
+				target, lowest = shard, fill
+			}
+		}
+		return target, nil
+	default:
+		return nil, fmt.Errorf("unknown shard policy %q", s.sharding.policy)
+	}
+}
+
+// shards returns all the shards, starting with the Store itself.
+func (s *Store) shards() []*Store {
+	return append([]*Store{s}, s.sharding.shards...)
+}
+
+// getShardedByHash looks the EDS up in the shards other than the Store itself.
+func (s *Store) getShardedByHash(ctx context.Context, datahash share.DataHash) (eds.AccessorStreamer, error) {
+	for _, shard := range s.sharding.shards {
+		f, err := shard.GetByHash(ctx, datahash)
+		if !errors.Is(err, ErrNotFound) {
+			return f, err
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// getShardedByHeight looks the EDS up in the shards other than the Store itself.
+func (s *Store) getShardedByHeight(ctx context.Context, height uint64) (eds.AccessorStreamer, error) {
+	for _, shard := range s.sharding.shards {
+		f, err := shard.GetByHeight(ctx, height)
+		if !errors.Is(err, ErrNotFound) {
+			return f, err
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// hasShardedByHash checks the shards other than the Store itself for the EDS.
+func (s *Store) hasShardedByHash(ctx context.Context, datahash share.DataHash) (bool, error) {
+	for _, shard := range s.sharding.shards {
+		has, err := shard.HasByHash(ctx, datahash)
+		if err != nil || has {
+			return has, err
+		}
+	}
+	return false, nil
+}
+
+// hasShardedByHeight checks the shards other than the Store itself for the EDS.
+func (s *Store) hasShardedByHeight(ctx context.Context, height uint64) (bool, error) {
+	for _, shard := range s.sharding.shards {
+		has, err := shard.HasByHeight(ctx, height)
+		if err != nil || has {
+			return has, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin
+
+package store
+
+import (
+	"errors"
+)
+
+// diskFill returns the used fraction of the disk the path is on.
+func diskFill(string) (float64, error) {
+	return 0, errors.New("disk fill is not supported on this platform")
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestStore_Shards(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	dirs := []string{t.TempDir(), t.TempDir(), t.TempDir()}
+	store, err := NewStore(paramsNoCache(), dirs[0], WithShards(ShardByHeight, dirs[1:]...))
+	require.NoError(t, err)
+
+	squares := make(map[uint64]*rsmt2d.ExtendedDataSquare)
+	roots := make(map[uint64]*share.AxisRoots)
+	for height := uint64(1); height <= 6; height++ {
+		squares[height], roots[height] = randomEDS(t)
+		require.NoError(t, store.PutODSQ4(ctx, roots[height], height, squares[height]))
+	}
+
+	// heights are distributed by modulo, with the Store itself being the first shard
+	for _, dir := range dirs {
+		ensureAmountFileAndLinks(t, dir, 4, 2)
+	}
+	for height := uint64(1); height <= 6; height++ {
+		hasByHashAndHeight(t, store, ctx, roots[height].Hash(), height, true, true)
+
+		f, err := store.GetByHeight(ctx, height)
+		require.NoError(t, err)
+		datahash, err := f.DataHash(ctx)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		require.EqualValues(t, roots[height].Hash(), datahash)
+
+		f, err = store.GetByHash(ctx, roots[height].Hash())
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+
+	stats, err := store.Stats(ctx)
+	require.NoError(t, err)
+	require.Len(t, stats.Shards, 2)
+	for _, shardStats := range stats.Shards {
+		require.Equal(t, 2, shardStats.Heights)
+	}
+
+	// removals reach the shards
+	require.NoError(t, store.RemoveODSQ4(ctx, 1, roots[1].Hash()))
+	hasByHashAndHeight(t, store, ctx, roots[1].Hash(), 1, false, false)
+	ensureAmountFileAndLinks(t, dirs[1], 2, 1)
+	require.NoError(t, store.Stop(ctx))
+
+	// the EDSes stay in their shards after the policy changes
+	store, err = NewStore(paramsNoCache(), dirs[0], WithShards(ShardByFill, dirs[1:]...))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop(ctx))
+	})
+	require.NoError(t, store.PutODSQ4(ctx, roots[2], 2, squares[2]))
+	ensureAmountFileAndLinks(t, dirs[2], 4, 2)
+	for height := uint64(2); height <= 6; height++ {
+		hasByHashAndHeight(t, store, ctx, roots[height].Hash(), height, true, true)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build linux || darwin
+
+package store
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// diskFill returns the used fraction of the disk the path is on.
+func diskFill(path string) (float64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	if stat.Blocks == 0 {
+		return 1, nil
+	}
+	return 1 - float64(stat.Bavail)/float64(stat.Blocks), nil
+}
@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/store/file"
+)
+
+// Stats describes the disk usage and the stored heights of the Store.
+type Stats struct {
+	TierStats
+	// Cold is the statistics of the cold tier, if it is configured.
+	Cold *TierStats `json:"cold,omitempty"`
+	// Shards are the statistics of the shards other than the Store itself, if sharding is
+	// configured.
+	Shards []*TierStats `json:"shards,omitempty"`
+}
+
+// TierStats describes the disk usage and the stored heights of a storage tier.
+type TierStats struct {
+	// TotalSize is the size of the EDS files of the stored heights in bytes.
+	TotalSize int64 `json:"total_size"`
+	// ODSSize is the size of the ODS files, which keep the first quadrant of the EDSes.
+	ODSSize int64 `json:"ods_size"`
+	// Q4Size is the size of the Q4 files, which keep the fourth quadrant of the EDSes.
+	Q4Size int64 `json:"q4_size"`
+	// Heights is the number of stored heights.
+	Heights int `json:"heights"`
+	// LowestHeight is the lowest stored height.
+	LowestHeight uint64 `json:"lowest_height"`
+	// HighestHeight is the highest stored height.
+	HighestHeight uint64 `json:"highest_height"`
+	// LowestContiguousHeight is the lowest height, such that all heights from it up to the
+	// highest one are stored.
+	LowestContiguousHeight uint64 `json:"lowest_contiguous_height"`
+}
+
+// Stats returns the statistics of the Store from the running totals of its disk usage. Files no
+// height links to are not accounted, and EDSes stored once for several heights are accounted for
+// every one of them.
+func (s *Store) Stats(ctx context.Context) (*Stats, error) {
+	hot, err := s.tierStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{TierStats: *hot}
+	if s.tiering != nil {
+		stats.Cold, err = s.tiering.cold.tierStats(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cold tier: %w", err)
+		}
+	}
+	if s.sharding != nil {
+		for _, shard := range s.sharding.shards {
+			shardStats, err := shard.tierStats(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("shard %s: %w", shard.basepath, err)
+			}
+			stats.Shards = append(stats.Shards, shardStats)
+		}
+	}
+	return stats, nil
+}
+
+func (s *Store) tierStats(ctx context.Context) (*TierStats, error) {
+	s.usage.lk.Lock()
+	defer s.usage.lk.Unlock()
+	if err := s.ensureUsage(ctx); err != nil {
+		return nil, err
+	}
+	return s.usage.stats(), nil
+}
+
+// SizeByHeight returns the size of the EDS files stored for the height in bytes, including the cold
+// tier and the shards, or zero if the height is not stored.
+func (s *Store) SizeByHeight(ctx context.Context, height uint64) (int64, error) {
+	lock := s.stripLock.byHeight(height)
+	lock.RLock()
+	size, err := s.heightSize(ctx, height)
+	lock.RUnlock()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+
+	if s.tiering != nil {
+		coldSize, err := s.tiering.cold.SizeByHeight(ctx, height)
+		if err != nil {
+			return 0, fmt.Errorf("cold tier: %w", err)
+		}
+		size += coldSize
+	}
+	if s.sharding != nil {
+		for _, shard := range s.sharding.shards {
+			shardSize, err := shard.SizeByHeight(ctx, height)
+			if err != nil {
+				return 0, fmt.Errorf("shard %s: %w", shard.basepath, err)
+			}
+			size += shardSize
+		}
+	}
+	return size, nil
+}
+
+// heightSize returns the size of the EDS files of the height. Empty EDSes are symlinked and take
+// no space.
+func (s *Store) heightSize(ctx context.Context, height uint64) (int64, error) {
+	path := s.heightToPath(height, odsFileExt)
+	info, err := os.Lstat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return 0, err
+	}
+
+	ods, err := file.OpenODS(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+	if err != nil {
+		// the file is corrupted, which is up to GC, so only its own size is counted
+		return info.Size(), nil
+	}
+	defer utils.CloseAndLog(log, "ods", ods)
+	datahash, err := ods.DataHash(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	q4, err := os.Stat(s.hashToPath(datahash, q4FileExt))
+	switch {
+	case err == nil:
+		return info.Size() + q4.Size(), nil
+	case errors.Is(err, os.ErrNotExist):
+		return info.Size(), nil
+	default:
+		return 0, err
+	}
+}
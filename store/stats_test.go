@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestStore_Stats(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	store, err := NewStore(paramsNoCache(), t.TempDir())
+	require.NoError(t, err)
+
+	stats, err := store.Stats(ctx)
+	require.NoError(t, err)
+	require.Zero(t, stats.Heights)
+
+	// heights 2, 4, 5 and 6 are stored
+	for _, height := range []uint64{2, 4, 6} {
+		eds, roots := randomEDS(t)
+		require.NoError(t, store.PutODSQ4(ctx, roots, height, eds))
+	}
+	require.NoError(t, store.PutODSQ4(ctx, share.EmptyEDSRoots(), 5, share.EmptyEDS()))
+
+	stats, err = store.Stats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 4, stats.Heights)
+	require.EqualValues(t, 2, stats.LowestHeight)
+	require.EqualValues(t, 6, stats.HighestHeight)
+	require.EqualValues(t, 4, stats.LowestContiguousHeight)
+	require.Positive(t, stats.ODSSize)
+	require.Positive(t, stats.Q4Size)
+	require.Equal(t, stats.TotalSize, stats.ODSSize+stats.Q4Size)
+	require.Nil(t, stats.Cold)
+}
+
+func TestStore_UsageByBucket(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	store, err := NewStore(paramsNoCache(), dir)
+	require.NoError(t, err)
+
+	// heights 2 and 4 share the first bucket, the last height is in the second one
+	hashes := make(map[uint64]share.DataHash)
+	for _, height := range []uint64{2, 4, usageBucketSize + 2, usageBucketSize + 3} {
+		eds, roots := randomEDS(t)
+		require.NoError(t, store.PutODSQ4(ctx, roots, height, eds))
+		hashes[height] = roots.Hash()
+	}
+	// empty EDSes take no space
+	require.NoError(t, store.PutODSQ4(ctx, share.EmptyEDSRoots(), 5, share.EmptyEDS()))
+
+	usage, err := store.usageByBucket(ctx)
+	require.NoError(t, err)
+	require.Len(t, usage, 2)
+	require.EqualValues(t, 3, usage[0].heights)
+	require.EqualValues(t, 2, usage[usageBucketSize].heights)
+
+	stats, err := store.Stats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, stats.TotalSize, usage[0].size+usage[usageBucketSize].size)
+
+	// the totals are kept up to date by puts and removals
+	sizeBefore := usage[0].size
+	require.NoError(t, store.RemoveQ4(ctx, 2, hashes[2]))
+	require.NoError(t, store.RemoveODSQ4(ctx, usageBucketSize+2, hashes[usageBucketSize+2]))
+	usage, err = store.usageByBucket(ctx)
+	require.NoError(t, err)
+	require.Less(t, usage[0].size, sizeBefore)
+	require.EqualValues(t, 3, usage[0].heights)
+	require.EqualValues(t, 1, usage[usageBucketSize].heights)
+
+	// and match the ones collected from the files
+	require.NoError(t, store.Stop(ctx))
+	store, err = NewStore(paramsNoCache(), dir)
+	require.NoError(t, err)
+	collected, err := store.usageByBucket(ctx)
+	require.NoError(t, err)
+	require.Equal(t, usage, collected)
+}
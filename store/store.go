@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	logging "github.com/ipfs/go-log/v2"
@@ -30,7 +31,11 @@ const (
 	defaultDirPerm = 0o755
 )
 
-var ErrNotFound = errors.New("eds not found in store")
+var (
+	ErrNotFound = errors.New("eds not found in store")
+	// ErrReadOnly is returned by the operations modifying a read-only store.
+	ErrReadOnly = errors.New("eds store is read-only")
+)
 
 // Store is a storage for EDS files. It persists EDS files on disk in form of Q1Q4 files or ODS
 // files. It provides methods to put, get and remove EDS files. It has two caches: recent eds cache
@@ -43,31 +48,64 @@ type Store struct {
 	cache cache.Cache
 	// stripedLocks is used to synchronize parallel operations
 	stripLock *striplock
-	metrics   *metrics
+	// backend is the optional object storage EDS files are offloaded to
+	backend Backend
+	// nsIndex is the optional index of heights by namespace
+	nsIndex *namespaceIndex
+	// odsOnly makes the store write ODS files for Q4 puts too
+	odsOnly bool
+	// compression is the codec the ODS files are written with
+	compression file.Compression
+	// tiering is the optional cold tier EDSes past the hot window are moved to
+	tiering *tiering
+	// sharding is the optional spreading of the EDSes over several directories
+	sharding *sharding
+	// readOnly makes the store reject all writes
+	readOnly bool
+	// usage is the running totals of the disk usage of the stored heights
+	usage   usage
+	metrics *metrics
 }
 
 // NewStore creates a new EDS Store under the given basepath and datastore.
-func NewStore(params *Parameters, basePath string) (*Store, error) {
+func NewStore(params *Parameters, basePath string, opts ...Option) (*Store, error) {
 	err := params.Validate()
 	if err != nil {
 		return nil, err
 	}
 
-	// ensure the blocks dir exists
-	blocksDir := filepath.Join(basePath, blocksPath)
-	if err := mkdir(blocksDir); err != nil {
-		return nil, fmt.Errorf("ensuring blocks directory: %w", err)
-	}
+	if params.ReadOnly {
+		// the writer owns the directories, so they must exist already
+		if _, err := os.Stat(filepath.Join(basePath, heightsPath)); err != nil {
+			return nil, fmt.Errorf("opening read-only store: %w", err)
+		}
+	} else {
+		// ensure the blocks dir exists
+		blocksDir := filepath.Join(basePath, blocksPath)
+		if err := mkdir(blocksDir); err != nil {
+			return nil, fmt.Errorf("ensuring blocks directory: %w", err)
+		}
+
+		// ensure the heights dir exists
+		heightsDir := filepath.Join(basePath, heightsPath)
+		if err := mkdir(heightsDir); err != nil {
+			return nil, fmt.Errorf("ensuring heights directory: %w", err)
+		}
 
-	// ensure the heights dir exists
-	heightsDir := filepath.Join(basePath, heightsPath)
-	if err := mkdir(heightsDir); err != nil {
-		return nil, fmt.Errorf("ensuring heights directory: %w", err)
+		// ensure the journal dir exists
+		journalDir := filepath.Join(basePath, journalPath)
+		if err := mkdir(journalDir); err != nil {
+			return nil, fmt.Errorf("ensuring journal directory: %w", err)
+		}
 	}
 
 	var recentCache cache.Cache = cache.NoopCache{}
 	if params.RecentBlocksCacheSize > 0 {
-		recentCache, err = cache.NewAccessorCache("recent", params.RecentBlocksCacheSize)
+		recentCache, err = cache.NewAccessorCacheWithPolicy(
+			"recent",
+			params.RecentBlocksCacheSize,
+			params.RecentBlocksCachePolicy,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create recent eds cache: %w", err)
 		}
@@ -77,16 +115,52 @@ func NewStore(params *Parameters, basePath string) (*Store, error) {
 		basepath:  basePath,
 		cache:     recentCache,
 		stripLock: newStripLock(1024),
+		odsOnly:   params.ODSOnly,
+		readOnly:  params.ReadOnly,
+	}
+	if params.CompressODS {
+		store.compression = file.ZstdCompression
+	}
+	for _, opt := range opts {
+		opt(store)
+	}
+	if store.sharding != nil && (store.tiering != nil || store.backend != nil) {
+		return nil, errors.New("sharding can't be combined with the cold tier or the backend")
 	}
 
-	if err := store.populateEmptyFile(); err != nil {
-		return nil, fmt.Errorf("ensuring empty EDS: %w", err)
+	if !store.readOnly {
+		if err := store.recoverJournal(context.Background()); err != nil {
+			return nil, fmt.Errorf("recovering interrupted writes: %w", err)
+		}
+		if err := store.populateEmptyFile(); err != nil {
+			return nil, fmt.Errorf("ensuring empty EDS: %w", err)
+		}
+	}
+	if store.tiering != nil {
+		if err := store.startTiering(); err != nil {
+			return nil, err
+		}
+	}
+	if store.sharding != nil {
+		if err := store.startSharding(); err != nil {
+			return nil, err
+		}
 	}
 
 	return store, nil
 }
 
-func (s *Store) Stop(context.Context) error {
+func (s *Store) Stop(ctx context.Context) error {
+	if s.sharding != nil {
+		if err := s.stopSharding(ctx); err != nil {
+			return err
+		}
+	}
+	if s.tiering != nil {
+		if err := s.stopTiering(ctx); err != nil {
+			return err
+		}
+	}
 	return s.metrics.close()
 }
 
@@ -96,7 +170,8 @@ func (s *Store) PutODSQ4(
 	height uint64,
 	square *rsmt2d.ExtendedDataSquare,
 ) error {
-	return s.put(ctx, roots, height, square, true)
+	// Q4 is recomputed from the ODS on reads in the ODS-only mode
+	return s.put(ctx, roots, height, square, !s.odsOnly)
 }
 
 func (s *Store) PutODS(
@@ -115,6 +190,12 @@ func (s *Store) put(
 	square *rsmt2d.ExtendedDataSquare,
 	writeQ4 bool,
 ) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if s.tiering != nil {
+		s.tiering.advance(height)
+	}
 	datahash := share.DataHash(roots.Hash())
 	// we don't need to store empty EDS, just link the height to the empty file
 	if datahash.IsEmptyEDS() {
@@ -125,7 +206,13 @@ func (s *Store) put(
 		if errors.Is(err, os.ErrExist) {
 			return nil
 		}
-		return err
+		if err != nil || s.backend == nil {
+			return err
+		}
+		if err := s.upload(ctx, datahash, height, false); err != nil {
+			return errors.Join(err, s.removeODS(height, datahash))
+		}
+		return nil
 	}
 
 	// put to cache before writing to make it accessible while write is happening
@@ -143,22 +230,60 @@ func (s *Store) put(
 	lock.lock()
 	defer lock.unlock()
 
+	shard, err := s.shardFor(height)
+	if err != nil {
+		return fmt.Errorf("selecting shard: %w", err)
+	}
+	if shard != s {
+		shardLock := shard.stripLock.byHashAndHeight(datahash, height)
+		shardLock.lock()
+		defer shardLock.unlock()
+	}
+
+	// journal the put, so that the files left behind by a crash in the middle of it are recovered
+	if err := shard.journal(height, datahash); err != nil {
+		return fmt.Errorf("journaling put: %w", err)
+	}
+	defer shard.unjournal(height)
+
 	var exists bool
 	if writeQ4 {
-		exists, err = s.createODSQ4File(square, roots, height)
+		exists, err = shard.createODSQ4File(square, roots, height)
 	} else {
-		exists, err = s.createODSFile(square, roots, height)
-	}
-
-	if exists {
-		s.metrics.observePutExist(ctx)
-		return nil
+		exists, err = shard.createODSFile(square, roots, height)
 	}
 	if err != nil {
 		s.metrics.observePut(ctx, time.Since(tNow), square.Width(), writeQ4, true)
 		return fmt.Errorf("creating file: %w", err)
 	}
 
+	// existing files are uploaded too, as the upload of the previous put could have been cut short
+	if s.backend != nil {
+		if err := s.upload(ctx, datahash, height, writeQ4); err != nil {
+			s.metrics.observePut(ctx, time.Since(tNow), square.Width(), writeQ4, true)
+			err = fmt.Errorf("uploading to backend: %w", err)
+			if exists {
+				// the local files belong to the previous put, so they are kept
+				return err
+			}
+			// drop the local files, so that the put is retried as a whole
+			return errors.Join(err, s.removeODSQ4(height, datahash))
+		}
+	}
+
+	// index the namespaces only once the square is stored, so that the index never lists a height
+	// that failed to be stored. Existing files are indexed too, as the previous put could have
+	// failed to index them.
+	if s.nsIndex != nil {
+		if err := s.nsIndex.add(ctx, height, square); err != nil {
+			return fmt.Errorf("indexing namespaces: %w", err)
+		}
+	}
+
+	if exists {
+		s.metrics.observePutExist(ctx)
+		return nil
+	}
 	s.metrics.observePut(ctx, time.Since(tNow), square.Width(), writeQ4, false)
 	return nil
 }
@@ -171,7 +296,7 @@ func (s *Store) createODSQ4File(
 	pathODS := s.hashToPath(roots.Hash(), odsFileExt)
 	pathQ4 := s.hashToPath(roots.Hash(), q4FileExt)
 
-	err := file.CreateODSQ4(pathODS, pathQ4, roots, square)
+	err := file.CreateODSQ4(pathODS, pathQ4, roots, square, s.compression)
 	if err != nil && !errors.Is(err, os.ErrExist) {
 		// ensure we don't have partial writes if any operation fails
 		removeErr := s.removeODSQ4(height, roots.Hash())
@@ -204,7 +329,7 @@ func (s *Store) createODSFile(
 	height uint64,
 ) (bool, error) {
 	pathODS := s.hashToPath(roots.Hash(), odsFileExt)
-	err := file.CreateODS(pathODS, roots, square)
+	err := file.CreateODS(pathODS, roots, square, s.compression)
 	if err != nil && !errors.Is(err, os.ErrExist) {
 		// ensure we don't have partial writes if any operation fails
 		removeErr := s.removeODS(height, roots.Hash())
@@ -233,6 +358,20 @@ func (s *Store) createODSFile(
 
 func (s *Store) linkHeight(datahash share.DataHash, height uint64) error {
 	linktoOds := s.heightToPath(height, odsFileExt)
+	s.usage.lk.Lock()
+	err := s.link(datahash, linktoOds)
+	if err == nil {
+		odsSize, q4Size := s.heightFilesSize(height, datahash)
+		s.usage.add(height, odsSize, q4Size)
+	}
+	s.usage.lk.Unlock()
+	if err != nil || datahash.IsEmptyEDS() {
+		return err
+	}
+	return file.SyncDir(filepath.Dir(linktoOds))
+}
+
+func (s *Store) link(datahash share.DataHash, linktoOds string) error {
 	if datahash.IsEmptyEDS() {
 		// empty EDS is always symlinked, because there is limited number of hardlinks
 		// for the same file in some filesystems (ext4)
@@ -256,7 +395,13 @@ func (s *Store) populateEmptyFile() error {
 		return fmt.Errorf("cleaning old empty EDS file: %w", err)
 	}
 
-	err = file.CreateODSQ4(pathOds, pathQ4, share.EmptyEDSRoots(), eds.EmptyAccessor.ExtendedDataSquare)
+	err = file.CreateODSQ4(
+		pathOds,
+		pathQ4,
+		share.EmptyEDSRoots(),
+		eds.EmptyAccessor.ExtendedDataSquare,
+		file.NoCompression,
+	)
 	if err != nil {
 		return fmt.Errorf("creating fresh empty EDS file: %w", err)
 	}
@@ -269,11 +414,26 @@ func (s *Store) GetByHash(ctx context.Context, datahash share.DataHash) (eds.Acc
 		return eds.EmptyAccessor, nil
 	}
 	lock := s.stripLock.byHash(datahash)
-	lock.RLock()
-	defer lock.RUnlock()
 
 	tNow := time.Now()
+	lock.RLock()
 	f, err := s.getByHash(ctx, datahash)
+	lock.RUnlock()
+	if errors.Is(err, ErrNotFound) && s.sharding != nil {
+		f, err = s.getShardedByHash(ctx, datahash)
+	}
+	if errors.Is(err, ErrNotFound) && s.tiering != nil {
+		f, err = s.tiering.cold.GetByHash(ctx, datahash)
+	}
+	// fall back to the backend for EDSes missing on local disk
+	if errors.Is(err, ErrNotFound) && s.backend != nil {
+		err = s.restoreByHash(ctx, datahash)
+		if err == nil {
+			lock.RLock()
+			f, err = s.getByHash(ctx, datahash)
+			lock.RUnlock()
+		}
+	}
 	s.metrics.observeGet(ctx, time.Since(tNow), err != nil)
 	return f, err
 }
@@ -285,11 +445,26 @@ func (s *Store) getByHash(ctx context.Context, datahash share.DataHash) (eds.Acc
 
 func (s *Store) GetByHeight(ctx context.Context, height uint64) (eds.AccessorStreamer, error) {
 	lock := s.stripLock.byHeight(height)
-	lock.RLock()
-	defer lock.RUnlock()
 
 	tNow := time.Now()
+	lock.RLock()
 	f, err := s.getByHeight(ctx, height)
+	lock.RUnlock()
+	if errors.Is(err, ErrNotFound) && s.sharding != nil {
+		f, err = s.getShardedByHeight(ctx, height)
+	}
+	if errors.Is(err, ErrNotFound) && s.tiering != nil {
+		f, err = s.tiering.cold.GetByHeight(ctx, height)
+	}
+	// fall back to the backend for EDSes missing on local disk
+	if errors.Is(err, ErrNotFound) && s.backend != nil {
+		err = s.restoreByHeight(ctx, height)
+		if err == nil {
+			lock.RLock()
+			f, err = s.getByHeight(ctx, height)
+			lock.RUnlock()
+		}
+	}
 	s.metrics.observeGet(ctx, time.Since(tNow), err != nil)
 	return f, err
 }
@@ -338,6 +513,15 @@ func (s *Store) HasByHash(ctx context.Context, datahash share.DataHash) (bool, e
 
 	tNow := time.Now()
 	exist, err := s.hasByHash(datahash)
+	if err == nil && !exist && s.sharding != nil {
+		exist, err = s.hasShardedByHash(ctx, datahash)
+	}
+	if err == nil && !exist && s.tiering != nil {
+		exist, err = s.tiering.cold.HasByHash(ctx, datahash)
+	}
+	if err == nil && !exist && s.backend != nil {
+		exist, err = s.backend.Has(ctx, hashToKey(datahash, odsFileExt))
+	}
 	s.metrics.observeHas(ctx, time.Since(tNow), err != nil)
 	return exist, err
 }
@@ -355,6 +539,15 @@ func (s *Store) HasByHeight(ctx context.Context, height uint64) (bool, error) {
 
 	tNow := time.Now()
 	exist, err := s.hasByHeight(height)
+	if err == nil && !exist && s.sharding != nil {
+		exist, err = s.hasShardedByHeight(ctx, height)
+	}
+	if err == nil && !exist && s.tiering != nil {
+		exist, err = s.tiering.cold.HasByHeight(ctx, height)
+	}
+	if err == nil && !exist && s.backend != nil {
+		exist, err = s.backend.Has(ctx, heightToKey(height))
+	}
 	s.metrics.observeHas(ctx, time.Since(tNow), err != nil)
 	return exist, err
 }
@@ -370,12 +563,25 @@ func (s *Store) hasByHeight(height uint64) (bool, error) {
 }
 
 func (s *Store) RemoveODSQ4(ctx context.Context, height uint64, datahash share.DataHash) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
 	lock := s.stripLock.byHashAndHeight(datahash, height)
 	lock.lock()
 	defer lock.unlock()
 
 	tNow := time.Now()
 	err := s.removeODSQ4(height, datahash)
+	if err == nil && s.sharding != nil {
+		for _, shard := range s.sharding.shards {
+			if err = shard.RemoveODSQ4(ctx, height, datahash); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil && s.tiering != nil {
+		err = s.tiering.cold.RemoveODSQ4(ctx, height, datahash)
+	}
 	s.metrics.observeRemoveODSQ4(ctx, time.Since(tNow), err != nil)
 	return err
 }
@@ -395,8 +601,7 @@ func (s *Store) removeODS(height uint64, datahash share.DataHash) error {
 		return fmt.Errorf("removing from cache: %w", err)
 	}
 
-	pathLink := s.heightToPath(height, odsFileExt)
-	if err := remove(pathLink); err != nil {
+	if err := s.unlinkHeight(height, datahash); err != nil {
 		return fmt.Errorf("removing hardlink: %w", err)
 	}
 
@@ -413,12 +618,25 @@ func (s *Store) removeODS(height uint64, datahash share.DataHash) error {
 }
 
 func (s *Store) RemoveQ4(ctx context.Context, height uint64, datahash share.DataHash) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
 	lock := s.stripLock.byHashAndHeight(datahash, height)
 	lock.lock()
 	defer lock.unlock()
 
 	tNow := time.Now()
 	err := s.removeQ4(height, datahash)
+	if err == nil && s.sharding != nil {
+		for _, shard := range s.sharding.shards {
+			if err = shard.RemoveQ4(ctx, height, datahash); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil && s.tiering != nil {
+		err = s.tiering.cold.RemoveQ4(ctx, height, datahash)
+	}
 	s.metrics.observeRemoveQ4(ctx, time.Since(tNow), err != nil)
 	return err
 }
@@ -435,9 +653,13 @@ func (s *Store) removeQ4(height uint64, datahash share.DataHash) error {
 
 	// remove Q4 file
 	pathQ4File := s.hashToPath(datahash, q4FileExt)
+	s.usage.lk.Lock()
+	defer s.usage.lk.Unlock()
+	size := fileSize(pathQ4File)
 	if err := remove(pathQ4File); err != nil {
 		return fmt.Errorf("removing Q4 file: %w", err)
 	}
+	s.usage.removeQ4(height, size)
 	return nil
 }
 
@@ -453,6 +675,28 @@ func (s *Store) heightToPath(height uint64, ext string) string {
 	return filepath.Join(s.basepath, heightsPath, strconv.Itoa(int(height))) + ext
 }
 
+// heights returns the heights stored in the Store.
+func (s *Store) heights() ([]uint64, error) {
+	entries, err := os.ReadDir(filepath.Join(s.basepath, heightsPath))
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if filepath.Ext(name) != odsFileExt {
+			continue
+		}
+		height, err := strconv.ParseUint(strings.TrimSuffix(name, odsFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	return heights, nil
+}
+
 func accessorLoader(accessor eds.AccessorStreamer) cache.OpenAccessorFn {
 	return func(context.Context) (eds.AccessorStreamer, error) {
 		return wrapAccessor(accessor), nil
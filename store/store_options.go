@@ -2,17 +2,35 @@ package store
 
 import (
 	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-node/store/cache"
 )
 
 type Parameters struct {
 	// RecentBlocksCacheSize is the size of the cache for recent blocks.
 	RecentBlocksCacheSize int
+	// RecentBlocksCachePolicy is the eviction policy of the cache for recent blocks: "lru" evicts
+	// the least recently used block, while "frequency" prefers to keep blocks read more than once.
+	RecentBlocksCachePolicy cache.EvictionPolicy
+	// ODSOnly makes the store keep only the original data square of every EDS, cutting the disk
+	// usage about 4x. The rest of the EDS is recomputed from the ODS when it is read.
+	ODSOnly bool
+	// CompressODS compresses the ODS files with zstd. It saves disk space on chains with many
+	// near-empty blocks, whose squares are mostly padding, at the cost of reading the whole ODS into
+	// memory on the first read. Existing files are read regardless of the setting.
+	CompressODS bool
+	// ReadOnly opens the store without ever writing to it, so that serving replicas can share the
+	// store directory of a single writing node, e.g. over a network filesystem. Puts and removals
+	// fail with ErrReadOnly.
+	ReadOnly bool
 }
 
 // DefaultParameters returns the default configuration values for the EDS store parameters.
 func DefaultParameters() *Parameters {
 	return &Parameters{
-		RecentBlocksCacheSize: 10,
+		RecentBlocksCacheSize:   10,
+		RecentBlocksCachePolicy: cache.LRU,
 	}
 }
 
@@ -20,5 +38,8 @@ func (p *Parameters) Validate() error {
 	if p.RecentBlocksCacheSize < 0 {
 		return errors.New("recent eds cache size cannot be negative")
 	}
+	if err := p.RecentBlocksCachePolicy.Validate(); err != nil {
+		return fmt.Errorf("recent eds cache: %w", err)
+	}
 	return nil
 }
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path"
 	"sync/atomic"
@@ -302,6 +303,78 @@ func TestEDSStore(t *testing.T) {
 		require.True(t, hash.IsEmptyEDS())
 	})
 
+	t.Run("identical EDSes stored once", func(t *testing.T) {
+		dir := t.TempDir()
+		edsStore, err := NewStore(paramsNoCache(), dir)
+		require.NoError(t, err)
+
+		eds, roots := randomEDS(t)
+		for height := uint64(1); height <= 3; height++ {
+			require.NoError(t, edsStore.PutODSQ4(ctx, roots, height, eds))
+		}
+		// heights are linked to the single copy of the files
+		ensureAmountFileAndLinks(t, dir, 2, 3)
+		for height := uint64(1); height <= 3; height++ {
+			hasByHashAndHeight(t, edsStore, ctx, roots.Hash(), height, true, true)
+		}
+	})
+
+	t.Run("ODS only", func(t *testing.T) {
+		dir := t.TempDir()
+		params := paramsNoCache()
+		params.ODSOnly = true
+		edsStore, err := NewStore(params, dir)
+		require.NoError(t, err)
+
+		eds, roots := randomEDS(t)
+		err = edsStore.PutODSQ4(ctx, roots, 1, eds)
+		require.NoError(t, err)
+		// only the ODS file is written
+		ensureAmountFileAndLinks(t, dir, 1, 1)
+
+		// Q4 is recomputed from the ODS
+		f, err := edsStore.GetByHeight(ctx, 1)
+		require.NoError(t, err)
+		defer f.Close()
+		odsSize := int(eds.Width() / 2)
+		sample, err := f.Sample(ctx, odsSize, odsSize)
+		require.NoError(t, err)
+		require.Equal(t, eds.GetCell(uint(odsSize), uint(odsSize)), sample.Share)
+	})
+
+	t.Run("compressed ODS", func(t *testing.T) {
+		dir := t.TempDir()
+		params := paramsNoCache()
+		params.CompressODS = true
+		edsStore, err := NewStore(params, dir)
+		require.NoError(t, err)
+
+		eds, roots := randomEDS(t)
+		err = edsStore.PutODSQ4(ctx, roots, 1, eds)
+		require.NoError(t, err)
+
+		f, err := edsStore.GetByHeight(ctx, 1)
+		require.NoError(t, err)
+		defer f.Close()
+		shares, err := f.Shares(ctx)
+		require.NoError(t, err)
+		require.Equal(t, eds.FlattenedODS(), shares)
+
+		// compressed files are read regardless of the setting
+		params.CompressODS = false
+		edsStore, err = NewStore(params, dir)
+		require.NoError(t, err)
+		has, err := edsStore.HasByHeight(ctx, 1)
+		require.NoError(t, err)
+		require.True(t, has)
+		f, err = edsStore.GetByHeight(ctx, 1)
+		require.NoError(t, err)
+		defer f.Close()
+		sample, err := f.Sample(ctx, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, eds.GetCell(0, 0), sample.Share)
+	})
+
 	t.Run("reopen", func(t *testing.T) {
 		dir := t.TempDir()
 		edsStore, err := NewStore(paramsNoCache(), dir)
@@ -314,6 +387,41 @@ func TestEDSStore(t *testing.T) {
 		_, err = NewStore(paramsNoCache(), dir)
 		require.NoError(t, err)
 	})
+
+	t.Run("read-only", func(t *testing.T) {
+		dir := t.TempDir()
+		readOnlyParams := paramsNoCache()
+		readOnlyParams.ReadOnly = true
+		// the writer creates the store
+		_, err := NewStore(readOnlyParams, dir)
+		require.Error(t, err)
+
+		writer, err := NewStore(paramsNoCache(), dir)
+		require.NoError(t, err)
+		replica, err := NewStore(readOnlyParams, dir)
+		require.NoError(t, err)
+
+		eds, roots := randomEDS(t)
+		require.NoError(t, writer.PutODSQ4(ctx, roots, 1, eds))
+		require.NoError(t, writer.PutODSQ4(ctx, share.EmptyEDSRoots(), 2, share.EmptyEDS()))
+		// writes of the writer are served by the replica
+		hasByHashAndHeight(t, replica, ctx, roots.Hash(), 1, true, true)
+		hasByHashAndHeight(t, replica, ctx, share.EmptyEDSDataHash(), 2, true, true)
+		f, err := replica.GetByHeight(ctx, 1)
+		require.NoError(t, err)
+		sample, err := f.Sample(ctx, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, eds.GetCell(0, 0), sample.Share)
+		require.NoError(t, f.Close())
+
+		otherEDS, otherRoots := randomEDS(t)
+		require.ErrorIs(t, replica.PutODSQ4(ctx, otherRoots, 3, otherEDS), ErrReadOnly)
+		require.ErrorIs(t, replica.RemoveODSQ4(ctx, 1, roots.Hash()), ErrReadOnly)
+		_, err = replica.GC(ctx)
+		require.ErrorIs(t, err, ErrReadOnly)
+		require.NoError(t, replica.Stop(ctx))
+		ensureAmountFileAndLinks(t, dir, 2, 2)
+	})
 }
 
 func BenchmarkStore(b *testing.B) {
@@ -370,6 +478,37 @@ func BenchmarkStore(b *testing.B) {
 			require.NoError(b, f.Close())
 		}
 	})
+
+	// reads of different heights take different lock stripes, so the throughput scales with -cpu
+	const heights = 256
+	for _, params := range []*Parameters{paramsNoCache(), {RecentBlocksCacheSize: heights}} {
+		name := fmt.Sprintf("parallel sample by height, 128, cache %d", params.RecentBlocksCacheSize)
+		b.Run(name, func(b *testing.B) {
+			edsStore, err := NewStore(params, b.TempDir())
+			require.NoError(b, err)
+			for height := uint64(1); height <= heights; height++ {
+				require.NoError(b, edsStore.PutODSQ4(ctx, roots, height, eds))
+			}
+
+			var next atomic.Uint64
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					height := next.Add(1)%heights + 1
+					f, err := edsStore.GetByHeight(ctx, height)
+					if err != nil {
+						b.Error(err)
+						return
+					}
+					_, err = f.Sample(ctx, int(height%128), 0)
+					if err != nil {
+						b.Error(err)
+					}
+					_ = f.Close()
+				}
+			})
+		})
+	}
 }
 
 func randomEDS(t testing.TB) (*rsmt2d.ExtendedDataSquare, *share.AxisRoots) {
@@ -386,8 +525,8 @@ func ensureAmountFileAndLinks(t testing.TB, dir string, files, links int) {
 }
 
 func ensureAmountFiles(t testing.TB, dir string, files int) {
-	// add empty file ods and q4 parts and heights folder to the count
-	files += 3
+	// add empty file ods and q4 parts, heights and journal folders to the count
+	files += 4
 	// ensure block folder contains the correct amount of files
 	blockPath := path.Join(dir, blocksPath)
 	entries, err := os.ReadDir(blockPath)
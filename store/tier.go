@@ -0,0 +1,236 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/store/file"
+)
+
+// tieringInterval is the interval of moving the EDSes past the hot window to the cold tier.
+var tieringInterval = time.Minute
+
+// WithColdTier makes the Store keep only the EDSes of the most recent hotWindow heights under its
+// path and move older ones to the cold tier under the given path, e.g. a slower but bigger disk.
+// EDSes are looked up in the hot tier first. The hot window can be changed with SetHotWindow.
+func WithColdTier(path string, hotWindow uint64) Option {
+	return func(s *Store) {
+		s.tiering = &tiering{path: path}
+		s.tiering.hotWindow.Store(hotWindow)
+	}
+}
+
+// tiering moves the EDSes past the hot window from the Store to the cold tier.
+type tiering struct {
+	path string
+	cold *Store
+
+	hotWindow atomic.Uint64
+	// head is the highest height put to the Store
+	head atomic.Uint64
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (s *Store) startTiering() error {
+	cold, err := NewStore(&Parameters{ReadOnly: s.readOnly}, s.tiering.path)
+	if err != nil {
+		return fmt.Errorf("opening cold tier: %w", err)
+	}
+	s.tiering.cold = cold
+	if s.readOnly {
+		// the writer moves the EDSes between the tiers
+		return nil
+	}
+
+	head, err := s.highestHeight()
+	if err != nil {
+		return fmt.Errorf("reading heights: %w", err)
+	}
+	s.tiering.head.Store(head)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.tiering.cancel = cancel
+	s.tiering.done = make(chan struct{})
+	go s.tieringLoop(ctx)
+	return nil
+}
+
+// advance updates the head with the put height.
+func (t *tiering) advance(height uint64) {
+	for {
+		head := t.head.Load()
+		if height <= head || t.head.CompareAndSwap(head, height) {
+			return
+		}
+	}
+}
+
+func (s *Store) stopTiering(ctx context.Context) error {
+	if s.tiering.cancel == nil {
+		return s.tiering.cold.Stop(ctx)
+	}
+	s.tiering.cancel()
+	select {
+	case <-s.tiering.done:
+		return s.tiering.cold.Stop(ctx)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetHotWindow sets the number of the most recent heights kept in the hot tier.
+func (s *Store) SetHotWindow(window uint64) error {
+	if s.tiering == nil {
+		return errors.New("cold tier is not configured")
+	}
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	s.tiering.hotWindow.Store(window)
+	return nil
+}
+
+func (s *Store) tieringLoop(ctx context.Context) {
+	defer close(s.tiering.done)
+	ticker := time.NewTicker(tieringInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.moveToCold(ctx); err != nil && ctx.Err() == nil {
+				log.Errorw("moving EDSes to cold tier", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// moveToCold moves the EDSes of the heights past the hot window to the cold tier.
+func (s *Store) moveToCold(ctx context.Context) error {
+	head, window := s.tiering.head.Load(), s.tiering.hotWindow.Load()
+	if head <= window {
+		return nil
+	}
+
+	heights, err := s.heights()
+	if err != nil {
+		return err
+	}
+	for _, height := range heights {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if height > head-window {
+			continue
+		}
+		if err := s.moveHeightToCold(ctx, height); err != nil {
+			return fmt.Errorf("moving height %d: %w", height, err)
+		}
+	}
+	return nil
+}
+
+// moveHeightToCold copies the files of the height to the cold tier and removes them from the hot
+// one.
+func (s *Store) moveHeightToCold(ctx context.Context, height uint64) error {
+	datahash, err := s.heightDataHash(ctx, height)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	lock := s.stripLock.byHashAndHeight(datahash, height)
+	lock.lock()
+	defer lock.unlock()
+
+	cold := s.tiering.cold
+	coldLock := cold.stripLock.byHashAndHeight(datahash, height)
+	coldLock.lock()
+	defer coldLock.unlock()
+
+	if !datahash.IsEmptyEDS() {
+		err = copyFile(s.hashToPath(datahash, odsFileExt), cold.hashToPath(datahash, odsFileExt))
+		if err != nil {
+			return fmt.Errorf("copying ODS: %w", err)
+		}
+		err = copyFile(s.hashToPath(datahash, q4FileExt), cold.hashToPath(datahash, q4FileExt))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("copying Q4: %w", err)
+		}
+	}
+	err = cold.linkHeight(datahash, height)
+	if err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("linking height: %w", err)
+	}
+	return s.removeODSQ4(height, datahash)
+}
+
+// heightDataHash reads the datahash of the EDS stored at the height.
+func (s *Store) heightDataHash(ctx context.Context, height uint64) (share.DataHash, error) {
+	lock := s.stripLock.byHeight(height)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	ods, err := file.OpenODS(s.heightToPath(height, odsFileExt))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening ODS: %w", err)
+	}
+	defer utils.CloseAndLog(log, "ods", ods)
+	return ods.DataHash(ctx)
+}
+
+func (s *Store) highestHeight() (uint64, error) {
+	heights, err := s.heights()
+	if err != nil {
+		return 0, err
+	}
+	var highest uint64
+	for _, height := range heights {
+		highest = max(highest, height)
+	}
+	return highest, nil
+}
+
+// copyFile copies the file through a temporary file, so that the copy is either complete or
+// missing. Existing files are kept.
+func copyFile(src, dst string) error {
+	if ok, err := exists(dst); err != nil || ok {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*"+tmpFileExt)
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+	_, err = io.Copy(tmp, in)
+	err = errors.Join(err, tmp.Close())
+	if err == nil {
+		err = os.Rename(tmp.Name(), dst)
+	}
+	if err != nil {
+		return errors.Join(err, remove(tmp.Name()))
+	}
+	return nil
+}
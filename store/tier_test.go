@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestStore_ColdTier(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	hotDir, coldDir := t.TempDir(), t.TempDir()
+	store, err := NewStore(paramsNoCache(), hotDir, WithColdTier(coldDir, 2))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop(ctx))
+	})
+
+	roots := make(map[uint64]*share.AxisRoots)
+	for height := uint64(1); height <= 5; height++ {
+		if height == 2 {
+			roots[height] = share.EmptyEDSRoots()
+			require.NoError(t, store.PutODSQ4(ctx, roots[height], height, share.EmptyEDS()))
+			continue
+		}
+		eds, edsRoots := randomEDS(t)
+		roots[height] = edsRoots
+		require.NoError(t, store.PutODSQ4(ctx, edsRoots, height, eds))
+	}
+
+	require.NoError(t, store.moveToCold(ctx))
+	// heights past the hot window are moved to the cold tier
+	ensureAmountFileAndLinks(t, hotDir, 4, 2)
+	ensureAmountFileAndLinks(t, coldDir, 4, 3)
+	for height := uint64(1); height <= 5; height++ {
+		hasByHashAndHeight(t, store, ctx, roots[height].Hash(), height, true, true)
+
+		f, err := store.GetByHeight(ctx, height)
+		require.NoError(t, err)
+		datahash, err := f.DataHash(ctx)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		require.EqualValues(t, roots[height].Hash(), datahash)
+	}
+
+	// removals reach the cold tier
+	require.NoError(t, store.RemoveODSQ4(ctx, 1, roots[1].Hash()))
+	hasByHashAndHeight(t, store, ctx, roots[1].Hash(), 1, false, false)
+	ensureAmountFileAndLinks(t, coldDir, 2, 2)
+
+	// the hot window is reconfigurable
+	require.NoError(t, store.SetHotWindow(0))
+	require.NoError(t, store.moveToCold(ctx))
+	ensureAmountFileAndLinks(t, hotDir, 0, 0)
+	hasByHashAndHeight(t, store, ctx, roots[5].Hash(), 5, true, true)
+}
@@ -0,0 +1,296 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// usageBucketSize is the number of heights disk usage is reported for together.
+const usageBucketSize = 10_000
+
+// usage keeps the running totals of the disk usage of the stored heights, so that it is reported
+// without walking the Store. The totals are collected from the file metadata on the first use and
+// kept up to date by linking and removing the heights. Files no height links to are not
+// accounted, and EDSes stored once for several heights are accounted for every one of them.
+type usage struct {
+	// lk serializes linking and removing heights with the updates of the totals
+	lk        sync.Mutex
+	collected bool
+	odsSize   int64
+	q4Size    int64
+	buckets   map[uint64]*usageBucket
+}
+
+// usageBucket is the disk usage of a bucket of heights.
+type usageBucket struct {
+	size    int64
+	heights int
+	// stored marks the stored heights of the bucket
+	stored [(usageBucketSize + 63) / 64]uint64
+}
+
+func (b *usageBucket) has(height uint64) bool {
+	i := height % usageBucketSize
+	return b.stored[i/64]&(1<<(i%64)) != 0
+}
+
+func (b *usageBucket) set(height uint64, stored bool) {
+	i := height % usageBucketSize
+	if stored {
+		b.stored[i/64] |= 1 << (i % 64)
+	} else {
+		b.stored[i/64] &^= 1 << (i % 64)
+	}
+}
+
+// lowest returns the lowest stored height of the bucket.
+func (b *usageBucket) lowest() uint64 {
+	for i, word := range b.stored {
+		if word != 0 {
+			return uint64(i*64 + bits.TrailingZeros64(word))
+		}
+	}
+	return 0
+}
+
+// highest returns the highest stored height of the bucket.
+func (b *usageBucket) highest() uint64 {
+	for i := len(b.stored) - 1; i >= 0; i-- {
+		if word := b.stored[i]; word != 0 {
+			return uint64(i*64 + 63 - bits.LeadingZeros64(word))
+		}
+	}
+	return 0
+}
+
+func (u *usage) has(height uint64) bool {
+	bucket, ok := u.buckets[height-height%usageBucketSize]
+	return ok && bucket.has(height)
+}
+
+func (u *usage) stats() *TierStats {
+	stats := &TierStats{
+		TotalSize: u.odsSize + u.q4Size,
+		ODSSize:   u.odsSize,
+		Q4Size:    u.q4Size,
+	}
+	if len(u.buckets) == 0 {
+		return stats
+	}
+	keys := slices.Sorted(maps.Keys(u.buckets))
+	for _, bucket := range u.buckets {
+		stats.Heights += bucket.heights
+	}
+	stats.LowestHeight = keys[0] + u.buckets[keys[0]].lowest()
+	stats.HighestHeight = keys[len(keys)-1] + u.buckets[keys[len(keys)-1]].highest()
+	stats.LowestContiguousHeight = stats.HighestHeight
+	for stats.LowestContiguousHeight > stats.LowestHeight && u.has(stats.LowestContiguousHeight-1) {
+		stats.LowestContiguousHeight--
+	}
+	return stats
+}
+
+func (u *usage) add(height uint64, odsSize, q4Size int64) {
+	if !u.collected {
+		return
+	}
+	key := height - height%usageBucketSize
+	bucket, ok := u.buckets[key]
+	if !ok {
+		bucket = &usageBucket{}
+		u.buckets[key] = bucket
+	}
+	if bucket.has(height) {
+		return
+	}
+	bucket.set(height, true)
+	bucket.heights++
+	bucket.size += odsSize + q4Size
+	u.odsSize += odsSize
+	u.q4Size += q4Size
+}
+
+func (u *usage) remove(height uint64, odsSize, q4Size int64) {
+	if !u.collected {
+		return
+	}
+	key := height - height%usageBucketSize
+	bucket, ok := u.buckets[key]
+	if !ok || !bucket.has(height) {
+		return
+	}
+	bucket.set(height, false)
+	bucket.heights--
+	bucket.size -= odsSize + q4Size
+	u.odsSize -= odsSize
+	u.q4Size -= q4Size
+	if bucket.heights == 0 {
+		delete(u.buckets, key)
+	}
+}
+
+// removeQ4 accounts the removal of the Q4 file of the height, which stays stored.
+func (u *usage) removeQ4(height uint64, q4Size int64) {
+	if !u.collected {
+		return
+	}
+	bucket, ok := u.buckets[height-height%usageBucketSize]
+	if !ok || !bucket.has(height) {
+		return
+	}
+	bucket.size -= q4Size
+	u.q4Size -= q4Size
+}
+
+// bucketUsage describes the disk usage of a bucket of heights.
+type bucketUsage struct {
+	size    int64
+	heights int64
+}
+
+// usageByBucket returns the disk usage of the stored heights in buckets of usageBucketSize
+// heights, keyed by the lowest height of the bucket.
+func (s *Store) usageByBucket(ctx context.Context) (map[uint64]bucketUsage, error) {
+	s.usage.lk.Lock()
+	defer s.usage.lk.Unlock()
+	if err := s.ensureUsage(ctx); err != nil {
+		return nil, err
+	}
+
+	buckets := make(map[uint64]bucketUsage, len(s.usage.buckets))
+	for key, bucket := range s.usage.buckets {
+		buckets[key] = bucketUsage{size: bucket.size, heights: int64(bucket.heights)}
+	}
+	return buckets, nil
+}
+
+// ensureUsage collects the usage, unless it is collected already. Read-only stores collect it
+// every time, as the writer updates the files. It must be called with the usage lock held.
+func (s *Store) ensureUsage(ctx context.Context) error {
+	if s.usage.collected && !s.readOnly {
+		return nil
+	}
+	if err := s.collectUsage(ctx); err != nil {
+		return fmt.Errorf("collecting disk usage: %w", err)
+	}
+	return nil
+}
+
+// collectUsage collects the usage of the stored heights from the metadata of their files, without
+// reading them. The height links are matched with the Q4 files through the ODS files they link to,
+// on the platforms identifying the files.
+func (s *Store) collectUsage(ctx context.Context) error {
+	entries, err := os.ReadDir(filepath.Join(s.basepath, blocksPath))
+	if err != nil {
+		return err
+	}
+	// the entries are sorted, so the Q4 file follows the ODS file of the same EDS
+	q4Sizes := make(map[uint64]int64)
+	var lastODS string
+	var lastODSID uint64
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		name, ext := entry.Name(), filepath.Ext(entry.Name())
+		if !entry.Type().IsRegular() || (ext != odsFileExt && ext != q4FileExt) {
+			continue
+		}
+		info, err := entry.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			// removed in the meantime
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		hash := strings.TrimSuffix(name, ext)
+		switch id, ok := fileID(info); {
+		case ext == odsFileExt && ok:
+			lastODS, lastODSID = hash, id
+		case ext == q4FileExt && hash == lastODS:
+			q4Sizes[lastODSID] = info.Size()
+		}
+	}
+
+	entries, err = os.ReadDir(filepath.Join(s.basepath, heightsPath))
+	if err != nil {
+		return err
+	}
+	s.usage.collected = true
+	s.usage.odsSize, s.usage.q4Size = 0, 0
+	s.usage.buckets = make(map[uint64]*usageBucket)
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			s.usage.collected = false
+			return ctx.Err()
+		}
+		height, err := strconv.ParseUint(strings.TrimSuffix(entry.Name(), odsFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			s.usage.collected = false
+			return err
+		}
+		var odsSize, q4Size int64
+		// empty EDSes are symlinked and take no space
+		if info.Mode().IsRegular() {
+			odsSize = info.Size()
+			if id, ok := fileID(info); ok {
+				q4Size = q4Sizes[id]
+			}
+		}
+		s.usage.add(height, odsSize, q4Size)
+	}
+	return nil
+}
+
+// heightFilesSize returns the sizes of the ODS file the height links to and of the Q4 file of the
+// datahash, if it is known.
+func (s *Store) heightFilesSize(height uint64, datahash share.DataHash) (odsSize, q4Size int64) {
+	info, err := os.Lstat(s.heightToPath(height, odsFileExt))
+	if err == nil && info.Mode().IsRegular() {
+		odsSize = info.Size()
+	}
+	if datahash != nil && !datahash.IsEmptyEDS() {
+		q4Size = fileSize(s.hashToPath(datahash, q4FileExt))
+	}
+	return odsSize, q4Size
+}
+
+// unlinkHeight removes the height link. The datahash is nil, if the link is broken.
+func (s *Store) unlinkHeight(height uint64, datahash share.DataHash) error {
+	s.usage.lk.Lock()
+	defer s.usage.lk.Unlock()
+	odsSize, q4Size := s.heightFilesSize(height, datahash)
+	if err := remove(s.heightToPath(height, odsFileExt)); err != nil {
+		return err
+	}
+	s.usage.remove(height, odsSize, q4Size)
+	return nil
+}
+
+// fileSize returns the size of the file, or zero if it is missing.
+func fileSize(path string) int64 {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
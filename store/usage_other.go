@@ -0,0 +1,17 @@
+//go:build !linux && !darwin
+
+package store
+
+import (
+	"os"
+)
+
+// fileID returns the inode of the file, which is shared by its hardlinks.
+func fileID(os.FileInfo) (uint64, bool) {
+	return 0, false
+}
+
+// linkCount returns the amount of hardlinks to the file.
+func linkCount(os.FileInfo) (uint64, bool) {
+	return 0, false
+}